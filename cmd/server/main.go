@@ -15,12 +15,16 @@ import (
 	"github.com/kuldeepstechwork/gocart-api/internal/database"
 	"github.com/kuldeepstechwork/gocart-api/internal/events"
 	"github.com/kuldeepstechwork/gocart-api/internal/interfaces"
+	"github.com/kuldeepstechwork/gocart-api/internal/invoices"
 	"github.com/kuldeepstechwork/gocart-api/internal/logger"
-	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/payments"
 	"github.com/kuldeepstechwork/gocart-api/internal/providers"
 	"github.com/kuldeepstechwork/gocart-api/internal/repositories"
 	"github.com/kuldeepstechwork/gocart-api/internal/server"
 	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/tax"
+	"github.com/kuldeepstechwork/gocart-api/internal/workers"
 )
 
 // @title Gocart API
@@ -49,19 +53,28 @@ func main() {
 		log.Fatal().Err(err).Msg("failed to connect to database")
 	}
 
+	currency, err := money.ParseCurrency(cfg.Store.Currency)
+	if err != nil {
+		log.Fatal().Err(err).Msg("unsupported store currency")
+	}
+
+	currencies := make([]money.Currency, len(cfg.Store.Currencies))
+	for i, code := range cfg.Store.Currencies {
+		if currencies[i], err = money.ParseCurrency(code); err != nil {
+			log.Fatal().Err(err).Msg("unsupported store currency")
+		}
+	}
+
 	// Auto Migration
 	log.Info().Msg("running database migrations")
-	err = db.AutoMigrate(
-		&models.User{},
-		&models.RefreshToken{},
-		&models.Category{},
-		&models.Product{},
-		&models.ProductImage{},
-		&models.Cart{},
-		&models.CartItem{},
-		&models.Order{},
-		&models.OrderItem{},
-	)
+	if err := database.MigrateMoney(db, currency); err != nil {
+		log.Fatal().Err(err).Msg("failed to migrate money columns")
+	}
+	if err := database.MigrateIdempotencyKeys(db); err != nil {
+		log.Fatal().Err(err).Msg("failed to migrate idempotency keys")
+	}
+
+	err = database.AutoMigrate(db)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to run database migrations")
 	}
@@ -83,16 +96,46 @@ func main() {
 
 	userRepo := repositories.NewUserRepository(db)
 	cartRepo := repositories.NewCartRepository(db)
+	orderRepo := repositories.NewOrderRepository(db)
+	idempotencyRepo := repositories.NewIdempotencyRepository(db)
+	taxRateRepo := repositories.NewTaxRateRepository(db)
 	authService := services.NewAuthService(
 		cfg,
 		eventPublisher,
 		userRepo,
 		cartRepo,
+		orderRepo,
 	)
-	productService := services.NewProductService(db)
+	productService := services.NewProductService(db, currency)
 	userService := services.NewUserService(db)
-	cartService := services.NewCartService(db)
-	orderService := services.NewOrderService(db)
+	addressService := services.NewAddressService(db)
+	cartService := services.NewCartService(db, currency, cfg.Inventory.ReservationTTL)
+	shippingService := services.NewShippingService(db, currency)
+	taxService := services.NewTaxService(db)
+	couponService := services.NewCouponService(db, currency)
+	promotionService := services.NewPromotionService(db, currency)
+	currencyService := services.NewCurrencyService(db, currency, currencies)
+
+	var paymentProvider payments.PaymentProvider
+	switch cfg.Payment.Provider {
+	case "fake":
+		paymentProvider = payments.NewFakeProvider(payments.FakeOutcome(cfg.Payment.FakeOutcome))
+	default:
+		log.Fatal().Str("provider", cfg.Payment.Provider).Msg("unsupported payment provider")
+	}
+
+	taxPricing := tax.PricingMode(cfg.Tax.Pricing)
+	if !taxPricing.IsValid() {
+		log.Fatal().Str("pricing", cfg.Tax.Pricing).Msg("unsupported tax pricing mode")
+	}
+
+	var taxCalculator tax.TaxCalculator
+	switch cfg.Tax.Provider {
+	case "table":
+		taxCalculator = tax.NewTableCalculator(taxRateRepo, taxPricing)
+	default:
+		log.Fatal().Str("provider", cfg.Tax.Provider).Msg("unsupported tax provider")
+	}
 
 	var uploadProvider interfaces.UploadProvider
 	if cfg.Upload.UploadProvider == "s3" {
@@ -101,6 +144,20 @@ func main() {
 		uploadProvider = providers.NewLocalUploadProvider(cfg.Upload.Path)
 	}
 
+	invoiceService := services.NewInvoiceService(db, uploadProvider, invoices.NewRenderer())
+	loyaltyService := services.NewLoyaltyService(db, currency, services.LoyaltyProgram{
+		PointsPerUnit: cfg.Loyalty.PointsPerUnit,
+		PointValue:    cfg.Loyalty.PointValue,
+		Expiry:        cfg.Loyalty.PointsExpiry,
+	})
+	orderService := services.NewOrderService(db, currency, eventPublisher, paymentProvider, taxCalculator, invoiceService, loyaltyService)
+	checkoutService := services.NewCheckoutService(db, orderService, cfg.Checkout.SessionTTL)
+	subscriptionService := services.NewSubscriptionService(db, orderService)
+	walletService := services.NewWalletService(db, currency)
+	returnService := services.NewReturnService(db, currency, paymentProvider, loyaltyService)
+	shipmentService := services.NewShipmentService(db)
+	inventoryService := services.NewInventoryService(db)
+
 	uploadService := services.NewUploadService(uploadProvider)
 
 	srv := server.New(cfg,
@@ -108,9 +165,24 @@ func main() {
 		authService,
 		productService,
 		userService,
+		addressService,
 		uploadService,
 		cartService,
-		orderService)
+		orderService,
+		checkoutService,
+		subscriptionService,
+		walletService,
+		loyaltyService,
+		returnService,
+		shipmentService,
+		shippingService,
+		taxService,
+		couponService,
+		promotionService,
+		invoiceService,
+		currencyService,
+		inventoryService,
+		idempotencyRepo)
 
 	router := srv.SetupRoutes()
 
@@ -121,6 +193,18 @@ func main() {
 		WriteTimeout: 10 * time.Second,
 	}
 
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	sweeper := workers.NewReservationSweeper(inventoryService, cfg.Inventory.SweepInterval, &log)
+	go sweeper.Run(workerCtx)
+
+	subscriptionRunner := workers.NewSubscriptionRunner(subscriptionService, cfg.Subscriptions.RunInterval, &log)
+	go subscriptionRunner.Run(workerCtx)
+
+	loyaltyRunner := workers.NewLoyaltyRunner(loyaltyService, cfg.Loyalty.RunInterval, &log)
+	go loyaltyRunner.Run(workerCtx)
+
 	go func() {
 		log.Info().Str("port", cfg.Server.Port).Msg("starting http server")
 		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -133,6 +217,8 @@ func main() {
 	<-quit
 
 	log.Info().Msg("shutting down server")
+	stopWorkers()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
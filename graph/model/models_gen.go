@@ -14,6 +14,12 @@ type OrderConnection struct {
 	PageInfo *PageInfo    `json:"pageInfo"`
 }
 
+type OrderDetail struct {
+	Order         *dto.OrderResponse                `json:"order"`
+	Customer      *dto.UserResponse                 `json:"customer"`
+	StatusHistory []*dto.OrderStatusHistoryResponse `json:"status_history"`
+}
+
 type OrderEdge struct {
 	Node *dto.OrderResponse `json:"node"`
 }
@@ -36,3 +42,12 @@ type ProductEdge struct {
 
 type Query struct {
 }
+
+type ReturnConnection struct {
+	Edges    []*ReturnEdge `json:"edges"`
+	PageInfo *PageInfo     `json:"pageInfo"`
+}
+
+type ReturnEdge struct {
+	Node *dto.ReturnResponse `json:"node"`
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 
+	"github.com/kuldeepstechwork/gocart-api/graph/model"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
 	"github.com/kuldeepstechwork/gocart-api/internal/utils"
 )
 
@@ -42,6 +45,13 @@ func GetUserRoleFromContext(ctx context.Context) (string, error) {
 	return "", ErrUnauthorized
 }
 
+// GetCurrencyFromContext returns the currency the request shops in, or ""
+// for the store currency.
+func GetCurrencyFromContext(ctx context.Context) money.Currency {
+	currency, _ := ctx.Value(utils.CurrencyKey).(money.Currency)
+	return currency
+}
+
 func IsAdminFromContext(ctx context.Context) bool {
 	role, err := GetUserRoleFromContext(ctx)
 	if err != nil {
@@ -72,3 +82,41 @@ func getPagingNumbers(page, limit *int) (pageNumber, pageLimit int) {
 
 	return p, l
 }
+
+func newOrderConnection(orders []dto.OrderResponse, meta *utils.PaginationMeta) *model.OrderConnection {
+	edges := make([]*model.OrderEdge, len(orders))
+	for i := range orders {
+		edges[i] = &model.OrderEdge{
+			Node: &orders[i],
+		}
+	}
+
+	return &model.OrderConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			Page:       meta.Page,
+			Limit:      meta.Limit,
+			Total:      int(meta.Total),
+			TotalPages: meta.TotalPages,
+		},
+	}
+}
+
+func newReturnConnection(returns []dto.ReturnResponse, meta *utils.PaginationMeta) *model.ReturnConnection {
+	edges := make([]*model.ReturnEdge, len(returns))
+	for i := range returns {
+		edges[i] = &model.ReturnEdge{
+			Node: &returns[i],
+		}
+	}
+
+	return &model.ReturnConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			Page:       meta.Page,
+			Limit:      meta.Limit,
+			Total:      int(meta.Total),
+			TotalPages: meta.TotalPages,
+		},
+	}
+}
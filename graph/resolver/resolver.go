@@ -13,23 +13,29 @@ import (
 type Resolver struct {
 	authService    services.AuthServiceInterface
 	userService    services.UserServiceInterface
+	addressService services.AddressServiceInterface
 	productService services.ProductServiceInterface
 	cartService    services.CartServiceInterface
 	orderService   services.OrderServiceInterface
+	returnService  services.ReturnServiceInterface
 }
 
 func NewResolver(authService services.AuthServiceInterface,
 	userService services.UserServiceInterface,
+	addressService services.AddressServiceInterface,
 	productService services.ProductServiceInterface,
 	cartService services.CartServiceInterface,
-	orderService services.OrderServiceInterface) *Resolver {
+	orderService services.OrderServiceInterface,
+	returnService services.ReturnServiceInterface) *Resolver {
 
 	return &Resolver{
 		authService:    authService,
 		userService:    userService,
+		addressService: addressService,
 		productService: productService,
 		cartService:    cartService,
 		orderService:   orderService,
+		returnService:  returnService,
 	}
 
 }
@@ -68,6 +68,61 @@ func (r *mutationResolver) UpdateProfile(ctx context.Context, input dto.UpdatePr
 	return user, nil
 }
 
+// CreateAddress is the resolver for the createAddress field.
+func (r *mutationResolver) CreateAddress(ctx context.Context, input dto.AddressRequest) (*dto.AddressResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	address, err := r.addressService.CreateAddress(userID, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create address: %w", err)
+	}
+
+	return address, nil
+}
+
+// UpdateAddress is the resolver for the updateAddress field.
+func (r *mutationResolver) UpdateAddress(ctx context.Context, id string, input dto.AddressRequest) (*dto.AddressResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	addressID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address ID: %w", err)
+	}
+
+	address, err := r.addressService.UpdateAddress(userID, addressID, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update address: %w", err)
+	}
+
+	return address, nil
+}
+
+// DeleteAddress is the resolver for the deleteAddress field.
+func (r *mutationResolver) DeleteAddress(ctx context.Context, id string) (bool, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, ErrUnauthorized
+	}
+
+	addressID, err := r.parseID(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid address ID: %w", err)
+	}
+
+	err = r.addressService.DeleteAddress(userID, addressID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete address: %w", err)
+	}
+
+	return true, nil
+}
+
 // CreateCategory is the resolver for the createCategory field. - Admin action
 func (r *mutationResolver) CreateCategory(ctx context.Context, input dto.CreateCategoryRequest) (*dto.CategoryResponse, error) {
 	if !IsAdminFromContext(ctx) {
@@ -179,7 +234,7 @@ func (r *mutationResolver) AddToCart(ctx context.Context, input dto.AddToCartReq
 		return nil, ErrUnauthorized
 	}
 
-	cart, err := r.cartService.AddToCart(userID, &input)
+	cart, err := r.cartService.AddToCart(userID, &input, GetCurrencyFromContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to add to cart: %w", err)
 	}
@@ -199,7 +254,7 @@ func (r *mutationResolver) UpdateCartItem(ctx context.Context, id string, input
 		return nil, fmt.Errorf("invalid item ID: %w", err)
 	}
 
-	cart, err := r.cartService.UpdateCartItem(userID, itemID, &input)
+	cart, err := r.cartService.UpdateCartItem(userID, itemID, &input, GetCurrencyFromContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to update cart item: %w", err)
 	}
@@ -227,14 +282,59 @@ func (r *mutationResolver) RemoveFromCart(ctx context.Context, id string) (bool,
 	return true, nil
 }
 
+// SelectShippingMethod is the resolver for the selectShippingMethod field.
+func (r *mutationResolver) SelectShippingMethod(ctx context.Context, input dto.SelectShippingMethodRequest) (*dto.CartResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	cart, err := r.cartService.SelectShippingMethod(userID, &input, GetCurrencyFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to select shipping method: %w", err)
+	}
+
+	return cart, nil
+}
+
+// ApplyCoupon is the resolver for the applyCoupon field.
+func (r *mutationResolver) ApplyCoupon(ctx context.Context, input dto.ApplyCouponRequest) (*dto.CartResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	cart, err := r.cartService.ApplyCoupon(userID, &input, GetCurrencyFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply coupon: %w", err)
+	}
+
+	return cart, nil
+}
+
+// RemoveCoupon is the resolver for the removeCoupon field.
+func (r *mutationResolver) RemoveCoupon(ctx context.Context) (*dto.CartResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	cart, err := r.cartService.RemoveCoupon(userID, GetCurrencyFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove coupon: %w", err)
+	}
+
+	return cart, nil
+}
+
 // CreateOrder is the resolver for the createOrder field.
-func (r *mutationResolver) CreateOrder(ctx context.Context) (*dto.OrderResponse, error) {
+func (r *mutationResolver) CreateOrder(ctx context.Context, input *dto.CreateOrderRequest) (*dto.OrderResponse, error) {
 	userID, err := GetUserIDFromContext(ctx)
 	if err != nil {
 		return nil, ErrUnauthorized
 	}
 
-	order, err := r.orderService.CreateOrder(userID)
+	order, err := r.orderService.CreateOrder(userID, input, GetCurrencyFromContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
@@ -242,6 +342,201 @@ func (r *mutationResolver) CreateOrder(ctx context.Context) (*dto.OrderResponse,
 	return order, nil
 }
 
+// CancelOrder is the resolver for the cancelOrder field.
+func (r *mutationResolver) CancelOrder(ctx context.Context, id string, reason *string) (*dto.OrderResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	orderID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	req := dto.CancelOrderRequest{}
+	if reason != nil {
+		req.Reason = *reason
+	}
+
+	order, err := r.orderService.CancelOrder(userID, orderID, &req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel order: %w", err)
+	}
+
+	return order, nil
+}
+
+// Reorder is the resolver for the reorder field.
+func (r *mutationResolver) Reorder(ctx context.Context, id string) (*dto.ReorderResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	orderID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	reorder, err := r.cartService.Reorder(userID, orderID, GetCurrencyFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to reorder: %w", err)
+	}
+
+	return reorder, nil
+}
+
+// UpdateOrderStatus is the resolver for the updateOrderStatus field. - Admin action
+func (r *mutationResolver) UpdateOrderStatus(ctx context.Context, id string, input dto.UpdateOrderStatusRequest) (*dto.OrderResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	adminID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	orderID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	order, err := r.orderService.UpdateOrderStatus(orderID, adminID, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	return order, nil
+}
+
+// RequestReturn is the resolver for the requestReturn field.
+func (r *mutationResolver) RequestReturn(ctx context.Context, orderID string, input dto.CreateReturnRequest) (*dto.ReturnResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	parsedOrderID, err := r.parseID(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	ret, err := r.returnService.RequestReturn(userID, parsedOrderID, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request return: %w", err)
+	}
+
+	return ret, nil
+}
+
+// ApproveReturn is the resolver for the approveReturn field. - Admin action
+func (r *mutationResolver) ApproveReturn(ctx context.Context, id string) (*dto.ReturnResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	returnID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid return ID: %w", err)
+	}
+
+	ret, err := r.returnService.ApproveReturn(returnID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve return: %w", err)
+	}
+
+	return ret, nil
+}
+
+// RejectReturn is the resolver for the rejectReturn field. - Admin action
+func (r *mutationResolver) RejectReturn(ctx context.Context, id string) (*dto.ReturnResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	returnID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid return ID: %w", err)
+	}
+
+	ret, err := r.returnService.RejectReturn(returnID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reject return: %w", err)
+	}
+
+	return ret, nil
+}
+
+// CancelReturn is the resolver for the cancelReturn field.
+func (r *mutationResolver) CancelReturn(ctx context.Context, id string) (*dto.ReturnResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	returnID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid return ID: %w", err)
+	}
+
+	ret, err := r.returnService.CancelReturn(userID, returnID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel return: %w", err)
+	}
+
+	return ret, nil
+}
+
+// ReceiveReturn is the resolver for the receiveReturn field. - Admin action
+func (r *mutationResolver) ReceiveReturn(ctx context.Context, id string, restock *bool) (*dto.ReturnResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	returnID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid return ID: %w", err)
+	}
+
+	req := dto.ReceiveReturnRequest{}
+	if restock != nil {
+		req.Restock = *restock
+	}
+
+	ret, err := r.returnService.ReceiveReturn(returnID, &req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive return: %w", err)
+	}
+
+	return ret, nil
+}
+
+// RefundReturn is the resolver for the refundReturn field. - Admin action
+func (r *mutationResolver) RefundReturn(ctx context.Context, id string, toWallet *bool) (*dto.ReturnResponse, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	returnID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid return ID: %w", err)
+	}
+
+	req := dto.RefundReturnRequest{}
+	if toWallet != nil {
+		req.ToWallet = *toWallet
+	}
+
+	ret, err := r.returnService.RefundReturn(returnID, &req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refund return: %w", err)
+	}
+
+	return ret, nil
+}
+
 // Me is the resolver for the me field.
 func (r *queryResolver) Me(ctx context.Context) (*dto.UserResponse, error) {
 	userID, err := GetUserIDFromContext(ctx)
@@ -257,11 +552,51 @@ func (r *queryResolver) Me(ctx context.Context) (*dto.UserResponse, error) {
 	return user, nil
 }
 
+// Addresses is the resolver for the addresses field.
+func (r *queryResolver) Addresses(ctx context.Context) ([]*dto.AddressResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	addresses, err := r.addressService.GetAddresses(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get addresses: %w", err)
+	}
+
+	result := make([]*dto.AddressResponse, len(addresses))
+	for i := range addresses {
+		result[i] = &addresses[i]
+	}
+
+	return result, nil
+}
+
+// Address is the resolver for the address field.
+func (r *queryResolver) Address(ctx context.Context, id string) (*dto.AddressResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	addressID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address ID: %w", err)
+	}
+
+	address, err := r.addressService.GetAddress(userID, addressID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address: %w", err)
+	}
+
+	return address, nil
+}
+
 // Products is the resolver for the products field.
 func (r *queryResolver) Products(ctx context.Context, page *int, limit *int) (*model.ProductConnection, error) {
 	p, l := getPagingNumbers(page, limit)
 
-	products, meta, err := r.productService.GetProducts(p, l)
+	products, meta, err := r.productService.GetProducts(p, l, GetCurrencyFromContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get products: %w", err)
 	}
@@ -291,7 +626,7 @@ func (r *queryResolver) Product(ctx context.Context, id string) (*dto.ProductRes
 		return nil, fmt.Errorf("invalid product ID: %w", err)
 	}
 
-	product, err := r.productService.GetProduct(productID)
+	product, err := r.productService.GetProduct(productID, GetCurrencyFromContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
@@ -321,7 +656,7 @@ func (r *queryResolver) Cart(ctx context.Context) (*dto.CartResponse, error) {
 		return nil, ErrUnauthorized
 	}
 
-	cart, err := r.cartService.GetCart(userID)
+	cart, err := r.cartService.GetCart(userID, GetCurrencyFromContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cart: %w", err)
 	}
@@ -329,6 +664,31 @@ func (r *queryResolver) Cart(ctx context.Context) (*dto.CartResponse, error) {
 	return cart, nil
 }
 
+// ShippingOptions is the resolver for the shippingOptions field.
+func (r *queryResolver) ShippingOptions(ctx context.Context, addressID string) ([]*dto.ShippingOptionResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	parsedAddressID, err := r.parseID(addressID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address ID: %w", err)
+	}
+
+	options, err := r.cartService.GetShippingOptions(userID, parsedAddressID, GetCurrencyFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipping options: %w", err)
+	}
+
+	result := make([]*dto.ShippingOptionResponse, len(options))
+	for i := range options {
+		result[i] = &options[i]
+	}
+
+	return result, nil
+}
+
 // Orders is the resolver for the orders field.
 func (r *queryResolver) Orders(ctx context.Context, page *int, limit *int) (*model.OrderConnection, error) {
 	userID, err := GetUserIDFromContext(ctx)
@@ -381,6 +741,92 @@ func (r *queryResolver) Order(ctx context.Context, id string) (*dto.OrderRespons
 	return order, nil
 }
 
+// AllOrders is the resolver for the allOrders field.
+func (r *queryResolver) AllOrders(ctx context.Context, filter *dto.ListOrdersRequest, page *int, limit *int) (*model.OrderConnection, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	req := dto.ListOrdersRequest{}
+	if filter != nil {
+		req = *filter
+	}
+	req.Page, req.Limit = getPagingNumbers(page, limit)
+
+	orders, meta, err := r.orderService.ListOrders(&req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orders: %w", err)
+	}
+
+	return newOrderConnection(orders, meta), nil
+}
+
+// AdminOrder is the resolver for the adminOrder field.
+func (r *queryResolver) AdminOrder(ctx context.Context, id string) (*model.OrderDetail, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	orderID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	order, err := r.orderService.GetOrderDetail(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	history := make([]*dto.OrderStatusHistoryResponse, len(order.StatusHistory))
+	for i := range order.StatusHistory {
+		history[i] = &order.StatusHistory[i]
+	}
+
+	return &model.OrderDetail{
+		Order:         &order.OrderResponse,
+		Customer:      &order.Customer,
+		StatusHistory: history,
+	}, nil
+}
+
+// Returns is the resolver for the returns field.
+func (r *queryResolver) Returns(ctx context.Context, page *int, limit *int) (*model.ReturnConnection, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	p, l := getPagingNumbers(page, limit)
+
+	returns, meta, err := r.returnService.GetReturns(userID, p, l)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get returns: %w", err)
+	}
+
+	return newReturnConnection(returns, meta), nil
+}
+
+// AllReturns is the resolver for the allReturns field. - Admin action
+func (r *queryResolver) AllReturns(ctx context.Context, status *string, page *int, limit *int) (*model.ReturnConnection, error) {
+	if !IsAdminFromContext(ctx) {
+		return nil, ErrUnauthorized
+	}
+
+	p, l := getPagingNumbers(page, limit)
+
+	filter := ""
+	if status != nil {
+		filter = *status
+	}
+
+	returns, meta, err := r.returnService.ListReturns(filter, p, l)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get returns: %w", err)
+	}
+
+	return newReturnConnection(returns, meta), nil
+}
+
 // Mutation returns graph.MutationResolver implementation.
 func (r *Resolver) Mutation() graph.MutationResolver { return &mutationResolver{r} }
 
@@ -12,6 +12,16 @@ import (
 	"github.com/kuldeepstechwork/gocart-api/internal/dto"
 )
 
+// ID is the resolver for the id field.
+func (r *addressResolver) ID(ctx context.Context, obj *dto.AddressResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// PromotionID is the resolver for the promotion_id field.
+func (r *appliedPromotionResolver) PromotionID(ctx context.Context, obj *dto.AppliedPromotionResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.PromotionID), nil
+}
+
 // ID is the resolver for the id field.
 func (r *cartResolver) ID(ctx context.Context, obj *dto.CartResponse) (string, error) {
 	return fmt.Sprintf("%d", obj.ID), nil
@@ -47,6 +57,21 @@ func (r *orderItemResolver) ID(ctx context.Context, obj *dto.OrderItemResponse)
 	return fmt.Sprintf("%d", obj.ID), nil
 }
 
+// ID is the resolver for the id field.
+func (r *orderStatusChangeResolver) ID(ctx context.Context, obj *dto.OrderStatusHistoryResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// ChangedBy is the resolver for the changed_by field.
+func (r *orderStatusChangeResolver) ChangedBy(ctx context.Context, obj *dto.OrderStatusHistoryResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ChangedBy), nil
+}
+
+// ID is the resolver for the id field.
+func (r *paymentResolver) ID(ctx context.Context, obj *dto.PaymentResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
 // ID is the resolver for the id field.
 func (r *productResolver) ID(ctx context.Context, obj *dto.ProductResponse) (string, error) {
 	return fmt.Sprintf("%d", obj.ID), nil
@@ -62,11 +87,64 @@ func (r *productImageResolver) ID(ctx context.Context, obj *dto.ProductImageResp
 	return fmt.Sprintf("%d", obj.ID), nil
 }
 
+// OrderItemID is the resolver for the order_item_id field.
+func (r *reorderLineResolver) OrderItemID(ctx context.Context, obj *dto.ReorderLineResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.OrderItemID), nil
+}
+
+// ProductID is the resolver for the product_id field.
+func (r *reorderLineResolver) ProductID(ctx context.Context, obj *dto.ReorderLineResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ProductID), nil
+}
+
+// ID is the resolver for the id field.
+func (r *returnResolver) ID(ctx context.Context, obj *dto.ReturnResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// OrderID is the resolver for the order_id field.
+func (r *returnResolver) OrderID(ctx context.Context, obj *dto.ReturnResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.OrderID), nil
+}
+
+// UserID is the resolver for the user_id field.
+func (r *returnResolver) UserID(ctx context.Context, obj *dto.ReturnResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.UserID), nil
+}
+
+// ID is the resolver for the id field.
+func (r *returnLineResolver) ID(ctx context.Context, obj *dto.ReturnLineResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// OrderItemID is the resolver for the order_item_id field.
+func (r *returnLineResolver) OrderItemID(ctx context.Context, obj *dto.ReturnLineResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.OrderItemID), nil
+}
+
+// ProductID is the resolver for the product_id field.
+func (r *returnLineResolver) ProductID(ctx context.Context, obj *dto.ReturnLineResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ProductID), nil
+}
+
+// RateID is the resolver for the rate_id field.
+func (r *shippingOptionResolver) RateID(ctx context.Context, obj *dto.ShippingOptionResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.RateID), nil
+}
+
 // ID is the resolver for the id field.
 func (r *userResolver) ID(ctx context.Context, obj *dto.UserResponse) (string, error) {
 	return fmt.Sprintf("%d", obj.ID), nil
 }
 
+// Address returns graph.AddressResolver implementation.
+func (r *Resolver) Address() graph.AddressResolver { return &addressResolver{r} }
+
+// AppliedPromotion returns graph.AppliedPromotionResolver implementation.
+func (r *Resolver) AppliedPromotion() graph.AppliedPromotionResolver {
+	return &appliedPromotionResolver{r}
+}
+
 // Cart returns graph.CartResolver implementation.
 func (r *Resolver) Cart() graph.CartResolver { return &cartResolver{r} }
 
@@ -82,20 +160,48 @@ func (r *Resolver) Order() graph.OrderResolver { return &orderResolver{r} }
 // OrderItem returns graph.OrderItemResolver implementation.
 func (r *Resolver) OrderItem() graph.OrderItemResolver { return &orderItemResolver{r} }
 
+// OrderStatusChange returns graph.OrderStatusChangeResolver implementation.
+func (r *Resolver) OrderStatusChange() graph.OrderStatusChangeResolver {
+	return &orderStatusChangeResolver{r}
+}
+
+// Payment returns graph.PaymentResolver implementation.
+func (r *Resolver) Payment() graph.PaymentResolver { return &paymentResolver{r} }
+
 // Product returns graph.ProductResolver implementation.
 func (r *Resolver) Product() graph.ProductResolver { return &productResolver{r} }
 
 // ProductImage returns graph.ProductImageResolver implementation.
 func (r *Resolver) ProductImage() graph.ProductImageResolver { return &productImageResolver{r} }
 
+// ReorderLine returns graph.ReorderLineResolver implementation.
+func (r *Resolver) ReorderLine() graph.ReorderLineResolver { return &reorderLineResolver{r} }
+
+// Return returns graph.ReturnResolver implementation.
+func (r *Resolver) Return() graph.ReturnResolver { return &returnResolver{r} }
+
+// ReturnLine returns graph.ReturnLineResolver implementation.
+func (r *Resolver) ReturnLine() graph.ReturnLineResolver { return &returnLineResolver{r} }
+
+// ShippingOption returns graph.ShippingOptionResolver implementation.
+func (r *Resolver) ShippingOption() graph.ShippingOptionResolver { return &shippingOptionResolver{r} }
+
 // User returns graph.UserResolver implementation.
 func (r *Resolver) User() graph.UserResolver { return &userResolver{r} }
 
+type addressResolver struct{ *Resolver }
+type appliedPromotionResolver struct{ *Resolver }
 type cartResolver struct{ *Resolver }
 type cartItemResolver struct{ *Resolver }
 type categoryResolver struct{ *Resolver }
 type orderResolver struct{ *Resolver }
 type orderItemResolver struct{ *Resolver }
+type orderStatusChangeResolver struct{ *Resolver }
+type paymentResolver struct{ *Resolver }
 type productResolver struct{ *Resolver }
 type productImageResolver struct{ *Resolver }
+type reorderLineResolver struct{ *Resolver }
+type returnResolver struct{ *Resolver }
+type returnLineResolver struct{ *Resolver }
+type shippingOptionResolver struct{ *Resolver }
 type userResolver struct{ *Resolver }
@@ -3,23 +3,35 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	AWS      AWSConfig
-	Upload   UploadConfig
-	SMTP     SMTPConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	JWT       JWTConfig
+	AWS       AWSConfig
+	Upload    UploadConfig
+	SMTP      SMTPConfig
+	Payment   PaymentConfig
+	Tax       TaxConfig
+	Store     StoreConfig
+	Checkout  CheckoutConfig
+	Inventory InventoryConfig
+
+	Subscriptions SubscriptionConfig
+	Loyalty       LoyaltyConfig
 }
 
 type ServerConfig struct {
 	Port    string
 	GinMode string
+
+	// IdempotencyKeyTTL is how long a stored Idempotency-Key response is replayed
+	IdempotencyKeyTTL time.Duration
 }
 
 type DatabaseConfig struct {
@@ -61,6 +73,65 @@ type UploadConfig struct {
 	UploadProvider string
 }
 
+type PaymentConfig struct {
+	// Provider selects the payment gateway, currently only fake
+	Provider string
+
+	// FakeOutcome makes the fake provider succeed, decline or timeout
+	FakeOutcome string
+}
+
+type TaxConfig struct {
+	// Provider selects the tax calculator, currently only table
+	Provider string
+
+	// Pricing is exclusive when catalogue prices exclude tax, inclusive when they include it
+	Pricing string
+}
+
+type StoreConfig struct {
+	// Currency is the ISO 4217 code of the currency prices are kept in
+	Currency string
+
+	// Currencies lists the other currencies customers may shop in
+	Currencies []string
+}
+
+type CheckoutConfig struct {
+	// SessionTTL is how long a checkout session holds its prices and stock
+	SessionTTL time.Duration
+}
+
+type InventoryConfig struct {
+	// ReservationTTL is how long a cart holds the stock of what it contains
+	ReservationTTL time.Duration
+
+	// SweepInterval is how often expired reservations are released
+	SweepInterval time.Duration
+}
+
+type SubscriptionConfig struct {
+	// RunInterval is how often the subscriptions that are due place their orders
+	RunInterval time.Duration
+}
+
+type LoyaltyConfig struct {
+	// PointsPerUnit are earned for every unit of the store currency a
+	// delivered order cost
+	PointsPerUnit int64
+
+	// PointValue is what a point takes off an order, in minor units of the
+	// store currency
+	PointValue int64
+
+	// PointsExpiry is how long earned points can be spent; 0 never expires them
+	PointsExpiry time.Duration
+
+	// RunInterval is how often delivered orders earn their points and
+	// lapsed points expire
+	RunInterval time.Duration
+}
+
 func Load() (*Config, error) {
 	_ = godotenv.Load()
 
@@ -68,11 +139,21 @@ func Load() (*Config, error) {
 	refreshTokenExpires, _ := time.ParseDuration(getEnv("REFRESH_TOKEN_EXPIRES_IN", "720h"))
 	maxUploadSize, _ := strconv.ParseInt(getEnv("MAX_UPLOAD_SIZE", "10485760"), 10, 64)
 	smtpPort, _ := strconv.Atoi(getEnv("SMTP_PORT", "1025"))
+	idempotencyKeyTTL, _ := time.ParseDuration(getEnv("IDEMPOTENCY_KEY_TTL", "24h"))
+	checkoutSessionTTL, _ := time.ParseDuration(getEnv("CHECKOUT_SESSION_TTL", "15m"))
+	reservationTTL, _ := time.ParseDuration(getEnv("INVENTORY_RESERVATION_TTL", "30m"))
+	sweepInterval, _ := time.ParseDuration(getEnv("INVENTORY_SWEEP_INTERVAL", "1m"))
+	subscriptionRunInterval, _ := time.ParseDuration(getEnv("SUBSCRIPTION_RUN_INTERVAL", "1m"))
+	loyaltyPointsPerUnit, _ := strconv.ParseInt(getEnv("LOYALTY_POINTS_PER_UNIT", "1"), 10, 64)
+	loyaltyPointValue, _ := strconv.ParseInt(getEnv("LOYALTY_POINT_VALUE", "1"), 10, 64)
+	loyaltyPointsExpiry, _ := time.ParseDuration(getEnv("LOYALTY_POINTS_EXPIRY", "8760h"))
+	loyaltyRunInterval, _ := time.ParseDuration(getEnv("LOYALTY_RUN_INTERVAL", "5m"))
 
 	return &Config{
 		Server: ServerConfig{
-			Port:    getEnv("PORT", "8080"),
-			GinMode: getEnv("GIN_MODE", "debug"),
+			Port:              getEnv("PORT", "8080"),
+			GinMode:           getEnv("GIN_MODE", "debug"),
+			IdempotencyKeyTTL: idempotencyKeyTTL,
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -107,6 +188,34 @@ func Load() (*Config, error) {
 			Password: getEnv("SMTP_PASSWORD", ""),
 			From:     getEnv("SMTP_FROM", "noreply@shop.com"),
 		},
+		Payment: PaymentConfig{
+			Provider:    getEnv("PAYMENT_PROVIDER", "fake"),
+			FakeOutcome: getEnv("PAYMENT_FAKE_OUTCOME", "succeed"),
+		},
+		Tax: TaxConfig{
+			Provider: getEnv("TAX_PROVIDER", "table"),
+			Pricing:  getEnv("TAX_PRICING", "exclusive"),
+		},
+		Store: StoreConfig{
+			Currency:   getEnv("STORE_CURRENCY", "USD"),
+			Currencies: getEnvList("STORE_CURRENCIES"),
+		},
+		Checkout: CheckoutConfig{
+			SessionTTL: checkoutSessionTTL,
+		},
+		Inventory: InventoryConfig{
+			ReservationTTL: reservationTTL,
+			SweepInterval:  sweepInterval,
+		},
+		Subscriptions: SubscriptionConfig{
+			RunInterval: subscriptionRunInterval,
+		},
+		Loyalty: LoyaltyConfig{
+			PointsPerUnit: loyaltyPointsPerUnit,
+			PointValue:    loyaltyPointValue,
+			PointsExpiry:  loyaltyPointsExpiry,
+			RunInterval:   loyaltyRunInterval,
+		},
 	}, nil
 
 }
@@ -117,3 +226,14 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvList reads a comma separated list, skipping empty entries.
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
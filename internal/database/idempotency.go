@@ -0,0 +1,19 @@
+package database
+
+import (
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// MigrateIdempotencyKeys drops the unique index that kept idempotency keys per
+// user only, which AutoMigrate leaves in place, so that guests, who all have
+// no user, can each use their own keys. AutoMigrate then adds the index that
+// also takes the cart token into account.
+func MigrateIdempotencyKeys(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasIndex(&models.IdempotencyKey{}, "idx_idempotency_keys_user_key") {
+		return nil
+	}
+
+	return migrator.DropIndex(&models.IdempotencyKey{}, "idx_idempotency_keys_user_key")
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// Models returns every model the API stores, in the order their tables are
+// migrated.
+func Models() []interface{} {
+	return []interface{}{
+		&models.User{},
+		&models.RefreshToken{},
+		&models.Category{},
+		&models.Product{},
+		&models.ProductImage{},
+		&models.Cart{},
+		&models.CartItem{},
+		&models.Order{},
+		&models.OrderItem{},
+		&models.OrderStatusHistory{},
+		&models.IdempotencyKey{},
+		&models.Payment{},
+		&models.Return{},
+		&models.ReturnLine{},
+		&models.Shipment{},
+		&models.ShipmentLine{},
+		&models.Address{},
+		&models.ShippingZone{},
+		&models.ShippingZoneRegion{},
+		&models.ShippingRate{},
+		&models.TaxRate{},
+		&models.Coupon{},
+		&models.CouponRedemption{},
+		&models.Promotion{},
+		&models.PromotionTier{},
+		&models.OrderPromotion{},
+		&models.Invoice{},
+		&models.InvoiceSequence{},
+		&models.ExchangeRate{},
+		&models.ProductPrice{},
+		&models.CheckoutSession{},
+		&models.CheckoutSessionItem{},
+		&models.CheckoutSessionPromotion{},
+		&models.InventoryReservation{},
+		&models.Subscription{},
+		&models.SubscriptionRun{},
+		&models.Wallet{},
+		&models.WalletEntry{},
+		&models.GiftCard{},
+		&models.LoyaltyEntry{},
+	}
+}
+
+// AutoMigrate creates or updates the tables of every model.
+func AutoMigrate(db *gorm.DB) error {
+	return db.AutoMigrate(Models()...)
+}
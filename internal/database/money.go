@@ -0,0 +1,145 @@
+package database
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"gorm.io/gorm"
+)
+
+// moneyColumn is a column that held an amount of money as a float before
+// amounts were stored as an integer number of minor units and a currency.
+type moneyColumn struct {
+	table  string
+	column string
+	// prefix is the embeddedPrefix of the Money field that replaces the column
+	prefix string
+}
+
+var moneyColumns = []moneyColumn{
+	{"products", "price", "price_"},
+	{"orders", "total_amount", "total_"},
+	{"orders", "shipping_cost", "shipping_cost_"},
+	{"orders", "discount_amount", "discount_"},
+	{"orders", "tax_amount", "tax_"},
+	{"order_items", "price", "price_"},
+	{"order_items", "discount", "discount_"},
+	{"order_items", "tax_amount", "tax_"},
+	{"payments", "amount", ""},
+	{"payments", "captured_amount", "captured_"},
+	{"payments", "refunded_amount", "refunded_"},
+	{"returns", "refund_amount", "refund_"},
+	{"return_lines", "refund_amount", "refund_"},
+	{"coupons", "min_subtotal", "min_subtotal_"},
+	{"coupon_redemptions", "discount", "discount_"},
+	{"promotions", "bundle_price", "bundle_price_"},
+	{"promotion_tiers", "threshold", "threshold_"},
+	{"promotion_tiers", "amount", ""},
+	{"order_promotions", "discount", "discount_"},
+	{"shipping_rates", "price", "price_"},
+	{"shipping_rates", "free_over", "free_over_"},
+}
+
+// MigrateMoney converts amounts stored as floats in major units to integer
+// minor units of currency, the store currency every existing amount is in.
+// It must run before AutoMigrate, which would otherwise add the new columns
+// empty next to the old ones. Columns that are already converted are left
+// alone, so it is safe to run on every start.
+func MigrateMoney(db *gorm.DB, currency money.Currency) error {
+	scale := int64(math.Pow10(currency.Exponent()))
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, c := range moneyColumns {
+			if err := migrateMoneyColumn(tx, c, currency, scale); err != nil {
+				return fmt.Errorf("failed to migrate %s.%s: %w", c.table, c.column, err)
+			}
+		}
+
+		if err := migrateCouponValue(tx, currency, scale); err != nil {
+			return fmt.Errorf("failed to migrate coupons.value: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// migrateMoneyColumn turns the float column into the amount column of the
+// Money field, rounding to the minor unit, and adds the currency column.
+func migrateMoneyColumn(tx *gorm.DB, c moneyColumn, currency money.Currency, scale int64) error {
+	dataType, err := columnType(tx, c.table, c.column)
+	if err != nil || !isDecimalType(dataType) {
+		return err
+	}
+
+	amount := c.prefix + "amount"
+	if c.column != amount {
+		if err := tx.Exec(fmt.Sprintf(`ALTER TABLE %q RENAME COLUMN %q TO %q`, c.table, c.column, amount)).Error; err != nil {
+			return err
+		}
+	}
+
+	statements := []string{
+		fmt.Sprintf(`ALTER TABLE %q ALTER COLUMN %q TYPE bigint USING ROUND(COALESCE(%q, 0) * %d)::bigint`, c.table, amount, amount, scale),
+		fmt.Sprintf(`ALTER TABLE %q ALTER COLUMN %q SET DEFAULT 0`, c.table, amount),
+		fmt.Sprintf(`ALTER TABLE %q ADD COLUMN IF NOT EXISTS %q varchar(3) NOT NULL DEFAULT ''`, c.table, c.prefix+"currency"),
+	}
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Exec(fmt.Sprintf(`UPDATE %q SET %q = ?`, c.table, c.prefix+"currency"), currency).Error
+}
+
+// migrateCouponValue splits the coupon value, a percentage or an amount
+// depending on the coupon type, into the percent and fixed amount columns.
+func migrateCouponValue(tx *gorm.DB, currency money.Currency, scale int64) error {
+	dataType, err := columnType(tx, "coupons", "value")
+	if err != nil || dataType == "" {
+		return err
+	}
+
+	statements := []struct {
+		sql  string
+		args []interface{}
+	}{
+		{`ALTER TABLE "coupons" ADD COLUMN IF NOT EXISTS "percent" double precision NOT NULL DEFAULT 0`, nil},
+		{`ALTER TABLE "coupons" ADD COLUMN IF NOT EXISTS "fixed_amount" bigint NOT NULL DEFAULT 0`, nil},
+		{`ALTER TABLE "coupons" ADD COLUMN IF NOT EXISTS "fixed_currency" varchar(3) NOT NULL DEFAULT ''`, nil},
+		{`UPDATE "coupons" SET "percent" = COALESCE("value", 0) WHERE "type" = ?`, []interface{}{models.CouponPercentage}},
+		{fmt.Sprintf(`UPDATE "coupons" SET "fixed_amount" = ROUND(COALESCE("value", 0) * %d)::bigint WHERE "type" = ?`, scale), []interface{}{models.CouponFixed}},
+		{`UPDATE "coupons" SET "fixed_currency" = ?`, []interface{}{currency}},
+		{`ALTER TABLE "coupons" DROP COLUMN "value"`, nil},
+	}
+	for _, statement := range statements {
+		if err := tx.Exec(statement.sql, statement.args...).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// columnType returns the data type of the column, or "" when the table or
+// the column does not exist.
+func columnType(tx *gorm.DB, table, column string) (string, error) {
+	var dataType string
+	err := tx.Raw(
+		`SELECT data_type FROM information_schema.columns WHERE table_schema = CURRENT_SCHEMA() AND table_name = ? AND column_name = ?`,
+		table, column,
+	).Scan(&dataType).Error
+
+	return dataType, err
+}
+
+func isDecimalType(dataType string) bool {
+	switch dataType {
+	case "double precision", "real", "numeric":
+		return true
+	}
+
+	return false
+}
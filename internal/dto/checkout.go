@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+// CheckoutSessionResponse is a priced quote of the cart, held until
+// ExpiresAt. Completing the session places an order at exactly these prices.
+type CheckoutSessionResponse struct {
+	ID uint `json:"id"`
+	// Status is open, completed, cancelled once replaced by a newer session,
+	// or expired
+	Status           string                        `json:"status"`
+	Items            []CheckoutSessionItemResponse `json:"items"`
+	TotalAmount      money.Money                   `json:"total_amount"`
+	ShippingMethod   string                        `json:"shipping_method"`
+	ShippingCost     money.Money                   `json:"shipping_cost"`
+	CouponCode       string                        `json:"coupon_code"`
+	DiscountAmount   money.Money                   `json:"discount_amount"`
+	TaxAmount        money.Money                   `json:"tax_amount"`
+	PricesIncludeTax bool                          `json:"prices_include_tax"`
+	ExchangeRate     float64                       `json:"exchange_rate"`
+	ShippingAddress  *OrderAddressResponse         `json:"shipping_address"`
+	BillingAddress   *OrderAddressResponse         `json:"billing_address"`
+	Promotions       []AppliedPromotionResponse    `json:"promotions"`
+	UseWallet        bool                          `json:"use_wallet"`
+	RedeemPoints     int                           `json:"redeem_points"`
+	// OrderID is the order placed by completing the session
+	OrderID   *uint     `json:"order_id,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CheckoutSessionItemResponse struct {
+	ProductID   uint        `json:"product_id"`
+	ProductName string      `json:"product_name"`
+	SKU         string      `json:"sku"`
+	Quantity    int         `json:"quantity"`
+	Price       money.Money `json:"price"`
+	Discount    money.Money `json:"discount"`
+	TaxClass    string      `json:"tax_class"`
+	TaxRate     float64     `json:"tax_rate"`
+	TaxAmount   money.Money `json:"tax_amount"`
+}
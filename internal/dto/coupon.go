@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+type CouponRequest struct {
+	// Code is matched case insensitively and stored upper cased
+	Code        string `json:"code" binding:"required,max=64"`
+	Description string `json:"description"`
+	Type        string `json:"type" binding:"required,oneof=percentage fixed free_shipping"`
+
+	// Percent is the percentage off of percentage coupons and FixedAmount the
+	// amount off of fixed coupons; free shipping coupons ignore both
+	Percent     float64     `json:"percent" binding:"min=0,max=100"`
+	FixedAmount money.Money `json:"fixed_amount"`
+	MinSubtotal money.Money `json:"min_subtotal"`
+
+	// UsageLimit and PerCustomerLimit are unlimited when 0
+	UsageLimit       int `json:"usage_limit" binding:"min=0"`
+	PerCustomerLimit int `json:"per_customer_limit" binding:"min=0"`
+
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+
+	// ProductIDs and CategoryIDs restrict the discount to those products
+	// and categories; the coupon covers every product when both are empty
+	ProductIDs  []uint `json:"product_ids"`
+	CategoryIDs []uint `json:"category_ids"`
+	IsActive    *bool  `json:"is_active"`
+}
+
+type CouponResponse struct {
+	ID               uint        `json:"id"`
+	Code             string      `json:"code"`
+	Description      string      `json:"description"`
+	Type             string      `json:"type"`
+	Percent          float64     `json:"percent"`
+	FixedAmount      money.Money `json:"fixed_amount"`
+	MinSubtotal      money.Money `json:"min_subtotal"`
+	UsageLimit       int         `json:"usage_limit"`
+	PerCustomerLimit int         `json:"per_customer_limit"`
+	UsedCount        int         `json:"used_count"`
+	StartsAt         *time.Time  `json:"starts_at"`
+	EndsAt           *time.Time  `json:"ends_at"`
+	ProductIDs       []uint      `json:"product_ids"`
+	CategoryIDs      []uint      `json:"category_ids"`
+	IsActive         bool        `json:"is_active"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+}
+
+type ApplyCouponRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type AppliedCouponResponse struct {
+	Code string `json:"code"`
+	Type string `json:"type"`
+	// Discount is what the coupon currently takes off the cart, including
+	// waived shipping
+	Discount money.Money `json:"discount"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+type CurrencyResponse struct {
+	Code money.Currency `json:"code"`
+
+	// Rate is what one unit of the store currency is worth in the currency
+	Rate      float64 `json:"rate"`
+	IsDefault bool    `json:"is_default"`
+}
+
+type ExchangeRateRequest struct {
+	// Rate is what one unit of the store currency is worth in the currency
+	Rate float64 `json:"rate" binding:"required,gt=0"`
+}
+
+type ExchangeRateResponse struct {
+	Currency  money.Currency `json:"currency"`
+	Rate      float64        `json:"rate"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+type ProductPriceRequest struct {
+	// Amount is in minor units of the currency
+	Amount int64 `json:"amount" binding:"required,gt=0"`
+}
+
+type ProductPriceResponse struct {
+	ProductID uint        `json:"product_id"`
+	Price     money.Money `json:"price"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
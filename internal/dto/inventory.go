@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+// ListBackordersRequest filters and pages the order items waiting for stock.
+// Every filter is optional.
+type ListBackordersRequest struct {
+	ProductID   *uint  `form:"product_id" json:"product_id"`
+	StockStatus string `form:"stock_status" json:"stock_status" binding:"omitempty,oneof=backordered preordered"`
+
+	Page  int `form:"page" json:"page"`
+	Limit int `form:"limit" json:"limit"`
+}
+
+// BackorderResponse is an order item waiting for stock, either backordered
+// or preordered ahead of its product's availability date.
+type BackorderResponse struct {
+	OrderItemID         uint       `json:"order_item_id"`
+	OrderID             uint       `json:"order_id"`
+	OrderNumber         string     `json:"order_number"`
+	OrderStatus         string     `json:"order_status"`
+	ProductID           uint       `json:"product_id"`
+	ProductName         string     `json:"product_name"`
+	SKU                 string     `json:"sku"`
+	StockStatus         string     `json:"stock_status"`
+	Quantity            int        `json:"quantity"`
+	BackorderedQuantity int        `json:"backordered_quantity"`
+	AvailableAt         *time.Time `json:"available_at"`
+	// Stock is the product's stock on hand, below 0 by the units still owed
+	Stock     int       `json:"stock"`
+	OrderedAt time.Time `json:"ordered_at"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+type InvoiceResponse struct {
+	ID       uint      `json:"id"`
+	OrderID  uint      `json:"order_id"`
+	Number   string    `json:"number"`
+	IssuedAt time.Time `json:"issued_at"`
+
+	// DocumentPath is where the upload provider stored the rendered invoice
+	DocumentPath string     `json:"document_path"`
+	ContentType  string     `json:"content_type"`
+	RenderedAt   *time.Time `json:"rendered_at"`
+}
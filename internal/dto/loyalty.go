@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+// LoyaltyResponse is a user's loyalty points balance with a page of the
+// entries of their points ledger, newest first.
+type LoyaltyResponse struct {
+	// Balance is the points that can be spent now
+	Balance int `json:"balance"`
+	// BalanceValue is what the balance takes off an order, in the store currency
+	BalanceValue money.Money            `json:"balance_value"`
+	Entries      []LoyaltyEntryResponse `json:"entries"`
+}
+
+type LoyaltyEntryResponse struct {
+	ID uint `json:"id"`
+	// Type is earned, redeemed, expired, reversed or restored
+	Type string `json:"type"`
+	// Points are positive for credits and negative for debits
+	Points    int        `json:"points"`
+	OrderID   *uint      `json:"order_id,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
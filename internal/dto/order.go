@@ -1,6 +1,10 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
 
 type AddToCartRequest struct {
 	ProductID uint `json:"product_id" binding:"required"`
@@ -12,37 +16,210 @@ type UpdateCartItemRequest struct {
 }
 
 type CartResponse struct {
-	ID        uint               `json:"id"`
-	UserID    uint               `json:"user_id"`
+	ID     uint `json:"id"`
+	UserID uint `json:"user_id"`
+	// Token identifies a guest cart; it is empty for a user's cart
+	Token     string             `json:"token,omitempty"`
 	CartItems []CartItemResponse `json:"cart_items"`
-	Total     float64            `json:"total"`
-	CreatedAt time.Time          `json:"created_at"`
-	UpdatedAt time.Time          `json:"updated_at"`
+	Total     money.Money        `json:"total"`
+	// Shipping is the selected shipping method; it is empty while no method
+	// is selected or the selected one no longer applies to the cart
+	Shipping *ShippingOptionResponse `json:"shipping"`
+	// Coupon is the applied coupon; it is empty while no coupon is applied
+	// or the applied one no longer applies to the cart
+	Coupon     *AppliedCouponResponse     `json:"coupon"`
+	Promotions []AppliedPromotionResponse `json:"promotions"`
+	// Discount is what the promotions and the coupon take off together
+	Discount  money.Money `json:"discount"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
 }
 
 type CartItemResponse struct {
 	ID        uint            `json:"id"`
 	Product   ProductResponse `json:"product"`
 	Quantity  int             `json:"quantity"`
-	Subtotal  float64         `json:"subtotal"`
+	Subtotal  money.Money     `json:"subtotal"`
 	CreatedAt time.Time       `json:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at"`
 }
 
+type CreateOrderRequest struct {
+	// ShippingAddressID is an address from the user's address book
+	ShippingAddressID *uint `json:"shipping_address_id"`
+
+	// BillingAddressID defaults to the shipping address when omitted
+	BillingAddressID *uint `json:"billing_address_id"`
+
+	// UseWallet pays what the wallet balance covers of the order from the
+	// user's wallet; the payment provider is charged the rest
+	UseWallet bool `json:"use_wallet"`
+
+	// RedeemPoints spends up to this many of the user's loyalty points on the
+	// order, no more than its total needs
+	RedeemPoints int `json:"redeem_points" binding:"omitempty,min=0"`
+}
+
+// GuestOrderRequest checks out a guest cart. The guest's email receives the
+// order number, which together with the email looks the order up later.
+type GuestOrderRequest struct {
+	Email           string         `json:"email" binding:"required,email"`
+	ShippingAddress AddressRequest `json:"shipping_address" binding:"required"`
+
+	// BillingAddress defaults to the shipping address when omitted
+	BillingAddress *AddressRequest `json:"billing_address"`
+
+	// ShippingRateID selects the shipping method, quoted for the shipping
+	// address; the order ships for free without one
+	ShippingRateID *uint `json:"shipping_rate_id"`
+}
+
+// GuestOrderLookupRequest finds a guest order by the order number and the
+// email it was placed with, in any case.
+type GuestOrderLookupRequest struct {
+	Number string `json:"number" binding:"required"`
+	Email  string `json:"email" binding:"required,email"`
+}
+
 type OrderResponse struct {
-	ID          uint                `json:"id"`
-	UserID      uint                `json:"user_id"`
-	Status      string              `json:"status"`
-	TotalAmount float64             `json:"total_amount"`
-	OrderItems  []OrderItemResponse `json:"order_items"`
-	CreatedAt   time.Time           `json:"created_at"`
-	UpdatedAt   time.Time           `json:"updated_at"`
+	ID     uint   `json:"id"`
+	Number string `json:"number"`
+	// UserID is 0 for a guest order not yet attached to an account
+	UserID           uint                       `json:"user_id"`
+	GuestEmail       string                     `json:"guest_email,omitempty"`
+	Status           string                     `json:"status"`
+	TotalAmount      money.Money                `json:"total_amount"`
+	ShippingMethod   string                     `json:"shipping_method"`
+	ShippingCost     money.Money                `json:"shipping_cost"`
+	CouponCode       string                     `json:"coupon_code"`
+	DiscountAmount   money.Money                `json:"discount_amount"`
+	TaxAmount        money.Money                `json:"tax_amount"`
+	WalletAmount     money.Money                `json:"wallet_amount"`
+	LoyaltyPoints    int                        `json:"loyalty_points"`
+	LoyaltyDiscount  money.Money                `json:"loyalty_discount"`
+	PricesIncludeTax bool                       `json:"prices_include_tax"`
+	ExchangeRate     float64                    `json:"exchange_rate"`
+	ShippingAddress  *OrderAddressResponse      `json:"shipping_address"`
+	BillingAddress   *OrderAddressResponse      `json:"billing_address"`
+	OrderItems       []OrderItemResponse        `json:"order_items"`
+	Promotions       []AppliedPromotionResponse `json:"promotions"`
+	Payments         []PaymentResponse          `json:"payments"`
+	CreatedAt        time.Time                  `json:"created_at"`
+	UpdatedAt        time.Time                  `json:"updated_at"`
 }
 
 type OrderItemResponse struct {
 	ID        uint            `json:"id"`
 	Product   ProductResponse `json:"product"`
 	Quantity  int             `json:"quantity"`
-	Price     float64         `json:"price"`
+	Price     money.Money     `json:"price"`
+	Discount  money.Money     `json:"discount"`
+	TaxClass  string          `json:"tax_class"`
+	TaxRate   float64         `json:"tax_rate"`
+	TaxAmount money.Money     `json:"tax_amount"`
 	CreatedAt time.Time       `json:"created_at"`
+
+	// StockStatus is in_stock, backordered or preordered
+	StockStatus         string     `json:"stock_status"`
+	BackorderedQuantity int        `json:"backordered_quantity"`
+	AvailableAt         *time.Time `json:"available_at"`
+}
+
+type PaymentResponse struct {
+	ID             uint        `json:"id"`
+	Provider       string      `json:"provider"`
+	Reference      string      `json:"reference"`
+	Status         string      `json:"status"`
+	Amount         money.Money `json:"amount"`
+	CapturedAmount money.Money `json:"captured_amount"`
+	RefundedAmount money.Money `json:"refunded_amount"`
+	CreatedAt      time.Time   `json:"created_at"`
+}
+
+type UpdateOrderStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+	Reason string `json:"reason"`
+}
+
+type CancelOrderRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ListOrdersRequest filters, sorts and pages the orders of every customer.
+// Every filter is optional.
+type ListOrdersRequest struct {
+	Status      string     `form:"status" json:"status"`
+	CreatedFrom *time.Time `form:"created_from" json:"created_from"`
+	CreatedTo   *time.Time `form:"created_to" json:"created_to"`
+
+	// Email is the email address of the customer or of the guest who placed
+	// the order, in any case
+	Email string `form:"email" json:"email"`
+
+	// MinTotal and MaxTotal are in minor units of the order's currency. A
+	// currency given with them also narrows the orders to that currency.
+	MinTotal *money.Money `form:"min_total" json:"min_total"`
+	MaxTotal *money.Money `form:"max_total" json:"max_total"`
+
+	// SKU matches orders with at least one item of the product
+	SKU string `form:"sku" json:"sku"`
+
+	// Sort is created_at, total or status, prefixed with - to sort
+	// descending. Orders are newest first by default.
+	Sort string `form:"sort" json:"sort"`
+
+	Page  int `form:"page" json:"page"`
+	Limit int `form:"limit" json:"limit"`
+}
+
+// ExportOrdersRequest exports the orders matching the filters of an order
+// listing. Paging is ignored; Format is csv, the default, or jsonl.
+type ExportOrdersRequest struct {
+	ListOrdersRequest
+	Format string `form:"format" json:"format" binding:"omitempty,oneof=csv jsonl"`
+}
+
+// AdminOrderResponse is an order with the customer who placed it and the
+// full history of its status changes.
+type AdminOrderResponse struct {
+	OrderResponse
+	Customer      UserResponse                 `json:"customer"`
+	StatusHistory []OrderStatusHistoryResponse `json:"status_history"`
+}
+
+type OrderStatusHistoryResponse struct {
+	ID         uint      `json:"id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	ChangedBy  uint      `json:"changed_by"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Reorder line statuses
+const (
+	ReorderLineAdded    = "added"
+	ReorderLineAdjusted = "adjusted"
+	ReorderLineDropped  = "dropped"
+)
+
+// ReorderResponse is the cart after the items of a past order were added to
+// it, with a line for every item of the order. Cart is empty when nothing
+// could be added and the user has no cart.
+type ReorderResponse struct {
+	Cart  *CartResponse         `json:"cart"`
+	Lines []ReorderLineResponse `json:"lines"`
+}
+
+// ReorderLineResponse reports what happened to one item of the past order:
+// added in full, adjusted to a lower quantity or dropped, with the reason
+// for the last two.
+type ReorderLineResponse struct {
+	OrderItemID       uint   `json:"order_item_id"`
+	ProductID         uint   `json:"product_id"`
+	ProductName       string `json:"product_name"`
+	RequestedQuantity int    `json:"requested_quantity"`
+	AddedQuantity     int    `json:"added_quantity"`
+	Status            string `json:"status"`
+	Reason            string `json:"reason,omitempty"`
 }
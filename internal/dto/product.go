@@ -1,6 +1,10 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
 
 type CreateCategoryRequest struct {
 	Name        string `json:"name" binding:"required"`
@@ -23,21 +27,45 @@ type CategoryResponse struct {
 }
 
 type CreateProductRequest struct {
-	CategoryID  uint    `json:"category_id" binding:"required"`
-	Name        string  `json:"name" binding:"required"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" binding:"required,gt=0"`
-	Stock       int     `json:"stock" binding:"min=0"`
-	SKU         string  `json:"sku" binding:"required"`
+	CategoryID  uint        `json:"category_id" binding:"required"`
+	Name        string      `json:"name" binding:"required"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price"`
+	Stock       int         `json:"stock" binding:"min=0"`
+	SKU         string      `json:"sku" binding:"required"`
+	Weight      float64     `json:"weight" binding:"min=0"`
+	Length      float64     `json:"length" binding:"min=0"`
+	Width       float64     `json:"width" binding:"min=0"`
+	Height      float64     `json:"height" binding:"min=0"`
+	TaxClass    string      `json:"tax_class"`
+
+	// InventoryPolicy is deny, the default, backorder or preorder.
+	// BackorderLimit is how many units may be sold beyond the stock on hand,
+	// and AvailableAt when a preordered product is expected to be available.
+	InventoryPolicy string     `json:"inventory_policy"`
+	BackorderLimit  int        `json:"backorder_limit" binding:"min=0"`
+	AvailableAt     *time.Time `json:"available_at"`
 }
 
 type UpdateProductRequest struct {
-	CategoryID  uint    `json:"category_id" binding:"required"`
-	Name        string  `json:"name" binding:"required"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" binding:"required,gt=0"`
-	Stock       int     `json:"stock" binding:"min=0"`
-	IsActive    *bool   `json:"is_active"`
+	CategoryID  uint        `json:"category_id" binding:"required"`
+	Name        string      `json:"name" binding:"required"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price"`
+	Stock       int         `json:"stock" binding:"min=0"`
+	Weight      float64     `json:"weight" binding:"min=0"`
+	Length      float64     `json:"length" binding:"min=0"`
+	Width       float64     `json:"width" binding:"min=0"`
+	Height      float64     `json:"height" binding:"min=0"`
+	TaxClass    string      `json:"tax_class"`
+	IsActive    *bool       `json:"is_active"`
+
+	// InventoryPolicy is deny, the default, backorder or preorder.
+	// BackorderLimit is how many units may be sold beyond the stock on hand,
+	// and AvailableAt when a preordered product is expected to be available.
+	InventoryPolicy string     `json:"inventory_policy"`
+	BackorderLimit  int        `json:"backorder_limit" binding:"min=0"`
+	AvailableAt     *time.Time `json:"available_at"`
 }
 
 type ProductResponse struct {
@@ -45,14 +73,24 @@ type ProductResponse struct {
 	CategoryID  uint                   `json:"category_id"`
 	Name        string                 `json:"name"`
 	Description string                 `json:"description"`
-	Price       float64                `json:"price"`
+	Price       money.Money            `json:"price"`
 	Stock       int                    `json:"stock"`
 	SKU         string                 `json:"sku"`
+	Weight      float64                `json:"weight"`
+	Length      float64                `json:"length"`
+	Width       float64                `json:"width"`
+	Height      float64                `json:"height"`
+	TaxClass    string                 `json:"tax_class"`
 	IsActive    bool                   `json:"is_active"`
 	Category    CategoryResponse       `json:"category"`
 	Images      []ProductImageResponse `json:"images"`
 	CreatedAt   time.Time              `json:"created_at"`
 	UpdatedAt   time.Time              `json:"updated_at"`
+
+	// BackorderLimit, like Stock, is what is left to sell
+	InventoryPolicy string     `json:"inventory_policy"`
+	BackorderLimit  int        `json:"backorder_limit"`
+	AvailableAt     *time.Time `json:"available_at"`
 }
 
 type ProductImageResponse struct {
@@ -64,12 +102,14 @@ type ProductImageResponse struct {
 }
 
 type SearchProductsRequest struct {
-	Query      string   `form:"q" binding:"required,min=1"`
-	Page       int      `form:"page"`
-	Limit      int      `form:"limit"`
-	CategoryID *uint    `form:"category_id"`
-	MinPrice   *float64 `form:"min_price"`
-	MaxPrice   *float64 `form:"max_price"`
+	Query      string `form:"q" binding:"required,min=1"`
+	Page       int    `form:"page"`
+	Limit      int    `form:"limit"`
+	CategoryID *uint  `form:"category_id"`
+
+	// MinPrice and MaxPrice are in minor units of the currency shopped in
+	MinPrice *money.Money `form:"min_price"`
+	MaxPrice *money.Money `form:"max_price"`
 }
 
 type ProductSearchResult struct {
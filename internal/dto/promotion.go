@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+type PromotionRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Type        string `json:"type" binding:"required,oneof=buy_x_get_y spend_threshold bundle flash_sale"`
+
+	// ProductID and CategoryID limit the promotion to those items; it looks
+	// at every item when both are empty
+	ProductID  *uint `json:"product_id"`
+	CategoryID *uint `json:"category_id"`
+
+	// BuyQuantity, GetQuantity and DiscountPercent configure buy_x_get_y:
+	// buy 2 get 1 free is 2, 1 and 100
+	BuyQuantity int `json:"buy_quantity" binding:"min=0"`
+	GetQuantity int `json:"get_quantity" binding:"min=0"`
+
+	// DiscountPercent is also the percentage off of a flash_sale
+	DiscountPercent float64 `json:"discount_percent" binding:"min=0,max=100"`
+
+	// BundleQuantity units of a bundle cost BundlePrice together
+	BundleQuantity int         `json:"bundle_quantity" binding:"min=0"`
+	BundlePrice    money.Money `json:"bundle_price"`
+
+	// Tiers configure spend_threshold; the highest tier reached applies
+	Tiers []PromotionTierRequest `json:"tiers" binding:"dive"`
+
+	// Priority orders evaluation, highest first. A promotion that is not
+	// stackable is never combined with other promotions
+	Priority  int        `json:"priority"`
+	Stackable *bool      `json:"stackable"`
+	StartsAt  *time.Time `json:"starts_at"`
+	EndsAt    *time.Time `json:"ends_at"`
+	IsActive  *bool      `json:"is_active"`
+}
+
+type PromotionTierRequest struct {
+	Threshold money.Money `json:"threshold"`
+	Amount    money.Money `json:"amount"`
+}
+
+type PromotionResponse struct {
+	ID              uint                    `json:"id"`
+	Name            string                  `json:"name"`
+	Description     string                  `json:"description"`
+	Type            string                  `json:"type"`
+	ProductID       *uint                   `json:"product_id"`
+	CategoryID      *uint                   `json:"category_id"`
+	BuyQuantity     int                     `json:"buy_quantity"`
+	GetQuantity     int                     `json:"get_quantity"`
+	DiscountPercent float64                 `json:"discount_percent"`
+	BundleQuantity  int                     `json:"bundle_quantity"`
+	BundlePrice     money.Money             `json:"bundle_price"`
+	Tiers           []PromotionTierResponse `json:"tiers"`
+	Priority        int                     `json:"priority"`
+	Stackable       bool                    `json:"stackable"`
+	StartsAt        *time.Time              `json:"starts_at"`
+	EndsAt          *time.Time              `json:"ends_at"`
+	IsActive        bool                    `json:"is_active"`
+	CreatedAt       time.Time               `json:"created_at"`
+	UpdatedAt       time.Time               `json:"updated_at"`
+}
+
+type PromotionTierResponse struct {
+	ID        uint        `json:"id"`
+	Threshold money.Money `json:"threshold"`
+	Amount    money.Money `json:"amount"`
+}
+
+type AppliedPromotionResponse struct {
+	PromotionID uint        `json:"promotion_id"`
+	Name        string      `json:"name"`
+	Discount    money.Money `json:"discount"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+type CreateReturnRequest struct {
+	Reason string              `json:"reason"`
+	Items  []ReturnItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+type ReturnItemRequest struct {
+	OrderItemID uint   `json:"order_item_id" binding:"required"`
+	Quantity    int    `json:"quantity" binding:"required,min=1"`
+	Reason      string `json:"reason"`
+}
+
+type ReceiveReturnRequest struct {
+	// Restock puts the returned quantities back on product stock
+	Restock bool `json:"restock"`
+}
+
+type RefundReturnRequest struct {
+	// ToWallet credits the whole refund to the user's wallet rather than
+	// paying it back through the order's payments
+	ToWallet bool `json:"to_wallet"`
+}
+
+type ReturnResponse struct {
+	ID                    uint                 `json:"id"`
+	OrderID               uint                 `json:"order_id"`
+	UserID                uint                 `json:"user_id"`
+	Status                string               `json:"status"`
+	Reason                string               `json:"reason"`
+	RefundAmount          money.Money          `json:"refund_amount"`
+	WalletRefundAmount    money.Money          `json:"wallet_refund_amount"`
+	LoyaltyPointsRestored int                  `json:"loyalty_points_restored"`
+	Restocked             bool                 `json:"restocked"`
+	Lines                 []ReturnLineResponse `json:"lines"`
+	CreatedAt             time.Time            `json:"created_at"`
+	UpdatedAt             time.Time            `json:"updated_at"`
+}
+
+type ReturnLineResponse struct {
+	ID           uint        `json:"id"`
+	OrderItemID  uint        `json:"order_item_id"`
+	ProductID    uint        `json:"product_id"`
+	Quantity     int         `json:"quantity"`
+	Price        money.Money `json:"price"`
+	RefundAmount money.Money `json:"refund_amount"`
+	Reason       string      `json:"reason"`
+}
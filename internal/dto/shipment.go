@@ -0,0 +1,49 @@
+package dto
+
+import "time"
+
+type CreateShipmentRequest struct {
+	Carrier        string `json:"carrier" binding:"required"`
+	TrackingNumber string `json:"tracking_number"`
+
+	// ShippedAt defaults to now
+	ShippedAt *time.Time `json:"shipped_at"`
+
+	// Items defaults to everything on the order that has not been shipped yet
+	Items []ShipmentItemRequest `json:"items" binding:"omitempty,dive"`
+}
+
+type ShipmentItemRequest struct {
+	OrderItemID uint `json:"order_item_id" binding:"required"`
+	Quantity    int  `json:"quantity" binding:"required,min=1"`
+}
+
+// UpdateShipmentRequest changes the tracking details of a shipment. Fields
+// left out are kept.
+type UpdateShipmentRequest struct {
+	Carrier        *string `json:"carrier" binding:"omitempty,min=1"`
+	TrackingNumber *string `json:"tracking_number"`
+
+	// DeliveredAt marks the shipment delivered
+	DeliveredAt *time.Time `json:"delivered_at"`
+}
+
+type ShipmentResponse struct {
+	ID             uint                   `json:"id"`
+	OrderID        uint                   `json:"order_id"`
+	Status         string                 `json:"status"`
+	Carrier        string                 `json:"carrier"`
+	TrackingNumber string                 `json:"tracking_number"`
+	ShippedAt      time.Time              `json:"shipped_at"`
+	DeliveredAt    *time.Time             `json:"delivered_at"`
+	Lines          []ShipmentLineResponse `json:"lines"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+type ShipmentLineResponse struct {
+	ID          uint `json:"id"`
+	OrderItemID uint `json:"order_item_id"`
+	ProductID   uint `json:"product_id"`
+	Quantity    int  `json:"quantity"`
+}
@@ -0,0 +1,84 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+type ShippingZoneRequest struct {
+	Name     string                      `json:"name" binding:"required"`
+	IsActive *bool                       `json:"is_active"`
+	Regions  []ShippingZoneRegionRequest `json:"regions" binding:"required,min=1,dive"`
+}
+
+type ShippingZoneRegionRequest struct {
+	// Country is an ISO 3166-1 alpha-2 code
+	Country string `json:"country" binding:"required,len=2"`
+
+	// State narrows the region to one state or province of the country
+	State string `json:"state"`
+
+	// PostcodePrefix narrows the region to postcodes starting with it
+	PostcodePrefix string `json:"postcode_prefix"`
+}
+
+type ShippingRateRequest struct {
+	Name string `json:"name" binding:"required"`
+	Type string `json:"type" binding:"required,oneof=flat weight free_over"`
+
+	// Price is charged when the rate applies; free_over rates charge nothing
+	// once the cart subtotal reaches FreeOver
+	Price money.Money `json:"price"`
+
+	// MinWeight and MaxWeight bound weight rates in kilograms, from MinWeight
+	// up to but excluding MaxWeight; a MaxWeight of 0 means no upper bound
+	MinWeight float64 `json:"min_weight" binding:"min=0"`
+	MaxWeight float64 `json:"max_weight" binding:"min=0"`
+
+	FreeOver money.Money `json:"free_over"`
+	IsActive *bool       `json:"is_active"`
+}
+
+type ShippingZoneResponse struct {
+	ID        uint                         `json:"id"`
+	Name      string                       `json:"name"`
+	IsActive  bool                         `json:"is_active"`
+	Regions   []ShippingZoneRegionResponse `json:"regions"`
+	Rates     []ShippingRateResponse       `json:"rates"`
+	CreatedAt time.Time                    `json:"created_at"`
+	UpdatedAt time.Time                    `json:"updated_at"`
+}
+
+type ShippingZoneRegionResponse struct {
+	ID             uint   `json:"id"`
+	Country        string `json:"country"`
+	State          string `json:"state"`
+	PostcodePrefix string `json:"postcode_prefix"`
+}
+
+type ShippingRateResponse struct {
+	ID        uint        `json:"id"`
+	ZoneID    uint        `json:"zone_id"`
+	Name      string      `json:"name"`
+	Type      string      `json:"type"`
+	Price     money.Money `json:"price"`
+	MinWeight float64     `json:"min_weight"`
+	MaxWeight float64     `json:"max_weight"`
+	FreeOver  money.Money `json:"free_over"`
+	IsActive  bool        `json:"is_active"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+type SelectShippingMethodRequest struct {
+	// AddressID is the address book entry the cart will be shipped to
+	AddressID      uint `json:"address_id" binding:"required"`
+	ShippingRateID uint `json:"shipping_rate_id" binding:"required"`
+}
+
+type ShippingOptionResponse struct {
+	RateID uint        `json:"rate_id"`
+	Method string      `json:"method"`
+	Cost   money.Money `json:"cost"`
+}
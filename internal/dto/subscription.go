@@ -0,0 +1,62 @@
+package dto
+
+import "time"
+
+// CreateSubscriptionRequest subscribes to a product delivered every
+// IntervalWeeks weeks to an address of the user's address book.
+type CreateSubscriptionRequest struct {
+	ProductID         uint `json:"product_id" binding:"required"`
+	Quantity          int  `json:"quantity" binding:"required,min=1"`
+	IntervalWeeks     int  `json:"interval_weeks" binding:"required,min=1,max=52"`
+	ShippingAddressID uint `json:"shipping_address_id" binding:"required"`
+
+	// BillingAddressID defaults to the shipping address when omitted
+	BillingAddressID *uint `json:"billing_address_id"`
+
+	// ShippingRateID selects the shipping method, quoted for the shipping
+	// address on every order; the orders ship for free without one
+	ShippingRateID *uint `json:"shipping_rate_id"`
+
+	// StartsAt is when the first order is placed, straight away when omitted
+	StartsAt *time.Time `json:"starts_at"`
+}
+
+// UpdateSubscriptionRequest changes what a subscription orders and where to.
+// NextRunAt, when given, moves the next order to another date.
+type UpdateSubscriptionRequest struct {
+	Quantity          int        `json:"quantity" binding:"required,min=1"`
+	IntervalWeeks     int        `json:"interval_weeks" binding:"required,min=1,max=52"`
+	ShippingAddressID uint       `json:"shipping_address_id" binding:"required"`
+	BillingAddressID  *uint      `json:"billing_address_id"`
+	ShippingRateID    *uint      `json:"shipping_rate_id"`
+	NextRunAt         *time.Time `json:"next_run_at"`
+}
+
+type SubscriptionResponse struct {
+	ID                uint       `json:"id"`
+	ProductID         uint       `json:"product_id"`
+	ProductName       string     `json:"product_name"`
+	Quantity          int        `json:"quantity"`
+	IntervalWeeks     int        `json:"interval_weeks"`
+	ShippingAddressID *uint      `json:"shipping_address_id"`
+	BillingAddressID  *uint      `json:"billing_address_id"`
+	ShippingRateID    *uint      `json:"shipping_rate_id"`
+	Status            string     `json:"status"`
+	NextRunAt         time.Time  `json:"next_run_at"`
+	CancelledAt       *time.Time `json:"cancelled_at"`
+	// Runs are the latest runs, newest first; they are only listed for a
+	// single subscription
+	Runs      []SubscriptionRunResponse `json:"runs,omitempty"`
+	CreatedAt time.Time                 `json:"created_at"`
+	UpdatedAt time.Time                 `json:"updated_at"`
+}
+
+// SubscriptionRunResponse is a scheduled run of a subscription, with the
+// order it placed or the error that kept it from placing one.
+type SubscriptionRunResponse struct {
+	ID        uint      `json:"id"`
+	RunAt     time.Time `json:"run_at"`
+	OrderID   *uint     `json:"order_id"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+type TaxRateRequest struct {
+	Name string `json:"name" binding:"required"`
+
+	// Country is an ISO 3166-1 alpha-2 code
+	Country string `json:"country" binding:"required,len=2"`
+
+	// State limits the rate to one state of the country; it then takes
+	// precedence over the country wide rate of the same tax class
+	State string `json:"state"`
+
+	// TaxClass defaults to standard
+	TaxClass string `json:"tax_class"`
+
+	// Rate is a percentage, 20 for 20%
+	Rate     float64 `json:"rate" binding:"min=0,max=100"`
+	IsActive *bool   `json:"is_active"`
+}
+
+type TaxRateResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Country   string    `json:"country"`
+	State     string    `json:"state"`
+	TaxClass  string    `json:"tax_class"`
+	Rate      float64   `json:"rate"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
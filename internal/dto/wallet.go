@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+// WalletResponse is a user's store credit, in the store currency.
+type WalletResponse struct {
+	Balance money.Money `json:"balance"`
+}
+
+type WalletEntryResponse struct {
+	ID uint `json:"id"`
+	// Type is credit or debit
+	Type string `json:"type"`
+	// Source is gift_card, grant, order, order_cancelled or return
+	Source     string      `json:"source"`
+	Amount     money.Money `json:"amount"`
+	Balance    money.Money `json:"balance"`
+	GiftCardID *uint       `json:"gift_card_id,omitempty"`
+	OrderID    *uint       `json:"order_id,omitempty"`
+	ReturnID   *uint       `json:"return_id,omitempty"`
+	Note       string      `json:"note,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type RedeemGiftCardRequest struct {
+	// Code is matched ignoring case, dashes and spaces
+	Code string `json:"code" binding:"required"`
+}
+
+// GrantCreditRequest credits a user's wallet with store credit.
+type GrantCreditRequest struct {
+	Amount money.Money `json:"amount"`
+	Note   string      `json:"note" binding:"max=255"`
+}
+
+type GiftCardRequest struct {
+	// Value is the store credit the card is worth, in the store currency
+	Value     money.Money `json:"value"`
+	Note      string      `json:"note" binding:"max=255"`
+	ExpiresAt *time.Time  `json:"expires_at"`
+}
+
+type GiftCardResponse struct {
+	ID uint `json:"id"`
+	// Code is only returned when the card is issued; afterwards the card is
+	// known by the last characters of its code
+	Code         string      `json:"code,omitempty"`
+	CodeHint     string      `json:"code_hint"`
+	InitialValue money.Money `json:"initial_value"`
+	Note         string      `json:"note"`
+	ExpiresAt    *time.Time  `json:"expires_at"`
+	IssuedBy     uint        `json:"issued_by"`
+	RedeemedBy   *uint       `json:"redeemed_by"`
+	RedeemedAt   *time.Time  `json:"redeemed_at"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
@@ -0,0 +1,92 @@
+package exports
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+var csvHeader = []string{
+	"order_id", "order_date", "status", "customer_id", "customer_email", "currency",
+	"order_total", "shipping_cost", "discount", "tax", "exchange_rate",
+	"item_id", "product_id", "sku", "product_name", "quantity",
+	"unit_price", "item_discount", "tax_rate", "item_tax",
+}
+
+// CSVWriter writes one row per order item, repeating the order's columns on
+// every row. An order without items gets a single row with empty item
+// columns. Amounts are decimals in the order's currency.
+type CSVWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+// NewCSVWriter creates a CSV writer writing to w.
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w)}
+}
+
+func (cw *CSVWriter) Write(order *Order) error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+
+	columns := []string{
+		strconv.FormatUint(uint64(order.ID), 10),
+		order.CreatedAt.UTC().Format(time.RFC3339),
+		order.Status,
+		strconv.FormatUint(uint64(order.CustomerID), 10),
+		order.CustomerEmail,
+		string(order.TotalAmount.Currency),
+		order.TotalAmount.Decimal(),
+		order.ShippingCost.Decimal(),
+		order.DiscountAmount.Decimal(),
+		order.TaxAmount.Decimal(),
+		strconv.FormatFloat(order.ExchangeRate, 'f', -1, 64),
+	}
+
+	if len(order.Items) == 0 {
+		return cw.w.Write(append(columns, make([]string, len(csvHeader)-len(columns))...))
+	}
+
+	for _, item := range order.Items {
+		row := append(columns[:len(columns):len(columns)],
+			strconv.FormatUint(uint64(item.ID), 10),
+			strconv.FormatUint(uint64(item.ProductID), 10),
+			item.SKU,
+			item.ProductName,
+			strconv.Itoa(item.Quantity),
+			item.UnitPrice.Decimal(),
+			item.Discount.Decimal(),
+			strconv.FormatFloat(item.TaxRate, 'f', -1, 64),
+			item.TaxAmount.Decimal(),
+		)
+
+		if err := cw.w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Flush writes out the buffered rows. An export without orders still gets
+// its header.
+func (cw *CSVWriter) Flush() error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *CSVWriter) writeHeader() error {
+	if cw.headerWritten {
+		return nil
+	}
+
+	cw.headerWritten = true
+	return cw.w.Write(csvHeader)
+}
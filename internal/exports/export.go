@@ -0,0 +1,89 @@
+// Package exports writes orders out for other systems such as accounting.
+// Writers take one order at a time, so an export never has to hold more than
+// the order being written in memory.
+package exports
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+// ErrUnsupportedFormat is returned for an export format without a writer.
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Format is a file format orders can be exported to.
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+// ContentType is the media type of files in the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatJSONL:
+		return "application/jsonl; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Extension is the file extension of the format, including the dot.
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// Order is an exported order with its items.
+type Order struct {
+	ID             uint        `json:"id"`
+	Status         string      `json:"status"`
+	CustomerID     uint        `json:"customer_id"`
+	CustomerEmail  string      `json:"customer_email"`
+	TotalAmount    money.Money `json:"total_amount"`
+	ShippingCost   money.Money `json:"shipping_cost"`
+	DiscountAmount money.Money `json:"discount_amount"`
+	TaxAmount      money.Money `json:"tax_amount"`
+	ExchangeRate   float64     `json:"exchange_rate"`
+	CreatedAt      time.Time   `json:"created_at"`
+	Items          []Item      `json:"items"`
+}
+
+// Item is one line of an exported order.
+type Item struct {
+	ID          uint        `json:"id"`
+	ProductID   uint        `json:"product_id"`
+	SKU         string      `json:"sku"`
+	ProductName string      `json:"product_name"`
+	Quantity    int         `json:"quantity"`
+	UnitPrice   money.Money `json:"unit_price"`
+	Discount    money.Money `json:"discount"`
+	TaxRate     float64     `json:"tax_rate"`
+	TaxAmount   money.Money `json:"tax_amount"`
+}
+
+// Writer is implemented by every format orders can be exported to.
+type Writer interface {
+	Write(order *Order) error
+	// Flush writes out anything still buffered. It must be called once
+	// every order is written.
+	Flush() error
+}
+
+// NewWriter creates the writer of format writing to w.
+func NewWriter(format Format, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatJSONL:
+		return NewJSONLWriter(w), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
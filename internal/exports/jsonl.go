@@ -0,0 +1,32 @@
+package exports
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// JSONLWriter writes one JSON object per line for every order, with its
+// items nested. Amounts are in minor units, like in API responses.
+type JSONLWriter struct {
+	buf     *bufio.Writer
+	encoder *json.Encoder
+}
+
+// NewJSONLWriter creates a JSON Lines writer writing to w.
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	buf := bufio.NewWriter(w)
+	return &JSONLWriter{buf: buf, encoder: json.NewEncoder(buf)}
+}
+
+func (jw *JSONLWriter) Write(order *Order) error {
+	if order.Items == nil {
+		order.Items = []Item{}
+	}
+
+	return jw.encoder.Encode(order)
+}
+
+func (jw *JSONLWriter) Flush() error {
+	return jw.buf.Flush()
+}
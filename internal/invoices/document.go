@@ -0,0 +1,212 @@
+// Package invoices renders the invoice documents of orders. Renderers only
+// work on a Document built from loaded models; storing the result is left to
+// the caller.
+package invoices
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+// Document is everything printed on an invoice.
+type Document struct {
+	Number           string
+	IssuedAt         time.Time
+	OrderID          uint
+	OrderedAt        time.Time
+	BillingAddress   []string
+	ShippingAddress  []string
+	Lines            []Line
+	TaxLines         []TaxLine
+	PricesIncludeTax bool
+	CouponCode       string
+
+	Subtotal       money.Money
+	DiscountAmount money.Money
+	ShippingMethod string
+	ShippingCost   money.Money
+	TaxAmount      money.Money
+	Total          money.Money
+}
+
+// Line is one ordered item. Total is what the customer paid for the line,
+// after its discount and, with tax exclusive pricing, including its tax.
+type Line struct {
+	Description string
+	SKU         string
+	Quantity    int
+	UnitPrice   money.Money
+	Discount    money.Money
+	TaxRate     float64
+	TaxAmount   money.Money
+	Total       money.Money
+}
+
+// TaxLine sums up the tax charged at one rate of a tax class.
+type TaxLine struct {
+	TaxClass  string
+	Rate      float64
+	NetAmount money.Money
+	TaxAmount money.Money
+}
+
+// NewDocument builds the document of the invoice. The order must have its
+// items and their products loaded.
+func NewDocument(invoice *models.Invoice, order *models.Order) *Document {
+	doc := &Document{
+		Number:           invoice.Number,
+		IssuedAt:         invoice.IssuedAt,
+		OrderID:          order.ID,
+		OrderedAt:        order.CreatedAt,
+		BillingAddress:   addressLines(order.BillingAddress),
+		ShippingAddress:  addressLines(order.ShippingAddress),
+		Lines:            make([]Line, len(order.OrderItems)),
+		PricesIncludeTax: order.PricesIncludeTax,
+		CouponCode:       order.CouponCode,
+		DiscountAmount:   order.DiscountAmount,
+		ShippingMethod:   order.ShippingMethod,
+		ShippingCost:     order.ShippingCost,
+		TaxAmount:        order.TaxAmount,
+		Subtotal:         money.Zero(order.TotalAmount.Currency),
+		Total:            order.TotalAmount,
+	}
+
+	type taxKey struct {
+		class string
+		rate  float64
+	}
+	taxes := make(map[taxKey]*TaxLine)
+
+	for i := range order.OrderItems {
+		item := &order.OrderItems[i]
+
+		amount := item.Price.Mul(item.Quantity).Sub(item.Discount)
+		net, total := amount, amount
+		if order.PricesIncludeTax {
+			net = amount.Sub(item.TaxAmount)
+		} else {
+			total = amount.Add(item.TaxAmount)
+		}
+
+		// Items ordered before their product was kept on them fall back to
+		// the product as it is now
+		description, sku := item.ProductName, item.SKU
+		if description == "" {
+			description, sku = item.Product.Name, item.Product.SKU
+		}
+
+		doc.Lines[i] = Line{
+			Description: description,
+			SKU:         sku,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.Price,
+			Discount:    item.Discount,
+			TaxRate:     item.TaxRate,
+			TaxAmount:   item.TaxAmount,
+			Total:       total,
+		}
+		doc.Subtotal = doc.Subtotal.Add(item.Price.Mul(item.Quantity))
+
+		key := taxKey{class: item.TaxClass, rate: item.TaxRate}
+		line, ok := taxes[key]
+		if !ok {
+			line = &TaxLine{TaxClass: item.TaxClass, Rate: item.TaxRate}
+			taxes[key] = line
+		}
+		line.NetAmount = line.NetAmount.Add(net)
+		line.TaxAmount = line.TaxAmount.Add(item.TaxAmount)
+	}
+
+	for _, line := range taxes {
+		doc.TaxLines = append(doc.TaxLines, *line)
+	}
+	sort.Slice(doc.TaxLines, func(i, j int) bool {
+		if doc.TaxLines[i].Rate != doc.TaxLines[j].Rate {
+			return doc.TaxLines[i].Rate > doc.TaxLines[j].Rate
+		}
+		return doc.TaxLines[i].TaxClass < doc.TaxLines[j].TaxClass
+	})
+
+	return doc
+}
+
+// addressLines formats the address the way it is printed, skipping empty parts.
+func addressLines(address models.OrderAddress) []string {
+	if address.IsZero() {
+		return nil
+	}
+
+	var lines []string
+	for _, line := range []string{
+		strings.TrimSpace(address.FirstName + " " + address.LastName),
+		address.Line1,
+		address.Line2,
+		strings.TrimSpace(strings.Join(nonEmpty(address.PostalCode, address.City, address.State), " ")),
+		address.Country,
+		address.Phone,
+	} {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+// totalRow is one line of the totals block under the items.
+type totalRow struct {
+	label string
+	value string
+	bold  bool
+}
+
+func totalRows(doc *Document) []totalRow {
+	rows := []totalRow{{label: "Subtotal", value: formatMoney(doc.Subtotal)}}
+
+	if doc.DiscountAmount.IsPositive() {
+		label := "Discount"
+		if doc.CouponCode != "" {
+			label += " (" + doc.CouponCode + ")"
+		}
+		rows = append(rows, totalRow{label: label, value: formatMoney(doc.DiscountAmount.Neg())})
+	}
+
+	if doc.ShippingMethod != "" {
+		rows = append(rows, totalRow{label: "Shipping (" + doc.ShippingMethod + ")", value: formatMoney(doc.ShippingCost)})
+	}
+
+	rows = append(rows,
+		totalRow{label: "Tax", value: formatMoney(doc.TaxAmount)},
+		totalRow{label: "Total (" + string(doc.Total.Currency) + ")", value: formatMoney(doc.Total), bold: true},
+	)
+
+	return rows
+}
+
+func formatMoney(amount money.Money) string {
+	return amount.Decimal()
+}
+
+func formatRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', -1, 64)
+}
+
+func formatDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
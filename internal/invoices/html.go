@@ -0,0 +1,95 @@
+package invoices
+
+import (
+	"bytes"
+	"html/template"
+)
+
+var invoiceTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"money": formatMoney,
+	"rate":  formatRate,
+	"date":  formatDate,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Invoice {{.Doc.Number}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; font-size: 14px; margin: 40px; }
+table { border-collapse: collapse; width: 100%; margin-top: 24px; }
+th, td { text-align: left; padding: 4px 8px; }
+td.amount, th.amount { text-align: right; }
+.addresses td { vertical-align: top; width: 50%; }
+.sku { color: #666; font-size: 11px; }
+</style>
+</head>
+<body>
+<h1>Invoice</h1>
+<p>
+Invoice number: {{.Doc.Number}}<br>
+Issued: {{date .Doc.IssuedAt}}<br>
+Order: #{{.Doc.OrderID}}<br>
+Order date: {{date .Doc.OrderedAt}}
+</p>
+<table class="addresses">
+<tr><th>Bill to</th><th>Ship to</th></tr>
+<tr>
+<td>{{range .Doc.BillingAddress}}{{.}}<br>{{end}}</td>
+<td>{{range .Doc.ShippingAddress}}{{.}}<br>{{end}}</td>
+</tr>
+</table>
+<table class="items">
+<tr><th>Description</th><th class="amount">Qty</th><th class="amount">Unit price</th><th class="amount">Discount</th><th class="amount">Tax</th><th class="amount">Total</th></tr>
+{{range .Doc.Lines}}<tr>
+<td>{{.Description}}{{if .SKU}}<br><span class="sku">SKU {{.SKU}}</span>{{end}}</td>
+<td class="amount">{{.Quantity}}</td>
+<td class="amount">{{money .UnitPrice}}</td>
+<td class="amount">{{money .Discount}}</td>
+<td class="amount">{{money .TaxAmount}} ({{rate .TaxRate}}%)</td>
+<td class="amount">{{money .Total}}</td>
+</tr>
+{{end}}</table>
+<table class="totals">
+{{range .Totals}}<tr><td class="amount">{{if .Bold}}<strong>{{.Label}}</strong>{{else}}{{.Label}}{{end}}</td><td class="amount">{{if .Bold}}<strong>{{.Value}}</strong>{{else}}{{.Value}}{{end}}</td></tr>
+{{end}}</table>
+{{if .Doc.PricesIncludeTax}}<p>Prices include tax.</p>
+{{end}}{{if .Doc.TaxLines}}<table class="taxes">
+<tr><th>Tax summary</th><th class="amount">Rate</th><th class="amount">Net</th><th class="amount">Tax</th></tr>
+{{range .Doc.TaxLines}}<tr><td>{{.TaxClass}}</td><td class="amount">{{rate .Rate}}%</td><td class="amount">{{money .NetAmount}}</td><td class="amount">{{money .TaxAmount}}</td></tr>
+{{end}}</table>
+{{end}}</body>
+</html>
+`))
+
+// HTMLRenderer renders invoices to a standalone HTML page. It prints any
+// text, which makes it the fallback for the PDF renderer.
+type HTMLRenderer struct{}
+
+// NewHTMLRenderer creates the HTML invoice renderer.
+func NewHTMLRenderer() *HTMLRenderer {
+	return &HTMLRenderer{}
+}
+
+func (r *HTMLRenderer) Render(doc *Document) (*Rendered, error) {
+	type total struct {
+		Label string
+		Value string
+		Bold  bool
+	}
+
+	rows := totalRows(doc)
+	totals := make([]total, len(rows))
+	for i, row := range rows {
+		totals[i] = total{Label: row.label, Value: row.value, Bold: row.bold}
+	}
+
+	var out bytes.Buffer
+	if err := invoiceTemplate.Execute(&out, struct {
+		Doc    *Document
+		Totals []total
+	}{Doc: doc, Totals: totals}); err != nil {
+		return nil, err
+	}
+
+	return &Rendered{Content: out.Bytes(), ContentType: "text/html; charset=utf-8", Extension: ".html"}, nil
+}
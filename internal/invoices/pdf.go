@@ -0,0 +1,231 @@
+package invoices
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 in points, the unit PDF coordinates are given in.
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+	margin     = 50.0
+)
+
+// Columns of the item table.
+const (
+	colDescription = margin
+	colQuantity    = 290.0
+	colUnitPrice   = 330.0
+	colDiscount    = 395.0
+	colTax         = 455.0
+	colTotal       = 505.0
+)
+
+// PDFRenderer renders invoices to PDF using the standard Helvetica fonts, so
+// no font has to be embedded. Helvetica covers Latin-1; documents with other
+// characters fail with ErrUnsupportedText.
+type PDFRenderer struct{}
+
+// NewPDFRenderer creates the PDF invoice renderer.
+func NewPDFRenderer() *PDFRenderer {
+	return &PDFRenderer{}
+}
+
+func (r *PDFRenderer) Render(doc *Document) (*Rendered, error) {
+	p := newPDFPages()
+
+	p.text(margin, 18, true, "INVOICE")
+	p.text(colUnitPrice, 10, false, "Invoice number: "+doc.Number)
+	p.advance(14)
+	p.text(colUnitPrice, 10, false, "Issued: "+formatDate(doc.IssuedAt))
+	p.advance(14)
+	p.text(colUnitPrice, 10, false, fmt.Sprintf("Order: #%d", doc.OrderID))
+	p.advance(14)
+	p.text(colUnitPrice, 10, false, "Order date: "+formatDate(doc.OrderedAt))
+	p.advance(28)
+
+	p.text(margin, 10, true, "Bill to")
+	p.text(colUnitPrice, 10, true, "Ship to")
+	rows := max(len(doc.BillingAddress), len(doc.ShippingAddress))
+	for i := 0; i < rows; i++ {
+		p.advance(13)
+		if i < len(doc.BillingAddress) {
+			p.text(margin, 10, false, doc.BillingAddress[i])
+		}
+		if i < len(doc.ShippingAddress) {
+			p.text(colUnitPrice, 10, false, doc.ShippingAddress[i])
+		}
+	}
+	p.advance(30)
+
+	p.text(colDescription, 10, true, "Description")
+	p.text(colQuantity, 10, true, "Qty")
+	p.text(colUnitPrice, 10, true, "Unit price")
+	p.text(colDiscount, 10, true, "Discount")
+	p.text(colTax, 10, true, "Tax")
+	p.text(colTotal, 10, true, "Total")
+
+	for _, line := range doc.Lines {
+		p.advance(16)
+		p.text(colDescription, 10, false, truncate(line.Description, 42))
+		p.text(colQuantity, 10, false, fmt.Sprintf("%d", line.Quantity))
+		p.text(colUnitPrice, 10, false, formatMoney(line.UnitPrice))
+		p.text(colDiscount, 10, false, formatMoney(line.Discount))
+		p.text(colTax, 10, false, fmt.Sprintf("%s (%s%%)", formatMoney(line.TaxAmount), formatRate(line.TaxRate)))
+		p.text(colTotal, 10, false, formatMoney(line.Total))
+		if line.SKU != "" {
+			p.advance(11)
+			p.text(colDescription, 8, false, "SKU "+line.SKU)
+		}
+	}
+	p.advance(30)
+
+	for _, total := range totalRows(doc) {
+		p.text(colDiscount, 10, total.bold, total.label)
+		p.text(colTotal, 10, total.bold, total.value)
+		p.advance(14)
+	}
+
+	if doc.PricesIncludeTax {
+		p.advance(6)
+		p.text(margin, 9, false, "Prices include tax.")
+		p.advance(14)
+	}
+
+	if len(doc.TaxLines) > 0 {
+		p.advance(16)
+		p.text(margin, 10, true, "Tax summary")
+		p.text(colQuantity, 10, true, "Rate")
+		p.text(colDiscount, 10, true, "Net")
+		p.text(colTotal, 10, true, "Tax")
+		for _, line := range doc.TaxLines {
+			p.advance(14)
+			p.text(margin, 10, false, line.TaxClass)
+			p.text(colQuantity, 10, false, formatRate(line.Rate)+"%")
+			p.text(colDiscount, 10, false, formatMoney(line.NetAmount))
+			p.text(colTotal, 10, false, formatMoney(line.TaxAmount))
+		}
+	}
+
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return &Rendered{Content: p.bytes(), ContentType: "application/pdf", Extension: ".pdf"}, nil
+}
+
+// pdfPages lays text out top down over as many pages as it needs.
+type pdfPages struct {
+	pages []*bytes.Buffer
+	y     float64
+	err   error
+}
+
+func newPDFPages() *pdfPages {
+	p := &pdfPages{}
+	p.newPage()
+	return p
+}
+
+func (p *pdfPages) newPage() {
+	p.pages = append(p.pages, &bytes.Buffer{})
+	p.y = pageHeight - margin
+}
+
+// advance moves down by height points, starting a new page at the bottom margin.
+func (p *pdfPages) advance(height float64) {
+	p.y -= height
+	if p.y < margin {
+		p.newPage()
+	}
+}
+
+func (p *pdfPages) text(x, size float64, bold bool, s string) {
+	encoded, ok := encodeText(s)
+	if !ok {
+		if p.err == nil {
+			p.err = fmt.Errorf("%w: %q", ErrUnsupportedText, s)
+		}
+		return
+	}
+
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+
+	fmt.Fprintf(p.pages[len(p.pages)-1], "BT /%s %g Tf %g %g Td (%s) Tj ET\n", font, size, x, p.y, encoded)
+}
+
+// bytes assembles the document: the catalog, the page tree, the two fonts
+// and a page and content stream object for every page, followed by the
+// cross-reference table.
+func (p *pdfPages) bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	const firstPage = 5
+	kids := make([]string, len(p.pages))
+	for i := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range p.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %g %g] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// encodeText escapes the text for a PDF string in WinAnsi encoding, which
+// matches Latin-1 for the printable characters. It reports false for text
+// with characters outside of it.
+func encodeText(s string) (string, bool) {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			return "", false
+		}
+	}
+
+	return b.String(), true
+}
+
+func truncate(s string, length int) string {
+	runes := []rune(s)
+	if len(runes) <= length {
+		return s
+	}
+
+	return string(runes[:length-3]) + "..."
+}
@@ -0,0 +1,54 @@
+package invoices
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupportedText is returned by renderers that cannot print some of the
+// document's text, for example characters outside their font.
+var ErrUnsupportedText = errors.New("the document contains text the renderer cannot print")
+
+// Renderer is implemented by every format invoices can be rendered to.
+type Renderer interface {
+	Render(doc *Document) (*Rendered, error)
+}
+
+// Rendered is a rendered invoice document.
+type Rendered struct {
+	Content     []byte
+	ContentType string
+	// Extension is the file extension of the format, including the dot.
+	Extension string
+}
+
+// FallbackRenderer renders with the primary renderer and falls back to the
+// secondary one when the primary cannot render the document.
+type FallbackRenderer struct {
+	primary  Renderer
+	fallback Renderer
+}
+
+// NewFallbackRenderer creates a renderer trying primary first and fallback second.
+func NewFallbackRenderer(primary, fallback Renderer) *FallbackRenderer {
+	return &FallbackRenderer{primary: primary, fallback: fallback}
+}
+
+// NewRenderer creates the default invoice renderer: PDF, with HTML as a fallback.
+func NewRenderer() *FallbackRenderer {
+	return NewFallbackRenderer(NewPDFRenderer(), NewHTMLRenderer())
+}
+
+func (r *FallbackRenderer) Render(doc *Document) (*Rendered, error) {
+	rendered, err := r.primary.Render(doc)
+	if err == nil {
+		return rendered, nil
+	}
+
+	rendered, fallbackErr := r.fallback.Render(doc)
+	if fallbackErr != nil {
+		return nil, fmt.Errorf("failed to render invoice: %w", errors.Join(err, fallbackErr))
+	}
+
+	return rendered, nil
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Address is an entry in a user's address book.
+type Address struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	UserID     uint           `json:"user_id" gorm:"not null;index"`
+	FirstName  string         `json:"first_name" gorm:"not null"`
+	LastName   string         `json:"last_name" gorm:"not null"`
+	Line1      string         `json:"line1" gorm:"not null"`
+	Line2      string         `json:"line2"`
+	City       string         `json:"city" gorm:"not null"`
+	State      string         `json:"state"`
+	PostalCode string         `json:"postal_code" gorm:"not null"`
+	Country    string         `json:"country" gorm:"size:2;not null"`
+	Phone      string         `json:"phone"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	User User `json:"-"`
+}
+
+// OrderAddress is a copy of an address taken when an order is placed, so
+// later edits to the address book leave the order unchanged.
+type OrderAddress struct {
+	FirstName  string `json:"first_name"`
+	LastName   string `json:"last_name"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+	Phone      string `json:"phone"`
+}
+
+// Snapshot copies the address into an OrderAddress.
+func (a *Address) Snapshot() OrderAddress {
+	return OrderAddress{
+		FirstName:  a.FirstName,
+		LastName:   a.LastName,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		State:      a.State,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+		Phone:      a.Phone,
+	}
+}
+
+// IsZero reports whether no address was recorded.
+func (a OrderAddress) IsZero() bool {
+	return a == OrderAddress{}
+}
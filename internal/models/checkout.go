@@ -0,0 +1,128 @@
+package models
+
+import (
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+type CheckoutSessionStatus string
+
+const (
+	CheckoutSessionOpen      CheckoutSessionStatus = "open"
+	CheckoutSessionCompleted CheckoutSessionStatus = "completed"
+	// CheckoutSessionCancelled sessions were replaced by a newer session of
+	// the same user
+	CheckoutSessionCancelled CheckoutSessionStatus = "cancelled"
+	// CheckoutSessionExpired is never stored; open sessions past their
+	// expiry are reported as expired
+	CheckoutSessionExpired CheckoutSessionStatus = "expired"
+)
+
+// CheckoutSession is the first phase of a two-phase checkout. It holds a
+// priced quote of the user's cart, which completing the session turns into
+// an order at exactly those prices, and holds the stock of the quoted items,
+// through the inventory reservations of its cart, until it expires.
+// CartFingerprint tells whether the cart changed since the quote.
+type CheckoutSession struct {
+	ID               uint                  `json:"id" gorm:"primaryKey"`
+	UserID           uint                  `json:"user_id" gorm:"not null;index"`
+	CartID           uint                  `json:"cart_id" gorm:"not null"`
+	CartFingerprint  string                `json:"-" gorm:"size:64;not null"`
+	Status           CheckoutSessionStatus `json:"status" gorm:"size:20;not null;default:open;index"`
+	TotalAmount      money.Money           `json:"total_amount" gorm:"embedded;embeddedPrefix:total_"`
+	ShippingMethod   string                `json:"shipping_method"`
+	ShippingCost     money.Money           `json:"shipping_cost" gorm:"embedded;embeddedPrefix:shipping_cost_"`
+	CouponID         *uint                 `json:"coupon_id"`
+	CouponCode       string                `json:"coupon_code"`
+	CouponDiscount   money.Money           `json:"coupon_discount" gorm:"embedded;embeddedPrefix:coupon_discount_"`
+	DiscountAmount   money.Money           `json:"discount_amount" gorm:"embedded;embeddedPrefix:discount_"`
+	TaxAmount        money.Money           `json:"tax_amount" gorm:"embedded;embeddedPrefix:tax_"`
+	PricesIncludeTax bool                  `json:"prices_include_tax" gorm:"default:false"`
+	ExchangeRate     float64               `json:"exchange_rate" gorm:"not null;default:1"`
+	ShippingAddress  OrderAddress          `json:"shipping_address" gorm:"embedded;embeddedPrefix:shipping_"`
+	BillingAddress   OrderAddress          `json:"billing_address" gorm:"embedded;embeddedPrefix:billing_"`
+	OrderID          *uint                 `json:"order_id"`
+	ExpiresAt        time.Time             `json:"expires_at" gorm:"not null;index"`
+	CreatedAt        time.Time             `json:"created_at"`
+	UpdatedAt        time.Time             `json:"updated_at"`
+
+	// UseWallet pays the order from the user's wallet balance, as far as it
+	// goes, when the session is completed
+	UseWallet bool `json:"use_wallet" gorm:"not null;default:false"`
+
+	// RedeemPoints are the loyalty points to spend on the order when the
+	// session is completed
+	RedeemPoints int `json:"redeem_points" gorm:"not null;default:0"`
+
+	// Relationships
+	Items      []CheckoutSessionItem      `json:"items"`
+	Promotions []CheckoutSessionPromotion `json:"promotions"`
+}
+
+// CurrentStatus is the status of the session at now, reporting open
+// sessions past their expiry as expired.
+func (s *CheckoutSession) CurrentStatus(now time.Time) CheckoutSessionStatus {
+	if s.Status == CheckoutSessionOpen && !now.Before(s.ExpiresAt) {
+		return CheckoutSessionExpired
+	}
+
+	return s.Status
+}
+
+// Order builds the order the session quoted, with its items. The customer
+// and the order number are left to the caller.
+func (s *CheckoutSession) Order() Order {
+	items := make([]OrderItem, len(s.Items))
+	for i, item := range s.Items {
+		items[i] = OrderItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     item.Price,
+			Discount:  item.Discount,
+			TaxClass:  item.TaxClass,
+			TaxRate:   item.TaxRate,
+			TaxAmount: item.TaxAmount,
+		}
+	}
+
+	return Order{
+		TotalAmount:      s.TotalAmount,
+		ShippingMethod:   s.ShippingMethod,
+		ShippingCost:     s.ShippingCost,
+		CouponCode:       s.CouponCode,
+		DiscountAmount:   s.DiscountAmount,
+		TaxAmount:        s.TaxAmount,
+		PricesIncludeTax: s.PricesIncludeTax,
+		ExchangeRate:     s.ExchangeRate,
+		ShippingAddress:  s.ShippingAddress,
+		BillingAddress:   s.BillingAddress,
+		OrderItems:       items,
+	}
+}
+
+// CheckoutSessionItem is a quoted line of a checkout session.
+type CheckoutSessionItem struct {
+	ID                uint        `json:"id" gorm:"primaryKey"`
+	CheckoutSessionID uint        `json:"checkout_session_id" gorm:"not null;index"`
+	ProductID         uint        `json:"product_id" gorm:"not null;index"`
+	Quantity          int         `json:"quantity" gorm:"not null"`
+	Price             money.Money `json:"price" gorm:"embedded;embeddedPrefix:price_"`
+	Discount          money.Money `json:"discount" gorm:"embedded;embeddedPrefix:discount_"`
+	TaxClass          string      `json:"tax_class"`
+	TaxRate           float64     `json:"tax_rate" gorm:"default:0"`
+	TaxAmount         money.Money `json:"tax_amount" gorm:"embedded;embeddedPrefix:tax_"`
+
+	// Relationships
+	Product Product `json:"product"`
+}
+
+// CheckoutSessionPromotion is a promotion applied to the quote of a checkout
+// session, recorded on the order when the session completes.
+type CheckoutSessionPromotion struct {
+	ID                uint        `json:"id" gorm:"primaryKey"`
+	CheckoutSessionID uint        `json:"checkout_session_id" gorm:"not null;index"`
+	PromotionID       uint        `json:"promotion_id" gorm:"not null"`
+	Name              string      `json:"name" gorm:"not null"`
+	Discount          money.Money `json:"discount" gorm:"embedded;embeddedPrefix:discount_"`
+}
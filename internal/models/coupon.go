@@ -0,0 +1,158 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"gorm.io/gorm"
+)
+
+type CouponType string
+
+const (
+	// CouponPercentage takes Percent percent off the covered items.
+	CouponPercentage CouponType = "percentage"
+	// CouponFixed takes FixedAmount off the covered items, never more than they cost.
+	CouponFixed CouponType = "fixed"
+	// CouponFreeShipping waives the shipping cost of the order.
+	CouponFreeShipping CouponType = "free_shipping"
+)
+
+// IsValid reports whether the type is one of the known coupon types.
+func (t CouponType) IsValid() bool {
+	switch t {
+	case CouponPercentage, CouponFixed, CouponFreeShipping:
+		return true
+	}
+
+	return false
+}
+
+// Coupon is a discount code customers apply to their cart. A coupon limited
+// to products or categories only discounts the items it covers.
+type Coupon struct {
+	ID          uint        `json:"id" gorm:"primaryKey"`
+	Code        string      `json:"code" gorm:"uniqueIndex;not null"`
+	Description string      `json:"description"`
+	Type        CouponType  `json:"type" gorm:"not null"`
+	Percent     float64     `json:"percent"`
+	FixedAmount money.Money `json:"fixed_amount" gorm:"embedded;embeddedPrefix:fixed_"`
+	MinSubtotal money.Money `json:"min_subtotal" gorm:"embedded;embeddedPrefix:min_subtotal_"`
+
+	// UsageLimit caps the redemptions across all customers and
+	// PerCustomerLimit the redemptions of a single customer; 0 means unlimited
+	UsageLimit       int `json:"usage_limit"`
+	PerCustomerLimit int `json:"per_customer_limit"`
+	UsedCount        int `json:"used_count" gorm:"default:0"`
+
+	StartsAt  *time.Time     `json:"starts_at"`
+	EndsAt    *time.Time     `json:"ends_at"`
+	IsActive  bool           `json:"is_active" gorm:"default:true"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	Products   []Product  `json:"products" gorm:"many2many:coupon_products"`
+	Categories []Category `json:"categories" gorm:"many2many:coupon_categories"`
+}
+
+// CouponRedemption records a coupon used on an order.
+type CouponRedemption struct {
+	ID        uint        `json:"id" gorm:"primaryKey"`
+	CouponID  uint        `json:"coupon_id" gorm:"not null;index"`
+	UserID    uint        `json:"user_id" gorm:"not null;index"`
+	OrderID   uint        `json:"order_id" gorm:"not null;uniqueIndex"`
+	Discount  money.Money `json:"discount" gorm:"embedded;embeddedPrefix:discount_"`
+	CreatedAt time.Time   `json:"created_at"`
+
+	// Relationships
+	Coupon Coupon `json:"-"`
+	Order  Order  `json:"-"`
+}
+
+// IsAvailable reports whether the coupon is active, within its validity
+// window and below its usage limit at the given time.
+func (c *Coupon) IsAvailable(now time.Time) bool {
+	if !c.IsActive {
+		return false
+	}
+
+	if c.StartsAt != nil && now.Before(*c.StartsAt) {
+		return false
+	}
+
+	if c.EndsAt != nil && !now.Before(*c.EndsAt) {
+		return false
+	}
+
+	return c.UsageLimit == 0 || c.UsedCount < c.UsageLimit
+}
+
+// Covers reports whether the coupon discounts the product. Coupons without
+// product or category restrictions cover every product.
+func (c *Coupon) Covers(product *Product) bool {
+	if len(c.Products) == 0 && len(c.Categories) == 0 {
+		return true
+	}
+
+	for i := range c.Products {
+		if c.Products[i].ID == product.ID {
+			return true
+		}
+	}
+
+	for i := range c.Categories {
+		if c.Categories[i].ID == product.CategoryID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LineDiscounts splits the coupon's discount across the cart items, in the
+// order given, in proportion to the price of the covered items. The discount
+// is taken off what is left of each item's price after the discounts already
+// given, which may be nil. Items must have their product loaded. Free
+// shipping coupons discount no items.
+func (c *Coupon) LineDiscounts(items []CartItem, given []money.Money) []money.Money {
+	discounts := make([]money.Money, len(items))
+
+	weights := make([]int64, len(items))
+	var covered money.Money
+	for i := range items {
+		if !c.Covers(&items[i].Product) {
+			continue
+		}
+
+		amount := items[i].Product.Price.Mul(items[i].Quantity)
+		if given != nil {
+			amount = amount.Sub(given[i])
+		}
+
+		if amount.IsPositive() {
+			weights[i] = amount.Amount
+			covered = covered.Add(amount)
+		}
+	}
+
+	if !covered.IsPositive() {
+		return discounts
+	}
+
+	var total money.Money
+	switch c.Type {
+	case CouponPercentage:
+		total = covered.Percent(math.Min(c.Percent, 100))
+	case CouponFixed:
+		total = money.Min(c.FixedAmount, covered)
+	default:
+		return discounts
+	}
+
+	// Every covered line gets its share rounded to the minor unit and the last
+	// one takes the remainder, so the lines always add up to the total.
+	return total.Allocate(weights)
+}
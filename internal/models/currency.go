@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+// ExchangeRate is what one unit of the store currency is worth in Currency.
+// Prices without an override in Currency are converted at this rate.
+type ExchangeRate struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	Currency  money.Currency `json:"currency" gorm:"type:varchar(3);uniqueIndex;not null"`
+	Rate      float64        `json:"rate" gorm:"not null"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// ProductPrice is the price of a product in a currency other than the store
+// currency, set by an admin instead of converting the store price.
+type ProductPrice struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	ProductID uint           `json:"product_id" gorm:"not null;uniqueIndex:idx_product_prices_product_currency"`
+	Currency  money.Currency `json:"currency" gorm:"type:varchar(3);not null;uniqueIndex:idx_product_prices_product_currency"`
+	Amount    int64          `json:"amount" gorm:"not null"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+
+	// Relationships
+	Product Product `json:"-"`
+}
+
+// Price returns the override as an amount of money.
+func (p *ProductPrice) Price() money.Money {
+	return money.New(p.Amount, p.Currency)
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// IdempotencyKey stores the outcome of a request made with an Idempotency-Key
+// header so that retries of the same request can be answered from storage.
+// Keys belong to the user who sent them or, for a guest, to the guest's cart.
+type IdempotencyKey struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	UserID       uint       `json:"user_id" gorm:"not null;uniqueIndex:idx_idempotency_keys_owner_key"`
+	CartToken    string     `json:"cart_token" gorm:"size:64;not null;default:'';uniqueIndex:idx_idempotency_keys_owner_key"`
+	Key          string     `json:"key" gorm:"size:255;not null;uniqueIndex:idx_idempotency_keys_owner_key"`
+	Method       string     `json:"method" gorm:"not null"`
+	Path         string     `json:"path" gorm:"not null"`
+	RequestHash  string     `json:"request_hash" gorm:"not null"`
+	StatusCode   int        `json:"status_code"`
+	ResponseBody []byte     `json:"-"`
+	CompletedAt  *time.Time `json:"completed_at"`
+	ExpiresAt    time.Time  `json:"expires_at" gorm:"not null;index"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// InventoryReservation holds stock of a product for a cart until it expires,
+// so that the stock cannot be sold to anyone else meanwhile. A cart holds
+// what it contains; a checkout session takes over the holds of its cart for
+// as long as the session is open. The available stock of a product is its
+// stock on hand less its active reservations.
+type InventoryReservation struct {
+	ID                uint      `json:"id" gorm:"primaryKey"`
+	CartID            uint      `json:"cart_id" gorm:"not null;uniqueIndex:idx_inventory_reservations_cart_product"`
+	ProductID         uint      `json:"product_id" gorm:"not null;uniqueIndex:idx_inventory_reservations_cart_product;index"`
+	CheckoutSessionID *uint     `json:"checkout_session_id" gorm:"index"`
+	Quantity          int       `json:"quantity" gorm:"not null"`
+	ExpiresAt         time.Time `json:"expires_at" gorm:"not null;index"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// InvoiceSequenceName names the sequence every invoice number is taken from.
+const InvoiceSequenceName = "invoice"
+
+// Invoice is the invoice issued for a confirmed order. Its number is
+// allocated once, when the order is confirmed; the document can be rendered
+// again at any time from the order.
+type Invoice struct {
+	ID       uint      `json:"id" gorm:"primaryKey"`
+	OrderID  uint      `json:"order_id" gorm:"not null;uniqueIndex"`
+	Number   string    `json:"number" gorm:"not null;uniqueIndex"`
+	IssuedAt time.Time `json:"issued_at" gorm:"not null"`
+
+	// DocumentPath is where the upload provider stored the rendered document;
+	// it is empty until the document has been rendered
+	DocumentPath string     `json:"document_path"`
+	ContentType  string     `json:"content_type"`
+	RenderedAt   *time.Time `json:"rendered_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+
+	// Relationships
+	Order Order `json:"-"`
+}
+
+// InvoiceSequence holds the last number handed out by a sequence. The row is
+// locked while a number is taken, so numbers are allocated without gaps.
+type InvoiceSequence struct {
+	Name       string `json:"name" gorm:"primaryKey"`
+	LastNumber int64  `json:"last_number" gorm:"not null;default:0"`
+}
+
+// FormatInvoiceNumber formats the n-th number of the invoice sequence.
+func FormatInvoiceNumber(n int64) string {
+	return fmt.Sprintf("INV-%06d", n)
+}
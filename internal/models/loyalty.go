@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+type LoyaltyEntryType string
+
+const (
+	// LoyaltyEntryEarned credits the points a delivered order earned
+	LoyaltyEntryEarned LoyaltyEntryType = "earned"
+	// LoyaltyEntryRedeemed debits the points spent on an order
+	LoyaltyEntryRedeemed LoyaltyEntryType = "redeemed"
+	// LoyaltyEntryExpired debits the points left of a credit once it expires
+	LoyaltyEntryExpired LoyaltyEntryType = "expired"
+	// LoyaltyEntryReversed debits the points earned for what was refunded
+	LoyaltyEntryReversed LoyaltyEntryType = "reversed"
+	// LoyaltyEntryRestored credits back points spent on a cancelled or
+	// refunded order
+	LoyaltyEntryRestored LoyaltyEntryType = "restored"
+)
+
+// LoyaltyEntry is a line of a user's loyalty points ledger. Points are
+// positive for credits and negative for debits, so the points of a user's
+// entries add up to their balance.
+//
+// Credits are spent, reversed and expire by Remaining, the part of their
+// points not yet debited. A credit can be spent until ExpiresAt, after which
+// what remains of it expires; credits without ExpiresAt never expire.
+type LoyaltyEntry struct {
+	ID        uint             `json:"id" gorm:"primaryKey"`
+	UserID    uint             `json:"user_id" gorm:"not null;index"`
+	OrderID   *uint            `json:"order_id" gorm:"index"`
+	Type      LoyaltyEntryType `json:"type" gorm:"size:10;not null"`
+	Points    int              `json:"points" gorm:"not null"`
+	Remaining int              `json:"remaining" gorm:"not null;default:0"`
+	ExpiresAt *time.Time       `json:"expires_at" gorm:"index"`
+	CreatedAt time.Time        `json:"created_at"`
+
+	// Relationships
+	User User `json:"-"`
+}
+
+// IsSpendable reports whether points remain of the credit at now.
+func (e *LoyaltyEntry) IsSpendable(now time.Time) bool {
+	return e.Remaining > 0 && (e.ExpiresAt == nil || now.Before(*e.ExpiresAt))
+}
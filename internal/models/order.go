@@ -1,33 +1,166 @@
 package models
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
 	"gorm.io/gorm"
 )
 
+// Order is placed by a user or, at guest checkout, by a guest known only by
+// GuestEmail. Guest orders have no user until the guest registers with the
+// same email.
 type Order struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	UserID      uint           `json:"user_id" gorm:"not null"`
-	Status      OrderStatus    `json:"status" gorm:"default:pending"`
-	TotalAmount float64        `json:"total_amount" gorm:"not null"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID               uint           `json:"id" gorm:"primaryKey"`
+	Number           string         `json:"number" gorm:"size:20;index:idx_orders_number,unique,where:number <> ''"`
+	UserID           *uint          `json:"user_id" gorm:"index"`
+	GuestEmail       string         `json:"guest_email" gorm:"index"`
+	Status           OrderStatus    `json:"status" gorm:"default:pending"`
+	TotalAmount      money.Money    `json:"total_amount" gorm:"embedded;embeddedPrefix:total_"`
+	ShippingMethod   string         `json:"shipping_method"`
+	ShippingCost     money.Money    `json:"shipping_cost" gorm:"embedded;embeddedPrefix:shipping_cost_"`
+	CouponCode       string         `json:"coupon_code"`
+	DiscountAmount   money.Money    `json:"discount_amount" gorm:"embedded;embeddedPrefix:discount_"`
+	TaxAmount        money.Money    `json:"tax_amount" gorm:"embedded;embeddedPrefix:tax_"`
+	PricesIncludeTax bool           `json:"prices_include_tax" gorm:"default:false"`
+	ExchangeRate     float64        `json:"exchange_rate" gorm:"not null;default:1"` // store currency to order currency, at checkout
+	ShippingAddress  OrderAddress   `json:"shipping_address" gorm:"embedded;embeddedPrefix:shipping_"`
+	BillingAddress   OrderAddress   `json:"billing_address" gorm:"embedded;embeddedPrefix:billing_"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// WalletAmount is the part of the total paid from the user's wallet; the
+	// payment provider is charged the rest
+	WalletAmount money.Money `json:"wallet_amount" gorm:"embedded;embeddedPrefix:wallet_"`
+
+	// LoyaltyPoints were spent on the order, taking LoyaltyDiscount off what
+	// the wallet and the payment provider pay. LoyaltyAwardedAt is when the
+	// delivered order earned its points.
+	LoyaltyPoints    int         `json:"loyalty_points" gorm:"not null;default:0"`
+	LoyaltyDiscount  money.Money `json:"loyalty_discount" gorm:"embedded;embeddedPrefix:loyalty_discount_"`
+	LoyaltyAwardedAt *time.Time  `json:"loyalty_awarded_at" gorm:"index"`
 
 	// Relationships
-	User       User        `json:"user"`
-	OrderItems []OrderItem `json:"order_items"`
+	User          User                 `json:"user"`
+	OrderItems    []OrderItem          `json:"order_items"`
+	StatusHistory []OrderStatusHistory `json:"status_history"`
+	Payments      []Payment            `json:"payments"`
+	Promotions    []OrderPromotion     `json:"promotions"`
+	Shipments     []Shipment           `json:"shipments"`
+}
+
+// CustomerID returns the ID of the user who placed the order, or 0 for a
+// guest order.
+func (o *Order) CustomerID() uint {
+	if o.UserID == nil {
+		return 0
+	}
+
+	return *o.UserID
+}
+
+// IsGuest reports whether the order was placed at guest checkout.
+func (o *Order) IsGuest() bool {
+	return o.GuestEmail != ""
+}
+
+// orderNumberAlphabet leaves out letters and digits that are easily mistaken
+// for each other when an order number is read out or typed in.
+const orderNumberAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// NewOrderNumber returns a random order number to hand out to customers. It
+// reveals nothing about how many orders the store takes.
+func NewOrderNumber() (string, error) {
+	random := make([]byte, 10)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	number := make([]byte, len(random))
+	for i, b := range random {
+		number[i] = orderNumberAlphabet[int(b)%len(orderNumberAlphabet)]
+	}
+
+	return "GC-" + string(number), nil
 }
 
 type OrderStatus string
 
 const (
-	OrderStatusPending   OrderStatus = "pending"
-	OrderStatusConfirmed OrderStatus = "confirmed"
-	OrderStatusShipped   OrderStatus = "shipped"
-	OrderStatusDelivered OrderStatus = "delivered"
-	OrderStatusCancelled OrderStatus = "cancelled"
+	OrderStatusPending          OrderStatus = "pending"
+	OrderStatusConfirmed        OrderStatus = "confirmed"
+	OrderStatusPartiallyShipped OrderStatus = "partially_shipped"
+	OrderStatusShipped          OrderStatus = "shipped"
+	OrderStatusDelivered        OrderStatus = "delivered"
+	OrderStatusCancelled        OrderStatus = "cancelled"
+)
+
+// orderStatusTransitions lists, for every status, the statuses an order may move to next.
+var orderStatusTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending:          {OrderStatusConfirmed, OrderStatusCancelled},
+	OrderStatusConfirmed:        {OrderStatusPartiallyShipped, OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusPartiallyShipped: {OrderStatusShipped},
+	OrderStatusShipped:          {OrderStatusDelivered},
+	OrderStatusDelivered:        {},
+	OrderStatusCancelled:        {},
+}
+
+// IsValid reports whether the status is one of the known order statuses.
+func (s OrderStatus) IsValid() bool {
+	_, ok := orderStatusTransitions[s]
+	return ok
+}
+
+// IsShipmentStatus reports whether the status follows from the order's
+// shipments, which alone move the order to it.
+func (s OrderStatus) IsShipmentStatus() bool {
+	switch s {
+	case OrderStatusPartiallyShipped, OrderStatusShipped, OrderStatusDelivered:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanTransitionTo reports whether an order in status s may move to next.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OrderStatusHistory records a single status change of an order.
+type OrderStatusHistory struct {
+	ID         uint        `json:"id" gorm:"primaryKey"`
+	OrderID    uint        `json:"order_id" gorm:"not null;index"`
+	FromStatus OrderStatus `json:"from_status"`
+	ToStatus   OrderStatus `json:"to_status" gorm:"not null"`
+	ChangedBy  uint        `json:"changed_by" gorm:"not null"`
+	Reason     string      `json:"reason"`
+	CreatedAt  time.Time   `json:"created_at"`
+
+	// Relationships
+	Order Order `json:"-"`
+}
+
+// StockStatus tells whether an order item could ship when it was ordered.
+type StockStatus string
+
+const (
+	StockStatusInStock     StockStatus = "in_stock"
+	StockStatusBackordered StockStatus = "backordered"
+	StockStatusPreordered  StockStatus = "preordered"
 )
 
 type OrderItem struct {
@@ -35,24 +168,98 @@ type OrderItem struct {
 	OrderID   uint           `json:"order_id" gorm:"not null"`
 	ProductID uint           `json:"product_id" gorm:"not null"`
 	Quantity  int            `json:"quantity" gorm:"not null"`
-	Price     float64        `json:"price" gorm:"not null"`
+	Price     money.Money    `json:"price" gorm:"embedded;embeddedPrefix:price_"`
+	Discount  money.Money    `json:"discount" gorm:"embedded;embeddedPrefix:discount_"`
+	TaxClass  string         `json:"tax_class"`
+	TaxRate   float64        `json:"tax_rate" gorm:"default:0"`
+	TaxAmount money.Money    `json:"tax_amount" gorm:"embedded;embeddedPrefix:tax_"`
 	CreatedAt time.Time      `json:"created_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
+	// Whether the item could ship when it was ordered, see StockStatus.
+	// BackorderedQuantity is how many of its units wait for stock, and
+	// AvailableAt when a preordered product is expected to be available.
+	StockStatus         StockStatus `json:"stock_status" gorm:"not null;default:in_stock;index"`
+	BackorderedQuantity int         `json:"backordered_quantity" gorm:"not null;default:0"`
+	AvailableAt         *time.Time  `json:"available_at"`
+
+	// ProductName and SKU are the product's as it was ordered, so that the
+	// order's invoice keeps describing what was bought after the product
+	// changes. Items ordered before they were kept have neither.
+	ProductName string `json:"product_name"`
+	SKU         string `json:"sku"`
+
 	// Relationships
 	Order   Order   `json:"-"`
 	Product Product `json:"product"`
 }
 
+// Cart belongs to a user or, for guests, is known by its opaque Token.
 type Cart struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	UserID    uint           `json:"user_id" gorm:"uniqueIndex;not null"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	ID             uint           `json:"id" gorm:"primaryKey"`
+	UserID         *uint          `json:"user_id" gorm:"uniqueIndex"`
+	Token          *string        `json:"-" gorm:"size:64;uniqueIndex"`
+	ShippingRateID *uint          `json:"shipping_rate_id"`
+	CouponID       *uint          `json:"coupon_id"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
-	CartItems []CartItem `json:"cart_items"`
+	CartItems    []CartItem    `json:"cart_items"`
+	ShippingRate *ShippingRate `json:"shipping_rate,omitempty"`
+	Coupon       *Coupon       `json:"coupon,omitempty"`
+}
+
+// CustomerID returns the ID of the user owning the cart, or 0 for a guest cart.
+func (c *Cart) CustomerID() uint {
+	if c.UserID == nil {
+		return 0
+	}
+
+	return *c.UserID
+}
+
+// GuestToken returns the token of a guest cart, or an empty string for a
+// user's cart.
+func (c *Cart) GuestToken() string {
+	if c.Token == nil {
+		return ""
+	}
+
+	return *c.Token
+}
+
+// Fingerprint identifies the contents of the cart: its items and their
+// quantities, the selected shipping method and the applied coupon. It
+// changes whenever any of them does.
+func (c *Cart) Fingerprint() string {
+	lines := make([]string, 0, len(c.CartItems)+2)
+	for _, item := range c.CartItems {
+		lines = append(lines, fmt.Sprintf("item:%d:%d", item.ProductID, item.Quantity))
+	}
+	sort.Strings(lines)
+
+	if c.ShippingRateID != nil {
+		lines = append(lines, fmt.Sprintf("shipping:%d", *c.ShippingRateID))
+	}
+	if c.CouponID != nil {
+		lines = append(lines, fmt.Sprintf("coupon:%d", *c.CouponID))
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Totals returns the price and the weight of everything in the cart. Items
+// must have their product loaded.
+func (c *Cart) Totals() (subtotal money.Money, weight float64) {
+	for i := range c.CartItems {
+		subtotal = subtotal.Add(c.CartItems[i].Product.Price.Mul(c.CartItems[i].Quantity))
+		weight += float64(c.CartItems[i].Quantity) * c.CartItems[i].Product.Weight
+	}
+
+	return subtotal, weight
 }
 
 type CartItem struct {
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"gorm.io/gorm"
+)
+
+type PaymentStatus string
+
+const (
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	PaymentStatusCaptured   PaymentStatus = "captured"
+	PaymentStatusVoided     PaymentStatus = "voided"
+	PaymentStatusRefunded   PaymentStatus = "refunded"
+)
+
+// Payment is a charge against an order made through a payment provider.
+type Payment struct {
+	ID             uint           `json:"id" gorm:"primaryKey"`
+	OrderID        uint           `json:"order_id" gorm:"not null;index"`
+	Provider       string         `json:"provider" gorm:"not null"`
+	Reference      string         `json:"reference" gorm:"not null;index"`
+	Status         PaymentStatus  `json:"status" gorm:"not null"`
+	Amount         money.Money    `json:"amount" gorm:"embedded"`
+	CapturedAmount money.Money    `json:"captured_amount" gorm:"embedded;embeddedPrefix:captured_"`
+	RefundedAmount money.Money    `json:"refunded_amount" gorm:"embedded;embeddedPrefix:refunded_"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	Order Order `json:"-"`
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
 	"gorm.io/gorm"
 )
 
@@ -24,14 +25,24 @@ type Product struct {
 	CategoryID  uint           `json:"category_id" gorm:"not null"`
 	Name        string         `json:"name" gorm:"not null"`
 	Description string         `json:"description"`
-	Price       float64        `json:"price" gorm:"not null"`
+	Price       money.Money    `json:"price" gorm:"embedded;embeddedPrefix:price_"`
 	Stock       int            `json:"stock" gorm:"default:0"`
 	SKU         string         `json:"sku" gorm:"uniqueIndex;not null"`
+	Weight      float64        `json:"weight" gorm:"default:0"` // kilograms
+	Length      float64        `json:"length" gorm:"default:0"` // centimetres
+	Width       float64        `json:"width" gorm:"default:0"`  // centimetres
+	Height      float64        `json:"height" gorm:"default:0"` // centimetres
+	TaxClass    string         `json:"tax_class" gorm:"not null;default:standard"`
 	IsActive    bool           `json:"is_active" gorm:"default:true"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
 
+	// What can be sold once the stock runs out, see InventoryPolicy
+	InventoryPolicy InventoryPolicy `json:"inventory_policy" gorm:"not null;default:deny"`
+	BackorderLimit  int             `json:"backorder_limit" gorm:"not null;default:0"`
+	AvailableAt     *time.Time      `json:"available_at"`
+
 	// Relationships
 	Category   Category       `json:"category"`
 	Images     []ProductImage `json:"images"`
@@ -39,6 +50,43 @@ type Product struct {
 	CartItems  []CartItem     `json:"-"`
 }
 
+// InventoryPolicy decides whether a product can be sold beyond its stock on
+// hand. Products that deny it have a BackorderLimit of 0.
+type InventoryPolicy string
+
+const (
+	// InventoryPolicyDeny sells the stock on hand only
+	InventoryPolicyDeny InventoryPolicy = "deny"
+	// InventoryPolicyBackorder sells up to BackorderLimit units beyond the
+	// stock on hand, shipped once more stock arrives
+	InventoryPolicyBackorder InventoryPolicy = "backorder"
+	// InventoryPolicyPreorder sells up to BackorderLimit units beyond the
+	// stock on hand ahead of AvailableAt, the date the product is expected to
+	// be available; every unit ordered before then is preordered
+	InventoryPolicyPreorder InventoryPolicy = "preorder"
+)
+
+// IsValid reports whether the policy is one of the known policies.
+func (p InventoryPolicy) IsValid() bool {
+	switch p {
+	case InventoryPolicyDeny, InventoryPolicyBackorder, InventoryPolicyPreorder:
+		return true
+	}
+	return false
+}
+
+// Sellable returns how many units of the product can be sold: the stock on
+// hand and the units that may be sold beyond it.
+func (p *Product) Sellable() int {
+	return max(p.Stock+p.BackorderLimit, 0)
+}
+
+// IsPreorder reports whether the product is sold ahead of its availability
+// date at now.
+func (p *Product) IsPreorder(now time.Time) bool {
+	return p.InventoryPolicy == InventoryPolicyPreorder && p.AvailableAt != nil && now.Before(*p.AvailableAt)
+}
+
 type ProductImage struct {
 	ID        uint           `json:"id" gorm:"primaryKey"`
 	ProductID uint           `json:"product_id" gorm:"not null"`
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"gorm.io/gorm"
+)
+
+type PromotionType string
+
+const (
+	// PromotionBuyXGetY discounts GetQuantity of every BuyQuantity+GetQuantity
+	// eligible units by DiscountPercent, cheapest units first.
+	PromotionBuyXGetY PromotionType = "buy_x_get_y"
+	// PromotionSpendThreshold takes the amount of the highest tier whose
+	// threshold the eligible subtotal reaches off the eligible items.
+	PromotionSpendThreshold PromotionType = "spend_threshold"
+	// PromotionBundle prices every BundleQuantity eligible units at BundlePrice.
+	PromotionBundle PromotionType = "bundle"
+	// PromotionFlashSale takes DiscountPercent off the eligible items.
+	PromotionFlashSale PromotionType = "flash_sale"
+)
+
+// IsValid reports whether the type is one of the known promotion types.
+func (t PromotionType) IsValid() bool {
+	switch t {
+	case PromotionBuyXGetY, PromotionSpendThreshold, PromotionBundle, PromotionFlashSale:
+		return true
+	}
+
+	return false
+}
+
+// Promotion is a rule applied automatically to every cart it matches. A
+// promotion limited to a product or category only looks at those items.
+type Promotion struct {
+	ID          uint          `json:"id" gorm:"primaryKey"`
+	Name        string        `json:"name" gorm:"not null"`
+	Description string        `json:"description"`
+	Type        PromotionType `json:"type" gorm:"not null"`
+	ProductID   *uint         `json:"product_id" gorm:"index"`
+	CategoryID  *uint         `json:"category_id" gorm:"index"`
+
+	BuyQuantity     int         `json:"buy_quantity"`
+	GetQuantity     int         `json:"get_quantity"`
+	DiscountPercent float64     `json:"discount_percent"`
+	BundleQuantity  int         `json:"bundle_quantity"`
+	BundlePrice     money.Money `json:"bundle_price" gorm:"embedded;embeddedPrefix:bundle_price_"`
+
+	// Promotions are evaluated from the highest priority down. A promotion
+	// that is not stackable only applies to carts no other promotion applied
+	// to, and no promotion applies after it.
+	Priority  int  `json:"priority" gorm:"default:0"`
+	Stackable bool `json:"stackable" gorm:"default:true"`
+
+	StartsAt  *time.Time     `json:"starts_at"`
+	EndsAt    *time.Time     `json:"ends_at"`
+	IsActive  bool           `json:"is_active" gorm:"default:true"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	Tiers []PromotionTier `json:"tiers"`
+}
+
+// PromotionTier is one step of a spend threshold promotion.
+type PromotionTier struct {
+	ID          uint        `json:"id" gorm:"primaryKey"`
+	PromotionID uint        `json:"promotion_id" gorm:"not null;index"`
+	Threshold   money.Money `json:"threshold" gorm:"embedded;embeddedPrefix:threshold_"`
+	Amount      money.Money `json:"amount" gorm:"embedded"`
+
+	// Relationships
+	Promotion Promotion `json:"-"`
+}
+
+// OrderPromotion records a promotion applied to an order and what it took off.
+type OrderPromotion struct {
+	ID          uint        `json:"id" gorm:"primaryKey"`
+	OrderID     uint        `json:"order_id" gorm:"not null;index"`
+	PromotionID uint        `json:"promotion_id" gorm:"not null;index"`
+	Name        string      `json:"name" gorm:"not null"`
+	Discount    money.Money `json:"discount" gorm:"embedded;embeddedPrefix:discount_"`
+	CreatedAt   time.Time   `json:"created_at"`
+
+	// Relationships
+	Order Order `json:"-"`
+}
+
+// IsRunning reports whether the promotion is active and within its validity
+// window at the given time.
+func (p *Promotion) IsRunning(now time.Time) bool {
+	if !p.IsActive {
+		return false
+	}
+
+	if p.StartsAt != nil && now.Before(*p.StartsAt) {
+		return false
+	}
+
+	return p.EndsAt == nil || now.Before(*p.EndsAt)
+}
+
+// Covers reports whether the promotion looks at the product.
+func (p *Promotion) Covers(product *Product) bool {
+	if p.ProductID != nil && *p.ProductID != product.ID {
+		return false
+	}
+
+	return p.CategoryID == nil || *p.CategoryID == product.CategoryID
+}
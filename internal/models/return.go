@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"gorm.io/gorm"
+)
+
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "requested"
+	ReturnStatusApproved  ReturnStatus = "approved"
+	ReturnStatusReceived  ReturnStatus = "received"
+	ReturnStatusRefunded  ReturnStatus = "refunded"
+	ReturnStatusRejected  ReturnStatus = "rejected"
+	ReturnStatusCancelled ReturnStatus = "cancelled"
+)
+
+// returnStatusTransitions lists, for every return status, the statuses a return may move to next.
+var returnStatusTransitions = map[ReturnStatus][]ReturnStatus{
+	ReturnStatusRequested: {ReturnStatusApproved, ReturnStatusRejected, ReturnStatusCancelled},
+	ReturnStatusApproved:  {ReturnStatusReceived, ReturnStatusCancelled},
+	ReturnStatusReceived:  {ReturnStatusRefunded},
+	ReturnStatusRefunded:  {},
+	ReturnStatusRejected:  {},
+	ReturnStatusCancelled: {},
+}
+
+// CanTransitionTo reports whether a return in status s may move to next.
+func (s ReturnStatus) CanTransitionTo(next ReturnStatus) bool {
+	for _, allowed := range returnStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Return is a customer's request to send back items from a delivered order.
+type Return struct {
+	ID           uint           `json:"id" gorm:"primaryKey"`
+	OrderID      uint           `json:"order_id" gorm:"not null;index"`
+	UserID       uint           `json:"user_id" gorm:"not null;index"`
+	Status       ReturnStatus   `json:"status" gorm:"default:requested"`
+	Reason       string         `json:"reason"`
+	RefundAmount money.Money    `json:"refund_amount" gorm:"embedded;embeddedPrefix:refund_"`
+	Restocked    bool           `json:"restocked" gorm:"default:false"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// WalletRefundAmount is the part of the refund credited to the user's
+	// wallet rather than paid back through the order's payments
+	WalletRefundAmount money.Money `json:"wallet_refund_amount" gorm:"embedded;embeddedPrefix:wallet_refund_"`
+
+	// LoyaltyPointsRestored are the points spent on the order given back for
+	// the part of the refund they paid
+	LoyaltyPointsRestored int `json:"loyalty_points_restored" gorm:"not null;default:0"`
+
+	// Relationships
+	Order Order        `json:"-"`
+	User  User         `json:"-"`
+	Lines []ReturnLine `json:"lines"`
+}
+
+// ReturnLine is the quantity of a single order item being returned.
+type ReturnLine struct {
+	ID           uint        `json:"id" gorm:"primaryKey"`
+	ReturnID     uint        `json:"return_id" gorm:"not null;index"`
+	OrderItemID  uint        `json:"order_item_id" gorm:"not null;index"`
+	Quantity     int         `json:"quantity" gorm:"not null"`
+	Reason       string      `json:"reason"`
+	RefundAmount money.Money `json:"refund_amount" gorm:"embedded;embeddedPrefix:refund_"`
+	CreatedAt    time.Time   `json:"created_at"`
+
+	// Relationships
+	Return    Return    `json:"-"`
+	OrderItem OrderItem `json:"order_item"`
+}
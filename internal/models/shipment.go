@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Shipment is a parcel sent to the customer with some or all of the items
+// of an order. The order's status follows its shipments.
+type Shipment struct {
+	ID             uint           `json:"id" gorm:"primaryKey"`
+	OrderID        uint           `json:"order_id" gorm:"not null;index"`
+	Carrier        string         `json:"carrier" gorm:"not null"`
+	TrackingNumber string         `json:"tracking_number"`
+	ShippedAt      time.Time      `json:"shipped_at" gorm:"not null"`
+	DeliveredAt    *time.Time     `json:"delivered_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	Order Order          `json:"-"`
+	Lines []ShipmentLine `json:"lines"`
+}
+
+// IsDelivered reports whether the shipment has reached the customer.
+func (s *Shipment) IsDelivered() bool {
+	return s.DeliveredAt != nil
+}
+
+// ShipmentLine is the quantity of a single order item sent in a shipment.
+type ShipmentLine struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	ShipmentID  uint      `json:"shipment_id" gorm:"not null;index"`
+	OrderItemID uint      `json:"order_item_id" gorm:"not null;index"`
+	Quantity    int       `json:"quantity" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// Relationships
+	Shipment  Shipment  `json:"-"`
+	OrderItem OrderItem `json:"order_item"`
+}
@@ -0,0 +1,163 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"gorm.io/gorm"
+)
+
+// ShippingZone groups the destinations that share the same shipping rates.
+type ShippingZone struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	Name      string         `json:"name" gorm:"not null"`
+	IsActive  bool           `json:"is_active" gorm:"default:true"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	Regions []ShippingZoneRegion `json:"regions"`
+	Rates   []ShippingRate       `json:"rates"`
+}
+
+// ShippingZoneRegion is one destination covered by a zone. State and
+// PostcodePrefix are optional and narrow the region down within the country.
+type ShippingZoneRegion struct {
+	ID             uint   `json:"id" gorm:"primaryKey"`
+	ShippingZoneID uint   `json:"shipping_zone_id" gorm:"not null;index"`
+	Country        string `json:"country" gorm:"size:2;not null"`
+	State          string `json:"state"`
+	PostcodePrefix string `json:"postcode_prefix"`
+
+	// Relationships
+	ShippingZone ShippingZone `json:"-"`
+}
+
+type ShippingRateType string
+
+const (
+	// ShippingRateFlat charges Price regardless of the parcel.
+	ShippingRateFlat ShippingRateType = "flat"
+	// ShippingRateWeight charges Price for parcels weighing from MinWeight up to, but excluding, MaxWeight.
+	ShippingRateWeight ShippingRateType = "weight"
+	// ShippingRateFreeOver charges Price unless the subtotal reaches FreeOver.
+	ShippingRateFreeOver ShippingRateType = "free_over"
+)
+
+// IsValid reports whether the type is one of the known shipping rate types.
+func (t ShippingRateType) IsValid() bool {
+	switch t {
+	case ShippingRateFlat, ShippingRateWeight, ShippingRateFreeOver:
+		return true
+	}
+
+	return false
+}
+
+// ShippingRate is a shipping method offered within a zone. Rates of the weight
+// type that share a name form a tier table for that method.
+type ShippingRate struct {
+	ID             uint             `json:"id" gorm:"primaryKey"`
+	ShippingZoneID uint             `json:"shipping_zone_id" gorm:"not null;index"`
+	Name           string           `json:"name" gorm:"not null"`
+	Type           ShippingRateType `json:"type" gorm:"not null"`
+	Price          money.Money      `json:"price" gorm:"embedded;embeddedPrefix:price_"`
+	MinWeight      float64          `json:"min_weight"`
+	MaxWeight      float64          `json:"max_weight"`
+	FreeOver       money.Money      `json:"free_over" gorm:"embedded;embeddedPrefix:free_over_"`
+	IsActive       bool             `json:"is_active" gorm:"default:true"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt   `json:"-" gorm:"index"`
+
+	// Relationships
+	ShippingZone ShippingZone `json:"-"`
+}
+
+// Match scores how specifically the region covers the address: 0 when it does
+// not cover it, higher values for regions narrowed by state or postcode.
+func (r *ShippingZoneRegion) Match(address *OrderAddress) int {
+	if !strings.EqualFold(r.Country, address.Country) {
+		return 0
+	}
+
+	score := 1
+	if r.State != "" {
+		if !strings.EqualFold(r.State, address.State) {
+			return 0
+		}
+		score++
+	}
+
+	if r.PostcodePrefix != "" {
+		if !strings.HasPrefix(normalizePostcode(address.PostalCode), normalizePostcode(r.PostcodePrefix)) {
+			return 0
+		}
+		score += 1 + len(r.PostcodePrefix)
+	}
+
+	return score
+}
+
+// Match returns the score of the zone's most specific region covering the address.
+func (z *ShippingZone) Match(address *OrderAddress) int {
+	best := 0
+	for i := range z.Regions {
+		if score := z.Regions[i].Match(address); score > best {
+			best = score
+		}
+	}
+
+	return best
+}
+
+// Cost returns what the rate charges for a parcel of the given weight and
+// order subtotal, and false when the rate does not apply to the parcel.
+func (r *ShippingRate) Cost(weight float64, subtotal money.Money) (money.Money, bool) {
+	if !r.IsActive {
+		return money.Money{}, false
+	}
+
+	switch r.Type {
+	case ShippingRateFlat:
+		return r.Price, true
+	case ShippingRateWeight:
+		if weight < r.MinWeight || (r.MaxWeight > 0 && weight >= r.MaxWeight) {
+			return money.Money{}, false
+		}
+		return r.Price, true
+	case ShippingRateFreeOver:
+		if subtotal.Cmp(r.FreeOver) >= 0 {
+			return money.Zero(r.Price.Currency), true
+		}
+		return r.Price, true
+	}
+
+	return money.Money{}, false
+}
+
+// MatchShippingZone picks the active zone that covers the address most
+// specifically. Ties go to the zone listed first.
+func MatchShippingZone(zones []ShippingZone, address *OrderAddress) *ShippingZone {
+	var match *ShippingZone
+	best := 0
+
+	for i := range zones {
+		if !zones[i].IsActive {
+			continue
+		}
+
+		if score := zones[i].Match(address); score > best {
+			best = score
+			match = &zones[i]
+		}
+	}
+
+	return match
+}
+
+func normalizePostcode(postcode string) string {
+	return strings.ToUpper(strings.ReplaceAll(postcode, " ", ""))
+}
@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+type SubscriptionStatus string
+
+const (
+	SubscriptionStatusActive    SubscriptionStatus = "active"
+	SubscriptionStatusPaused    SubscriptionStatus = "paused"
+	SubscriptionStatusCancelled SubscriptionStatus = "cancelled"
+)
+
+// Subscription orders the quantity of a product for its user every
+// IntervalWeeks weeks, next at NextRunAt, until it is cancelled. A paused
+// subscription keeps its schedule but places no orders.
+type Subscription struct {
+	ID                uint               `json:"id" gorm:"primaryKey"`
+	UserID            uint               `json:"user_id" gorm:"not null;index"`
+	ProductID         uint               `json:"product_id" gorm:"not null"`
+	Quantity          int                `json:"quantity" gorm:"not null"`
+	IntervalWeeks     int                `json:"interval_weeks" gorm:"not null"`
+	ShippingAddressID *uint              `json:"shipping_address_id"`
+	BillingAddressID  *uint              `json:"billing_address_id"`
+	ShippingRateID    *uint              `json:"shipping_rate_id"`
+	Status            SubscriptionStatus `json:"status" gorm:"not null;default:active;index"`
+	NextRunAt         time.Time          `json:"next_run_at" gorm:"not null;index"`
+	CancelledAt       *time.Time         `json:"cancelled_at"`
+	CreatedAt         time.Time          `json:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at"`
+
+	// Relationships
+	User    User              `json:"-"`
+	Product Product           `json:"product"`
+	Runs    []SubscriptionRun `json:"runs"`
+}
+
+// SubscriptionRun records a scheduled run of a subscription: the order it
+// placed, or why none could be placed. There is one run per subscription and
+// date, so a run is never repeated.
+type SubscriptionRun struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	SubscriptionID uint      `json:"subscription_id" gorm:"not null;uniqueIndex:idx_subscription_runs_subscription_run_at"`
+	RunAt          time.Time `json:"run_at" gorm:"not null;uniqueIndex:idx_subscription_runs_subscription_run_at"`
+	OrderID        *uint     `json:"order_id"`
+	Error          string    `json:"error"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	// Relationships
+	Subscription Subscription `json:"-"`
+}
+
+// NextRunAfter returns the first run of the subscription's schedule after
+// now. Runs missed in between, while the subscription was paused or nothing
+// ran it, are skipped rather than caught up on.
+func (s *Subscription) NextRunAfter(now time.Time) time.Time {
+	next := s.NextRunAt
+	for !next.After(now) {
+		next = next.AddDate(0, 0, 7*max(s.IntervalWeeks, 1))
+	}
+
+	return next
+}
+
+// Cart returns a cart, which is not stored, holding what the subscription
+// orders, so that it is priced and ordered like any other cart. The product
+// must be loaded.
+func (s *Subscription) Cart() Cart {
+	return Cart{
+		UserID:         &s.UserID,
+		ShippingRateID: s.ShippingRateID,
+		CartItems: []CartItem{{
+			ProductID: s.ProductID,
+			Quantity:  s.Quantity,
+			Product:   s.Product,
+		}},
+	}
+}
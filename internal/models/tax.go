@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultTaxClass is the tax class of products that do not name one.
+const DefaultTaxClass = "standard"
+
+// TaxRate is the percentage charged on products of a tax class shipped to a
+// country, or to one state of it when State is set.
+type TaxRate struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	Name      string         `json:"name" gorm:"not null"`
+	Country   string         `json:"country" gorm:"size:2;not null;index"`
+	State     string         `json:"state"`
+	TaxClass  string         `json:"tax_class" gorm:"not null;default:standard"`
+	Rate      float64        `json:"rate" gorm:"not null"`
+	IsActive  bool           `json:"is_active" gorm:"default:true"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
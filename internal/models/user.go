@@ -19,9 +19,15 @@ type User struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
+	// EmailVerifiedAt is when the user proved they own the email, by sending
+	// back the EmailVerificationToken mailed to them
+	EmailVerifiedAt        *time.Time `json:"email_verified_at"`
+	EmailVerificationToken *string    `json:"-" gorm:"uniqueIndex"`
+
 	// Relationships
 	RefreshTokens []RefreshToken `json:"-"`
 	Orders        []Order        `json:"-"`
+	Addresses     []Address      `json:"-"`
 	Cart          Cart           `json:"-"`
 }
 
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+type WalletEntryType string
+
+const (
+	WalletEntryCredit WalletEntryType = "credit"
+	WalletEntryDebit  WalletEntryType = "debit"
+)
+
+// WalletEntrySource is what a wallet entry was posted for.
+type WalletEntrySource string
+
+const (
+	// WalletEntryGiftCard credits the value of a redeemed gift card
+	WalletEntryGiftCard WalletEntrySource = "gift_card"
+	// WalletEntryGrant credits store credit granted by an admin
+	WalletEntryGrant WalletEntrySource = "grant"
+	// WalletEntryOrder debits the part of an order paid by wallet
+	WalletEntryOrder WalletEntrySource = "order"
+	// WalletEntryOrderCancelled credits back what a cancelled order took
+	WalletEntryOrderCancelled WalletEntrySource = "order_cancelled"
+	// WalletEntryReturn credits the refund of a return
+	WalletEntryReturn WalletEntrySource = "return"
+)
+
+// Wallet holds a user's store credit in the store currency. Its balance is
+// made of the entries of its ledger and kept as the running balance of the
+// latest one; the wallet is locked to post an entry, so entries never
+// interleave.
+type Wallet struct {
+	ID        uint        `json:"id" gorm:"primaryKey"`
+	UserID    uint        `json:"user_id" gorm:"not null;uniqueIndex"`
+	Balance   money.Money `json:"balance" gorm:"embedded;embeddedPrefix:balance_"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+
+	// Relationships
+	User    User          `json:"-"`
+	Entries []WalletEntry `json:"entries"`
+}
+
+// WalletEntry credits or debits a wallet by Amount, leaving it with Balance.
+// Entries are never changed once posted.
+type WalletEntry struct {
+	ID         uint              `json:"id" gorm:"primaryKey"`
+	WalletID   uint              `json:"wallet_id" gorm:"not null;index"`
+	Type       WalletEntryType   `json:"type" gorm:"size:10;not null"`
+	Source     WalletEntrySource `json:"source" gorm:"size:20;not null"`
+	Amount     money.Money       `json:"amount" gorm:"embedded"`
+	Balance    money.Money       `json:"balance" gorm:"embedded;embeddedPrefix:balance_"`
+	GiftCardID *uint             `json:"gift_card_id"`
+	OrderID    *uint             `json:"order_id" gorm:"index"`
+	ReturnID   *uint             `json:"return_id"`
+	Note       string            `json:"note"`
+	// CreatedBy is the admin who granted the credit
+	CreatedBy *uint     `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relationships
+	Wallet Wallet `json:"-"`
+}
+
+// GiftCard is worth its initial value in store credit to whoever redeems its
+// code first. The code is only known when the card is issued; the card keeps
+// a hash of it, and its last characters to tell cards apart.
+type GiftCard struct {
+	ID           uint        `json:"id" gorm:"primaryKey"`
+	CodeHash     string      `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	CodeHint     string      `json:"code_hint" gorm:"size:4;not null"`
+	InitialValue money.Money `json:"initial_value" gorm:"embedded;embeddedPrefix:initial_value_"`
+	Note         string      `json:"note"`
+	ExpiresAt    *time.Time  `json:"expires_at"`
+	IssuedBy     uint        `json:"issued_by" gorm:"not null"`
+	RedeemedBy   *uint       `json:"redeemed_by" gorm:"index"`
+	RedeemedAt   *time.Time  `json:"redeemed_at"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+// IsRedeemable reports whether the card can still be redeemed at now.
+func (g *GiftCard) IsRedeemable(now time.Time) bool {
+	return g.RedeemedAt == nil && (g.ExpiresAt == nil || now.Before(*g.ExpiresAt))
+}
+
+// giftCardCodeGroups is how many groups of four characters a code has.
+const giftCardCodeGroups = 4
+
+// NewGiftCardCode returns a random gift card code, like 7KQ2-M9XW-4PHT-C3RD,
+// from the same unambiguous alphabet as order numbers.
+func NewGiftCardCode() (string, error) {
+	random := make([]byte, 4*giftCardCodeGroups)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	groups := make([]string, giftCardCodeGroups)
+	for i := range groups {
+		group := make([]byte, 4)
+		for j := range group {
+			group[j] = orderNumberAlphabet[int(random[4*i+j])%len(orderNumberAlphabet)]
+		}
+		groups[i] = string(group)
+	}
+
+	return strings.Join(groups, "-"), nil
+}
+
+// NormalizeGiftCardCode drops the dashes and spaces of a code as typed in and
+// upper-cases it.
+func NormalizeGiftCardCode(code string) string {
+	code = strings.ToUpper(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// HashGiftCardCode returns the hash a gift card keeps of its code.
+func HashGiftCardCode(code string) string {
+	sum := sha256.Sum256([]byte(NormalizeGiftCardCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// GiftCardCodeHint returns the last characters of a code, kept to tell cards
+// apart.
+func GiftCardCodeHint(code string) string {
+	code = NormalizeGiftCardCode(code)
+	return code[max(len(code)-4, 0):]
+}
@@ -0,0 +1,63 @@
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// MarshalGQL writes the amount as the Money GraphQL scalar, the same object
+// the REST API returns.
+func (m Money) MarshalGQL(w io.Writer) {
+	data, _ := json.Marshal(m)
+	_, _ = w.Write(data)
+}
+
+// UnmarshalGQL reads the Money GraphQL scalar: an object with the amount in
+// minor units and an optional currency.
+func (m *Money) UnmarshalGQL(v interface{}) error {
+	fields, ok := v.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("money must be an object with an amount and a currency")
+	}
+
+	amount, err := parseAmount(fields["amount"])
+	if err != nil {
+		return err
+	}
+
+	*m = Money{Amount: amount}
+
+	if code, ok := fields["currency"]; ok && code != nil {
+		s, ok := code.(string)
+		if !ok {
+			return fmt.Errorf("currency must be a string")
+		}
+
+		if m.Currency, err = ParseCurrency(s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseAmount(v interface{}) (int64, error) {
+	switch amount := v.(type) {
+	case int:
+		return int64(amount), nil
+	case int64:
+		return amount, nil
+	case json.Number:
+		return amount.Int64()
+	case string:
+		return strconv.ParseInt(amount, 10, 64)
+	case float64:
+		if amount == float64(int64(amount)) {
+			return int64(amount), nil
+		}
+	}
+
+	return 0, fmt.Errorf("amount must be a whole number of minor units")
+}
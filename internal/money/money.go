@@ -0,0 +1,341 @@
+// Package money represents amounts of money exactly, as an integer number of
+// minor units (cents for most currencies) of an ISO 4217 currency.
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned when amounts of different currencies meet.
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// Currency is an upper case ISO 4217 currency code.
+type Currency string
+
+// exponents lists the supported currencies and the number of digits of their
+// minor unit.
+var exponents = map[Currency]int{
+	"AED": 2, "AUD": 2, "BHD": 3, "BRL": 2, "CAD": 2, "CHF": 2, "CNY": 2,
+	"CZK": 2, "DKK": 2, "EUR": 2, "GBP": 2, "HKD": 2, "HUF": 2, "IDR": 2,
+	"ILS": 2, "INR": 2, "JPY": 0, "KRW": 0, "KWD": 3, "MXN": 2, "MYR": 2,
+	"NOK": 2, "NZD": 2, "PHP": 2, "PLN": 2, "SAR": 2, "SEK": 2, "SGD": 2,
+	"THB": 2, "TRY": 2, "TWD": 2, "USD": 2, "ZAR": 2,
+}
+
+// ParseCurrency returns the currency of the code, in any case.
+func ParseCurrency(code string) (Currency, error) {
+	currency := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if !currency.IsValid() {
+		return "", fmt.Errorf("unsupported currency %q", code)
+	}
+
+	return currency, nil
+}
+
+// IsValid reports whether the currency is a supported ISO 4217 currency.
+func (c Currency) IsValid() bool {
+	_, ok := exponents[c]
+	return ok
+}
+
+// UnmarshalJSON reads a currency code in any case. An empty code leaves the
+// currency unset.
+func (c *Currency) UnmarshalJSON(data []byte) error {
+	var code string
+	if err := json.Unmarshal(data, &code); err != nil {
+		return err
+	}
+
+	if code == "" {
+		*c = ""
+		return nil
+	}
+
+	currency, err := ParseCurrency(code)
+	if err != nil {
+		return err
+	}
+
+	*c = currency
+	return nil
+}
+
+// Exponent returns the number of digits of the currency's minor unit.
+func (c Currency) Exponent() int {
+	if exponent, ok := exponents[c]; ok {
+		return exponent
+	}
+
+	return 2
+}
+
+// Money is an amount of minor units of a currency. The zero value has no
+// currency and takes the currency of whatever it is combined with, so it can
+// be used to start a sum.
+type Money struct {
+	Amount   int64    `json:"amount" gorm:"not null;default:0"`
+	Currency Currency `json:"currency" gorm:"type:varchar(3);not null;default:''"`
+}
+
+// New returns amount minor units of the currency.
+func New(amount int64, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Zero returns no money in the currency.
+func Zero(currency Currency) Money {
+	return Money{Currency: currency}
+}
+
+// IsZero reports whether the amount is zero, whatever the currency.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsPositive reports whether the amount is above zero.
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// IsNegative reports whether the amount is below zero.
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// SameCurrency reports whether the amounts can be combined: they are in the
+// same currency or one of them has none.
+func (m Money) SameCurrency(other Money) bool {
+	return m.Currency == other.Currency || m.Currency == "" || other.Currency == ""
+}
+
+// In returns the amount with the currency set to currency when it has none. It
+// fails with ErrCurrencyMismatch when the amount is in another currency.
+func (m Money) In(currency Currency) (Money, error) {
+	if m.Currency == "" {
+		m.Currency = currency
+	}
+
+	if m.Currency != currency {
+		return m, fmt.Errorf("%w: expected %s, got %s", ErrCurrencyMismatch, currency, m.Currency)
+	}
+
+	return m, nil
+}
+
+// currency returns the currency the result of combining m with other is in.
+// Amounts of different currencies are never combined in the API, as amounts
+// from outside are checked with In, so mixing them is a programming error.
+func (m Money) currency(other Money) Currency {
+	if !m.SameCurrency(other) {
+		panic(fmt.Sprintf("money: %s and %s can't be combined", m.Currency, other.Currency))
+	}
+
+	if m.Currency == "" {
+		return other.Currency
+	}
+
+	return m.Currency
+}
+
+// Add returns m + other.
+func (m Money) Add(other Money) Money {
+	return Money{Amount: m.Amount + other.Amount, Currency: m.currency(other)}
+}
+
+// Sub returns m - other.
+func (m Money) Sub(other Money) Money {
+	return Money{Amount: m.Amount - other.Amount, Currency: m.currency(other)}
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// Mul returns m times n, typically a unit price times a quantity.
+func (m Money) Mul(n int) Money {
+	return Money{Amount: m.Amount * int64(n), Currency: m.Currency}
+}
+
+// Percent returns percent percent of m, rounded half away from zero to the
+// minor unit.
+func (m Money) Percent(percent float64) Money {
+	return Money{Amount: int64(math.Round(float64(m.Amount) * percent / 100)), Currency: m.Currency}
+}
+
+// MulRat returns m times num/den, rounded half away from zero to the minor
+// unit. den must not be zero.
+func (m Money) MulRat(num, den int64) Money {
+	product := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(num))
+	return Money{Amount: divRound(product, big.NewInt(den)), Currency: m.Currency}
+}
+
+// Convert returns m in the currency to, at rate units of to per unit of m's
+// currency, rounded half away from zero to the minor unit of to. The rate is
+// taken as the decimal it prints as, so 1.1 converts as exactly 11/10.
+func (m Money) Convert(to Currency, rate float64) Money {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(rate, 'f', -1, 64))
+	if !ok {
+		panic(fmt.Sprintf("money: invalid exchange rate %v", rate))
+	}
+
+	num := new(big.Int).Mul(big.NewInt(m.Amount), r.Num())
+	den := new(big.Int).Set(r.Denom())
+
+	// Scale between the minor units of the two currencies
+	if shift := to.Exponent() - m.Currency.Exponent(); shift > 0 {
+		num.Mul(num, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(shift)), nil))
+	} else if shift < 0 {
+		den.Mul(den, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-shift)), nil))
+	}
+
+	return Money{Amount: divRound(num, den), Currency: to}
+}
+
+// Cmp compares the amounts, returning -1, 0 or +1.
+func (m Money) Cmp(other Money) int {
+	m.currency(other)
+
+	switch {
+	case m.Amount < other.Amount:
+		return -1
+	case m.Amount > other.Amount:
+		return 1
+	}
+
+	return 0
+}
+
+// Min returns the smaller of the amounts.
+func Min(a, b Money) Money {
+	if a.Cmp(b) <= 0 {
+		return Money{Amount: a.Amount, Currency: a.currency(b)}
+	}
+
+	return Money{Amount: b.Amount, Currency: a.currency(b)}
+}
+
+// Max returns the larger of the amounts.
+func Max(a, b Money) Money {
+	if a.Cmp(b) >= 0 {
+		return Money{Amount: a.Amount, Currency: a.currency(b)}
+	}
+
+	return Money{Amount: b.Amount, Currency: a.currency(b)}
+}
+
+// Allocate splits m in proportion to the weights. Every share but the last
+// one with a weight is rounded to the minor unit and the last one takes the
+// remainder, so the shares always add up to m. Shares of zero or negative
+// weights are zero, as is everything when no weight is positive.
+func (m Money) Allocate(weights []int64) []Money {
+	shares := make([]Money, len(weights))
+	for i := range shares {
+		shares[i] = Zero(m.Currency)
+	}
+
+	var total int64
+	last := -1
+	for i, weight := range weights {
+		if weight > 0 {
+			total += weight
+			last = i
+		}
+	}
+
+	if last < 0 {
+		return shares
+	}
+
+	var allocated int64
+	for i, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+
+		if i == last {
+			shares[i].Amount = m.Amount - allocated
+			break
+		}
+
+		shares[i] = m.MulRat(weight, total)
+		allocated += shares[i].Amount
+	}
+
+	return shares
+}
+
+// Decimal formats the amount in major units, e.g. 1999 US cents as "19.99".
+func (m Money) Decimal() string {
+	exponent := m.Currency.Exponent()
+	if exponent == 0 {
+		return strconv.FormatInt(m.Amount, 10)
+	}
+
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+	}
+
+	digits := strconv.FormatUint(absUint(amount), 10)
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+
+	split := len(digits) - exponent
+	return sign + digits[:split] + "." + digits[split:]
+}
+
+// String formats the amount with its currency, e.g. "19.99 USD".
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Decimal()
+	}
+
+	return m.Decimal() + " " + string(m.Currency)
+}
+
+// UnmarshalParam reads a query or form parameter given in minor units. The
+// currency is left to the caller.
+func (m *Money) UnmarshalParam(param string) error {
+	amount, err := strconv.ParseInt(strings.TrimSpace(param), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid amount %q: expected minor units", param)
+	}
+
+	*m = Money{Amount: amount}
+	return nil
+}
+
+func absUint(amount int64) uint64 {
+	if amount < 0 {
+		return uint64(-(amount + 1)) + 1
+	}
+
+	return uint64(amount)
+}
+
+// divRound divides rounding half away from zero.
+func divRound(x, y *big.Int) int64 {
+	quotient, remainder := new(big.Int).QuoRem(x, y, new(big.Int))
+
+	// Round away from zero when twice the remainder reaches the divisor
+	twice := new(big.Int).Abs(remainder)
+	twice.Lsh(twice, 1)
+	if twice.Cmp(new(big.Int).Abs(y)) >= 0 {
+		if (x.Sign() < 0) != (y.Sign() < 0) {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	return quotient.Int64()
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"net/smtp"
+	"strconv"
 )
 
 type SMTPConfig struct {
@@ -31,7 +32,7 @@ func NewEmailNotifier(config *SMTPConfig) *EmailNotifier {
 }
 
 func (e *EmailNotifier) SendSimpleEmail(email *SimpleEmail) error {
-	addr := fmt.Sprintf("%s:%d", e.config.Host, e.config.Port)
+	addr := net.JoinHostPort(e.config.Host, strconv.Itoa(e.config.Port))
 
 	// Connect directly without TLS for development
 	conn, err := net.Dial("tcp", addr)
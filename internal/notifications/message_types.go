@@ -1,5 +1,11 @@
 package notifications
 
 const (
-	UserLoggedIn = "USER_LOGGED_IN"
+	UserLoggedIn   = "USER_LOGGED_IN"
+	OrderCancelled = "ORDER_CANCELLED"
+	// GuestOrderPlaced asks for the order number to be emailed to the guest
+	GuestOrderPlaced = "GUEST_ORDER_PLACED"
+	// EmailVerificationRequested asks for the verification token to be emailed
+	// to a newly registered user
+	EmailVerificationRequested = "EMAIL_VERIFICATION_REQUESTED"
 )
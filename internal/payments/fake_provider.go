@@ -0,0 +1,170 @@
+package payments
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+// FakeOutcome controls how the FakeProvider answers every operation.
+type FakeOutcome string
+
+const (
+	FakeOutcomeSucceed FakeOutcome = "succeed"
+	FakeOutcomeDecline FakeOutcome = "decline"
+	FakeOutcomeTimeout FakeOutcome = "timeout"
+)
+
+const fakeProviderName = "fake"
+
+var _ PaymentProvider = (*FakeProvider)(nil)
+
+// FakeProvider is an in-memory, deterministic PaymentProvider for local
+// development and tests. References are issued sequentially and every
+// operation succeeds, declines or times out according to the configured outcome.
+type FakeProvider struct {
+	mu       sync.Mutex
+	outcome  FakeOutcome
+	sequence int
+	payments map[string]*fakePayment
+	// authorizations holds the reference authorized for each idempotency key
+	authorizations map[string]string
+}
+
+type fakePayment struct {
+	authorized money.Money
+	captured   money.Money
+	refunded   money.Money
+	voided     bool
+}
+
+func NewFakeProvider(outcome FakeOutcome) *FakeProvider {
+	if outcome == "" {
+		outcome = FakeOutcomeSucceed
+	}
+
+	return &FakeProvider{
+		outcome:        outcome,
+		payments:       make(map[string]*fakePayment),
+		authorizations: make(map[string]string),
+	}
+}
+
+// SetOutcome changes how subsequent operations are answered.
+func (p *FakeProvider) SetOutcome(outcome FakeOutcome) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.outcome = outcome
+}
+
+func (p *FakeProvider) Name() string {
+	return fakeProviderName
+}
+
+func (p *FakeProvider) Authorize(req *AuthorizeRequest) (*Result, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.outcomeError(); err != nil {
+		return nil, err
+	}
+
+	if !req.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidPaymentOperation)
+	}
+
+	if reference, ok := p.authorizations[req.IdempotencyKey]; ok && req.IdempotencyKey != "" && !p.payments[reference].voided {
+		return &Result{Reference: reference, Amount: p.payments[reference].authorized}, nil
+	}
+
+	p.sequence++
+	reference := fmt.Sprintf("fake_%d", p.sequence)
+	p.payments[reference] = &fakePayment{authorized: req.Amount}
+	if req.IdempotencyKey != "" {
+		p.authorizations[req.IdempotencyKey] = reference
+	}
+
+	return &Result{Reference: reference, Amount: req.Amount}, nil
+}
+
+func (p *FakeProvider) Capture(reference string, amount money.Money) (*Result, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, err := p.lookup(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.voided || payment.captured.IsPositive() || !amount.IsPositive() ||
+		!amount.SameCurrency(payment.authorized) || amount.Cmp(payment.authorized) > 0 {
+		return nil, ErrInvalidPaymentOperation
+	}
+
+	payment.captured = amount
+
+	return &Result{Reference: reference, Amount: amount}, nil
+}
+
+func (p *FakeProvider) Void(reference string) (*Result, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, err := p.lookup(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.voided || payment.captured.IsPositive() {
+		return nil, ErrInvalidPaymentOperation
+	}
+
+	payment.voided = true
+
+	return &Result{Reference: reference, Amount: payment.authorized}, nil
+}
+
+func (p *FakeProvider) Refund(reference string, amount money.Money) (*Result, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, err := p.lookup(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	if !amount.IsPositive() || !amount.SameCurrency(payment.captured) || payment.refunded.Add(amount).Cmp(payment.captured) > 0 {
+		return nil, ErrInvalidPaymentOperation
+	}
+
+	payment.refunded = payment.refunded.Add(amount)
+
+	return &Result{Reference: reference, Amount: amount}, nil
+}
+
+// lookup applies the configured outcome and returns the stored payment.
+func (p *FakeProvider) lookup(reference string) (*fakePayment, error) {
+	if err := p.outcomeError(); err != nil {
+		return nil, err
+	}
+
+	payment, ok := p.payments[reference]
+	if !ok {
+		return nil, ErrPaymentNotFound
+	}
+
+	return payment, nil
+}
+
+func (p *FakeProvider) outcomeError() error {
+	switch p.outcome {
+	case FakeOutcomeDecline:
+		return ErrPaymentDeclined
+	case FakeOutcomeTimeout:
+		return ErrPaymentTimeout
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,49 @@
+// Package payments defines the contract payment gateways implement and the
+// providers that ship with the API.
+package payments
+
+import (
+	"errors"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+var (
+	// ErrPaymentDeclined is returned when the provider refuses the operation.
+	ErrPaymentDeclined = errors.New("payment declined")
+	// ErrPaymentTimeout is returned when the provider did not answer in time.
+	ErrPaymentTimeout = errors.New("payment provider timed out")
+	// ErrPaymentNotFound is returned for operations on an unknown payment reference.
+	ErrPaymentNotFound = errors.New("payment not found")
+	// ErrInvalidPaymentOperation is returned when the payment is not in a state that allows the operation.
+	ErrInvalidPaymentOperation = errors.New("invalid payment operation")
+)
+
+// PaymentProvider is implemented by every gateway orders can be charged through.
+// Funds are first authorized, then either captured or voided; captured funds
+// can later be refunded in full or in part.
+type PaymentProvider interface {
+	Name() string
+	Authorize(req *AuthorizeRequest) (*Result, error)
+	Capture(reference string, amount money.Money) (*Result, error)
+	Void(reference string) (*Result, error)
+	Refund(reference string, amount money.Money) (*Result, error)
+}
+
+type AuthorizeRequest struct {
+	OrderID uint
+	UserID  uint
+	Amount  money.Money
+	// IdempotencyKey, when set, identifies the authorization across retries:
+	// a request repeating the key gets the first authorization back instead
+	// of authorizing the amount again, unless that authorization was voided.
+	IdempotencyKey string
+}
+
+// Result describes the outcome of a successful provider operation.
+type Result struct {
+	// Reference identifies the payment at the provider.
+	Reference string
+	// Amount is the amount the operation applied to.
+	Amount money.Money
+}
@@ -0,0 +1,203 @@
+// Package promotions evaluates the automatic promotions of a cart. The
+// evaluator only works on loaded models and never touches the database, so
+// the same cart and rules always produce the same discounts.
+package promotions
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+// Applied is a promotion that discounted the cart.
+type Applied struct {
+	PromotionID uint
+	Name        string
+	Discount    money.Money
+}
+
+type Result struct {
+	// Lines holds the discount of every cart item, in the order of the cart's items
+	Lines   []money.Money
+	Applied []Applied
+	Total   money.Money
+}
+
+// unit is a single unit of a cart item, the granularity quantity based
+// promotions work at.
+type unit struct {
+	line  int
+	price money.Money
+}
+
+// Evaluate applies the running rules to the cart from the highest priority
+// down, ties going to the rule created first. Every rule discounts what the
+// rules before it left of the item prices, so an item is never discounted
+// below zero. Items must have their product loaded.
+func Evaluate(cart *models.Cart, rules []models.Promotion, now time.Time) *Result {
+	items := cart.CartItems
+
+	result := &Result{Lines: make([]money.Money, len(items)), Applied: []Applied{}}
+
+	remaining := make([]money.Money, len(items))
+	for i := range items {
+		remaining[i] = items[i].Product.Price.Mul(items[i].Quantity)
+		result.Lines[i] = money.Zero(remaining[i].Currency)
+		result.Total = money.Zero(remaining[i].Currency)
+	}
+
+	ordered := make([]models.Promotion, len(rules))
+	copy(ordered, rules)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority > ordered[j].Priority
+		}
+		return ordered[i].ID < ordered[j].ID
+	})
+
+	for i := range ordered {
+		rule := &ordered[i]
+
+		if !rule.IsRunning(now) {
+			continue
+		}
+
+		if !rule.Stackable && len(result.Applied) > 0 {
+			continue
+		}
+
+		discounts := evaluateRule(rule, items, remaining)
+
+		var total money.Money
+		for j := range discounts {
+			discounts[j] = money.Min(discounts[j], remaining[j])
+			total = total.Add(discounts[j])
+		}
+
+		if !total.IsPositive() {
+			continue
+		}
+
+		for j := range discounts {
+			remaining[j] = remaining[j].Sub(discounts[j])
+			result.Lines[j] = result.Lines[j].Add(discounts[j])
+		}
+
+		result.Applied = append(result.Applied, Applied{PromotionID: rule.ID, Name: rule.Name, Discount: total})
+		result.Total = result.Total.Add(total)
+
+		if !rule.Stackable {
+			break
+		}
+	}
+
+	return result
+}
+
+// evaluateRule returns the discount the rule gives on every item, before
+// capping it at what is left of the item's price.
+func evaluateRule(rule *models.Promotion, items []models.CartItem, remaining []money.Money) []money.Money {
+	discounts := make([]money.Money, len(items))
+
+	switch rule.Type {
+	case models.PromotionFlashSale:
+		for i := range items {
+			if rule.Covers(&items[i].Product) {
+				discounts[i] = remaining[i].Percent(rule.DiscountPercent)
+			}
+		}
+
+	case models.PromotionBuyXGetY:
+		group := rule.BuyQuantity + rule.GetQuantity
+		if rule.GetQuantity <= 0 || group <= 0 {
+			break
+		}
+
+		units := eligibleUnits(rule, items, true)
+		free := make([]int, len(items))
+		for _, u := range units[:len(units)/group*rule.GetQuantity] {
+			free[u.line]++
+		}
+
+		for i, quantity := range free {
+			if quantity > 0 {
+				discounts[i] = items[i].Product.Price.Mul(quantity).Percent(rule.DiscountPercent)
+			}
+		}
+
+	case models.PromotionBundle:
+		if rule.BundleQuantity <= 0 {
+			break
+		}
+
+		// Bundles are made from the most expensive units first, which gives
+		// the customer the largest saving
+		units := eligibleUnits(rule, items, false)
+		for start := 0; start+rule.BundleQuantity <= len(units); start += rule.BundleQuantity {
+			bundle := units[start : start+rule.BundleQuantity]
+
+			weights := make([]int64, len(bundle))
+			var price money.Money
+			for i, u := range bundle {
+				weights[i] = u.price.Amount
+				price = price.Add(u.price)
+			}
+
+			if price.Cmp(rule.BundlePrice) <= 0 {
+				continue
+			}
+
+			for i, share := range price.Sub(rule.BundlePrice).Allocate(weights) {
+				discounts[bundle[i].line] = discounts[bundle[i].line].Add(share)
+			}
+		}
+
+	case models.PromotionSpendThreshold:
+		weights := make([]int64, len(items))
+		var subtotal money.Money
+		for i := range items {
+			if rule.Covers(&items[i].Product) {
+				weights[i] = remaining[i].Amount
+				subtotal = subtotal.Add(remaining[i])
+			}
+		}
+
+		var amount money.Money
+		for _, tier := range rule.Tiers {
+			if subtotal.Cmp(tier.Threshold) >= 0 && tier.Amount.Cmp(amount) > 0 {
+				amount = tier.Amount
+			}
+		}
+
+		if amount.IsPositive() {
+			discounts = money.Min(amount, subtotal).Allocate(weights)
+		}
+	}
+
+	return discounts
+}
+
+// eligibleUnits lists the units of the items the rule covers ordered by
+// price, cheapest first when ascending, keeping the cart order on ties.
+func eligibleUnits(rule *models.Promotion, items []models.CartItem, ascending bool) []unit {
+	var units []unit
+	for i := range items {
+		if !rule.Covers(&items[i].Product) {
+			continue
+		}
+		for q := 0; q < items[i].Quantity; q++ {
+			units = append(units, unit{line: i, price: items[i].Product.Price})
+		}
+	}
+
+	sort.SliceStable(units, func(i, j int) bool {
+		if ascending {
+			return units[i].price.Amount < units[j].price.Amount
+		}
+		return units[i].price.Amount > units[j].price.Amount
+	})
+
+	return units
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrIdempotencyKeyExists is returned when a key is already stored for the user
+// or guest cart.
+var ErrIdempotencyKeyExists = errors.New("idempotency key already exists")
+
+type IdempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		db: db,
+	}
+}
+
+// Get returns the key sent by the user or, with a userID of 0, by the guest
+// with the cart token.
+func (r *IdempotencyRepository) Get(userID uint, cartToken, key string) (*models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+	if err := r.db.Where("user_id = ? AND cart_token = ? AND key = ?", userID, cartToken, key).First(&record).Error; err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Create inserts the record, returning ErrIdempotencyKeyExists when another
+// request already claimed the same key.
+func (r *IdempotencyRepository) Create(record *models.IdempotencyKey) error {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrIdempotencyKeyExists
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) Complete(id uint, statusCode int, body []byte) error {
+	return r.db.Model(&models.IdempotencyKey{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status_code":   statusCode,
+		"response_body": body,
+		"completed_at":  time.Now(),
+	}).Error
+}
+
+func (r *IdempotencyRepository) Delete(id uint) error {
+	return r.db.Delete(&models.IdempotencyKey{}, id).Error
+}
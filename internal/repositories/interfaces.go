@@ -6,6 +6,7 @@ type UserRepositoryInterface interface {
 	GetByEmail(email string) (*models.User, error)
 	GetByID(id uint) (*models.User, error)
 	GetByEmailAndActive(email string, isActive bool) (*models.User, error)
+	GetByEmailVerificationToken(token string) (*models.User, error)
 	Create(user *models.User) error
 	Update(user *models.User) error
 	Delete(id uint) error
@@ -22,3 +23,18 @@ type CartRepositoryInterface interface {
 	Update(cart *models.Cart) error
 	Delete(id uint) error
 }
+
+type OrderRepositoryInterface interface {
+	AttachGuestOrders(userID uint, email string) (int64, error)
+}
+
+type IdempotencyRepositoryInterface interface {
+	Get(userID uint, cartToken, key string) (*models.IdempotencyKey, error)
+	Create(record *models.IdempotencyKey) error
+	Complete(id uint, statusCode int, body []byte) error
+	Delete(id uint) error
+}
+
+type TaxRateRepositoryInterface interface {
+	FindTaxRates(country, state string) ([]models.TaxRate, error)
+}
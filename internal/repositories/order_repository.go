@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"strings"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type OrderRepository struct {
+	db *gorm.DB
+}
+
+func NewOrderRepository(db *gorm.DB) *OrderRepository {
+	return &OrderRepository{
+		db: db,
+	}
+}
+
+// AttachGuestOrders hands the guest orders placed with the email, in any
+// case, to the user and returns how many there were.
+func (r *OrderRepository) AttachGuestOrders(userID uint, email string) (int64, error) {
+	result := r.db.Model(&models.Order{}).
+		Where("user_id IS NULL AND LOWER(guest_email) = ?", strings.ToLower(strings.TrimSpace(email))).
+		Update("user_id", userID)
+	return result.RowsAffected, result.Error
+}
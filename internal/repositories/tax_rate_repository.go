@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type TaxRateRepository struct {
+	db *gorm.DB
+}
+
+func NewTaxRateRepository(db *gorm.DB) *TaxRateRepository {
+	return &TaxRateRepository{
+		db: db,
+	}
+}
+
+// FindTaxRates returns the active rates for the country, both country wide
+// and those specific to the state.
+func (r *TaxRateRepository) FindTaxRates(country, state string) ([]models.TaxRate, error) {
+	var rates []models.TaxRate
+	if err := r.db.Where("country = ? AND (state = '' OR state = ?) AND is_active = ?", country, state, true).
+		Order("id").
+		Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
@@ -33,6 +33,17 @@ func (r *UserRepository) GetByID(id uint) (*models.User, error) {
 
 	return &user, nil
 }
+
+// GetByEmailVerificationToken returns the user the email verification token
+// was mailed to.
+func (r *UserRepository) GetByEmailVerificationToken(token string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("email_verification_token = ?", token).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 func (r *UserRepository) GetByEmailAndActive(email string, isActive bool) (*models.User, error) {
 	var user models.User
 	if err := r.db.Where("email = ? AND is_active = ?", email, isActive).First(&user).Error; err != nil {
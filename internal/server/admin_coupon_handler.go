@@ -0,0 +1,133 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+)
+
+// @Summary List coupons
+// @Description Retrieve every coupon with its limits, restrictions and usage (Admin only)
+// @Tags Admin Coupons
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]dto.CouponResponse} "Coupons retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/coupons [get]
+func (s *Server) getCoupons(c *gin.Context) {
+	coupons, err := s.couponService.GetCoupons()
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch coupons", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Coupons retrieved successfully", coupons)
+}
+
+// @Summary Create a coupon
+// @Description Create a percentage, fixed amount or free shipping coupon (Admin only)
+// @Tags Admin Coupons
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CouponRequest true "Coupon data"
+// @Success 201 {object} utils.Response{data=dto.CouponResponse} "Coupon created successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/coupons [post]
+func (s *Server) createCoupon(c *gin.Context) {
+	var req dto.CouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	coupon, err := s.couponService.CreateCoupon(&req)
+	if err != nil {
+		s.handleCouponError(c, err, "Failed to create coupon")
+		return
+	}
+
+	utils.CreatedResponse(c, "Coupon created successfully", coupon)
+}
+
+// @Summary Update a coupon
+// @Description Update a coupon and replace its product and category restrictions (Admin only)
+// @Tags Admin Coupons
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Coupon ID"
+// @Param request body dto.CouponRequest true "Coupon data"
+// @Success 200 {object} utils.Response{data=dto.CouponResponse} "Coupon updated successfully"
+// @Failure 400 {object} utils.Response "Invalid coupon ID or request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Coupon not found"
+// @Router /admin/coupons/{id} [put]
+func (s *Server) updateCoupon(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid coupon ID", err)
+		return
+	}
+
+	var req dto.CouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	coupon, err := s.couponService.UpdateCoupon(uint(id), &req)
+	if err != nil {
+		s.handleCouponError(c, err, "Failed to update coupon")
+		return
+	}
+
+	utils.SuccessResponse(c, "Coupon updated successfully", coupon)
+}
+
+// @Summary Delete a coupon
+// @Description Delete a coupon; orders that redeemed it keep their discount (Admin only)
+// @Tags Admin Coupons
+// @Security BearerAuth
+// @Param id path int true "Coupon ID"
+// @Success 200 {object} utils.Response "Coupon deleted successfully"
+// @Failure 400 {object} utils.Response "Invalid coupon ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Coupon not found"
+// @Router /admin/coupons/{id} [delete]
+func (s *Server) deleteCoupon(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid coupon ID", err)
+		return
+	}
+
+	if err := s.couponService.DeleteCoupon(uint(id)); err != nil {
+		s.handleCouponError(c, err, "Failed to delete coupon")
+		return
+	}
+
+	utils.SuccessResponse(c, "Coupon deleted successfully", nil)
+}
+
+func (s *Server) handleCouponError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrCouponNotFound):
+		utils.NotFoundResponse(c, "Coupon not found")
+	case errors.Is(err, services.ErrInvalidCoupon):
+		utils.BadRequestResponse(c, message, err)
+	default:
+		utils.InternalServerErrorResponse(c, message, err)
+	}
+}
@@ -0,0 +1,243 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+)
+
+// @Summary List currencies
+// @Description List the currencies prices can be shown in: the store currency and every other supported currency with an exchange rate
+// @Tags Currencies
+// @Produce json
+// @Success 200 {object} utils.Response{data=[]dto.CurrencyResponse} "Currencies retrieved successfully"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /currencies [get]
+func (s *Server) getCurrencies(c *gin.Context) {
+	currencies, err := s.currencyService.GetCurrencies()
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch currencies", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Currencies retrieved successfully", currencies)
+}
+
+// @Summary List exchange rates
+// @Description Retrieve the exchange rates from the store currency (Admin only)
+// @Tags Admin Currencies
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]dto.ExchangeRateResponse} "Exchange rates retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/currencies/rates [get]
+func (s *Server) getExchangeRates(c *gin.Context) {
+	rates, err := s.currencyService.GetExchangeRates()
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch exchange rates", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Exchange rates retrieved successfully", rates)
+}
+
+// @Summary Set an exchange rate
+// @Description Create or replace the exchange rate from the store currency to a supported currency. Placed orders keep their rate (Admin only)
+// @Tags Admin Currencies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param currency path string true "ISO 4217 currency code"
+// @Param request body dto.ExchangeRateRequest true "Exchange rate"
+// @Success 200 {object} utils.Response{data=dto.ExchangeRateResponse} "Exchange rate saved successfully"
+// @Failure 400 {object} utils.Response "Invalid request data or unsupported currency"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/currencies/rates/{currency} [put]
+func (s *Server) setExchangeRate(c *gin.Context) {
+	currency, err := money.ParseCurrency(c.Param("currency"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid currency", err)
+		return
+	}
+
+	var req dto.ExchangeRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	rate, err := s.currencyService.SetExchangeRate(currency, &req)
+	if err != nil {
+		s.handleExchangeRateError(c, err, "Failed to save exchange rate")
+		return
+	}
+
+	utils.SuccessResponse(c, "Exchange rate saved successfully", rate)
+}
+
+// @Summary Delete an exchange rate
+// @Description Delete the exchange rate of a currency, which can then no longer be shopped in (Admin only)
+// @Tags Admin Currencies
+// @Security BearerAuth
+// @Param currency path string true "ISO 4217 currency code"
+// @Success 200 {object} utils.Response "Exchange rate deleted successfully"
+// @Failure 400 {object} utils.Response "Invalid currency"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Exchange rate not found"
+// @Router /admin/currencies/rates/{currency} [delete]
+func (s *Server) deleteExchangeRate(c *gin.Context) {
+	currency, err := money.ParseCurrency(c.Param("currency"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid currency", err)
+		return
+	}
+
+	if err := s.currencyService.DeleteExchangeRate(currency); err != nil {
+		s.handleExchangeRateError(c, err, "Failed to delete exchange rate")
+		return
+	}
+
+	utils.SuccessResponse(c, "Exchange rate deleted successfully", nil)
+}
+
+// @Summary List product prices
+// @Description Retrieve the prices a product has in other currencies instead of its converted store price (Admin only)
+// @Tags Admin Currencies
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} utils.Response{data=[]dto.ProductPriceResponse} "Product prices retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid product ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Router /admin/products/{id}/prices [get]
+func (s *Server) getProductPrices(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	prices, err := s.currencyService.GetProductPrices(uint(id))
+	if err != nil {
+		s.handleExchangeRateError(c, err, "Failed to fetch product prices")
+		return
+	}
+
+	utils.SuccessResponse(c, "Product prices retrieved successfully", prices)
+}
+
+// @Summary Set a product price
+// @Description Create or replace the price of a product in a supported currency, used instead of converting its store price (Admin only)
+// @Tags Admin Currencies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param currency path string true "ISO 4217 currency code"
+// @Param request body dto.ProductPriceRequest true "Price in minor units of the currency"
+// @Success 200 {object} utils.Response{data=dto.ProductPriceResponse} "Product price saved successfully"
+// @Failure 400 {object} utils.Response "Invalid request data or unsupported currency"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Router /admin/products/{id}/prices/{currency} [put]
+func (s *Server) setProductPrice(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	currency, err := money.ParseCurrency(c.Param("currency"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid currency", err)
+		return
+	}
+
+	var req dto.ProductPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	price, err := s.currencyService.SetProductPrice(uint(id), currency, &req)
+	if err != nil {
+		s.handleExchangeRateError(c, err, "Failed to save product price")
+		return
+	}
+
+	utils.SuccessResponse(c, "Product price saved successfully", price)
+}
+
+// @Summary Delete a product price
+// @Description Delete the price of a product in a currency, which then falls back to its converted store price (Admin only)
+// @Tags Admin Currencies
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param currency path string true "ISO 4217 currency code"
+// @Success 200 {object} utils.Response "Product price deleted successfully"
+// @Failure 400 {object} utils.Response "Invalid product ID or currency"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Product price not found"
+// @Router /admin/products/{id}/prices/{currency} [delete]
+func (s *Server) deleteProductPrice(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	currency, err := money.ParseCurrency(c.Param("currency"))
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid currency", err)
+		return
+	}
+
+	if err := s.currencyService.DeleteProductPrice(uint(id), currency); err != nil {
+		s.handleExchangeRateError(c, err, "Failed to delete product price")
+		return
+	}
+
+	utils.SuccessResponse(c, "Product price deleted successfully", nil)
+}
+
+func (s *Server) handleExchangeRateError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrCurrencyNotSupported),
+		errors.Is(err, services.ErrInvalidExchangeRate),
+		errors.Is(err, services.ErrInvalidProductPrice):
+		utils.BadRequestResponse(c, message, err)
+	case errors.Is(err, services.ErrExchangeRateNotFound):
+		utils.NotFoundResponse(c, "Exchange rate not found")
+	case errors.Is(err, services.ErrProductNotFound):
+		utils.NotFoundResponse(c, "Product not found")
+	case errors.Is(err, services.ErrProductPriceNotFound):
+		utils.NotFoundResponse(c, "Product price not found")
+	default:
+		utils.InternalServerErrorResponse(c, message, err)
+	}
+}
+
+// handleCurrencyError writes the response for a currency that prices cannot
+// be shown in and reports whether err was one.
+func (s *Server) handleCurrencyError(c *gin.Context, err error) bool {
+	if !errors.Is(err, services.ErrCurrencyNotAvailable) {
+		return false
+	}
+
+	utils.BadRequestResponse(c, "Currency not available", err)
+	return true
+}
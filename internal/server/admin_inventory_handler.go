@@ -0,0 +1,38 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+)
+
+// @Summary List open backorders
+// @Description Retrieve the backordered and preordered items of the orders that have not shipped yet, oldest first, to fulfil as stock arrives (Admin only)
+// @Tags Admin Inventory
+// @Produce json
+// @Security BearerAuth
+// @Param product_id query int false "Filter by product ID"
+// @Param stock_status query string false "Filter by stock status" Enums(backordered, preordered)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.BackorderResponse} "Backorders retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid filters"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/backorders [get]
+func (s *Server) listBackorders(c *gin.Context) {
+	var req dto.ListBackordersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid filters", err)
+		return
+	}
+
+	backorders, meta, err := s.inventoryService.ListBackorders(&req)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch backorders", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Backorders retrieved successfully", backorders, *meta)
+}
@@ -0,0 +1,262 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/exports"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+)
+
+// @Summary List orders
+// @Description Retrieve a paginated, filtered and sorted list of the orders of every customer (Admin only)
+// @Tags Admin Orders
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by order status"
+// @Param created_from query string false "Only orders placed at or after this time, RFC 3339"
+// @Param created_to query string false "Only orders placed at or before this time, RFC 3339"
+// @Param email query string false "Filter by the email address of the customer or guest, in any case"
+// @Param min_total query int false "Minimum order total, in minor units of the order's currency"
+// @Param max_total query int false "Maximum order total, in minor units of the order's currency"
+// @Param sku query string false "Only orders with an item of the product with this SKU"
+// @Param sort query string false "created_at, total or status, prefixed with - for descending" default(-created_at)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.OrderResponse} "Orders retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid filters"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/orders [get]
+func (s *Server) listOrders(c *gin.Context) {
+	var req dto.ListOrdersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid filters", err)
+		return
+	}
+
+	orders, meta, err := s.orderService.ListOrders(&req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidOrderFilter) || errors.Is(err, services.ErrInvalidOrderStatus) {
+			utils.BadRequestResponse(c, "Invalid filters", err)
+			return
+		}
+		utils.InternalServerErrorResponse(c, "Failed to fetch orders", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Orders retrieved successfully", orders, *meta)
+}
+
+// @Summary Export orders
+// @Description Download the orders of every customer matching the same filters as the order list, with their items, as CSV or JSON Lines. The file is streamed as it is read from the database (Admin only)
+// @Tags Admin Orders
+// @Produce text/csv
+// @Produce application/jsonl
+// @Security BearerAuth
+// @Param format query string false "csv, one row per item, or jsonl, one order per line" Enums(csv, jsonl) default(csv)
+// @Param status query string false "Filter by order status"
+// @Param created_from query string false "Only orders placed at or after this time, RFC 3339"
+// @Param created_to query string false "Only orders placed at or before this time, RFC 3339"
+// @Param email query string false "Filter by the email address of the customer or guest, in any case"
+// @Param min_total query int false "Minimum order total, in minor units of the order's currency"
+// @Param max_total query int false "Maximum order total, in minor units of the order's currency"
+// @Param sku query string false "Only orders with an item of the product with this SKU"
+// @Param sort query string false "created_at, total or status, prefixed with - for descending" default(-created_at)
+// @Success 200 {file} file "Order export"
+// @Failure 400 {object} utils.Response "Invalid filters or format"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/orders/export [get]
+func (s *Server) exportOrders(c *gin.Context) {
+	var req dto.ExportOrdersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid filters", err)
+		return
+	}
+
+	format := exports.Format(req.Format)
+	if format == "" {
+		format = exports.FormatCSV
+	}
+
+	writer, err := exports.NewWriter(format, c.Writer)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid export format", err)
+		return
+	}
+
+	// A large export streams for longer than the server's write timeout
+	// allows, so the deadline is lifted for this response
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		s.logger.Warn().Err(err).Msg("Failed to lift the write deadline of the order export")
+	}
+
+	filename := fmt.Sprintf("orders-%s%s", time.Now().UTC().Format("20060102-150405"), format.Extension())
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	err = s.orderService.ExportOrders(&req.ListOrdersRequest, &streamingExportWriter{Writer: writer, flusher: c.Writer})
+	if err == nil {
+		return
+	}
+
+	if c.Writer.Written() {
+		// The status and part of the file are already sent, so the export
+		// can only be cut short.
+		s.logger.Error().Err(err).Msg("Order export failed")
+		c.Abort()
+		return
+	}
+
+	c.Writer.Header().Del("Content-Type")
+	c.Writer.Header().Del("Content-Disposition")
+
+	if errors.Is(err, services.ErrInvalidOrderFilter) || errors.Is(err, services.ErrInvalidOrderStatus) {
+		utils.BadRequestResponse(c, "Invalid filters", err)
+		return
+	}
+	utils.InternalServerErrorResponse(c, "Failed to export orders", err)
+}
+
+// @Summary Get order details
+// @Description Retrieve any order with the customer who placed it and its full status history (Admin only)
+// @Tags Admin Orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Success 200 {object} utils.Response{data=dto.AdminOrderResponse} "Order retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid order ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/orders/{id} [get]
+func (s *Server) getOrderDetail(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID", err)
+		return
+	}
+
+	order, err := s.orderService.GetOrderDetail(uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrOrderNotFound) {
+			utils.NotFoundResponse(c, "Order not found")
+			return
+		}
+		utils.InternalServerErrorResponse(c, "Failed to fetch order", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Order retrieved successfully", order)
+}
+
+// @Summary Update order status
+// @Description Move an order to pending, confirmed or cancelled following the order state machine. The shipped statuses are set by the order's shipments (Admin only)
+// @Tags Admin Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Param request body dto.UpdateOrderStatusRequest true "New status and reason"
+// @Success 200 {object} utils.Response{data=dto.OrderResponse} "Order status updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request data, unknown status or a status set by shipments"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Failure 402 {object} utils.Response "Payment capture declined"
+// @Failure 409 {object} utils.Response "Status transition not allowed"
+// @Failure 504 {object} utils.Response "Payment provider timed out"
+// @Router /admin/orders/{id}/status [put]
+func (s *Server) updateOrderStatus(c *gin.Context) {
+	adminID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID", err)
+		return
+	}
+
+	var req dto.UpdateOrderStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	order, err := s.orderService.UpdateOrderStatus(uint(id), adminID, &req)
+	if err != nil {
+		s.handleOrderStatusError(c, err, "Failed to update order status")
+		return
+	}
+
+	utils.SuccessResponse(c, "Order status updated successfully", order)
+}
+
+// @Summary Regenerate an order's invoice
+// @Description Render the invoice of a confirmed order again from the order and replace its stored document; the invoice keeps its number (Admin only)
+// @Tags Admin Orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Success 200 {object} utils.Response{data=dto.InvoiceResponse} "Invoice regenerated successfully"
+// @Failure 400 {object} utils.Response "Invalid order ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Order not invoiced yet"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/orders/{id}/invoice [post]
+func (s *Server) regenerateInvoice(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID", err)
+		return
+	}
+
+	invoice, err := s.invoiceService.GenerateInvoice(uint(id))
+	if err != nil {
+		s.handleInvoiceError(c, err, "Failed to regenerate invoice")
+		return
+	}
+
+	utils.SuccessResponse(c, "Invoice regenerated successfully", invoice)
+}
+
+// exportFlushOrders is how many orders an export writes between sending what
+// it has written on to the client.
+const exportFlushOrders = 100
+
+// streamingExportWriter sends the export on to the client every
+// exportFlushOrders orders instead of leaving it in the buffers, so a large
+// export reaches the client as it is read.
+type streamingExportWriter struct {
+	exports.Writer
+	flusher http.Flusher
+	written int
+}
+
+func (w *streamingExportWriter) Write(order *exports.Order) error {
+	if err := w.Writer.Write(order); err != nil {
+		return err
+	}
+
+	w.written++
+	if w.written%exportFlushOrders != 0 {
+		return nil
+	}
+
+	if err := w.Writer.Flush(); err != nil {
+		return err
+	}
+	w.flusher.Flush()
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+)
+
+// @Summary List promotions
+// @Description Retrieve every promotion rule with its tiers, priority and validity window (Admin only)
+// @Tags Admin Promotions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]dto.PromotionResponse} "Promotions retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/promotions [get]
+func (s *Server) getPromotions(c *gin.Context) {
+	promotions, err := s.promotionService.GetPromotions()
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch promotions", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Promotions retrieved successfully", promotions)
+}
+
+// @Summary Create a promotion
+// @Description Create a buy X get Y, spend threshold, bundle or flash sale promotion applied automatically to matching carts (Admin only)
+// @Tags Admin Promotions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.PromotionRequest true "Promotion data"
+// @Success 201 {object} utils.Response{data=dto.PromotionResponse} "Promotion created successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/promotions [post]
+func (s *Server) createPromotion(c *gin.Context) {
+	var req dto.PromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	promotion, err := s.promotionService.CreatePromotion(&req)
+	if err != nil {
+		s.handlePromotionError(c, err, "Failed to create promotion")
+		return
+	}
+
+	utils.CreatedResponse(c, "Promotion created successfully", promotion)
+}
+
+// @Summary Update a promotion
+// @Description Update a promotion rule and replace its spend threshold tiers (Admin only)
+// @Tags Admin Promotions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Promotion ID"
+// @Param request body dto.PromotionRequest true "Promotion data"
+// @Success 200 {object} utils.Response{data=dto.PromotionResponse} "Promotion updated successfully"
+// @Failure 400 {object} utils.Response "Invalid promotion ID or request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Promotion not found"
+// @Router /admin/promotions/{id} [put]
+func (s *Server) updatePromotion(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid promotion ID", err)
+		return
+	}
+
+	var req dto.PromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	promotion, err := s.promotionService.UpdatePromotion(uint(id), &req)
+	if err != nil {
+		s.handlePromotionError(c, err, "Failed to update promotion")
+		return
+	}
+
+	utils.SuccessResponse(c, "Promotion updated successfully", promotion)
+}
+
+// @Summary Delete a promotion
+// @Description Delete a promotion; orders it was applied to keep their discount (Admin only)
+// @Tags Admin Promotions
+// @Security BearerAuth
+// @Param id path int true "Promotion ID"
+// @Success 200 {object} utils.Response "Promotion deleted successfully"
+// @Failure 400 {object} utils.Response "Invalid promotion ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Promotion not found"
+// @Router /admin/promotions/{id} [delete]
+func (s *Server) deletePromotion(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid promotion ID", err)
+		return
+	}
+
+	if err := s.promotionService.DeletePromotion(uint(id)); err != nil {
+		s.handlePromotionError(c, err, "Failed to delete promotion")
+		return
+	}
+
+	utils.SuccessResponse(c, "Promotion deleted successfully", nil)
+}
+
+func (s *Server) handlePromotionError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrPromotionNotFound):
+		utils.NotFoundResponse(c, "Promotion not found")
+	case errors.Is(err, services.ErrInvalidPromotion):
+		utils.BadRequestResponse(c, message, err)
+	default:
+		utils.InternalServerErrorResponse(c, message, err)
+	}
+}
@@ -0,0 +1,172 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+)
+
+// @Summary List returns
+// @Description Retrieve paginated list of every customer's returns, optionally filtered by status (Admin only)
+// @Tags Admin Returns
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Return status" Enums(requested, approved, received, refunded, rejected, cancelled)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.ReturnResponse} "Returns retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/returns [get]
+func (s *Server) listReturns(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	returns, meta, err := s.returnService.ListReturns(c.Query("status"), page, limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch returns", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Returns retrieved successfully", returns, *meta)
+}
+
+// @Summary Approve a return
+// @Description Approve a requested return so the customer can send the items back (Admin only)
+// @Tags Admin Returns
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Return ID"
+// @Success 200 {object} utils.Response{data=dto.ReturnResponse} "Return approved successfully"
+// @Failure 400 {object} utils.Response "Invalid return ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Return not found"
+// @Failure 409 {object} utils.Response "Return status transition not allowed"
+// @Router /admin/returns/{id}/approve [post]
+func (s *Server) approveReturn(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid return ID", err)
+		return
+	}
+
+	ret, err := s.returnService.ApproveReturn(uint(id))
+	if err != nil {
+		s.handleReturnError(c, err, "Failed to approve return")
+		return
+	}
+
+	utils.SuccessResponse(c, "Return approved successfully", ret)
+}
+
+// @Summary Reject a return
+// @Description Turn down a requested return. Its items can be returned again (Admin only)
+// @Tags Admin Returns
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Return ID"
+// @Success 200 {object} utils.Response{data=dto.ReturnResponse} "Return rejected successfully"
+// @Failure 400 {object} utils.Response "Invalid return ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Return not found"
+// @Failure 409 {object} utils.Response "Return status transition not allowed"
+// @Router /admin/returns/{id}/reject [post]
+func (s *Server) rejectReturn(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid return ID", err)
+		return
+	}
+
+	ret, err := s.returnService.RejectReturn(uint(id))
+	if err != nil {
+		s.handleReturnError(c, err, "Failed to reject return")
+		return
+	}
+
+	utils.SuccessResponse(c, "Return rejected successfully", ret)
+}
+
+// @Summary Receive a return
+// @Description Record that the returned items arrived, optionally restocking them (Admin only)
+// @Tags Admin Returns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Return ID"
+// @Param request body dto.ReceiveReturnRequest false "Whether to restock the returned items"
+// @Success 200 {object} utils.Response{data=dto.ReturnResponse} "Return received successfully"
+// @Failure 400 {object} utils.Response "Invalid return ID or request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Return not found"
+// @Failure 409 {object} utils.Response "Return status transition not allowed"
+// @Router /admin/returns/{id}/receive [post]
+func (s *Server) receiveReturn(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid return ID", err)
+		return
+	}
+
+	var req dto.ReceiveReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	ret, err := s.returnService.ReceiveReturn(uint(id), &req)
+	if err != nil {
+		s.handleReturnError(c, err, "Failed to receive return")
+		return
+	}
+
+	utils.SuccessResponse(c, "Return received successfully", ret)
+}
+
+// @Summary Refund a return
+// @Description Refund a received return through the order's captured payments, crediting what the wallet paid back to the wallet, or credit the whole refund to the wallet (Admin only)
+// @Tags Admin Returns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Return ID"
+// @Param Idempotency-Key header string false "Client generated key that makes retries of this request safe"
+// @Param request body dto.RefundReturnRequest false "Whether to credit the refund to the wallet"
+// @Success 200 {object} utils.Response{data=dto.ReturnResponse} "Return refunded successfully"
+// @Failure 400 {object} utils.Response "Invalid return ID or request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 402 {object} utils.Response "Refund declined"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Return not found"
+// @Failure 409 {object} utils.Response "Return status transition not allowed or nothing left to refund"
+// @Failure 504 {object} utils.Response "Payment provider timed out"
+// @Router /admin/returns/{id}/refund [post]
+func (s *Server) refundReturn(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid return ID", err)
+		return
+	}
+
+	var req dto.RefundReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	ret, err := s.returnService.RefundReturn(uint(id), &req)
+	if err != nil {
+		s.handleReturnError(c, err, "Failed to refund return")
+		return
+	}
+
+	utils.SuccessResponse(c, "Return refunded successfully", ret)
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+)
+
+// @Summary Create a shipment
+// @Description Record a parcel sent for some or all of the items of a confirmed order. The order becomes partially shipped or, once every item is covered, shipped (Admin only)
+// @Tags Admin Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Param request body dto.CreateShipmentRequest true "Carrier, tracking number and items; all unshipped items when none are given"
+// @Success 201 {object} utils.Response{data=dto.ShipmentResponse} "Shipment created successfully"
+// @Failure 400 {object} utils.Response "Invalid request data or shipment items"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Failure 409 {object} utils.Response "Order cannot be shipped"
+// @Router /admin/orders/{id}/shipments [post]
+func (s *Server) createShipment(c *gin.Context) {
+	adminID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID", err)
+		return
+	}
+
+	var req dto.CreateShipmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	shipment, err := s.shipmentService.CreateShipment(uint(id), adminID, &req)
+	if err != nil {
+		s.handleShipmentError(c, err, "Failed to create shipment")
+		return
+	}
+
+	utils.CreatedResponse(c, "Shipment created successfully", shipment)
+}
+
+// @Summary List order shipments
+// @Description Retrieve the shipments of any order (Admin only)
+// @Tags Admin Orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Success 200 {object} utils.Response{data=[]dto.ShipmentResponse} "Shipments retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid order ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Router /admin/orders/{id}/shipments [get]
+func (s *Server) listShipments(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID", err)
+		return
+	}
+
+	shipments, err := s.shipmentService.ListShipments(uint(id))
+	if err != nil {
+		s.handleShipmentError(c, err, "Failed to fetch shipments")
+		return
+	}
+
+	utils.SuccessResponse(c, "Shipments retrieved successfully", shipments)
+}
+
+// @Summary Update a shipment
+// @Description Change the carrier or tracking number of a shipment, or mark it delivered. The order becomes delivered once it is fully shipped and all of its shipments are delivered (Admin only)
+// @Tags Admin Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Shipment ID"
+// @Param request body dto.UpdateShipmentRequest true "Tracking details to change"
+// @Success 200 {object} utils.Response{data=dto.ShipmentResponse} "Shipment updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Shipment not found"
+// @Failure 409 {object} utils.Response "Order status transition not allowed"
+// @Router /admin/shipments/{id} [put]
+func (s *Server) updateShipment(c *gin.Context) {
+	adminID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid shipment ID", err)
+		return
+	}
+
+	var req dto.UpdateShipmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	shipment, err := s.shipmentService.UpdateShipment(uint(id), adminID, &req)
+	if err != nil {
+		s.handleShipmentError(c, err, "Failed to update shipment")
+		return
+	}
+
+	utils.SuccessResponse(c, "Shipment updated successfully", shipment)
+}
@@ -0,0 +1,233 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+)
+
+// @Summary List shipping zones
+// @Description Retrieve every shipping zone with its regions and rates (Admin only)
+// @Tags Admin Shipping
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]dto.ShippingZoneResponse} "Shipping zones retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/shipping/zones [get]
+func (s *Server) getShippingZones(c *gin.Context) {
+	zones, err := s.shippingService.GetZones()
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch shipping zones", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Shipping zones retrieved successfully", zones)
+}
+
+// @Summary Create a shipping zone
+// @Description Create a shipping zone covering countries, states or postcode prefixes (Admin only)
+// @Tags Admin Shipping
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ShippingZoneRequest true "Shipping zone data"
+// @Success 201 {object} utils.Response{data=dto.ShippingZoneResponse} "Shipping zone created successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/shipping/zones [post]
+func (s *Server) createShippingZone(c *gin.Context) {
+	var req dto.ShippingZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	zone, err := s.shippingService.CreateZone(&req)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to create shipping zone", err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Shipping zone created successfully", zone)
+}
+
+// @Summary Update a shipping zone
+// @Description Rename a shipping zone and replace its regions (Admin only)
+// @Tags Admin Shipping
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Shipping zone ID"
+// @Param request body dto.ShippingZoneRequest true "Shipping zone data"
+// @Success 200 {object} utils.Response{data=dto.ShippingZoneResponse} "Shipping zone updated successfully"
+// @Failure 400 {object} utils.Response "Invalid zone ID or request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Shipping zone not found"
+// @Router /admin/shipping/zones/{id} [put]
+func (s *Server) updateShippingZone(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid zone ID", err)
+		return
+	}
+
+	var req dto.ShippingZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	zone, err := s.shippingService.UpdateZone(uint(id), &req)
+	if err != nil {
+		s.handleShippingError(c, err, "Failed to update shipping zone")
+		return
+	}
+
+	utils.SuccessResponse(c, "Shipping zone updated successfully", zone)
+}
+
+// @Summary Delete a shipping zone
+// @Description Delete a shipping zone; its rates are no longer offered (Admin only)
+// @Tags Admin Shipping
+// @Security BearerAuth
+// @Param id path int true "Shipping zone ID"
+// @Success 200 {object} utils.Response "Shipping zone deleted successfully"
+// @Failure 400 {object} utils.Response "Invalid zone ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Shipping zone not found"
+// @Router /admin/shipping/zones/{id} [delete]
+func (s *Server) deleteShippingZone(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid zone ID", err)
+		return
+	}
+
+	if err := s.shippingService.DeleteZone(uint(id)); err != nil {
+		s.handleShippingError(c, err, "Failed to delete shipping zone")
+		return
+	}
+
+	utils.SuccessResponse(c, "Shipping zone deleted successfully", nil)
+}
+
+// @Summary Create a shipping rate
+// @Description Add a flat, weight tier or free-over-threshold rate to a shipping zone (Admin only)
+// @Tags Admin Shipping
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Shipping zone ID"
+// @Param request body dto.ShippingRateRequest true "Shipping rate data"
+// @Success 201 {object} utils.Response{data=dto.ShippingRateResponse} "Shipping rate created successfully"
+// @Failure 400 {object} utils.Response "Invalid zone ID or request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Shipping zone not found"
+// @Router /admin/shipping/zones/{id}/rates [post]
+func (s *Server) createShippingRate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid zone ID", err)
+		return
+	}
+
+	var req dto.ShippingRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	rate, err := s.shippingService.CreateRate(uint(id), &req)
+	if err != nil {
+		s.handleShippingError(c, err, "Failed to create shipping rate")
+		return
+	}
+
+	utils.CreatedResponse(c, "Shipping rate created successfully", rate)
+}
+
+// @Summary Update a shipping rate
+// @Description Update a shipping rate (Admin only)
+// @Tags Admin Shipping
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Shipping rate ID"
+// @Param request body dto.ShippingRateRequest true "Shipping rate data"
+// @Success 200 {object} utils.Response{data=dto.ShippingRateResponse} "Shipping rate updated successfully"
+// @Failure 400 {object} utils.Response "Invalid rate ID or request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Shipping rate not found"
+// @Router /admin/shipping/rates/{id} [put]
+func (s *Server) updateShippingRate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid rate ID", err)
+		return
+	}
+
+	var req dto.ShippingRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	rate, err := s.shippingService.UpdateRate(uint(id), &req)
+	if err != nil {
+		s.handleShippingError(c, err, "Failed to update shipping rate")
+		return
+	}
+
+	utils.SuccessResponse(c, "Shipping rate updated successfully", rate)
+}
+
+// @Summary Delete a shipping rate
+// @Description Delete a shipping rate (Admin only)
+// @Tags Admin Shipping
+// @Security BearerAuth
+// @Param id path int true "Shipping rate ID"
+// @Success 200 {object} utils.Response "Shipping rate deleted successfully"
+// @Failure 400 {object} utils.Response "Invalid rate ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Shipping rate not found"
+// @Router /admin/shipping/rates/{id} [delete]
+func (s *Server) deleteShippingRate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid rate ID", err)
+		return
+	}
+
+	if err := s.shippingService.DeleteRate(uint(id)); err != nil {
+		s.handleShippingError(c, err, "Failed to delete shipping rate")
+		return
+	}
+
+	utils.SuccessResponse(c, "Shipping rate deleted successfully", nil)
+}
+
+func (s *Server) handleShippingError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrShippingZoneNotFound):
+		utils.NotFoundResponse(c, "Shipping zone not found")
+	case errors.Is(err, services.ErrShippingRateNotFound):
+		utils.NotFoundResponse(c, "Shipping rate not found")
+	case errors.Is(err, services.ErrInvalidShippingRate):
+		utils.BadRequestResponse(c, message, err)
+	default:
+		utils.InternalServerErrorResponse(c, message, err)
+	}
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+)
+
+// @Summary List tax rates
+// @Description Retrieve every tax rate by country, state and tax class (Admin only)
+// @Tags Admin Tax
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]dto.TaxRateResponse} "Tax rates retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/tax/rates [get]
+func (s *Server) getTaxRates(c *gin.Context) {
+	rates, err := s.taxService.GetTaxRates()
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch tax rates", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Tax rates retrieved successfully", rates)
+}
+
+// @Summary Create a tax rate
+// @Description Create the tax rate charged on a tax class in a country or state (Admin only)
+// @Tags Admin Tax
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.TaxRateRequest true "Tax rate data"
+// @Success 201 {object} utils.Response{data=dto.TaxRateResponse} "Tax rate created successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/tax/rates [post]
+func (s *Server) createTaxRate(c *gin.Context) {
+	var req dto.TaxRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	rate, err := s.taxService.CreateTaxRate(&req)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to create tax rate", err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Tax rate created successfully", rate)
+}
+
+// @Summary Update a tax rate
+// @Description Update a tax rate; orders already placed keep the rate they were taxed at (Admin only)
+// @Tags Admin Tax
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Tax rate ID"
+// @Param request body dto.TaxRateRequest true "Tax rate data"
+// @Success 200 {object} utils.Response{data=dto.TaxRateResponse} "Tax rate updated successfully"
+// @Failure 400 {object} utils.Response "Invalid tax rate ID or request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Tax rate not found"
+// @Router /admin/tax/rates/{id} [put]
+func (s *Server) updateTaxRate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid tax rate ID", err)
+		return
+	}
+
+	var req dto.TaxRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	rate, err := s.taxService.UpdateTaxRate(uint(id), &req)
+	if err != nil {
+		s.handleTaxRateError(c, err, "Failed to update tax rate")
+		return
+	}
+
+	utils.SuccessResponse(c, "Tax rate updated successfully", rate)
+}
+
+// @Summary Delete a tax rate
+// @Description Delete a tax rate (Admin only)
+// @Tags Admin Tax
+// @Security BearerAuth
+// @Param id path int true "Tax rate ID"
+// @Success 200 {object} utils.Response "Tax rate deleted successfully"
+// @Failure 400 {object} utils.Response "Invalid tax rate ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "Tax rate not found"
+// @Router /admin/tax/rates/{id} [delete]
+func (s *Server) deleteTaxRate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid tax rate ID", err)
+		return
+	}
+
+	if err := s.taxService.DeleteTaxRate(uint(id)); err != nil {
+		s.handleTaxRateError(c, err, "Failed to delete tax rate")
+		return
+	}
+
+	utils.SuccessResponse(c, "Tax rate deleted successfully", nil)
+}
+
+func (s *Server) handleTaxRateError(c *gin.Context, err error, message string) {
+	if errors.Is(err, services.ErrTaxRateNotFound) {
+		utils.NotFoundResponse(c, "Tax rate not found")
+		return
+	}
+
+	utils.InternalServerErrorResponse(c, message, err)
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+)
+
+// @Summary Issue a gift card
+// @Description Issue a gift card worth the given store credit. The code is only returned in this response (Admin only)
+// @Tags Admin Wallet
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.GiftCardRequest true "Gift card value, note and expiry"
+// @Success 201 {object} utils.Response{data=dto.GiftCardResponse} "Gift card issued successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Router /admin/gift-cards [post]
+func (s *Server) issueGiftCard(c *gin.Context) {
+	adminID := c.GetUint("user_id")
+
+	var req dto.GiftCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	card, err := s.walletService.IssueGiftCard(adminID, &req)
+	if err != nil {
+		s.handleWalletError(c, err, "Failed to issue gift card")
+		return
+	}
+
+	utils.CreatedResponse(c, "Gift card issued successfully", card)
+}
+
+// @Summary List gift cards
+// @Description Retrieve paginated list of issued gift cards, newest first (Admin only)
+// @Tags Admin Wallet
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.GiftCardResponse} "Gift cards retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/gift-cards [get]
+func (s *Server) getGiftCards(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	cards, meta, err := s.walletService.GetGiftCards(page, limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch gift cards", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Gift cards retrieved successfully", cards, *meta)
+}
+
+// @Summary Grant store credit
+// @Description Credit a user's wallet with store credit (Admin only)
+// @Tags Admin Wallet
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param request body dto.GrantCreditRequest true "Amount and note"
+// @Success 201 {object} utils.Response{data=dto.WalletEntryResponse} "Credit granted successfully"
+// @Failure 400 {object} utils.Response "Invalid user ID or request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 404 {object} utils.Response "User not found"
+// @Router /admin/users/{id}/wallet/credits [post]
+func (s *Server) grantCredit(c *gin.Context) {
+	adminID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	var req dto.GrantCreditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	entry, err := s.walletService.GrantCredit(adminID, uint(id), &req)
+	if err != nil {
+		s.handleWalletError(c, err, "Failed to grant credit")
+		return
+	}
+
+	utils.CreatedResponse(c, "Credit granted successfully", entry)
+}
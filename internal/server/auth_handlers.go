@@ -103,6 +103,31 @@ func (s *Server) logout(c *gin.Context) {
 	utils.SuccessResponse(c, "Logout successful", nil)
 }
 
+// @Summary Verify email
+// @Description Verify the email of a newly registered user with the token mailed to it. The orders placed at guest checkout with the email are then added to the user's orders.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body dto.VerifyEmailRequest true "Verification token"
+// @Success 200 {object} utils.Response{data=dto.UserResponse} "Email verified successfully"
+// @Failure 400 {object} utils.Response "Invalid or already used verification token"
+// @Router /auth/verify-email [post]
+func (s *Server) verifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	user, err := s.authService.VerifyEmail(&req)
+	if err != nil {
+		utils.BadRequestResponse(c, "Email verification failed", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Email verified successfully", user)
+}
+
 // @Summary Get user profile
 // @Description Get current authenticated user's profile information
 // @Tags User
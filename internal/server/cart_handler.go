@@ -1,10 +1,12 @@
 package server
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
 	"github.com/kuldeepstechwork/gocart-api/internal/utils"
 )
 
@@ -16,13 +18,17 @@ import (
 // @Success 200 {object} utils.Response{data=dto.CartResponse} "Cart retrieved successfully"
 // @Failure 401 {object} utils.Response "Unauthorized"
 // @Failure 404 {object} utils.Response "Cart not found"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
 // @Router /cart [get]
 func (s *Server) getCart(c *gin.Context) {
 	userID := c.GetUint("user_id")
 
-	cart, err := s.cartService.GetCart(userID)
+	cart, err := s.cartService.GetCart(userID, requestCurrency(c))
 	if err != nil {
-		utils.NotFoundResponse(c, "Cart not found")
+		if !s.handleCurrencyError(c, err) {
+			utils.NotFoundResponse(c, "Cart not found")
+		}
 		return
 	}
 
@@ -39,6 +45,8 @@ func (s *Server) getCart(c *gin.Context) {
 // @Success 200 {object} utils.Response{data=dto.CartResponse} "Item added to cart successfully"
 // @Failure 400 {object} utils.Response "Invalid request data or insufficient stock"
 // @Failure 401 {object} utils.Response "Unauthorized"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
 // @Router /cart/items [post]
 func (s *Server) addToCart(c *gin.Context) {
 
@@ -50,7 +58,7 @@ func (s *Server) addToCart(c *gin.Context) {
 		return
 	}
 
-	cart, err := s.cartService.AddToCart(userID, &req)
+	cart, err := s.cartService.AddToCart(userID, &req, requestCurrency(c))
 	if err != nil {
 		utils.BadRequestResponse(c, "Failed to add item to cart", err)
 		return
@@ -70,6 +78,8 @@ func (s *Server) addToCart(c *gin.Context) {
 // @Success 200 {object} utils.Response{data=dto.CartResponse} "Cart item updated successfully"
 // @Failure 400 {object} utils.Response "Invalid request data or insufficient stock"
 // @Failure 401 {object} utils.Response "Unauthorized"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
 // @Router /cart/items/{id} [put]
 func (s *Server) updateCartItem(c *gin.Context) {
 	userID := c.GetUint("user_id")
@@ -86,7 +96,7 @@ func (s *Server) updateCartItem(c *gin.Context) {
 		return
 	}
 
-	cart, err := s.cartService.UpdateCartItem(userID, uint(id), &req)
+	cart, err := s.cartService.UpdateCartItem(userID, uint(id), &req, requestCurrency(c))
 	if err != nil {
 		utils.BadRequestResponse(c, "Failed to update cart item", err)
 		return
@@ -120,3 +130,153 @@ func (s *Server) removeFromCart(c *gin.Context) {
 
 	utils.SuccessResponse(c, "Item removed from cart successfully", nil)
 }
+
+// @Summary Get shipping options
+// @Description Quote the shipping methods available for the cart when shipped to one of the user's addresses
+// @Tags Cart
+// @Produce json
+// @Security BearerAuth
+// @Param address_id query int true "Address ID"
+// @Success 200 {object} utils.Response{data=[]dto.ShippingOptionResponse} "Shipping options retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid address ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Address or cart not found"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
+// @Router /cart/shipping-options [get]
+func (s *Server) getShippingOptions(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	addressID, err := strconv.ParseUint(c.Query("address_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid address ID", err)
+		return
+	}
+
+	options, err := s.cartService.GetShippingOptions(userID, uint(addressID), requestCurrency(c))
+	if err != nil {
+		s.handleCartShippingError(c, err, "Failed to fetch shipping options")
+		return
+	}
+
+	utils.SuccessResponse(c, "Shipping options retrieved successfully", options)
+}
+
+// @Summary Select shipping method
+// @Description Select the shipping method the cart will be charged for; it must be one of the options quoted for the address
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.SelectShippingMethodRequest true "Destination address and shipping rate"
+// @Success 200 {object} utils.Response{data=dto.CartResponse} "Shipping method selected successfully"
+// @Failure 400 {object} utils.Response "Invalid request data or shipping method unavailable"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Address or cart not found"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
+// @Router /cart/shipping [put]
+func (s *Server) selectShippingMethod(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req dto.SelectShippingMethodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	cart, err := s.cartService.SelectShippingMethod(userID, &req, requestCurrency(c))
+	if err != nil {
+		s.handleCartShippingError(c, err, "Failed to select shipping method")
+		return
+	}
+
+	utils.SuccessResponse(c, "Shipping method selected successfully", cart)
+}
+
+func (s *Server) handleCartShippingError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrAddressNotFound):
+		utils.NotFoundResponse(c, "Address not found")
+	case errors.Is(err, services.ErrCartNotFound):
+		utils.NotFoundResponse(c, "Cart not found")
+	case errors.Is(err, services.ErrShippingUnavailable):
+		utils.BadRequestResponse(c, message, err)
+	default:
+		if !s.handleCurrencyError(c, err) {
+			utils.InternalServerErrorResponse(c, message, err)
+		}
+	}
+}
+
+// @Summary Apply coupon
+// @Description Apply a coupon code to the cart, replacing any coupon applied before
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ApplyCouponRequest true "Coupon code"
+// @Success 200 {object} utils.Response{data=dto.CartResponse} "Coupon applied successfully"
+// @Failure 400 {object} utils.Response "Invalid request data or coupon not applicable"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Coupon or cart not found"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
+// @Router /cart/coupon [post]
+func (s *Server) applyCoupon(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req dto.ApplyCouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	cart, err := s.cartService.ApplyCoupon(userID, &req, requestCurrency(c))
+	if err != nil {
+		s.handleCartCouponError(c, err, "Failed to apply coupon")
+		return
+	}
+
+	utils.SuccessResponse(c, "Coupon applied successfully", cart)
+}
+
+// @Summary Remove coupon
+// @Description Remove the coupon applied to the cart
+// @Tags Cart
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=dto.CartResponse} "Coupon removed successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
+// @Router /cart/coupon [delete]
+func (s *Server) removeCoupon(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	cart, err := s.cartService.RemoveCoupon(userID, requestCurrency(c))
+	if err != nil {
+		if !s.handleCurrencyError(c, err) {
+			utils.InternalServerErrorResponse(c, "Failed to remove coupon", err)
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, "Coupon removed successfully", cart)
+}
+
+func (s *Server) handleCartCouponError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrCouponNotFound):
+		utils.NotFoundResponse(c, "Coupon not found")
+	case errors.Is(err, services.ErrCartNotFound):
+		utils.NotFoundResponse(c, "Cart not found")
+	case errors.Is(err, services.ErrCouponNotApplicable):
+		utils.BadRequestResponse(c, message, err)
+	default:
+		if !s.handleCurrencyError(c, err) {
+			utils.InternalServerErrorResponse(c, message, err)
+		}
+	}
+}
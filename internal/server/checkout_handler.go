@@ -0,0 +1,128 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+)
+
+// @Summary Start a checkout session
+// @Description Price the current user's cart into a quote that is held, with its items reserved, until the session expires. A new session replaces the user's open one.
+// @Tags Checkout
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateOrderRequest false "Shipping and billing address IDs"
+// @Success 201 {object} utils.Response{data=dto.CheckoutSessionResponse} "Checkout session created successfully"
+// @Failure 400 {object} utils.Response "Cart is empty, insufficient stock or the selected shipping method is unavailable"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Cart or address not found"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
+// @Router /checkout [post]
+func (s *Server) createCheckoutSession(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req dto.CreateOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	session, err := s.checkoutService.CreateCheckoutSession(userID, &req, requestCurrency(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrAddressNotFound):
+			utils.NotFoundResponse(c, "Address not found")
+		case errors.Is(err, services.ErrCartNotFound):
+			utils.NotFoundResponse(c, "Cart not found")
+		case !s.handleCurrencyError(c, err):
+			utils.BadRequestResponse(c, "Failed to create checkout session", err)
+		}
+		return
+	}
+
+	utils.CreatedResponse(c, "Checkout session created successfully", session)
+}
+
+// @Summary Get a checkout session
+// @Description Retrieve one of the current user's checkout sessions with its quote
+// @Tags Checkout
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Checkout session ID"
+// @Success 200 {object} utils.Response{data=dto.CheckoutSessionResponse} "Checkout session retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid checkout session ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Checkout session not found"
+// @Router /checkout/{id} [get]
+func (s *Server) getCheckoutSession(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid checkout session ID", err)
+		return
+	}
+
+	session, err := s.checkoutService.GetCheckoutSession(userID, uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrCheckoutSessionNotFound) {
+			utils.NotFoundResponse(c, "Checkout session not found")
+			return
+		}
+		utils.InternalServerErrorResponse(c, "Failed to fetch checkout session", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Checkout session retrieved successfully", session)
+}
+
+// @Summary Complete a checkout session
+// @Description Place the order quoted by the checkout session, at the quoted prices. Fails once the session has expired or when the cart has changed since the session was created.
+// @Tags Checkout
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Checkout session ID"
+// @Param Idempotency-Key header string false "Client generated key that makes retries of this request safe"
+// @Success 201 {object} utils.Response{data=dto.OrderResponse} "Order created successfully"
+// @Failure 400 {object} utils.Response "Invalid checkout session ID or insufficient stock"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 402 {object} utils.Response "Payment declined"
+// @Failure 404 {object} utils.Response "Checkout session not found"
+// @Failure 409 {object} utils.Response "The cart has changed or the session is no longer open"
+// @Failure 410 {object} utils.Response "Checkout session has expired"
+// @Failure 504 {object} utils.Response "Payment provider timed out"
+// @Router /checkout/{id}/complete [post]
+func (s *Server) completeCheckoutSession(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid checkout session ID", err)
+		return
+	}
+
+	order, err := s.checkoutService.CompleteCheckoutSession(userID, uint(id))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrCheckoutSessionNotFound):
+			utils.NotFoundResponse(c, "Checkout session not found")
+		case errors.Is(err, services.ErrCheckoutSessionExpired):
+			utils.ErrorResponse(c, http.StatusGone, "Checkout session has expired", err)
+		case errors.Is(err, services.ErrCheckoutCartChanged), errors.Is(err, services.ErrCheckoutSessionClosed):
+			utils.ConflictResponse(c, "Checkout session can no longer be completed", err)
+		case !s.handlePaymentError(c, err):
+			utils.BadRequestResponse(c, "Failed to complete checkout session", err)
+		}
+		return
+	}
+
+	utils.CreatedResponse(c, "Order created successfully", order)
+}
@@ -20,8 +20,10 @@ func (s *Server) createGraphQLHandler() *handler.Server {
 	rvr := resolver.NewResolver(
 		s.authService,
 		s.userService,
+		s.addressService,
 		s.productService, s.cartService,
 		s.orderService,
+		s.returnService,
 	)
 
 	schema := graph.NewExecutableSchema(graph.Config{Resolvers: rvr})
@@ -83,10 +85,12 @@ func (s *Server) graphqlMiddleware() gin.HandlerFunc {
 		userID, _ := c.Get("user_id")
 		userEmail, _ := c.Get("user_email")
 		userRole, _ := c.Get("user_role")
+		currency, _ := c.Get("currency")
 
 		ctx := context.WithValue(c.Request.Context(), utils.UserIDKey, userID)
 		ctx = context.WithValue(ctx, utils.UserEmailKey, userEmail)
 		ctx = context.WithValue(ctx, utils.UserRoleKey, userRole)
+		ctx = context.WithValue(ctx, utils.CurrencyKey, currency)
 		ctx = context.WithValue(ctx, utils.GinContextKey, c)
 
 		c.Request = c.Request.WithContext(ctx)
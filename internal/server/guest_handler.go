@@ -0,0 +1,232 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+)
+
+// cartTokenHeader carries the token of a guest cart.
+const cartTokenHeader = "X-Cart-Token"
+
+// guestCartMiddleware requires the token of a guest cart, which is handed out
+// when the cart is created, on every request on the cart.
+func (s *Server) guestCartMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(cartTokenHeader)
+		if token == "" {
+			utils.BadRequestResponse(c, "X-Cart-Token header required", nil)
+			c.Abort()
+			return
+		}
+
+		c.Set("cart_token", token)
+
+		c.Next()
+	}
+}
+
+// @Summary Create a guest cart
+// @Description Start an empty cart for a guest shopping without an account. The token in the response identifies the cart in the X-Cart-Token header of every later guest request.
+// @Tags Guest
+// @Produce json
+// @Success 201 {object} utils.Response{data=dto.CartResponse} "Guest cart created successfully"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
+// @Router /guest/cart [post]
+func (s *Server) createGuestCart(c *gin.Context) {
+	cart, err := s.cartService.CreateGuestCart(requestCurrency(c))
+	if err != nil {
+		if !s.handleCurrencyError(c, err) {
+			utils.InternalServerErrorResponse(c, "Failed to create guest cart", err)
+		}
+		return
+	}
+
+	utils.CreatedResponse(c, "Guest cart created successfully", cart)
+}
+
+// @Summary Get a guest cart
+// @Description Retrieve the guest cart identified by the X-Cart-Token header
+// @Tags Guest
+// @Produce json
+// @Param X-Cart-Token header string true "Guest cart token"
+// @Success 200 {object} utils.Response{data=dto.CartResponse} "Cart retrieved successfully"
+// @Failure 400 {object} utils.Response "Missing cart token"
+// @Failure 404 {object} utils.Response "Cart not found"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
+// @Router /guest/cart [get]
+func (s *Server) getGuestCart(c *gin.Context) {
+	cart, err := s.cartService.GetGuestCart(c.GetString("cart_token"), requestCurrency(c))
+	if err != nil {
+		if !s.handleCurrencyError(c, err) {
+			utils.NotFoundResponse(c, "Cart not found")
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, "Cart retrieved successfully", cart)
+}
+
+// @Summary Add item to a guest cart
+// @Description Add a product to the guest cart identified by the X-Cart-Token header
+// @Tags Guest
+// @Accept json
+// @Produce json
+// @Param X-Cart-Token header string true "Guest cart token"
+// @Param request body dto.AddToCartRequest true "Item to add to cart"
+// @Success 200 {object} utils.Response{data=dto.CartResponse} "Item added to cart successfully"
+// @Failure 400 {object} utils.Response "Invalid request data or insufficient stock"
+// @Failure 404 {object} utils.Response "Cart not found"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
+// @Router /guest/cart/items [post]
+func (s *Server) addToGuestCart(c *gin.Context) {
+	var req dto.AddToCartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	cart, err := s.cartService.AddToGuestCart(c.GetString("cart_token"), &req, requestCurrency(c))
+	if err != nil {
+		if errors.Is(err, services.ErrCartNotFound) {
+			utils.NotFoundResponse(c, "Cart not found")
+			return
+		}
+		utils.BadRequestResponse(c, "Failed to add item to cart", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Item added to cart successfully", cart)
+}
+
+// @Summary Update guest cart item quantity
+// @Description Update the quantity of an item in the guest cart identified by the X-Cart-Token header
+// @Tags Guest
+// @Accept json
+// @Produce json
+// @Param X-Cart-Token header string true "Guest cart token"
+// @Param id path int true "Cart Item ID"
+// @Param request body dto.UpdateCartItemRequest true "New quantity"
+// @Success 200 {object} utils.Response{data=dto.CartResponse} "Cart item updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request data or insufficient stock"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
+// @Router /guest/cart/items/{id} [put]
+func (s *Server) updateGuestCartItem(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid cart item ID", err)
+		return
+	}
+
+	var req dto.UpdateCartItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	cart, err := s.cartService.UpdateGuestCartItem(c.GetString("cart_token"), uint(id), &req, requestCurrency(c))
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to update cart item", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Cart item updated successfully", cart)
+}
+
+// @Summary Remove item from a guest cart
+// @Description Remove an item from the guest cart identified by the X-Cart-Token header
+// @Tags Guest
+// @Param X-Cart-Token header string true "Guest cart token"
+// @Param id path int true "Cart Item ID"
+// @Success 200 {object} utils.Response "Item removed from cart successfully"
+// @Failure 400 {object} utils.Response "Invalid cart item ID"
+// @Router /guest/cart/items/{id} [delete]
+func (s *Server) removeFromGuestCart(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid cart item ID", err)
+		return
+	}
+
+	if err := s.cartService.RemoveFromGuestCart(c.GetString("cart_token"), uint(id)); err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to remove item from cart", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Item removed from cart successfully", nil)
+}
+
+// @Summary Check out a guest cart
+// @Description Create an order from the guest cart identified by the X-Cart-Token header, shipped to and billed at the given addresses. The order number is emailed to the guest.
+// @Tags Guest
+// @Accept json
+// @Produce json
+// @Param X-Cart-Token header string true "Guest cart token"
+// @Param request body dto.GuestOrderRequest true "Email, addresses and shipping method"
+// @Success 201 {object} utils.Response{data=dto.OrderResponse} "Order created successfully"
+// @Failure 400 {object} utils.Response "Cart is empty, insufficient stock or the selected shipping method is unavailable"
+// @Failure 402 {object} utils.Response "Payment declined"
+// @Failure 404 {object} utils.Response "Cart not found"
+// @Failure 504 {object} utils.Response "Payment provider timed out"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
+// @Router /guest/orders [post]
+func (s *Server) createGuestOrder(c *gin.Context) {
+	var req dto.GuestOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	order, err := s.orderService.CreateGuestOrder(c.GetString("cart_token"), &req, requestCurrency(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrCartNotFound):
+			utils.NotFoundResponse(c, "Cart not found")
+		case !s.handlePaymentError(c, err):
+			utils.BadRequestResponse(c, "Failed to create order", err)
+		}
+		return
+	}
+
+	utils.CreatedResponse(c, "Order created successfully", order)
+}
+
+// @Summary Look up a guest order
+// @Description Retrieve a guest order by the order number emailed to the guest and the email it was placed with
+// @Tags Guest
+// @Accept json
+// @Produce json
+// @Param request body dto.GuestOrderLookupRequest true "Order number and email"
+// @Success 200 {object} utils.Response{data=dto.OrderResponse} "Order retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Router /guest/orders/lookup [post]
+func (s *Server) lookupGuestOrder(c *gin.Context) {
+	var req dto.GuestOrderLookupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	order, err := s.orderService.LookupGuestOrder(&req)
+	if err != nil {
+		if errors.Is(err, services.ErrOrderNotFound) {
+			utils.NotFoundResponse(c, "Order not found")
+			return
+		}
+		utils.InternalServerErrorResponse(c, "Failed to look up order", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Order retrieved successfully", order)
+}
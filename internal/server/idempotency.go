@@ -0,0 +1,186 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/repositories"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	defaultIdempotencyKeyTTL  = 24 * time.Hour
+	idempotencyReplayMimeType = "application/json; charset=utf-8"
+)
+
+// idempotencyMiddleware makes POST requests carrying an Idempotency-Key header
+// safe to retry. The first request with a key is executed and its response is
+// stored; later requests with the same key and payload get the stored response
+// back, while reusing the key for a different payload is rejected. Keys are
+// kept per user, or per cart for guests, so it must run after the middleware
+// that identifies either.
+func (s *Server) idempotencyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyKeyHeader)
+		if key == "" || c.Request.Method != http.MethodPost {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			utils.BadRequestResponse(c, "Idempotency-Key header is too long", nil)
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			utils.BadRequestResponse(c, "Unable to read request body", err)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		userID := c.GetUint("user_id")
+		cartToken := c.GetString("cart_token")
+		fingerprint := requestFingerprint(c.Request.Method, c.Request.URL.Path, requestCurrency(c), body)
+
+		existing, err := s.idempotencyRepo.Get(userID, cartToken, key)
+		switch {
+		case err == nil && existing.ExpiresAt.After(time.Now()):
+			s.replayIdempotentResponse(c, existing, fingerprint)
+			return
+		case err == nil:
+			// The stored key has expired, so it can be claimed again.
+			if err := s.idempotencyRepo.Delete(existing.ID); err != nil {
+				utils.InternalServerErrorResponse(c, "Failed to process Idempotency-Key", err)
+				c.Abort()
+				return
+			}
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			utils.InternalServerErrorResponse(c, "Failed to process Idempotency-Key", err)
+			c.Abort()
+			return
+		}
+
+		record := &models.IdempotencyKey{
+			UserID:      userID,
+			CartToken:   cartToken,
+			Key:         key,
+			Method:      c.Request.Method,
+			Path:        c.Request.URL.Path,
+			RequestHash: fingerprint,
+			ExpiresAt:   time.Now().Add(s.idempotencyKeyTTL()),
+		}
+
+		if err := s.idempotencyRepo.Create(record); err != nil {
+			if errors.Is(err, repositories.ErrIdempotencyKeyExists) {
+				utils.ConflictResponse(c, "A request with this Idempotency-Key is already in progress", nil)
+			} else {
+				utils.InternalServerErrorResponse(c, "Failed to process Idempotency-Key", err)
+			}
+			c.Abort()
+			return
+		}
+
+		writer := &bodyCaptureWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = writer
+
+		defer func() {
+			// A panicking handler is answered with a 500 by gin.Recovery, so
+			// it releases the key like any other server error.
+			recovered := recover()
+
+			status := writer.Status()
+			if recovered != nil || status >= http.StatusInternalServerError {
+				// Server errors are not stored so that the client can retry with the same key.
+				if err := s.idempotencyRepo.Delete(record.ID); err != nil {
+					s.logError(err, "failed to release idempotency key")
+				}
+			} else if err := s.idempotencyRepo.Complete(record.ID, status, writer.body.Bytes()); err != nil {
+				s.logError(err, "failed to store idempotent response")
+			}
+
+			if recovered != nil {
+				panic(recovered)
+			}
+		}()
+
+		c.Next()
+	}
+}
+
+func (s *Server) replayIdempotentResponse(c *gin.Context, record *models.IdempotencyKey, fingerprint string) {
+	if record.Method != c.Request.Method || record.Path != c.Request.URL.Path || record.RequestHash != fingerprint {
+		utils.UnprocessableEntityResponse(c, "Idempotency-Key was already used for a different request", nil)
+		c.Abort()
+		return
+	}
+
+	if record.CompletedAt == nil {
+		utils.ConflictResponse(c, "A request with this Idempotency-Key is already in progress", nil)
+		c.Abort()
+		return
+	}
+
+	c.Header(idempotentReplayedHeader, "true")
+	c.Data(record.StatusCode, idempotencyReplayMimeType, record.ResponseBody)
+	c.Abort()
+}
+
+func (s *Server) idempotencyKeyTTL() time.Duration {
+	if s.config.Server.IdempotencyKeyTTL > 0 {
+		return s.config.Server.IdempotencyKeyTTL
+	}
+	return defaultIdempotencyKeyTTL
+}
+
+func (s *Server) logError(err error, msg string) {
+	if s.logger == nil {
+		return
+	}
+	s.logger.Error().Err(err).Msg(msg)
+}
+
+// requestFingerprint identifies a request by its method, path, the currency
+// it shops in and its body.
+func requestFingerprint(method, path string, currency money.Currency, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(path))
+	hash.Write([]byte{0})
+	hash.Write([]byte(currency))
+	hash.Write([]byte{0})
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// bodyCaptureWriter copies everything written to the response so it can be stored.
+type bodyCaptureWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *bodyCaptureWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyCaptureWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+)
+
+// @Summary Get loyalty points
+// @Description Get the current user's spendable loyalty points and what they are worth, with a paginated list of the points earned, spent, expired, reversed and restored, newest first
+// @Tags Loyalty
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse{data=dto.LoyaltyResponse} "Loyalty points retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/loyalty [get]
+func (s *Server) getLoyalty(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	loyalty, meta, err := s.loyaltyService.GetLoyalty(userID, page, limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch loyalty points", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Loyalty points retrieved successfully", loyalty, *meta)
+}
@@ -1,10 +1,13 @@
 package server
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
 	"github.com/kuldeepstechwork/gocart-api/internal/utils"
 )
 
@@ -58,3 +61,58 @@ func (s *Server) adminMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// currencyMiddleware picks the currency the request shops in from the
+// currency query parameter or, failing that, the X-Currency header. Requests
+// naming neither shop in the store currency.
+func (s *Server) currencyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		code := c.Query("currency")
+		if code == "" {
+			code = c.GetHeader("X-Currency")
+		}
+
+		if code == "" {
+			c.Next()
+			return
+		}
+
+		currency, err := money.ParseCurrency(code)
+		if err == nil && !s.supportsCurrency(currency) {
+			err = fmt.Errorf("%w: %s", services.ErrCurrencyNotSupported, currency)
+		}
+		if err != nil {
+			utils.BadRequestResponse(c, "Unsupported currency", err)
+			c.Abort()
+			return
+		}
+
+		c.Set("currency", currency)
+
+		c.Next()
+	}
+}
+
+// supportsCurrency reports whether the store is configured to sell in the
+// currency.
+func (s *Server) supportsCurrency(currency money.Currency) bool {
+	if strings.EqualFold(s.config.Store.Currency, string(currency)) {
+		return true
+	}
+
+	for _, code := range s.config.Store.Currencies {
+		if strings.EqualFold(code, string(currency)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// requestCurrency returns the currency picked by currencyMiddleware, or ""
+// for the store currency.
+func requestCurrency(c *gin.Context) money.Currency {
+	value, _ := c.Get("currency")
+	currency, _ := value.(money.Currency)
+	return currency
+}
@@ -1,28 +1,54 @@
 package server
 
 import (
+	"errors"
+	"io"
+	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	_ "github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/payments"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
 	"github.com/kuldeepstechwork/gocart-api/internal/utils"
 )
 
 // @Summary Create an order
-// @Description Create an order from the current user's cart
+// @Description Create an order from the current user's cart, shipped to and billed at addresses from the user's address book
 // @Tags Orders
+// @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param Idempotency-Key header string false "Client generated key that makes retries of this request safe"
+// @Param request body dto.CreateOrderRequest false "Shipping and billing address IDs"
 // @Success 201 {object} utils.Response{data=dto.OrderResponse} "Order created successfully"
-// @Failure 400 {object} utils.Response "Cart is empty or insufficient stock"
+// @Failure 400 {object} utils.Response "Cart is empty, insufficient stock or the selected shipping method is unavailable"
 // @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 402 {object} utils.Response "Payment declined"
+// @Failure 404 {object} utils.Response "Address not found"
+// @Failure 409 {object} utils.Response "A request with the same Idempotency-Key is in progress"
+// @Failure 422 {object} utils.Response "Idempotency-Key reused with a different payload"
+// @Failure 504 {object} utils.Response "Payment provider timed out"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
 // @Router /orders [post]
 func (s *Server) createOrder(c *gin.Context) {
 	userID := c.GetUint("user_id")
 
-	order, err := s.orderService.CreateOrder(userID)
+	var req dto.CreateOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	order, err := s.orderService.CreateOrder(userID, &req, requestCurrency(c))
 	if err != nil {
-		utils.BadRequestResponse(c, "Failed to create order", err)
+		switch {
+		case errors.Is(err, services.ErrAddressNotFound):
+			utils.NotFoundResponse(c, "Address not found")
+		case !s.handlePaymentError(c, err):
+			utils.BadRequestResponse(c, "Failed to create order", err)
+		}
 		return
 	}
 
@@ -83,3 +109,155 @@ func (s *Server) getOrder(c *gin.Context) {
 
 	utils.SuccessResponse(c, "Order retrieved successfully", order)
 }
+
+// @Summary Cancel an order
+// @Description Cancel one of the current user's orders while it is pending or confirmed and restore product stock
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Param request body dto.CancelOrderRequest false "Cancellation reason"
+// @Success 200 {object} utils.Response{data=dto.OrderResponse} "Order cancelled successfully"
+// @Failure 400 {object} utils.Response "Invalid order ID or request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Failure 409 {object} utils.Response "Order can no longer be cancelled"
+// @Failure 504 {object} utils.Response "Payment provider timed out"
+// @Router /orders/{id}/cancel [post]
+func (s *Server) cancelOrder(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID", err)
+		return
+	}
+
+	var req dto.CancelOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	order, err := s.orderService.CancelOrder(userID, uint(id), &req)
+	if err != nil {
+		s.handleOrderStatusError(c, err, "Failed to cancel order")
+		return
+	}
+
+	utils.SuccessResponse(c, "Order cancelled successfully", order)
+}
+
+// @Summary Reorder
+// @Description Add the items of one of the current user's past orders to their cart. Items of deleted, inactive or out of stock products are dropped and quantities are lowered to the stock left; every item gets a line in the report
+// @Tags Orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
+// @Success 200 {object} utils.Response{data=dto.ReorderResponse} "Order items added to cart"
+// @Failure 400 {object} utils.Response "Invalid order ID or currency not available"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /orders/{id}/reorder [post]
+func (s *Server) reorder(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID", err)
+		return
+	}
+
+	reorder, err := s.cartService.Reorder(userID, uint(id), requestCurrency(c))
+	if err != nil {
+		if s.handleCurrencyError(c, err) {
+			return
+		}
+		if errors.Is(err, services.ErrOrderNotFound) {
+			utils.NotFoundResponse(c, "Order not found")
+			return
+		}
+		utils.InternalServerErrorResponse(c, "Failed to reorder", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Order items added to cart", reorder)
+}
+
+// @Summary Get an order's invoice
+// @Description Retrieve the invoice issued when one of the current user's orders was confirmed, with the path of its PDF or HTML document
+// @Tags Orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Success 200 {object} utils.Response{data=dto.InvoiceResponse} "Invoice retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid order ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Order not found or not invoiced yet"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /orders/{id}/invoice [get]
+func (s *Server) getOrderInvoice(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID", err)
+		return
+	}
+
+	invoice, err := s.invoiceService.GetInvoice(userID, uint(id))
+	if err != nil {
+		s.handleInvoiceError(c, err, "Failed to fetch invoice")
+		return
+	}
+
+	utils.SuccessResponse(c, "Invoice retrieved successfully", invoice)
+}
+
+func (s *Server) handleInvoiceError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrOrderNotFound):
+		utils.NotFoundResponse(c, "Order not found")
+	case errors.Is(err, services.ErrInvoiceNotFound):
+		utils.NotFoundResponse(c, "Invoice not found")
+	default:
+		utils.InternalServerErrorResponse(c, message, err)
+	}
+}
+
+// handleOrderStatusError maps order state machine errors to HTTP responses.
+func (s *Server) handleOrderStatusError(c *gin.Context, err error, message string) {
+	var transitionErr *services.InvalidStatusTransitionError
+
+	switch {
+	case errors.As(err, &transitionErr):
+		utils.ConflictResponse(c, "Order status transition not allowed", err)
+	case errors.Is(err, services.ErrInvalidOrderStatus):
+		utils.BadRequestResponse(c, "Invalid order status", err)
+	case errors.Is(err, services.ErrOrderNotFound):
+		utils.NotFoundResponse(c, "Order not found")
+	default:
+		if !s.handlePaymentError(c, err) {
+			utils.InternalServerErrorResponse(c, message, err)
+		}
+	}
+}
+
+// handlePaymentError writes the response for payment provider errors and
+// reports whether err was one of them.
+func (s *Server) handlePaymentError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, payments.ErrPaymentDeclined):
+		utils.ErrorResponse(c, http.StatusPaymentRequired, "Payment declined", err)
+	case errors.Is(err, payments.ErrPaymentTimeout):
+		utils.ErrorResponse(c, http.StatusGatewayTimeout, "Payment provider timed out", err)
+	default:
+		return false
+	}
+
+	return true
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
 	"github.com/kuldeepstechwork/gocart-api/internal/utils"
 )
 
@@ -135,6 +136,10 @@ func (s *Server) createProduct(c *gin.Context) {
 		return
 	}
 	product, err := s.productService.CreateProduct(&req)
+	if errors.Is(err, services.ErrInvalidProduct) {
+		utils.BadRequestResponse(c, "Invalid product", err)
+		return
+	}
 	if err != nil {
 		utils.InternalServerErrorResponse(c, "Failed to create product", err)
 		return
@@ -151,14 +156,18 @@ func (s *Server) createProduct(c *gin.Context) {
 // @Param limit query int false "Items per page" default(10)
 // @Success 200 {object} utils.PaginatedResponse{data=[]dto.ProductResponse} "Products retrieved successfully"
 // @Failure 500 {object} utils.Response "Internal server error"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
 // @Router /products [get]
 func (s *Server) getProducts(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
-	products, meta, err := s.productService.GetProducts(page, limit)
+	products, meta, err := s.productService.GetProducts(page, limit, requestCurrency(c))
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to fetch products", err)
+		if !s.handleCurrencyError(c, err) {
+			utils.InternalServerErrorResponse(c, "Failed to fetch products", err)
+		}
 		return
 	}
 
@@ -173,6 +182,8 @@ func (s *Server) getProducts(c *gin.Context) {
 // @Success 200 {object} utils.Response{data=dto.ProductResponse} "Product retrieved successfully"
 // @Failure 400 {object} utils.Response "Invalid product ID"
 // @Failure 404 {object} utils.Response "Product not found"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
 // @Router /products/{id} [get]
 func (s *Server) getProduct(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -181,9 +192,11 @@ func (s *Server) getProduct(c *gin.Context) {
 		return
 	}
 
-	product, err := s.productService.GetProduct(uint(id))
+	product, err := s.productService.GetProduct(uint(id), requestCurrency(c))
 	if err != nil {
-		utils.NotFoundResponse(c, "Product not found")
+		if !s.handleCurrencyError(c, err) {
+			utils.NotFoundResponse(c, "Product not found")
+		}
 		return
 	}
 
@@ -217,6 +230,10 @@ func (s *Server) updateProduct(c *gin.Context) {
 	}
 
 	product, err := s.productService.UpdateProduct(uint(id), &req)
+	if errors.Is(err, services.ErrInvalidProduct) {
+		utils.BadRequestResponse(c, "Invalid product", err)
+		return
+	}
 	if err != nil {
 		utils.InternalServerErrorResponse(c, "Failed to update product", err)
 		return
@@ -297,11 +314,13 @@ func (s *Server) uploadProductImage(c *gin.Context) {
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Param category_id query int false "Filter by category ID"
-// @Param min_price query number false "Minimum price filter"
-// @Param max_price query number false "Maximum price filter"
+// @Param min_price query int false "Minimum price filter, in minor units of the currency"
+// @Param max_price query int false "Maximum price filter, in minor units of the currency"
 // @Success 200 {object} utils.PaginatedResponse{data=[]dto.ProductSearchResult} "Search results"
 // @Failure 400 {object} utils.Response "Invalid search query"
 // @Failure 500 {object} utils.Response "Internal server error"
+// @Param X-Currency header string false "Currency to price in, defaults to the store currency"
+// @Param currency query string false "Currency to price in, overrides X-Currency"
 // @Router /search [get]
 func (s *Server) searchProducts(c *gin.Context) {
 	var req dto.SearchProductsRequest
@@ -310,8 +329,11 @@ func (s *Server) searchProducts(c *gin.Context) {
 		return
 	}
 
-	results, meta, err := s.productService.SearchProducts(&req)
+	results, meta, err := s.productService.SearchProducts(&req, requestCurrency(c))
 	if err != nil {
+		if s.handleCurrencyError(c, err) {
+			return
+		}
 		s.logger.Error().Err(err).Msg("Product search failed")
 		utils.InternalServerErrorResponse(c, "Search failed", errors.New("unable to complete search at this time"))
 		return
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+)
+
+// @Summary Request a return
+// @Description Request a return for items of one of the current user's delivered orders
+// @Tags Returns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Param request body dto.CreateReturnRequest true "Items to return"
+// @Success 201 {object} utils.Response{data=dto.ReturnResponse} "Return requested successfully"
+// @Failure 400 {object} utils.Response "Invalid request data or return items"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Failure 409 {object} utils.Response "Order has not been delivered"
+// @Router /orders/{id}/returns [post]
+func (s *Server) requestReturn(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID", err)
+		return
+	}
+
+	var req dto.CreateReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	ret, err := s.returnService.RequestReturn(userID, uint(id), &req)
+	if err != nil {
+		s.handleReturnError(c, err, "Failed to request return")
+		return
+	}
+
+	utils.CreatedResponse(c, "Return requested successfully", ret)
+}
+
+// @Summary Get user's returns
+// @Description Retrieve paginated list of the current user's returns
+// @Tags Returns
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.ReturnResponse} "Returns retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /returns [get]
+func (s *Server) getReturns(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	returns, meta, err := s.returnService.GetReturns(userID, page, limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch returns", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Returns retrieved successfully", returns, *meta)
+}
+
+// @Summary Cancel a return
+// @Description Withdraw one of the current user's returns that has not been received yet. Its items can be returned again
+// @Tags Returns
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Return ID"
+// @Success 200 {object} utils.Response{data=dto.ReturnResponse} "Return cancelled successfully"
+// @Failure 400 {object} utils.Response "Invalid return ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Return not found"
+// @Failure 409 {object} utils.Response "Return status transition not allowed"
+// @Router /returns/{id}/cancel [post]
+func (s *Server) cancelReturn(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid return ID", err)
+		return
+	}
+
+	ret, err := s.returnService.CancelReturn(userID, uint(id))
+	if err != nil {
+		s.handleReturnError(c, err, "Failed to cancel return")
+		return
+	}
+
+	utils.SuccessResponse(c, "Return cancelled successfully", ret)
+}
+
+// handleReturnError maps return workflow errors to HTTP responses.
+func (s *Server) handleReturnError(c *gin.Context, err error, message string) {
+	var transitionErr *services.InvalidReturnTransitionError
+
+	switch {
+	case errors.As(err, &transitionErr):
+		utils.ConflictResponse(c, "Return status transition not allowed", err)
+	case errors.Is(err, services.ErrOrderNotReturnable):
+		utils.ConflictResponse(c, "Order cannot be returned", err)
+	case errors.Is(err, services.ErrInvalidReturnItem):
+		utils.BadRequestResponse(c, "Invalid return items", err)
+	case errors.Is(err, services.ErrOrderNotFound):
+		utils.NotFoundResponse(c, "Order not found")
+	case errors.Is(err, services.ErrReturnNotFound):
+		utils.NotFoundResponse(c, "Return not found")
+	case errors.Is(err, services.ErrRefundExceedsPayments):
+		utils.ConflictResponse(c, "Refund exceeds the captured payments", err)
+	default:
+		if !s.handlePaymentError(c, err) {
+			utils.InternalServerErrorResponse(c, message, err)
+		}
+	}
+}
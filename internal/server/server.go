@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 	_ "github.com/kuldeepstechwork/gocart-api/docs"
 	"github.com/kuldeepstechwork/gocart-api/internal/config"
+	"github.com/kuldeepstechwork/gocart-api/internal/repositories"
 	"github.com/kuldeepstechwork/gocart-api/internal/services"
 	"github.com/rs/zerolog"
 	swaggerFiles "github.com/swaggo/files"
@@ -13,14 +14,30 @@ import (
 )
 
 type Server struct {
-	config         *config.Config
-	logger         *zerolog.Logger
-	authService    services.AuthServiceInterface
-	productService services.ProductServiceInterface
-	userService    services.UserServiceInterface
-	uploadService  services.UploadServiceInterface
-	cartService    services.CartServiceInterface
-	orderService   services.OrderServiceInterface
+	config              *config.Config
+	logger              *zerolog.Logger
+	authService         services.AuthServiceInterface
+	productService      services.ProductServiceInterface
+	userService         services.UserServiceInterface
+	addressService      services.AddressServiceInterface
+	uploadService       services.UploadServiceInterface
+	cartService         services.CartServiceInterface
+	orderService        services.OrderServiceInterface
+	checkoutService     services.CheckoutServiceInterface
+	subscriptionService services.SubscriptionServiceInterface
+	walletService       services.WalletServiceInterface
+	loyaltyService      services.LoyaltyServiceInterface
+	returnService       services.ReturnServiceInterface
+	shipmentService     services.ShipmentServiceInterface
+	shippingService     services.ShippingServiceInterface
+	taxService          services.TaxServiceInterface
+	couponService       services.CouponServiceInterface
+	promotionService    services.PromotionServiceInterface
+	invoiceService      services.InvoiceServiceInterface
+	currencyService     services.CurrencyServiceInterface
+	inventoryService    services.InventoryServiceInterface
+
+	idempotencyRepo repositories.IdempotencyRepositoryInterface
 }
 
 func New(cfg *config.Config,
@@ -28,19 +45,50 @@ func New(cfg *config.Config,
 	authService services.AuthServiceInterface,
 	productService services.ProductServiceInterface,
 	userService services.UserServiceInterface,
+	addressService services.AddressServiceInterface,
 	uploadService services.UploadServiceInterface,
 	cartService services.CartServiceInterface,
 	orderService services.OrderServiceInterface,
+	checkoutService services.CheckoutServiceInterface,
+	subscriptionService services.SubscriptionServiceInterface,
+	walletService services.WalletServiceInterface,
+	loyaltyService services.LoyaltyServiceInterface,
+	returnService services.ReturnServiceInterface,
+	shipmentService services.ShipmentServiceInterface,
+	shippingService services.ShippingServiceInterface,
+	taxService services.TaxServiceInterface,
+	couponService services.CouponServiceInterface,
+	promotionService services.PromotionServiceInterface,
+	invoiceService services.InvoiceServiceInterface,
+	currencyService services.CurrencyServiceInterface,
+	inventoryService services.InventoryServiceInterface,
+	idempotencyRepo repositories.IdempotencyRepositoryInterface,
 ) *Server {
 	return &Server{
-		config:         cfg,
-		logger:         logger,
-		authService:    authService,
-		productService: productService,
-		userService:    userService,
-		uploadService:  uploadService,
-		cartService:    cartService,
-		orderService:   orderService,
+		config:              cfg,
+		logger:              logger,
+		authService:         authService,
+		productService:      productService,
+		userService:         userService,
+		addressService:      addressService,
+		uploadService:       uploadService,
+		cartService:         cartService,
+		orderService:        orderService,
+		checkoutService:     checkoutService,
+		subscriptionService: subscriptionService,
+		walletService:       walletService,
+		loyaltyService:      loyaltyService,
+		returnService:       returnService,
+		shipmentService:     shipmentService,
+		shippingService:     shippingService,
+		taxService:          taxService,
+		couponService:       couponService,
+		promotionService:    promotionService,
+		invoiceService:      invoiceService,
+		currencyService:     currencyService,
+		inventoryService:    inventoryService,
+
+		idempotencyRepo: idempotencyRepo,
 	}
 }
 
@@ -67,15 +115,18 @@ func (s *Server) SetupRoutes() *gin.Engine {
 	router.GET("/playground/protected", s.playgroundProtectedHandler())
 
 	graphqlPublic := router.Group("/graphql/public")
+	graphqlPublic.Use(s.currencyMiddleware())
 	graphqlPublic.Use(s.graphqlMiddleware())
 	graphqlPublic.POST("/", s.graphqlHandler())
 
 	graphqlProtected := router.Group("/graphql")
 	graphqlProtected.Use(s.authMiddleware())
+	graphqlProtected.Use(s.currencyMiddleware())
 	graphqlProtected.Use(s.graphqlMiddleware())
 	graphqlProtected.POST("/", s.graphqlHandler())
 
 	api := router.Group("/api/v1")
+	api.Use(s.currencyMiddleware())
 	{
 		auth := api.Group("/auth")
 		{ //nolint:gocritic // I need this for readability
@@ -83,11 +134,13 @@ func (s *Server) SetupRoutes() *gin.Engine {
 			auth.POST("/login", s.login)
 			auth.POST("/refresh", s.refreshToken)
 			auth.POST("/logout", s.logout)
+			auth.POST("/verify-email", s.verifyEmail)
 
 		}
 
 		protected := api.Group("/")
 		protected.Use(s.authMiddleware())
+		protected.Use(s.idempotencyMiddleware())
 		{
 			// User routes
 			users := protected.Group("/users")
@@ -95,6 +148,17 @@ func (s *Server) SetupRoutes() *gin.Engine {
 				userRoutes := users
 				userRoutes.GET("/profile", s.getProfile)
 				userRoutes.PUT("/profile", s.updateProfile)
+
+				userRoutes.GET("/addresses", s.getAddresses)
+				userRoutes.POST("/addresses", s.createAddress)
+				userRoutes.GET("/addresses/:id", s.getAddress)
+				userRoutes.PUT("/addresses/:id", s.updateAddress)
+				userRoutes.DELETE("/addresses/:id", s.deleteAddress)
+
+				userRoutes.GET("/wallet", s.getWallet)
+				userRoutes.GET("/wallet/entries", s.getWalletEntries)
+				userRoutes.POST("/wallet/redeem", s.redeemGiftCard)
+				userRoutes.GET("/loyalty", s.getLoyalty)
 			}
 
 			// category routes
@@ -125,6 +189,10 @@ func (s *Server) SetupRoutes() *gin.Engine {
 				cartRoutes.POST("/items", s.addToCart)
 				cartRoutes.PUT("/items/:id", s.updateCartItem)
 				cartRoutes.DELETE("/items/:id", s.removeFromCart)
+				cartRoutes.GET("/shipping-options", s.getShippingOptions)
+				cartRoutes.PUT("/shipping", s.selectShippingMethod)
+				cartRoutes.POST("/coupon", s.applyCoupon)
+				cartRoutes.DELETE("/coupon", s.removeCoupon)
 			}
 
 			// Order routes
@@ -134,11 +202,134 @@ func (s *Server) SetupRoutes() *gin.Engine {
 				orderRoutes.POST("/", s.createOrder)
 				orderRoutes.GET("/", s.getOrders)
 				orderRoutes.GET("/:id", s.getOrder)
+				orderRoutes.POST("/:id/cancel", s.cancelOrder)
+				orderRoutes.POST("/:id/reorder", s.reorder)
+				orderRoutes.GET("/:id/invoice", s.getOrderInvoice)
+				orderRoutes.POST("/:id/returns", s.requestReturn)
+				orderRoutes.GET("/:id/shipments", s.getOrderShipments)
+			}
+
+			// Checkout routes
+			checkout := protected.Group("/checkout")
+			{
+				checkoutRoutes := checkout
+				checkoutRoutes.POST("/", s.createCheckoutSession)
+				checkoutRoutes.GET("/:id", s.getCheckoutSession)
+				checkoutRoutes.POST("/:id/complete", s.completeCheckoutSession)
+			}
+
+			// Subscription routes
+			subscriptions := protected.Group("/subscriptions")
+			{
+				subscriptionRoutes := subscriptions
+				subscriptionRoutes.GET("/", s.getSubscriptions)
+				subscriptionRoutes.POST("/", s.createSubscription)
+				subscriptionRoutes.GET("/:id", s.getSubscription)
+				subscriptionRoutes.PUT("/:id", s.updateSubscription)
+				subscriptionRoutes.POST("/:id/pause", s.pauseSubscription)
+				subscriptionRoutes.POST("/:id/resume", s.resumeSubscription)
+				subscriptionRoutes.POST("/:id/cancel", s.cancelSubscription)
+			}
+
+			// Return routes
+			returns := protected.Group("/returns")
+			{
+				returnRoutes := returns
+				returnRoutes.GET("/", s.getReturns)
+				returnRoutes.POST("/:id/cancel", s.cancelReturn)
 			}
+
+			// Admin routes
+			admin := protected.Group("/admin")
+			admin.Use(s.adminMiddleware())
+			{
+				adminOrders := admin.Group("/orders")
+				adminOrders.GET("/", s.listOrders)
+				adminOrders.GET("/export", s.exportOrders)
+				adminOrders.GET("/:id", s.getOrderDetail)
+				adminOrders.PUT("/:id/status", s.updateOrderStatus)
+				adminOrders.POST("/:id/invoice", s.regenerateInvoice)
+				adminOrders.GET("/:id/shipments", s.listShipments)
+				adminOrders.POST("/:id/shipments", s.createShipment)
+
+				adminBackorders := admin.Group("/backorders")
+				adminBackorders.GET("/", s.listBackorders)
+
+				adminShipments := admin.Group("/shipments")
+				adminShipments.PUT("/:id", s.updateShipment)
+
+				adminReturns := admin.Group("/returns")
+				adminReturns.GET("/", s.listReturns)
+				adminReturns.POST("/:id/approve", s.approveReturn)
+				adminReturns.POST("/:id/reject", s.rejectReturn)
+				adminReturns.POST("/:id/receive", s.receiveReturn)
+				adminReturns.POST("/:id/refund", s.refundReturn)
+
+				adminShipping := admin.Group("/shipping")
+				adminShipping.GET("/zones", s.getShippingZones)
+				adminShipping.POST("/zones", s.createShippingZone)
+				adminShipping.PUT("/zones/:id", s.updateShippingZone)
+				adminShipping.DELETE("/zones/:id", s.deleteShippingZone)
+				adminShipping.POST("/zones/:id/rates", s.createShippingRate)
+				adminShipping.PUT("/rates/:id", s.updateShippingRate)
+				adminShipping.DELETE("/rates/:id", s.deleteShippingRate)
+
+				adminTax := admin.Group("/tax")
+				adminTax.GET("/rates", s.getTaxRates)
+				adminTax.POST("/rates", s.createTaxRate)
+				adminTax.PUT("/rates/:id", s.updateTaxRate)
+				adminTax.DELETE("/rates/:id", s.deleteTaxRate)
+
+				adminCoupons := admin.Group("/coupons")
+				adminCoupons.GET("/", s.getCoupons)
+				adminCoupons.POST("/", s.createCoupon)
+				adminCoupons.PUT("/:id", s.updateCoupon)
+				adminCoupons.DELETE("/:id", s.deleteCoupon)
+
+				adminPromotions := admin.Group("/promotions")
+				adminPromotions.GET("/", s.getPromotions)
+				adminPromotions.POST("/", s.createPromotion)
+				adminPromotions.PUT("/:id", s.updatePromotion)
+				adminPromotions.DELETE("/:id", s.deletePromotion)
+
+				adminCurrencies := admin.Group("/currencies")
+				adminCurrencies.GET("/rates", s.getExchangeRates)
+				adminCurrencies.PUT("/rates/:currency", s.setExchangeRate)
+				adminCurrencies.DELETE("/rates/:currency", s.deleteExchangeRate)
+
+				adminProducts := admin.Group("/products")
+				adminProducts.GET("/:id/prices", s.getProductPrices)
+				adminProducts.PUT("/:id/prices/:currency", s.setProductPrice)
+				adminProducts.DELETE("/:id/prices/:currency", s.deleteProductPrice)
+
+				adminGiftCards := admin.Group("/gift-cards")
+				adminGiftCards.GET("/", s.getGiftCards)
+				adminGiftCards.POST("/", s.issueGiftCard)
+
+				adminUsers := admin.Group("/users")
+				adminUsers.POST("/:id/wallet/credits", s.grantCredit)
+			}
+		}
+
+		// guest routes, for shoppers without an account
+		guest := api.Group("/guest")
+		{
+			guest.POST("/cart", s.createGuestCart)
+			guest.POST("/orders/lookup", s.lookupGuestOrder)
+
+			guestCart := guest.Group("/")
+			guestCart.Use(s.guestCartMiddleware())
+			guestCart.Use(s.idempotencyMiddleware())
+			guestCart.GET("/cart", s.getGuestCart)
+			guestCart.POST("/cart/items", s.addToGuestCart)
+			guestCart.PUT("/cart/items/:id", s.updateGuestCartItem)
+			guestCart.DELETE("/cart/items/:id", s.removeFromGuestCart)
+			guestCart.POST("/orders", s.createGuestOrder)
 		}
 
 		// public routes
 		api.GET("/categories", s.getCategories)
+		api.GET("/currencies", s.getCurrencies)
 		api.GET("/search", s.searchProducts)
 		api.GET("/products", s.getProducts)
 		api.GET("/products/:id", s.getProduct)
@@ -157,7 +348,7 @@ func (s *Server) corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, Idempotency-Key, X-Cart-Token")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
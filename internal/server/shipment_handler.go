@@ -0,0 +1,60 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+)
+
+// @Summary Get order shipments
+// @Description Retrieve the shipments of one of the current user's orders with their carriers and tracking numbers
+// @Tags Orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Success 200 {object} utils.Response{data=[]dto.ShipmentResponse} "Shipments retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid order ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /orders/{id}/shipments [get]
+func (s *Server) getOrderShipments(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID", err)
+		return
+	}
+
+	shipments, err := s.shipmentService.GetShipments(userID, uint(id))
+	if err != nil {
+		s.handleShipmentError(c, err, "Failed to fetch shipments")
+		return
+	}
+
+	utils.SuccessResponse(c, "Shipments retrieved successfully", shipments)
+}
+
+// handleShipmentError maps shipment errors to HTTP responses.
+func (s *Server) handleShipmentError(c *gin.Context, err error, message string) {
+	var transitionErr *services.InvalidStatusTransitionError
+
+	switch {
+	case errors.As(err, &transitionErr):
+		utils.ConflictResponse(c, "Order status transition not allowed", err)
+	case errors.Is(err, services.ErrOrderNotShippable):
+		utils.ConflictResponse(c, "Order cannot be shipped", err)
+	case errors.Is(err, services.ErrInvalidShipmentItem):
+		utils.BadRequestResponse(c, "Invalid shipment items", err)
+	case errors.Is(err, services.ErrOrderNotFound):
+		utils.NotFoundResponse(c, "Order not found")
+	case errors.Is(err, services.ErrShipmentNotFound):
+		utils.NotFoundResponse(c, "Shipment not found")
+	default:
+		utils.InternalServerErrorResponse(c, message, err)
+	}
+}
@@ -0,0 +1,238 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+)
+
+// @Summary List subscriptions
+// @Description Get the current user's subscriptions, cancelled ones included
+// @Tags Subscriptions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]dto.SubscriptionResponse} "Subscriptions retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /subscriptions [get]
+func (s *Server) getSubscriptions(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	subscriptions, err := s.subscriptionService.GetSubscriptions(userID)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch subscriptions", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Subscriptions retrieved successfully", subscriptions)
+}
+
+// @Summary Get subscription by ID
+// @Description Get one of the current user's subscriptions with its latest runs and the orders they placed
+// @Tags Subscriptions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} utils.Response{data=dto.SubscriptionResponse} "Subscription retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid subscription ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Subscription not found"
+// @Router /subscriptions/{id} [get]
+func (s *Server) getSubscription(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid subscription ID", err)
+		return
+	}
+
+	subscription, err := s.subscriptionService.GetSubscription(userID, uint(id))
+	if err != nil {
+		s.handleSubscriptionError(c, err, "Failed to fetch subscription")
+		return
+	}
+
+	utils.SuccessResponse(c, "Subscription retrieved successfully", subscription)
+}
+
+// @Summary Subscribe to a product
+// @Description Order a product every few weeks, delivered to an address of the user's address book. The first order is placed at starts_at, or straight away when it is omitted
+// @Tags Subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateSubscriptionRequest true "Subscription data"
+// @Success 201 {object} utils.Response{data=dto.SubscriptionResponse} "Subscription created successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Product, address or shipping rate not found"
+// @Router /subscriptions [post]
+func (s *Server) createSubscription(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req dto.CreateSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	subscription, err := s.subscriptionService.CreateSubscription(userID, &req)
+	if err != nil {
+		s.handleSubscriptionError(c, err, "Failed to create subscription")
+		return
+	}
+
+	utils.CreatedResponse(c, "Subscription created successfully", subscription)
+}
+
+// @Summary Update a subscription
+// @Description Change the quantity, interval and addresses of a subscription, and optionally move its next order. Orders already placed are not changed
+// @Tags Subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Subscription ID"
+// @Param request body dto.UpdateSubscriptionRequest true "Subscription data"
+// @Success 200 {object} utils.Response{data=dto.SubscriptionResponse} "Subscription updated successfully"
+// @Failure 400 {object} utils.Response "Invalid subscription ID or request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Subscription, address or shipping rate not found"
+// @Failure 409 {object} utils.Response "Subscription has been cancelled"
+// @Router /subscriptions/{id} [put]
+func (s *Server) updateSubscription(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid subscription ID", err)
+		return
+	}
+
+	var req dto.UpdateSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	subscription, err := s.subscriptionService.UpdateSubscription(userID, uint(id), &req)
+	if err != nil {
+		s.handleSubscriptionError(c, err, "Failed to update subscription")
+		return
+	}
+
+	utils.SuccessResponse(c, "Subscription updated successfully", subscription)
+}
+
+// @Summary Pause a subscription
+// @Description Stop a subscription from placing orders until it is resumed
+// @Tags Subscriptions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} utils.Response{data=dto.SubscriptionResponse} "Subscription paused successfully"
+// @Failure 400 {object} utils.Response "Invalid subscription ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Subscription not found"
+// @Failure 409 {object} utils.Response "Subscription has been cancelled"
+// @Router /subscriptions/{id}/pause [post]
+func (s *Server) pauseSubscription(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid subscription ID", err)
+		return
+	}
+
+	subscription, err := s.subscriptionService.PauseSubscription(userID, uint(id))
+	if err != nil {
+		s.handleSubscriptionError(c, err, "Failed to pause subscription")
+		return
+	}
+
+	utils.SuccessResponse(c, "Subscription paused successfully", subscription)
+}
+
+// @Summary Resume a subscription
+// @Description Let a paused subscription place orders again. The orders it missed while paused are skipped
+// @Tags Subscriptions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} utils.Response{data=dto.SubscriptionResponse} "Subscription resumed successfully"
+// @Failure 400 {object} utils.Response "Invalid subscription ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Subscription not found"
+// @Failure 409 {object} utils.Response "Subscription has been cancelled"
+// @Router /subscriptions/{id}/resume [post]
+func (s *Server) resumeSubscription(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid subscription ID", err)
+		return
+	}
+
+	subscription, err := s.subscriptionService.ResumeSubscription(userID, uint(id))
+	if err != nil {
+		s.handleSubscriptionError(c, err, "Failed to resume subscription")
+		return
+	}
+
+	utils.SuccessResponse(c, "Subscription resumed successfully", subscription)
+}
+
+// @Summary Cancel a subscription
+// @Description End a subscription for good. A cancelled subscription places no more orders and can no longer be changed
+// @Tags Subscriptions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} utils.Response{data=dto.SubscriptionResponse} "Subscription cancelled successfully"
+// @Failure 400 {object} utils.Response "Invalid subscription ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Subscription not found"
+// @Failure 409 {object} utils.Response "Subscription has already been cancelled"
+// @Router /subscriptions/{id}/cancel [post]
+func (s *Server) cancelSubscription(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid subscription ID", err)
+		return
+	}
+
+	subscription, err := s.subscriptionService.CancelSubscription(userID, uint(id))
+	if err != nil {
+		s.handleSubscriptionError(c, err, "Failed to cancel subscription")
+		return
+	}
+
+	utils.SuccessResponse(c, "Subscription cancelled successfully", subscription)
+}
+
+func (s *Server) handleSubscriptionError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrSubscriptionNotFound):
+		utils.NotFoundResponse(c, "Subscription not found")
+	case errors.Is(err, services.ErrProductNotFound):
+		utils.NotFoundResponse(c, "Product not found")
+	case errors.Is(err, services.ErrAddressNotFound):
+		utils.NotFoundResponse(c, "Address not found")
+	case errors.Is(err, services.ErrShippingRateNotFound):
+		utils.NotFoundResponse(c, "Shipping rate not found")
+	case errors.Is(err, services.ErrSubscriptionCancelled):
+		utils.ConflictResponse(c, "Subscription has been cancelled", err)
+	case errors.Is(err, services.ErrInvalidSubscription):
+		utils.BadRequestResponse(c, message, err)
+	default:
+		utils.InternalServerErrorResponse(c, message, err)
+	}
+}
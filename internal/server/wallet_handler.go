@@ -0,0 +1,105 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+)
+
+// @Summary Get wallet
+// @Description Get the current user's store credit balance
+// @Tags Wallet
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=dto.WalletResponse} "Wallet retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/wallet [get]
+func (s *Server) getWallet(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	wallet, err := s.walletService.GetWallet(userID)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch wallet", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Wallet retrieved successfully", wallet)
+}
+
+// @Summary Get wallet entries
+// @Description Retrieve paginated list of the credits and debits of the current user's wallet, newest first
+// @Tags Wallet
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.WalletEntryResponse} "Wallet entries retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/wallet/entries [get]
+func (s *Server) getWalletEntries(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	entries, meta, err := s.walletService.GetWalletEntries(userID, page, limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch wallet entries", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Wallet entries retrieved successfully", entries, *meta)
+}
+
+// @Summary Redeem a gift card
+// @Description Credit the value of a gift card to the current user's wallet. A card can only be redeemed once
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.RedeemGiftCardRequest true "Gift card code"
+// @Success 200 {object} utils.Response{data=dto.WalletResponse} "Gift card redeemed successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Gift card not found"
+// @Failure 409 {object} utils.Response "Gift card already redeemed or expired"
+// @Router /users/wallet/redeem [post]
+func (s *Server) redeemGiftCard(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req dto.RedeemGiftCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	wallet, err := s.walletService.RedeemGiftCard(userID, &req)
+	if err != nil {
+		s.handleWalletError(c, err, "Failed to redeem gift card")
+		return
+	}
+
+	utils.SuccessResponse(c, "Gift card redeemed successfully", wallet)
+}
+
+// handleWalletError maps wallet and gift card errors to HTTP responses.
+func (s *Server) handleWalletError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrGiftCardNotFound):
+		utils.NotFoundResponse(c, "Gift card not found")
+	case errors.Is(err, services.ErrUserNotFound):
+		utils.NotFoundResponse(c, "User not found")
+	case errors.Is(err, services.ErrGiftCardNotRedeemable):
+		utils.ConflictResponse(c, "Gift card already redeemed or expired", err)
+	case errors.Is(err, services.ErrInvalidGiftCard), errors.Is(err, services.ErrInvalidWalletCredit):
+		utils.BadRequestResponse(c, message, err)
+	default:
+		utils.InternalServerErrorResponse(c, message, err)
+	}
+}
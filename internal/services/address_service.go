@@ -0,0 +1,131 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"gorm.io/gorm"
+)
+
+var _ AddressServiceInterface = (*AddressService)(nil)
+
+type AddressService struct {
+	db *gorm.DB
+}
+
+// NewAddressService creates the address service type
+func NewAddressService(db *gorm.DB) *AddressService {
+	return &AddressService{db: db}
+}
+
+func (s *AddressService) GetAddresses(userID uint) ([]dto.AddressResponse, error) {
+	var addresses []models.Address
+	if err := s.db.Where("user_id = ?", userID).Order("created_at").Find(&addresses).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.AddressResponse, len(addresses))
+	for i := range addresses {
+		response[i] = s.convertToAddressResponse(&addresses[i])
+	}
+
+	return response, nil
+}
+
+func (s *AddressService) GetAddress(userID, addressID uint) (*dto.AddressResponse, error) {
+	address, err := findUserAddress(s.db, userID, addressID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.convertToAddressResponse(address)
+
+	return &response, nil
+}
+
+func (s *AddressService) CreateAddress(userID uint, req *dto.AddressRequest) (*dto.AddressResponse, error) {
+	address := models.Address{UserID: userID}
+	applyAddressRequest(&address, req)
+
+	if err := s.db.Create(&address).Error; err != nil {
+		return nil, err
+	}
+
+	response := s.convertToAddressResponse(&address)
+
+	return &response, nil
+}
+
+func (s *AddressService) UpdateAddress(userID, addressID uint, req *dto.AddressRequest) (*dto.AddressResponse, error) {
+	address, err := findUserAddress(s.db, userID, addressID)
+	if err != nil {
+		return nil, err
+	}
+
+	applyAddressRequest(address, req)
+
+	if err := s.db.Save(address).Error; err != nil {
+		return nil, err
+	}
+
+	response := s.convertToAddressResponse(address)
+
+	return &response, nil
+}
+
+func (s *AddressService) DeleteAddress(userID, addressID uint) error {
+	result := s.db.Where("id = ? AND user_id = ?", addressID, userID).Delete(&models.Address{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrAddressNotFound
+	}
+
+	return nil
+}
+
+func (s *AddressService) convertToAddressResponse(address *models.Address) dto.AddressResponse {
+	return dto.AddressResponse{
+		ID:         address.ID,
+		FirstName:  address.FirstName,
+		LastName:   address.LastName,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		State:      address.State,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+		Phone:      address.Phone,
+		CreatedAt:  address.CreatedAt,
+		UpdatedAt:  address.UpdatedAt,
+	}
+}
+
+// findUserAddress loads an address from the user's address book.
+func findUserAddress(db *gorm.DB, userID, addressID uint) (*models.Address, error) {
+	var address models.Address
+	if err := db.Where("id = ? AND user_id = ?", addressID, userID).First(&address).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAddressNotFound
+		}
+		return nil, err
+	}
+
+	return &address, nil
+}
+
+func applyAddressRequest(address *models.Address, req *dto.AddressRequest) {
+	address.FirstName = req.FirstName
+	address.LastName = req.LastName
+	address.Line1 = req.Line1
+	address.Line2 = req.Line2
+	address.City = req.City
+	address.State = req.State
+	address.PostalCode = req.PostalCode
+	address.Country = strings.ToUpper(req.Country)
+	address.Phone = req.Phone
+}
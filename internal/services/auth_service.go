@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/kuldeepstechwork/gocart-api/internal/config"
 	"github.com/kuldeepstechwork/gocart-api/internal/dto"
 	"github.com/kuldeepstechwork/gocart-api/internal/events"
@@ -20,6 +22,7 @@ var _ AuthServiceInterface = (*AuthService)(nil)
 type AuthService struct {
 	userRepo       repositories.UserRepositoryInterface
 	cartRepo       repositories.CartRepositoryInterface
+	orderRepo      repositories.OrderRepositoryInterface
 	config         *config.Config
 	eventPublisher events.Publisher
 }
@@ -28,12 +31,14 @@ func NewAuthService(config *config.Config,
 	eventPublisher events.Publisher,
 	userRepo repositories.UserRepositoryInterface,
 	carRepo repositories.CartRepositoryInterface,
+	orderRepo repositories.OrderRepositoryInterface,
 ) *AuthService {
 	return &AuthService{
 		config:         config,
 		eventPublisher: eventPublisher,
 		userRepo:       userRepo,
 		cartRepo:       carRepo,
+		orderRepo:      orderRepo,
 	}
 }
 
@@ -48,25 +53,37 @@ func (s *AuthService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, err
 		return nil, err
 	}
 
-	// Create user
+	// Create user, unverified until the token mailed to the email comes back
+	verificationToken := uuid.NewString()
 	user := models.User{
-		Email:     req.Email,
-		Password:  hashedPassword,
-		FirstName: req.FirstName,
-		LastName:  req.LastName,
-		Phone:     req.Phone,
-		Role:      models.UserRoleCustomer,
+		Email:                  req.Email,
+		Password:               hashedPassword,
+		FirstName:              req.FirstName,
+		LastName:               req.LastName,
+		Phone:                  req.Phone,
+		Role:                   models.UserRoleCustomer,
+		EmailVerificationToken: &verificationToken,
 	}
 	if err := s.userRepo.Create(&user); err != nil {
 		return nil, err
 	}
 	// create a cart
-	cart := models.Cart{UserID: user.ID}
+	cart := models.Cart{UserID: &user.ID}
 	if err := s.cartRepo.Create(&cart); err != nil {
 		fmt.Println("Unable to create cart")
 		_ = err
 	}
 
+	// the guest orders placed with the email are handed over once it is verified
+	metadata := map[string]string{
+		"user_id": strconv.FormatUint(uint64(user.ID), 10),
+		"email":   user.Email,
+		"token":   verificationToken,
+	}
+	if err := s.eventPublisher.Publish(notifications.EmailVerificationRequested, user, metadata); err != nil {
+		log.Printf("unable to publish email verification event of user %d: %v", user.ID, err)
+	}
+
 	// generate token
 	return s.generateAuthResponse(&user)
 
@@ -113,6 +130,40 @@ func (s *AuthService) Logout(refreshToken string) error {
 	return s.userRepo.DeleteRefreshToken(refreshToken)
 }
 
+// VerifyEmail marks the email of the user the token was mailed to as
+// verified. Only then are the orders placed at guest checkout with the email
+// handed over to the user, so that registering with someone else's email
+// does not reveal their orders.
+func (s *AuthService) VerifyEmail(req *dto.VerifyEmailRequest) (*dto.UserResponse, error) {
+	user, err := s.userRepo.GetByEmailVerificationToken(req.Token)
+	if err != nil {
+		return nil, errors.New("invalid verification token")
+	}
+
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	user.EmailVerificationToken = nil
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.orderRepo.AttachGuestOrders(user.ID, user.Email); err != nil {
+		log.Printf("unable to attach the guest orders of user %d: %v", user.ID, err)
+	}
+
+	return &dto.UserResponse{
+		ID:        user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Phone:     user.Phone,
+		Role:      string(user.Role),
+		IsActive:  user.IsActive,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}, nil
+}
+
 func (s *AuthService) generateAuthResponse(user *models.User) (*dto.AuthResponse, error) {
 	accessToken, refreshToken, err := utils.GenerateTokenPair(
 		&s.config.JWT,
@@ -2,116 +2,470 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/kuldeepstechwork/gocart-api/internal/dto"
 	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var _ CartServiceInterface = (*CartService)(nil)
 
 type CartService struct {
-	db *gorm.DB
+	db             *gorm.DB
+	currency       money.Currency
+	reservationTTL time.Duration
 }
 
-func NewCartService(db *gorm.DB) *CartService {
-	return &CartService{db: db}
+// NewCartService creates the cart service type
+func NewCartService(db *gorm.DB, currency money.Currency, reservationTTL time.Duration) *CartService {
+	return &CartService{db: db, currency: currency, reservationTTL: reservationTTL}
 }
 
-func (s *CartService) GetCart(userID uint) (*dto.CartResponse, error) {
-	var cart models.Cart
-	err := s.db.Preload("CartItems.Product.Category").
-		Where("user_id = ?", userID).First(&cart).Error
-	if err != nil {
-		return nil, err
+// cartScope narrows a query on carts, possibly joined to their items, to a
+// single cart.
+type cartScope func(db *gorm.DB) *gorm.DB
+
+// userCart scopes a query to the user's cart.
+func userCart(userID uint) cartScope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("carts.user_id = ?", userID)
+	}
+}
+
+// guestCart scopes a query to the guest cart with the token.
+func guestCart(token string) cartScope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("carts.token = ?", token)
 	}
+}
+
+// GetCart returns the user's cart priced in the currency.
+func (s *CartService) GetCart(userID uint, currency money.Currency) (*dto.CartResponse, error) {
+	return s.getCart(userCart(userID), currency)
+}
 
-	return s.convertToCartResponse(&cart), nil
+func (s *CartService) AddToCart(userID uint, req *dto.AddToCartRequest, currency money.Currency) (*dto.CartResponse, error) {
+	return s.addToCart(userCart(userID), &models.Cart{UserID: &userID}, req, currency)
 }
 
-func (s *CartService) AddToCart(userID uint, req *dto.AddToCartRequest) (*dto.CartResponse, error) {
+func (s *CartService) UpdateCartItem(userID, itemID uint, req *dto.UpdateCartItemRequest, currency money.Currency) (*dto.CartResponse, error) {
+	return s.updateCartItem(userCart(userID), itemID, req, currency)
+}
 
-	// Check if product exists
-	var product models.Product
-	if err := s.db.First(&product, req.ProductID).Error; err != nil {
-		return nil, errors.New("product not found")
+func (s *CartService) RemoveFromCart(userID, itemID uint) error {
+	return s.removeFromCart(userCart(userID), itemID)
+}
+
+// CreateGuestCart starts an empty cart for a guest. The cart is known only by
+// the random token in the response, which the guest sends with every later
+// request on the cart.
+func (s *CartService) CreateGuestCart(currency money.Currency) (*dto.CartResponse, error) {
+	token := uuid.NewString()
+	cart := models.Cart{Token: &token}
+	if err := s.db.Create(&cart).Error; err != nil {
+		return nil, err
 	}
 
-	if product.Stock < req.Quantity {
-		return nil, errors.New("insufficient stock")
+	return s.getCart(guestCart(token), currency)
+}
+
+// GetGuestCart returns the guest cart with the token priced in the currency.
+func (s *CartService) GetGuestCart(token string, currency money.Currency) (*dto.CartResponse, error) {
+	return s.getCart(guestCart(token), currency)
+}
+
+func (s *CartService) AddToGuestCart(token string, req *dto.AddToCartRequest, currency money.Currency) (*dto.CartResponse, error) {
+	return s.addToCart(guestCart(token), nil, req, currency)
+}
+
+func (s *CartService) UpdateGuestCartItem(token string, itemID uint, req *dto.UpdateCartItemRequest, currency money.Currency) (*dto.CartResponse, error) {
+	return s.updateCartItem(guestCart(token), itemID, req, currency)
+}
+
+func (s *CartService) RemoveFromGuestCart(token string, itemID uint) error {
+	return s.removeFromCart(guestCart(token), itemID)
+}
+
+func (s *CartService) getCart(scope cartScope, currency money.Currency) (*dto.CartResponse, error) {
+	prices, err := newPricing(s.db, s.currency, currency)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get or create cart
 	var cart models.Cart
-	if err := s.db.Where("user_id = ?", userID).First(&cart).Error; err != nil {
-		cart = models.Cart{UserID: userID}
-		if err := s.db.Create(&cart).Error; err != nil {
-			return nil, err
-		}
+	err = s.db.Preload("CartItems.Product.Category").Preload("ShippingRate").
+		Preload("Coupon.Products").Preload("Coupon.Categories").
+		Scopes(scope).First(&cart).Error
+	if err != nil {
+		return nil, err
 	}
 
-	// Check if item already exists in cart
-	var cartItem models.CartItem
-	if err := s.db.Where("cart_id = ? AND product_id = ?", cart.ID, req.ProductID).First(&cartItem).Error; err != nil {
-		// Create new cart item
-		cartItem = models.CartItem{
-			CartID:    cart.ID,
-			ProductID: req.ProductID,
-			Quantity:  req.Quantity,
-		}
-		s.db.Create(&cartItem)
-	} else {
-		// Update existing cart item
+	products := make([]*models.Product, len(cart.CartItems))
+	for i := range cart.CartItems {
+		products[i] = &cart.CartItems[i].Product
+	}
+	if err := availableStock(s.db, cart.ID, products...); err != nil {
+		return nil, err
+	}
+
+	rules, err := activePromotions(s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := prices.cart(s.db, &cart); err != nil {
+		return nil, err
+	}
+	prices.promotions(rules)
+
+	return s.convertToCartResponse(&cart, rules, prices.currency), nil
+}
+
+// addToCart adds the product to the cart of the scope. A missing cart is
+// created as newCart, or reported as not found without one. The cart holds
+// the stock of what it contains for the reservation TTL. The product is
+// locked while its stock is checked and held, so that carts adding it at the
+// same time cannot both hold its last units.
+func (s *CartService) addToCart(scope cartScope, newCart *models.Cart, req *dto.AddToCartRequest, currency money.Currency) (*dto.CartResponse, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Check if product exists
+		var product models.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&product, req.ProductID).Error; err != nil {
+			return errors.New("product not found")
+		}
+
+		// Get cart, created below once the stock is checked
+		var cart models.Cart
+		if err := tx.Scopes(scope).First(&cart).Error; err != nil {
+			if newCart == nil {
+				return ErrCartNotFound
+			}
+			cart = *newCart
+		}
+
+		// Check if item already exists in cart
+		cartItem := models.CartItem{ProductID: req.ProductID}
+		if cart.ID != 0 {
+			tx.Where("cart_id = ? AND product_id = ?", cart.ID, req.ProductID).First(&cartItem)
+		}
 		cartItem.Quantity += req.Quantity
-		if cartItem.Quantity > product.Stock {
-			return nil, errors.New("insufficient stock")
+
+		if err := availableStock(tx, cart.ID, &product); err != nil {
+			return err
+		}
+
+		if cartItem.Quantity > product.Sellable() {
+			return errors.New("insufficient stock")
+		}
+
+		if cart.ID == 0 {
+			if err := tx.Create(&cart).Error; err != nil {
+				return err
+			}
+		}
+
+		cartItem.CartID = cart.ID
+		if err := tx.Save(&cartItem).Error; err != nil {
+			return err
 		}
-		s.db.Save(&cartItem)
+
+		return holdStock(tx, cart.ID, cartItem.ProductID, cartItem.Quantity, nil, time.Now().Add(s.reservationTTL))
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	return s.GetCart(userID)
+	return s.getCart(scope, currency)
 }
 
-func (s *CartService) UpdateCartItem(userID, itemID uint, req *dto.UpdateCartItemRequest) (*dto.CartResponse, error) {
+// updateCartItem sets the quantity of the item in the cart of the scope,
+// holding its stock like addToCart does.
+func (s *CartService) updateCartItem(scope cartScope, itemID uint, req *dto.UpdateCartItemRequest, currency money.Currency) (*dto.CartResponse, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var cartItem models.CartItem
+		if err := tx.Joins("JOIN carts ON cart_items.cart_id = carts.id").
+			Where("cart_items.id = ?", itemID).Scopes(scope).
+			First(&cartItem).Error; err != nil {
+			return errors.New("cart item not found")
+		}
+
+		var product models.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&product, cartItem.ProductID).Error; err != nil {
+			return errors.New("product not found")
+		}
+
+		if err := availableStock(tx, cartItem.CartID, &product); err != nil {
+			return err
+		}
+
+		if product.Sellable() < req.Quantity {
+			return errors.New("insufficient stock")
+		}
+
+		cartItem.Quantity = req.Quantity
+		if err := tx.Save(&cartItem).Error; err != nil {
+			return err
+		}
+
+		return holdStock(tx, cartItem.CartID, cartItem.ProductID, cartItem.Quantity, nil, time.Now().Add(s.reservationTTL))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getCart(scope, currency)
+}
+
+// removeFromCart removes the item from the cart of the scope along with the
+// stock held for it. Removing an item that is not in the cart does nothing.
+func (s *CartService) removeFromCart(scope cartScope, itemID uint) error {
 	var cartItem models.CartItem
 	if err := s.db.Joins("JOIN carts ON cart_items.cart_id = carts.id").
-		Where("cart_items.id = ? AND carts.user_id = ?", itemID, userID).
+		Where("cart_items.id = ?", itemID).Scopes(scope).
 		First(&cartItem).Error; err != nil {
-		return nil, errors.New("cart item not found")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
 	}
 
-	var product models.Product
-	if err := s.db.First(&product, cartItem.ProductID).Error; err != nil {
-		return nil, errors.New("product not found")
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&cartItem).Error; err != nil {
+			return err
+		}
+
+		return releaseStock(tx, cartItem.CartID, cartItem.ProductID)
+	})
+}
+
+// GetShippingOptions quotes the shipping methods available for the cart when
+// it is sent to one of the user's addresses, priced in the currency.
+func (s *CartService) GetShippingOptions(userID, addressID uint, currency money.Currency) ([]dto.ShippingOptionResponse, error) {
+	prices, err := newPricing(s.db, s.currency, currency)
+	if err != nil {
+		return nil, err
 	}
 
-	if product.Stock < req.Quantity {
-		return nil, errors.New("insufficient stock")
+	address, err := findUserAddress(s.db, userID, addressID)
+	if err != nil {
+		return nil, err
 	}
 
-	cartItem.Quantity = req.Quantity
-	if err := s.db.Save(&cartItem).Error; err != nil {
+	var cart models.Cart
+	if err := s.db.Preload("CartItems.Product").Where("user_id = ?", userID).First(&cart).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCartNotFound
+		}
 		return nil, err
 	}
 
-	return s.GetCart(userID)
+	if err := prices.cart(s.db, &cart); err != nil {
+		return nil, err
+	}
+
+	destination := address.Snapshot()
+
+	return shippingOptions(s.db, &cart, &destination, prices)
 }
 
-func (s *CartService) RemoveFromCart(userID, itemID uint) error {
-	return s.db.Where("id = ? AND cart_id IN (?)", itemID,
-		s.db.Select("id").Table("carts").
-			Where("user_id = ?", userID)).
-		Delete(&models.CartItem{}).Error
+// SelectShippingMethod stores the shipping method the cart will be charged
+// for. The method has to be one of the options quoted for the address.
+func (s *CartService) SelectShippingMethod(userID uint, req *dto.SelectShippingMethodRequest, currency money.Currency) (*dto.CartResponse, error) {
+	options, err := s.GetShippingOptions(userID, req.AddressID, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := findShippingOption(options, req.ShippingRateID); !ok {
+		return nil, ErrShippingUnavailable
+	}
+
+	if err := s.db.Model(&models.Cart{}).
+		Where("user_id = ?", userID).
+		Update("shipping_rate_id", req.ShippingRateID).Error; err != nil {
+		return nil, err
+	}
+
+	return s.GetCart(userID, currency)
+}
+
+// ApplyCoupon applies the coupon with the code to the user's cart, replacing
+// any coupon applied before. The coupon is checked again when ordering.
+func (s *CartService) ApplyCoupon(userID uint, req *dto.ApplyCouponRequest, currency money.Currency) (*dto.CartResponse, error) {
+	prices, err := newPricing(s.db, s.currency, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var cart models.Cart
+	if err := s.db.Preload("CartItems.Product").Where("user_id = ?", userID).First(&cart).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCartNotFound
+		}
+		return nil, err
+	}
+
+	coupon, err := findCoupon(s.db, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	// The coupon's conditions are checked on the cart as priced in the currency
+	if err := prices.cart(s.db, &cart); err != nil {
+		return nil, err
+	}
+	prices.coupon(coupon)
+
+	if err := checkCoupon(s.db, coupon, &cart, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Model(&models.Cart{}).
+		Where("id = ?", cart.ID).
+		Update("coupon_id", coupon.ID).Error; err != nil {
+		return nil, err
+	}
+
+	return s.GetCart(userID, currency)
+}
+
+func (s *CartService) RemoveCoupon(userID uint, currency money.Currency) (*dto.CartResponse, error) {
+	if err := s.db.Model(&models.Cart{}).
+		Where("user_id = ?", userID).
+		Update("coupon_id", nil).Error; err != nil {
+		return nil, err
+	}
+
+	return s.GetCart(userID, currency)
+}
+
+// Reorder adds the items of one of the user's past orders to their cart
+// through AddToCart, in the quantities they were ordered. Items of deleted,
+// inactive or out of stock products are dropped, and a quantity is lowered
+// to the stock available next to what is already in the cart. The report
+// has a line for every item of the order.
+func (s *CartService) Reorder(userID, orderID uint, currency money.Currency) (*dto.ReorderResponse, error) {
+	if _, err := newPricing(s.db, s.currency, currency); err != nil {
+		return nil, err
+	}
+
+	var order models.Order
+	if err := s.db.Preload("OrderItems", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Preload("OrderItems.Product", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Where("id = ? AND user_id = ?", orderID, userID).First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	cartID, inCart, err := s.cartQuantities(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]*models.Product, len(order.OrderItems))
+	for i := range order.OrderItems {
+		products[i] = &order.OrderItems[i].Product
+	}
+	if err := availableStock(s.db, cartID, products...); err != nil {
+		return nil, err
+	}
+
+	var cart *dto.CartResponse
+	lines := make([]dto.ReorderLineResponse, len(order.OrderItems))
+	for i := range order.OrderItems {
+		item := &order.OrderItems[i]
+		product := &item.Product
+
+		line := dto.ReorderLineResponse{
+			OrderItemID:       item.ID,
+			ProductID:         item.ProductID,
+			ProductName:       product.Name,
+			RequestedQuantity: item.Quantity,
+			Status:            dto.ReorderLineDropped,
+		}
+
+		available := product.Sellable() - inCart[item.ProductID]
+		switch {
+		case product.ID == 0 || product.DeletedAt.Valid:
+			line.Reason = "product is no longer sold"
+		case !product.IsActive:
+			line.Reason = "product is currently unavailable"
+		case available < 1:
+			line.Reason = "product is out of stock"
+		default:
+			quantity := min(item.Quantity, available)
+
+			response, err := s.AddToCart(userID, &dto.AddToCartRequest{ProductID: product.ID, Quantity: quantity}, currency)
+			if err != nil {
+				line.Reason = err.Error()
+				break
+			}
+			cart = response
+			inCart[product.ID] += quantity
+
+			line.AddedQuantity = quantity
+			line.Status = dto.ReorderLineAdded
+			if quantity < item.Quantity {
+				line.Status = dto.ReorderLineAdjusted
+				line.Reason = fmt.Sprintf("only %d left in stock", available)
+			}
+		}
+
+		lines[i] = line
+	}
+
+	if cart == nil {
+		cart, err = s.GetCart(userID, currency)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	return &dto.ReorderResponse{Cart: cart, Lines: lines}, nil
+}
+
+// cartQuantities returns the ID of the user's cart, 0 while it is empty,
+// and the quantity of every product in it.
+func (s *CartService) cartQuantities(userID uint) (uint, map[uint]int, error) {
+	var items []models.CartItem
+	if err := s.db.Joins("JOIN carts ON cart_items.cart_id = carts.id").
+		Where("carts.user_id = ?", userID).
+		Find(&items).Error; err != nil {
+		return 0, nil, err
+	}
+
+	var cartID uint
+	quantities := make(map[uint]int, len(items))
+	for _, item := range items {
+		cartID = item.CartID
+		quantities[item.ProductID] += item.Quantity
+	}
+
+	return cartID, quantities, nil
 }
 
-func (s *CartService) convertToCartResponse(cart *models.Cart) *dto.CartResponse {
+// convertToCartResponse prices the cart with the promotions it qualifies for
+// and the applied coupon, while the coupon still applies. The cart must be
+// priced in the currency already.
+func (s *CartService) convertToCartResponse(cart *models.Cart, rules []models.Promotion, currency money.Currency) *dto.CartResponse {
 
 	cartItems := make([]dto.CartItemResponse, len(cart.CartItems)) // memory allocation
-	var total float64
+	total := money.Zero(currency)
 
 	for i := range cart.CartItems {
-		subtotal := float64(cart.CartItems[i].Quantity) * cart.CartItems[i].Product.Price
-		total += subtotal
+		subtotal := cart.CartItems[i].Product.Price.Mul(cart.CartItems[i].Quantity)
+		total = total.Add(subtotal)
 
 		cartItems[i] = dto.CartItemResponse{
 			ID: cart.CartItems[i].ID,
@@ -123,6 +477,11 @@ func (s *CartService) convertToCartResponse(cart *models.Cart) *dto.CartResponse
 				Price:       cart.CartItems[i].Product.Price,
 				Stock:       cart.CartItems[i].Product.Stock,
 				SKU:         cart.CartItems[i].Product.SKU,
+				Weight:      cart.CartItems[i].Product.Weight,
+				Length:      cart.CartItems[i].Product.Length,
+				Width:       cart.CartItems[i].Product.Width,
+				Height:      cart.CartItems[i].Product.Height,
+				TaxClass:    cart.CartItems[i].Product.TaxClass,
 				IsActive:    cart.CartItems[i].Product.IsActive,
 				Category: dto.CategoryResponse{
 					ID:          cart.CartItems[i].Product.Category.ID,
@@ -130,6 +489,9 @@ func (s *CartService) convertToCartResponse(cart *models.Cart) *dto.CartResponse
 					Description: cart.CartItems[i].Product.Category.Description,
 					IsActive:    cart.CartItems[i].Product.Category.IsActive,
 				},
+				InventoryPolicy: string(cart.CartItems[i].Product.InventoryPolicy),
+				BackorderLimit:  cart.CartItems[i].Product.BackorderLimit,
+				AvailableAt:     cart.CartItems[i].Product.AvailableAt,
 			},
 			Quantity:  cart.CartItems[i].Quantity,
 			Subtotal:  subtotal,
@@ -138,12 +500,50 @@ func (s *CartService) convertToCartResponse(cart *models.Cart) *dto.CartResponse
 		}
 	}
 
+	var shipping *dto.ShippingOptionResponse
+	if cart.ShippingRate != nil {
+		_, weight := cart.Totals()
+		if cost, ok := cart.ShippingRate.Cost(weight, total); ok {
+			shipping = &dto.ShippingOptionResponse{
+				RateID: cart.ShippingRate.ID,
+				Method: cart.ShippingRate.Name,
+				Cost:   cost,
+			}
+		}
+	}
+
+	shippingCost := money.Zero(currency)
+	if shipping != nil {
+		shippingCost = shipping.Cost
+	}
+
+	var applicableCoupon *models.Coupon
+	if cart.Coupon != nil && couponApplies(cart.Coupon, cart) == nil {
+		applicableCoupon = cart.Coupon
+	}
+
+	discount := cartDiscounts(cart, rules, applicableCoupon, shippingCost)
+
+	var coupon *dto.AppliedCouponResponse
+	if applicableCoupon != nil {
+		coupon = &dto.AppliedCouponResponse{
+			Code:     applicableCoupon.Code,
+			Type:     string(applicableCoupon.Type),
+			Discount: discount.coupon,
+		}
+	}
+
 	return &dto.CartResponse{
-		ID:        cart.ID,
-		UserID:    cart.UserID,
-		CartItems: cartItems,
-		Total:     total,
-		CreatedAt: cart.CreatedAt,
-		UpdatedAt: cart.UpdatedAt,
+		ID:         cart.ID,
+		UserID:     cart.CustomerID(),
+		Token:      cart.GuestToken(),
+		CartItems:  cartItems,
+		Total:      total,
+		Shipping:   shipping,
+		Coupon:     coupon,
+		Promotions: convertToAppliedPromotions(discount.promotions.Applied),
+		Discount:   discount.total,
+		CreatedAt:  cart.CreatedAt,
+		UpdatedAt:  cart.UpdatedAt,
 	}
 }
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ CheckoutServiceInterface = (*CheckoutService)(nil)
+
+type CheckoutService struct {
+	db         *gorm.DB
+	orders     *OrderService
+	sessionTTL time.Duration
+}
+
+// NewCheckoutService creates the checkout service type
+func NewCheckoutService(db *gorm.DB, orders *OrderService, sessionTTL time.Duration) *CheckoutService {
+	return &CheckoutService{db: db, orders: orders, sessionTTL: sessionTTL}
+}
+
+// CreateCheckoutSession prices the user's cart like CreateOrder does and
+// holds the quote, reserving its items, for the session TTL. A new session
+// replaces the user's open one.
+func (s *CheckoutService) CreateCheckoutSession(userID uint, req *dto.CreateOrderRequest, currency money.Currency) (*dto.CheckoutSessionResponse, error) {
+	var sessionID uint
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		prices, err := newPricing(tx, s.orders.currency, currency)
+		if err != nil {
+			return err
+		}
+
+		shippingAddress, billingAddress, err := s.orders.orderAddresses(tx, userID, req)
+		if err != nil {
+			return err
+		}
+
+		var cart models.Cart
+		if err := tx.Preload("CartItems.Product").Where("user_id = ?", userID).First(&cart).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCartNotFound
+			}
+			return err
+		}
+
+		order := models.Order{
+			UserID:          &userID,
+			ShippingAddress: shippingAddress,
+			BillingAddress:  billingAddress,
+		}
+		quote, err := s.orders.priceOrder(tx, &order, &cart, prices)
+		if err != nil {
+			return err
+		}
+		quote.useWallet = req.UseWallet
+		quote.redeemPoints = req.RedeemPoints
+
+		// The open sessions are replaced and stop holding stock, also of the
+		// products taken out of the cart since
+		openSessions := tx.Model(&models.CheckoutSession{}).
+			Select("id").
+			Where("user_id = ? AND status = ?", userID, models.CheckoutSessionOpen)
+		if err := tx.Where("checkout_session_id IN (?)", openSessions).
+			Delete(&models.InventoryReservation{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.CheckoutSession{}).
+			Where("user_id = ? AND status = ?", userID, models.CheckoutSessionOpen).
+			Update("status", models.CheckoutSessionCancelled).Error; err != nil {
+			return err
+		}
+
+		session := newCheckoutSession(&order, &cart, quote)
+		session.ExpiresAt = time.Now().Add(s.sessionTTL)
+
+		if err := tx.Create(&session).Error; err != nil {
+			return err
+		}
+
+		// The session holds the items of the cart for as long as it is open
+		for _, item := range cart.CartItems {
+			if err := holdStock(tx, cart.ID, item.ProductID, item.Quantity, &session.ID, session.ExpiresAt); err != nil {
+				return err
+			}
+		}
+
+		sessionID = session.ID
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCheckoutSession(userID, sessionID)
+}
+
+// GetCheckoutSession returns one of the user's checkout sessions.
+func (s *CheckoutService) GetCheckoutSession(userID, sessionID uint) (*dto.CheckoutSessionResponse, error) {
+	var session models.CheckoutSession
+	if err := s.db.Preload("Items.Product").Preload("Promotions").
+		Where("id = ? AND user_id = ?", sessionID, userID).
+		First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCheckoutSessionNotFound
+		}
+		return nil, err
+	}
+
+	response := convertToCheckoutSessionResponse(&session)
+
+	return &response, nil
+}
+
+// CompleteCheckoutSession places the order the session quoted, at the quoted
+// prices, while the session is open. It fails once the session has expired
+// or when the cart no longer matches the quote.
+func (s *CheckoutService) CompleteCheckoutSession(userID, sessionID uint) (*dto.OrderResponse, error) {
+	var orderResponse *dto.OrderResponse
+	var quote *orderQuote
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var session models.CheckoutSession
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", sessionID, userID).
+			First(&session).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCheckoutSessionNotFound
+			}
+			return err
+		}
+
+		switch status := session.CurrentStatus(time.Now()); status {
+		case models.CheckoutSessionOpen:
+		case models.CheckoutSessionExpired:
+			return ErrCheckoutSessionExpired
+		default:
+			return fmt.Errorf("%w: the session is %s", ErrCheckoutSessionClosed, status)
+		}
+
+		if err := tx.Where("checkout_session_id = ?", session.ID).Order("id").Find(&session.Items).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("checkout_session_id = ?", session.ID).Order("id").Find(&session.Promotions).Error; err != nil {
+			return err
+		}
+
+		var cart models.Cart
+		if err := tx.Preload("CartItems.Product").Where("user_id = ?", userID).First(&cart).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCartNotFound
+			}
+			return err
+		}
+
+		if cart.ID != session.CartID || cart.Fingerprint() != session.CartFingerprint {
+			return ErrCheckoutCartChanged
+		}
+
+		if err := checkStock(tx, &cart); err != nil {
+			return err
+		}
+
+		order := session.Order()
+		order.UserID = &userID
+
+		quote = &orderQuote{couponID: session.CouponID, couponDiscount: session.CouponDiscount, useWallet: session.UseWallet, redeemPoints: session.RedeemPoints}
+		for _, promotion := range session.Promotions {
+			quote.promotions = append(quote.promotions, models.OrderPromotion{
+				PromotionID: promotion.PromotionID,
+				Name:        promotion.Name,
+				Discount:    promotion.Discount,
+			})
+		}
+
+		if err := s.orders.placeOrder(tx, &order, &cart, quote); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.CheckoutSession{}).Where("id = ?", session.ID).Updates(map[string]interface{}{
+			"status":   models.CheckoutSessionCompleted,
+			"order_id": order.ID,
+		}).Error; err != nil {
+			return err
+		}
+
+		response, err := s.orders.getOrderResponse(tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		orderResponse = response
+
+		return nil
+	})
+
+	if err != nil {
+		s.orders.voidAuthorization(quote)
+		return nil, err
+	}
+
+	return orderResponse, nil
+}
+
+// newCheckoutSession holds the order priced from the cart as an open session.
+func newCheckoutSession(order *models.Order, cart *models.Cart, quote *orderQuote) models.CheckoutSession {
+	session := models.CheckoutSession{
+		UserID:           order.CustomerID(),
+		CartID:           cart.ID,
+		CartFingerprint:  cart.Fingerprint(),
+		Status:           models.CheckoutSessionOpen,
+		TotalAmount:      order.TotalAmount,
+		ShippingMethod:   order.ShippingMethod,
+		ShippingCost:     order.ShippingCost,
+		CouponID:         quote.couponID,
+		CouponCode:       order.CouponCode,
+		CouponDiscount:   quote.couponDiscount,
+		DiscountAmount:   order.DiscountAmount,
+		TaxAmount:        order.TaxAmount,
+		PricesIncludeTax: order.PricesIncludeTax,
+		ExchangeRate:     order.ExchangeRate,
+		ShippingAddress:  order.ShippingAddress,
+		BillingAddress:   order.BillingAddress,
+		UseWallet:        quote.useWallet,
+		RedeemPoints:     quote.redeemPoints,
+	}
+
+	for _, item := range order.OrderItems {
+		session.Items = append(session.Items, models.CheckoutSessionItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     item.Price,
+			Discount:  item.Discount,
+			TaxClass:  item.TaxClass,
+			TaxRate:   item.TaxRate,
+			TaxAmount: item.TaxAmount,
+		})
+	}
+
+	for _, promotion := range quote.promotions {
+		session.Promotions = append(session.Promotions, models.CheckoutSessionPromotion{
+			PromotionID: promotion.PromotionID,
+			Name:        promotion.Name,
+			Discount:    promotion.Discount,
+		})
+	}
+
+	return session
+}
+
+func convertToCheckoutSessionResponse(session *models.CheckoutSession) dto.CheckoutSessionResponse {
+	items := make([]dto.CheckoutSessionItemResponse, len(session.Items))
+	for i := range session.Items {
+		item := &session.Items[i]
+
+		items[i] = dto.CheckoutSessionItemResponse{
+			ProductID:   item.ProductID,
+			ProductName: item.Product.Name,
+			SKU:         item.Product.SKU,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+			Discount:    item.Discount,
+			TaxClass:    item.TaxClass,
+			TaxRate:     item.TaxRate,
+			TaxAmount:   item.TaxAmount,
+		}
+	}
+
+	promotions := make([]dto.AppliedPromotionResponse, len(session.Promotions))
+	for i := range session.Promotions {
+		promotions[i] = dto.AppliedPromotionResponse{
+			PromotionID: session.Promotions[i].PromotionID,
+			Name:        session.Promotions[i].Name,
+			Discount:    session.Promotions[i].Discount,
+		}
+	}
+
+	return dto.CheckoutSessionResponse{
+		ID:               session.ID,
+		Status:           string(session.CurrentStatus(time.Now())),
+		Items:            items,
+		TotalAmount:      session.TotalAmount,
+		ShippingMethod:   session.ShippingMethod,
+		ShippingCost:     session.ShippingCost,
+		CouponCode:       session.CouponCode,
+		DiscountAmount:   session.DiscountAmount,
+		TaxAmount:        session.TaxAmount,
+		PricesIncludeTax: session.PricesIncludeTax,
+		ExchangeRate:     session.ExchangeRate,
+		ShippingAddress:  convertToOrderAddressResponse(session.ShippingAddress),
+		BillingAddress:   convertToOrderAddressResponse(session.BillingAddress),
+		Promotions:       promotions,
+		UseWallet:        session.UseWallet,
+		RedeemPoints:     session.RedeemPoints,
+		OrderID:          session.OrderID,
+		ExpiresAt:        session.ExpiresAt,
+		CreatedAt:        session.CreatedAt,
+	}
+}
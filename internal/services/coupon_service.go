@@ -0,0 +1,310 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ CouponServiceInterface = (*CouponService)(nil)
+
+type CouponService struct {
+	db       *gorm.DB
+	currency money.Currency
+}
+
+// NewCouponService creates the coupon service type
+func NewCouponService(db *gorm.DB, currency money.Currency) *CouponService {
+	return &CouponService{db: db, currency: currency}
+}
+
+func (s *CouponService) GetCoupons() ([]dto.CouponResponse, error) {
+	var coupons []models.Coupon
+	if err := s.db.Preload("Products").Preload("Categories").Order("id").Find(&coupons).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.CouponResponse, len(coupons))
+	for i := range coupons {
+		response[i] = s.convertToCouponResponse(&coupons[i])
+	}
+
+	return response, nil
+}
+
+func (s *CouponService) CreateCoupon(req *dto.CouponRequest) (*dto.CouponResponse, error) {
+	if err := validateCoupon(req, s.currency); err != nil {
+		return nil, err
+	}
+
+	coupon := models.Coupon{IsActive: true}
+	applyCouponRequest(&coupon, req)
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if coupon.Products, coupon.Categories, err = couponRestrictions(tx, req); err != nil {
+			return err
+		}
+
+		// The restricted products and categories already exist, only the
+		// join rows are written
+		return tx.Omit("Products.*", "Categories.*").Create(&coupon).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.convertToCouponResponse(&coupon)
+
+	return &response, nil
+}
+
+// UpdateCoupon changes the coupon and replaces its product and category
+// restrictions with the requested ones. Redemptions so far are kept.
+func (s *CouponService) UpdateCoupon(couponID uint, req *dto.CouponRequest) (*dto.CouponResponse, error) {
+	if err := validateCoupon(req, s.currency); err != nil {
+		return nil, err
+	}
+
+	var coupon models.Coupon
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&coupon, couponID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCouponNotFound
+			}
+			return err
+		}
+
+		products, categories, err := couponRestrictions(tx, req)
+		if err != nil {
+			return err
+		}
+
+		applyCouponRequest(&coupon, req)
+
+		if err := tx.Omit(clause.Associations).Save(&coupon).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&coupon).Omit("Products.*").Association("Products").Replace(products); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&coupon).Omit("Categories.*").Association("Categories").Replace(categories); err != nil {
+			return err
+		}
+
+		coupon.Products = products
+		coupon.Categories = categories
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.convertToCouponResponse(&coupon)
+
+	return &response, nil
+}
+
+func (s *CouponService) DeleteCoupon(couponID uint) error {
+	result := s.db.Delete(&models.Coupon{}, couponID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrCouponNotFound
+	}
+
+	return nil
+}
+
+func (s *CouponService) convertToCouponResponse(coupon *models.Coupon) dto.CouponResponse {
+	productIDs := make([]uint, len(coupon.Products))
+	for i := range coupon.Products {
+		productIDs[i] = coupon.Products[i].ID
+	}
+
+	categoryIDs := make([]uint, len(coupon.Categories))
+	for i := range coupon.Categories {
+		categoryIDs[i] = coupon.Categories[i].ID
+	}
+
+	return dto.CouponResponse{
+		ID:               coupon.ID,
+		Code:             coupon.Code,
+		Description:      coupon.Description,
+		Type:             string(coupon.Type),
+		Percent:          coupon.Percent,
+		FixedAmount:      coupon.FixedAmount,
+		MinSubtotal:      coupon.MinSubtotal,
+		UsageLimit:       coupon.UsageLimit,
+		PerCustomerLimit: coupon.PerCustomerLimit,
+		UsedCount:        coupon.UsedCount,
+		StartsAt:         coupon.StartsAt,
+		EndsAt:           coupon.EndsAt,
+		ProductIDs:       productIDs,
+		CategoryIDs:      categoryIDs,
+		IsActive:         coupon.IsActive,
+		CreatedAt:        coupon.CreatedAt,
+		UpdatedAt:        coupon.UpdatedAt,
+	}
+}
+
+// validateCoupon checks the request, putting its amounts in the store currency.
+func validateCoupon(req *dto.CouponRequest, currency money.Currency) error {
+	couponType := models.CouponType(req.Type)
+	if !couponType.IsValid() {
+		return fmt.Errorf("%w: unknown coupon type %q", ErrInvalidCoupon, req.Type)
+	}
+
+	if err := inCurrency(currency, &req.FixedAmount, &req.MinSubtotal); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCoupon, err)
+	}
+
+	if couponType == models.CouponPercentage && (req.Percent <= 0 || req.Percent > 100) {
+		return fmt.Errorf("%w: a percentage must be greater than 0 and at most 100", ErrInvalidCoupon)
+	}
+
+	if couponType == models.CouponFixed && !req.FixedAmount.IsPositive() {
+		return fmt.Errorf("%w: a fixed discount must be greater than 0", ErrInvalidCoupon)
+	}
+
+	if req.MinSubtotal.IsNegative() {
+		return fmt.Errorf("%w: min_subtotal must not be negative", ErrInvalidCoupon)
+	}
+
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		return fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidCoupon)
+	}
+
+	return nil
+}
+
+func applyCouponRequest(coupon *models.Coupon, req *dto.CouponRequest) {
+	coupon.Code = normalizeCouponCode(req.Code)
+	coupon.Description = req.Description
+	coupon.Type = models.CouponType(req.Type)
+	coupon.Percent = req.Percent
+	coupon.FixedAmount = req.FixedAmount
+	coupon.MinSubtotal = req.MinSubtotal
+	coupon.UsageLimit = req.UsageLimit
+	coupon.PerCustomerLimit = req.PerCustomerLimit
+	coupon.StartsAt = req.StartsAt
+	coupon.EndsAt = req.EndsAt
+	if req.IsActive != nil {
+		coupon.IsActive = *req.IsActive
+	}
+}
+
+// couponRestrictions loads the products and categories a coupon is limited
+// to, failing when any of them does not exist.
+func couponRestrictions(tx *gorm.DB, req *dto.CouponRequest) ([]models.Product, []models.Category, error) {
+	products := []models.Product{}
+	if len(req.ProductIDs) > 0 {
+		if err := tx.Where("id IN ?", req.ProductIDs).Find(&products).Error; err != nil {
+			return nil, nil, err
+		}
+		if len(products) != len(uniqueIDs(req.ProductIDs)) {
+			return nil, nil, fmt.Errorf("%w: unknown product in product_ids", ErrInvalidCoupon)
+		}
+	}
+
+	categories := []models.Category{}
+	if len(req.CategoryIDs) > 0 {
+		if err := tx.Where("id IN ?", req.CategoryIDs).Find(&categories).Error; err != nil {
+			return nil, nil, err
+		}
+		if len(categories) != len(uniqueIDs(req.CategoryIDs)) {
+			return nil, nil, fmt.Errorf("%w: unknown category in category_ids", ErrInvalidCoupon)
+		}
+	}
+
+	return products, categories, nil
+}
+
+func uniqueIDs(ids []uint) map[uint]struct{} {
+	unique := make(map[uint]struct{}, len(ids))
+	for _, id := range ids {
+		unique[id] = struct{}{}
+	}
+
+	return unique
+}
+
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// findCoupon loads the coupon with the code together with its restrictions.
+func findCoupon(db *gorm.DB, code string) (*models.Coupon, error) {
+	var coupon models.Coupon
+	if err := db.Preload("Products").Preload("Categories").
+		Where("code = ?", normalizeCouponCode(code)).
+		First(&coupon).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCouponNotFound
+		}
+		return nil, err
+	}
+
+	return &coupon, nil
+}
+
+// checkCoupon verifies that the user may redeem the coupon on the cart now.
+func checkCoupon(db *gorm.DB, coupon *models.Coupon, cart *models.Cart, userID uint) error {
+	if err := couponApplies(coupon, cart); err != nil {
+		return err
+	}
+
+	if coupon.PerCustomerLimit > 0 {
+		var redemptions int64
+		if err := db.Model(&models.CouponRedemption{}).
+			Where("coupon_id = ? AND user_id = ?", coupon.ID, userID).
+			Count(&redemptions).Error; err != nil {
+			return err
+		}
+		if redemptions >= int64(coupon.PerCustomerLimit) {
+			return fmt.Errorf("%w: the coupon has already been used the maximum number of times", ErrCouponNotApplicable)
+		}
+	}
+
+	return nil
+}
+
+// couponApplies checks the coupon's availability and its conditions on the
+// cart contents. Items must have their product loaded.
+func couponApplies(coupon *models.Coupon, cart *models.Cart) error {
+	if !coupon.IsAvailable(time.Now()) {
+		return fmt.Errorf("%w: the coupon has expired or is no longer available", ErrCouponNotApplicable)
+	}
+
+	subtotal, _ := cart.Totals()
+	if subtotal.Cmp(coupon.MinSubtotal) < 0 {
+		return fmt.Errorf("%w: the cart subtotal must be at least %s", ErrCouponNotApplicable, coupon.MinSubtotal)
+	}
+
+	if coupon.Type == models.CouponFreeShipping {
+		return nil
+	}
+
+	for i := range cart.CartItems {
+		if coupon.Covers(&cart.CartItems[i].Product) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: no item in the cart is eligible", ErrCouponNotApplicable)
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"gorm.io/gorm"
+)
+
+var _ CurrencyServiceInterface = (*CurrencyService)(nil)
+
+type CurrencyService struct {
+	db       *gorm.DB
+	currency money.Currency
+
+	// currencies are the other currencies customers may shop in
+	currencies []money.Currency
+}
+
+// NewCurrencyService creates the currency service type
+func NewCurrencyService(db *gorm.DB, currency money.Currency, currencies []money.Currency) *CurrencyService {
+	return &CurrencyService{db: db, currency: currency, currencies: currencies}
+}
+
+// GetCurrencies lists the currencies customers can shop in right now: the
+// store currency and the other currencies that have an exchange rate.
+func (s *CurrencyService) GetCurrencies() ([]dto.CurrencyResponse, error) {
+	response := []dto.CurrencyResponse{{Code: s.currency, Rate: 1, IsDefault: true}}
+	if len(s.currencies) == 0 {
+		return response, nil
+	}
+
+	var rates []models.ExchangeRate
+	if err := s.db.Where("currency IN ?", s.currencies).Order("currency").Find(&rates).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range rates {
+		response = append(response, dto.CurrencyResponse{Code: rates[i].Currency, Rate: rates[i].Rate})
+	}
+
+	return response, nil
+}
+
+func (s *CurrencyService) GetExchangeRates() ([]dto.ExchangeRateResponse, error) {
+	var rates []models.ExchangeRate
+	if err := s.db.Order("currency").Find(&rates).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.ExchangeRateResponse, len(rates))
+	for i := range rates {
+		response[i] = s.convertToExchangeRateResponse(&rates[i])
+	}
+
+	return response, nil
+}
+
+// SetExchangeRate creates or replaces the exchange rate of the currency.
+// Orders already placed keep the rate they were priced at.
+func (s *CurrencyService) SetExchangeRate(currency money.Currency, req *dto.ExchangeRateRequest) (*dto.ExchangeRateResponse, error) {
+	if err := s.checkCurrency(currency); err != nil {
+		return nil, err
+	}
+
+	if req.Rate <= 0 {
+		return nil, fmt.Errorf("%w: rate must be greater than 0", ErrInvalidExchangeRate)
+	}
+
+	var rate models.ExchangeRate
+	err := s.db.Where("currency = ?", currency).First(&rate).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	rate.Currency = currency
+	rate.Rate = req.Rate
+
+	if err := s.db.Save(&rate).Error; err != nil {
+		return nil, err
+	}
+
+	response := s.convertToExchangeRateResponse(&rate)
+
+	return &response, nil
+}
+
+func (s *CurrencyService) DeleteExchangeRate(currency money.Currency) error {
+	result := s.db.Where("currency = ?", currency).Delete(&models.ExchangeRate{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrExchangeRateNotFound
+	}
+
+	return nil
+}
+
+func (s *CurrencyService) GetProductPrices(productID uint) ([]dto.ProductPriceResponse, error) {
+	if err := s.findProduct(productID); err != nil {
+		return nil, err
+	}
+
+	var prices []models.ProductPrice
+	if err := s.db.Where("product_id = ?", productID).Order("currency").Find(&prices).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.ProductPriceResponse, len(prices))
+	for i := range prices {
+		response[i] = s.convertToProductPriceResponse(&prices[i])
+	}
+
+	return response, nil
+}
+
+// SetProductPrice creates or replaces the price of the product in the
+// currency, which is then used instead of converting the store price.
+func (s *CurrencyService) SetProductPrice(productID uint, currency money.Currency, req *dto.ProductPriceRequest) (*dto.ProductPriceResponse, error) {
+	if err := s.checkCurrency(currency); err != nil {
+		return nil, err
+	}
+
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("%w: amount must be greater than 0", ErrInvalidProductPrice)
+	}
+
+	if err := s.findProduct(productID); err != nil {
+		return nil, err
+	}
+
+	var price models.ProductPrice
+	err := s.db.Where("product_id = ? AND currency = ?", productID, currency).First(&price).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	price.ProductID = productID
+	price.Currency = currency
+	price.Amount = req.Amount
+
+	if err := s.db.Save(&price).Error; err != nil {
+		return nil, err
+	}
+
+	response := s.convertToProductPriceResponse(&price)
+
+	return &response, nil
+}
+
+func (s *CurrencyService) DeleteProductPrice(productID uint, currency money.Currency) error {
+	result := s.db.Where("product_id = ? AND currency = ?", productID, currency).Delete(&models.ProductPrice{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrProductPriceNotFound
+	}
+
+	return nil
+}
+
+// checkCurrency verifies that the currency is one of the other currencies
+// customers may shop in. The store currency needs no rate or override.
+func (s *CurrencyService) checkCurrency(currency money.Currency) error {
+	for _, supported := range s.currencies {
+		if supported == currency && currency != s.currency {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrCurrencyNotSupported, currency)
+}
+
+func (s *CurrencyService) findProduct(productID uint) error {
+	var product models.Product
+	if err := s.db.Select("id").First(&product, productID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrProductNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *CurrencyService) convertToExchangeRateResponse(rate *models.ExchangeRate) dto.ExchangeRateResponse {
+	return dto.ExchangeRateResponse{
+		Currency:  rate.Currency,
+		Rate:      rate.Rate,
+		CreatedAt: rate.CreatedAt,
+		UpdatedAt: rate.UpdatedAt,
+	}
+}
+
+func (s *CurrencyService) convertToProductPriceResponse(price *models.ProductPrice) dto.ProductPriceResponse {
+	return dto.ProductPriceResponse{
+		ProductID: price.ProductID,
+		Price:     price.Price(),
+		CreatedAt: price.CreatedAt,
+		UpdatedAt: price.UpdatedAt,
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+)
+
+var (
+	ErrOrderNotFound      = errors.New("order not found")
+	ErrInvalidOrderStatus = errors.New("invalid order status")
+	ErrInvalidOrderFilter = errors.New("invalid order filter")
+
+	ErrInvalidProduct       = errors.New("invalid product")
+	ErrProductNotFound      = errors.New("product not found")
+	ErrProductPriceNotFound = errors.New("product price not found")
+	ErrInvalidProductPrice  = errors.New("invalid product price")
+
+	ErrExchangeRateNotFound = errors.New("exchange rate not found")
+	ErrInvalidExchangeRate  = errors.New("invalid exchange rate")
+	ErrCurrencyNotSupported = errors.New("currency is not supported")
+	ErrCurrencyNotAvailable = errors.New("no exchange rate is set for the currency")
+
+	ErrAddressNotFound = errors.New("address not found")
+
+	ErrCartNotFound = errors.New("cart not found")
+
+	ErrCheckoutSessionNotFound = errors.New("checkout session not found")
+	ErrCheckoutSessionExpired  = errors.New("checkout session has expired")
+	ErrCheckoutSessionClosed   = errors.New("checkout session is no longer open")
+	ErrCheckoutCartChanged     = errors.New("the cart has changed since the checkout session was created")
+
+	ErrSubscriptionNotFound  = errors.New("subscription not found")
+	ErrInvalidSubscription   = errors.New("invalid subscription")
+	ErrSubscriptionCancelled = errors.New("subscription has been cancelled")
+
+	ErrUserNotFound              = errors.New("user not found")
+	ErrInvalidWalletCredit       = errors.New("invalid wallet credit")
+	ErrInsufficientWalletBalance = errors.New("insufficient wallet balance")
+
+	ErrGiftCardNotFound      = errors.New("gift card not found")
+	ErrInvalidGiftCard       = errors.New("invalid gift card")
+	ErrGiftCardNotRedeemable = errors.New("gift card has already been redeemed or has expired")
+
+	ErrInsufficientLoyaltyPoints = errors.New("insufficient loyalty points")
+
+	ErrShippingZoneNotFound    = errors.New("shipping zone not found")
+	ErrShippingRateNotFound    = errors.New("shipping rate not found")
+	ErrInvalidShippingRate     = errors.New("invalid shipping rate")
+	ErrShippingUnavailable     = errors.New("shipping method is not available for this cart")
+	ErrShippingAddressRequired = errors.New("a shipping address is required for the selected shipping method")
+
+	ErrTaxRateNotFound = errors.New("tax rate not found")
+
+	ErrCouponNotFound      = errors.New("coupon not found")
+	ErrInvalidCoupon       = errors.New("invalid coupon")
+	ErrCouponNotApplicable = errors.New("coupon cannot be applied to this cart")
+
+	ErrPromotionNotFound = errors.New("promotion not found")
+	ErrInvalidPromotion  = errors.New("invalid promotion")
+
+	ErrInvoiceNotFound = errors.New("invoice not found")
+
+	ErrShipmentNotFound    = errors.New("shipment not found")
+	ErrOrderNotShippable   = errors.New("only confirmed orders can be shipped")
+	ErrInvalidShipmentItem = errors.New("invalid shipment item")
+
+	ErrReturnNotFound        = errors.New("return not found")
+	ErrOrderNotReturnable    = errors.New("only delivered orders can be returned")
+	ErrInvalidReturnItem     = errors.New("invalid return item")
+	ErrRefundExceedsPayments = errors.New("refund exceeds the captured payments")
+)
+
+// InvalidStatusTransitionError is returned when an order is asked to move
+// to a status the transition table does not allow from its current one.
+type InvalidStatusTransitionError struct {
+	From models.OrderStatus
+	To   models.OrderStatus
+}
+
+func (e *InvalidStatusTransitionError) Error() string {
+	return fmt.Sprintf("cannot change order status from %s to %s", e.From, e.To)
+}
+
+// InvalidReturnTransitionError is returned when a return is asked to move
+// to a status its workflow does not allow from its current one.
+type InvalidReturnTransitionError struct {
+	From models.ReturnStatus
+	To   models.ReturnStatus
+}
+
+func (e *InvalidReturnTransitionError) Error() string {
+	return fmt.Sprintf("cannot change return status from %s to %s", e.From, e.To)
+}
@@ -2,8 +2,11 @@ package services
 
 import (
 	"mime/multipart"
+	"time"
 
 	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/exports"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
 	"github.com/kuldeepstechwork/gocart-api/internal/utils"
 )
 
@@ -12,6 +15,7 @@ type AuthServiceInterface interface {
 	Login(req *dto.LoginRequest) (*dto.AuthResponse, error)
 	RefreshToken(req *dto.RefreshTokenRequest) (*dto.AuthResponse, error)
 	Logout(refreshToken string) error
+	VerifyEmail(req *dto.VerifyEmailRequest) (*dto.UserResponse, error)
 }
 
 type UserServiceInterface interface {
@@ -19,6 +23,14 @@ type UserServiceInterface interface {
 	UpdateProfile(userID uint, req *dto.UpdateProfileRequest) (*dto.UserResponse, error)
 }
 
+type AddressServiceInterface interface {
+	GetAddresses(userID uint) ([]dto.AddressResponse, error)
+	GetAddress(userID, addressID uint) (*dto.AddressResponse, error)
+	CreateAddress(userID uint, req *dto.AddressRequest) (*dto.AddressResponse, error)
+	UpdateAddress(userID, addressID uint, req *dto.AddressRequest) (*dto.AddressResponse, error)
+	DeleteAddress(userID, addressID uint) error
+}
+
 type ProductServiceInterface interface {
 	CreateCategory(req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error)
 	GetCategories() ([]dto.CategoryResponse, error)
@@ -26,26 +38,149 @@ type ProductServiceInterface interface {
 	DeleteCategory(id uint) error
 
 	CreateProduct(req *dto.CreateProductRequest) (*dto.ProductResponse, error)
-	GetProducts(page, limit int) ([]dto.ProductResponse, *utils.PaginationMeta, error)
-	GetProduct(id uint) (*dto.ProductResponse, error)
+	GetProducts(page, limit int, currency money.Currency) ([]dto.ProductResponse, *utils.PaginationMeta, error)
+	GetProduct(id uint, currency money.Currency) (*dto.ProductResponse, error)
 	UpdateProduct(id uint, req *dto.UpdateProductRequest) (*dto.ProductResponse, error)
 	DeleteProduct(id uint) error
 
 	AddProductImage(productID uint, url, altText string) error
-	SearchProducts(req *dto.SearchProductsRequest) ([]dto.ProductSearchResult, *utils.PaginationMeta, error)
+	SearchProducts(req *dto.SearchProductsRequest, currency money.Currency) ([]dto.ProductSearchResult, *utils.PaginationMeta, error)
+}
+
+type CurrencyServiceInterface interface {
+	GetCurrencies() ([]dto.CurrencyResponse, error)
+
+	GetExchangeRates() ([]dto.ExchangeRateResponse, error)
+	SetExchangeRate(currency money.Currency, req *dto.ExchangeRateRequest) (*dto.ExchangeRateResponse, error)
+	DeleteExchangeRate(currency money.Currency) error
+
+	GetProductPrices(productID uint) ([]dto.ProductPriceResponse, error)
+	SetProductPrice(productID uint, currency money.Currency, req *dto.ProductPriceRequest) (*dto.ProductPriceResponse, error)
+	DeleteProductPrice(productID uint, currency money.Currency) error
 }
 
 type CartServiceInterface interface {
-	GetCart(userID uint) (*dto.CartResponse, error)
-	AddToCart(userID uint, req *dto.AddToCartRequest) (*dto.CartResponse, error)
-	UpdateCartItem(userID, itemID uint, req *dto.UpdateCartItemRequest) (*dto.CartResponse, error)
+	GetCart(userID uint, currency money.Currency) (*dto.CartResponse, error)
+	AddToCart(userID uint, req *dto.AddToCartRequest, currency money.Currency) (*dto.CartResponse, error)
+	UpdateCartItem(userID, itemID uint, req *dto.UpdateCartItemRequest, currency money.Currency) (*dto.CartResponse, error)
 	RemoveFromCart(userID, itemID uint) error
+	GetShippingOptions(userID, addressID uint, currency money.Currency) ([]dto.ShippingOptionResponse, error)
+	SelectShippingMethod(userID uint, req *dto.SelectShippingMethodRequest, currency money.Currency) (*dto.CartResponse, error)
+	ApplyCoupon(userID uint, req *dto.ApplyCouponRequest, currency money.Currency) (*dto.CartResponse, error)
+	RemoveCoupon(userID uint, currency money.Currency) (*dto.CartResponse, error)
+	Reorder(userID, orderID uint, currency money.Currency) (*dto.ReorderResponse, error)
+
+	CreateGuestCart(currency money.Currency) (*dto.CartResponse, error)
+	GetGuestCart(token string, currency money.Currency) (*dto.CartResponse, error)
+	AddToGuestCart(token string, req *dto.AddToCartRequest, currency money.Currency) (*dto.CartResponse, error)
+	UpdateGuestCartItem(token string, itemID uint, req *dto.UpdateCartItemRequest, currency money.Currency) (*dto.CartResponse, error)
+	RemoveFromGuestCart(token string, itemID uint) error
+}
+
+type CheckoutServiceInterface interface {
+	CreateCheckoutSession(userID uint, req *dto.CreateOrderRequest, currency money.Currency) (*dto.CheckoutSessionResponse, error)
+	GetCheckoutSession(userID, sessionID uint) (*dto.CheckoutSessionResponse, error)
+	CompleteCheckoutSession(userID, sessionID uint) (*dto.OrderResponse, error)
+}
+
+type SubscriptionServiceInterface interface {
+	GetSubscriptions(userID uint) ([]dto.SubscriptionResponse, error)
+	GetSubscription(userID, subscriptionID uint) (*dto.SubscriptionResponse, error)
+	CreateSubscription(userID uint, req *dto.CreateSubscriptionRequest) (*dto.SubscriptionResponse, error)
+	UpdateSubscription(userID, subscriptionID uint, req *dto.UpdateSubscriptionRequest) (*dto.SubscriptionResponse, error)
+	PauseSubscription(userID, subscriptionID uint) (*dto.SubscriptionResponse, error)
+	ResumeSubscription(userID, subscriptionID uint) (*dto.SubscriptionResponse, error)
+	CancelSubscription(userID, subscriptionID uint) (*dto.SubscriptionResponse, error)
+	RunDueSubscriptions(now time.Time) (ordered, failed int, err error)
+}
+
+type ShippingServiceInterface interface {
+	GetZones() ([]dto.ShippingZoneResponse, error)
+	CreateZone(req *dto.ShippingZoneRequest) (*dto.ShippingZoneResponse, error)
+	UpdateZone(zoneID uint, req *dto.ShippingZoneRequest) (*dto.ShippingZoneResponse, error)
+	DeleteZone(zoneID uint) error
+
+	CreateRate(zoneID uint, req *dto.ShippingRateRequest) (*dto.ShippingRateResponse, error)
+	UpdateRate(rateID uint, req *dto.ShippingRateRequest) (*dto.ShippingRateResponse, error)
+	DeleteRate(rateID uint) error
 }
 
 type OrderServiceInterface interface {
-	CreateOrder(userID uint) (*dto.OrderResponse, error)
+	CreateOrder(userID uint, req *dto.CreateOrderRequest, currency money.Currency) (*dto.OrderResponse, error)
 	GetOrders(userID uint, page, limit int) ([]dto.OrderResponse, *utils.PaginationMeta, error)
 	GetOrder(userID, orderID uint) (*dto.OrderResponse, error)
+	ListOrders(req *dto.ListOrdersRequest) ([]dto.OrderResponse, *utils.PaginationMeta, error)
+	GetOrderDetail(orderID uint) (*dto.AdminOrderResponse, error)
+	ExportOrders(req *dto.ListOrdersRequest, w exports.Writer) error
+	UpdateOrderStatus(orderID, changedBy uint, req *dto.UpdateOrderStatusRequest) (*dto.OrderResponse, error)
+	CancelOrder(userID, orderID uint, req *dto.CancelOrderRequest) (*dto.OrderResponse, error)
+
+	CreateGuestOrder(token string, req *dto.GuestOrderRequest, currency money.Currency) (*dto.OrderResponse, error)
+	LookupGuestOrder(req *dto.GuestOrderLookupRequest) (*dto.OrderResponse, error)
+}
+
+type TaxServiceInterface interface {
+	GetTaxRates() ([]dto.TaxRateResponse, error)
+	CreateTaxRate(req *dto.TaxRateRequest) (*dto.TaxRateResponse, error)
+	UpdateTaxRate(rateID uint, req *dto.TaxRateRequest) (*dto.TaxRateResponse, error)
+	DeleteTaxRate(rateID uint) error
+}
+
+type CouponServiceInterface interface {
+	GetCoupons() ([]dto.CouponResponse, error)
+	CreateCoupon(req *dto.CouponRequest) (*dto.CouponResponse, error)
+	UpdateCoupon(couponID uint, req *dto.CouponRequest) (*dto.CouponResponse, error)
+	DeleteCoupon(couponID uint) error
+}
+
+type PromotionServiceInterface interface {
+	GetPromotions() ([]dto.PromotionResponse, error)
+	CreatePromotion(req *dto.PromotionRequest) (*dto.PromotionResponse, error)
+	UpdatePromotion(promotionID uint, req *dto.PromotionRequest) (*dto.PromotionResponse, error)
+	DeletePromotion(promotionID uint) error
+}
+
+type InvoiceServiceInterface interface {
+	GetInvoice(userID, orderID uint) (*dto.InvoiceResponse, error)
+	GenerateInvoice(orderID uint) (*dto.InvoiceResponse, error)
+}
+
+type ReturnServiceInterface interface {
+	RequestReturn(userID, orderID uint, req *dto.CreateReturnRequest) (*dto.ReturnResponse, error)
+	GetReturns(userID uint, page, limit int) ([]dto.ReturnResponse, *utils.PaginationMeta, error)
+	ListReturns(status string, page, limit int) ([]dto.ReturnResponse, *utils.PaginationMeta, error)
+	ApproveReturn(returnID uint) (*dto.ReturnResponse, error)
+	RejectReturn(returnID uint) (*dto.ReturnResponse, error)
+	CancelReturn(userID, returnID uint) (*dto.ReturnResponse, error)
+	ReceiveReturn(returnID uint, req *dto.ReceiveReturnRequest) (*dto.ReturnResponse, error)
+	RefundReturn(returnID uint, req *dto.RefundReturnRequest) (*dto.ReturnResponse, error)
+}
+
+type WalletServiceInterface interface {
+	GetWallet(userID uint) (*dto.WalletResponse, error)
+	GetWalletEntries(userID uint, page, limit int) ([]dto.WalletEntryResponse, *utils.PaginationMeta, error)
+	RedeemGiftCard(userID uint, req *dto.RedeemGiftCardRequest) (*dto.WalletResponse, error)
+	GrantCredit(adminID, userID uint, req *dto.GrantCreditRequest) (*dto.WalletEntryResponse, error)
+	IssueGiftCard(adminID uint, req *dto.GiftCardRequest) (*dto.GiftCardResponse, error)
+	GetGiftCards(page, limit int) ([]dto.GiftCardResponse, *utils.PaginationMeta, error)
+}
+
+type LoyaltyServiceInterface interface {
+	GetLoyalty(userID uint, page, limit int) (*dto.LoyaltyResponse, *utils.PaginationMeta, error)
+	AwardPoints(now time.Time) (awarded int, err error)
+	ExpirePoints(now time.Time) (expired int, err error)
+}
+
+type ShipmentServiceInterface interface {
+	CreateShipment(orderID, adminID uint, req *dto.CreateShipmentRequest) (*dto.ShipmentResponse, error)
+	UpdateShipment(shipmentID, adminID uint, req *dto.UpdateShipmentRequest) (*dto.ShipmentResponse, error)
+	ListShipments(orderID uint) ([]dto.ShipmentResponse, error)
+	GetShipments(userID, orderID uint) ([]dto.ShipmentResponse, error)
+}
+
+type InventoryServiceInterface interface {
+	ReleaseExpiredReservations(now time.Time) (int64, error)
+	ListBackorders(req *dto.ListBackordersRequest) ([]dto.BackorderResponse, *utils.PaginationMeta, error)
 }
 
 type UploadServiceInterface interface {
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ InventoryServiceInterface = (*InventoryService)(nil)
+
+type InventoryService struct {
+	db *gorm.DB
+}
+
+// NewInventoryService creates the inventory service type
+func NewInventoryService(db *gorm.DB) *InventoryService {
+	return &InventoryService{db: db}
+}
+
+// ReleaseExpiredReservations deletes the reservations that expired by now,
+// returning how many were released. Expired reservations no longer hold any
+// stock, so this only keeps the table small.
+func (s *InventoryService) ReleaseExpiredReservations(now time.Time) (int64, error) {
+	result := s.db.Where("expires_at <= ?", now).Delete(&models.InventoryReservation{})
+
+	return result.RowsAffected, result.Error
+}
+
+// backorderStatuses are the statuses of orders whose items may still wait for
+// stock.
+var backorderStatuses = []models.OrderStatus{
+	models.OrderStatusPending,
+	models.OrderStatusConfirmed,
+	models.OrderStatusPartiallyShipped,
+}
+
+// ListBackorders lists the backordered and preordered items of the orders
+// that have not shipped yet, oldest first, the order in which arriving stock
+// should fulfil them.
+func (s *InventoryService) ListBackorders(req *dto.ListBackordersRequest) ([]dto.BackorderResponse, *utils.PaginationMeta, error) {
+	page, limit := req.Page, req.Limit
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = 10
+	}
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	filter := func(db *gorm.DB) *gorm.DB {
+		db = db.Joins("JOIN orders ON orders.id = order_items.order_id").
+			Where("order_items.stock_status <> ? AND orders.status IN ?", models.StockStatusInStock, backorderStatuses)
+		if req.ProductID != nil {
+			db = db.Where("order_items.product_id = ?", *req.ProductID)
+		}
+		if req.StockStatus != "" {
+			db = db.Where("order_items.stock_status = ?", req.StockStatus)
+		}
+		return db
+	}
+
+	offset := (page - 1) * limit
+	var items []models.OrderItem
+	var total int64
+
+	if err := s.db.Model(&models.OrderItem{}).Scopes(filter).Count(&total).Error; err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.db.Scopes(filter).Preload("Order").Preload("Product").
+		Order("order_items.created_at, order_items.id").
+		Offset(offset).Limit(limit).
+		Find(&items).Error; err != nil {
+		return nil, nil, err
+	}
+
+	response := make([]dto.BackorderResponse, len(items))
+	for i := range items {
+		item := &items[i]
+
+		response[i] = dto.BackorderResponse{
+			OrderItemID:         item.ID,
+			OrderID:             item.OrderID,
+			OrderNumber:         item.Order.Number,
+			OrderStatus:         string(item.Order.Status),
+			ProductID:           item.ProductID,
+			ProductName:         item.Product.Name,
+			SKU:                 item.Product.SKU,
+			StockStatus:         string(item.StockStatus),
+			Quantity:            item.Quantity,
+			BackorderedQuantity: item.BackorderedQuantity,
+			AvailableAt:         item.AvailableAt,
+			Stock:               item.Product.Stock,
+			OrderedAt:           item.CreatedAt,
+		}
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	meta := &utils.PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	return response, meta, nil
+}
+
+// checkStock checks that every item of the cart, which must have its products
+// loaded, can be sold once the quantities held by other carts are set aside.
+func checkStock(db *gorm.DB, cart *models.Cart) error {
+	productIDs := make([]uint, len(cart.CartItems))
+	for i := range cart.CartItems {
+		productIDs[i] = cart.CartItems[i].ProductID
+	}
+
+	reserved, err := reservedStock(db, productIDs, cart.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := range cart.CartItems {
+		cartItem := &cart.CartItems[i]
+
+		if cartItem.Product.Sellable()-reserved[cartItem.ProductID] < cartItem.Quantity {
+			return fmt.Errorf("insufficient stock for product: %s", cartItem.Product.Name)
+		}
+	}
+
+	return nil
+}
+
+// reservedStock sums, per product, the quantities held by the active
+// reservations of carts other than cartID. A cartID of 0 counts every
+// reservation.
+func reservedStock(db *gorm.DB, productIDs []uint, cartID uint) (map[uint]int, error) {
+	reserved := make(map[uint]int, len(productIDs))
+	if len(productIDs) == 0 {
+		return reserved, nil
+	}
+
+	var rows []struct {
+		ProductID uint
+		Quantity  int
+	}
+	if err := db.Model(&models.InventoryReservation{}).
+		Select("product_id, SUM(quantity) AS quantity").
+		Where("product_id IN ? AND expires_at > ? AND cart_id <> ?", productIDs, time.Now(), cartID).
+		Group("product_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		reserved[row.ProductID] = row.Quantity
+	}
+
+	return reserved, nil
+}
+
+// availableStock lowers the stock and backorder limit of the loaded products
+// to what is available to the cart: what can be sold less what other carts
+// hold, taken from the stock on hand first. A cartID of 0 leaves what is
+// available to anyone. The products must not be saved afterwards.
+func availableStock(db *gorm.DB, cartID uint, products ...*models.Product) error {
+	productIDs := make([]uint, len(products))
+	for i, product := range products {
+		productIDs[i] = product.ID
+	}
+
+	reserved, err := reservedStock(db, productIDs, cartID)
+	if err != nil {
+		return err
+	}
+
+	for _, product := range products {
+		sellable := product.Stock + product.BackorderLimit - reserved[product.ID]
+		product.Stock = max(product.Stock-reserved[product.ID], 0)
+		product.BackorderLimit = max(sellable-product.Stock, 0)
+	}
+
+	return nil
+}
+
+// takeStock takes the quantity of the cart item out of the stock of its
+// product, returning the stock left. The stock is decremented in place, and
+// only while enough can be sold besides what the active reservations of other
+// carts hold, so that concurrent orders cannot both take the last units nor
+// units held for another cart; the stock checked beforehand may already be
+// out of date. The stock goes below 0 by the units sold on backorder or
+// preorder.
+func takeStock(tx *gorm.DB, cartItem *models.CartItem) (int, error) {
+	reserved := tx.Model(&models.InventoryReservation{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("product_id = ? AND expires_at > ? AND cart_id <> ?", cartItem.ProductID, time.Now(), cartItem.CartID)
+
+	var product models.Product
+	result := tx.Model(&product).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "stock"}}}).
+		Where("id = ? AND stock + backorder_limit - (?) >= ?", cartItem.ProductID, reserved, cartItem.Quantity).
+		UpdateColumn("stock", gorm.Expr("stock - ?", cartItem.Quantity))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return 0, fmt.Errorf("insufficient stock for product: %s", cartItem.Product.Name)
+	}
+
+	cartItem.Product.Stock = product.Stock
+
+	return product.Stock, nil
+}
+
+// markStockStatus marks the order item as preordered when its product is
+// sold ahead of its availability date, or as backordered when some of its
+// units were not in stock, given the stock left once they were taken.
+func markStockStatus(item *models.OrderItem, product *models.Product, stockLeft int, now time.Time) {
+	switch {
+	case product.IsPreorder(now):
+		item.StockStatus = models.StockStatusPreordered
+		item.BackorderedQuantity = item.Quantity
+		item.AvailableAt = product.AvailableAt
+	case stockLeft < 0:
+		item.StockStatus = models.StockStatusBackordered
+		item.BackorderedQuantity = min(item.Quantity, -stockLeft)
+	default:
+		item.StockStatus = models.StockStatusInStock
+	}
+}
+
+// holdStock reserves the quantity of the product for the cart until
+// expiresAt, replacing what the cart held of it before.
+func holdStock(db *gorm.DB, cartID, productID uint, quantity int, checkoutSessionID *uint, expiresAt time.Time) error {
+	reservation := models.InventoryReservation{
+		CartID:            cartID,
+		ProductID:         productID,
+		CheckoutSessionID: checkoutSessionID,
+		Quantity:          quantity,
+		ExpiresAt:         expiresAt,
+	}
+
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "cart_id"}, {Name: "product_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"checkout_session_id", "quantity", "expires_at", "updated_at"}),
+	}).Create(&reservation).Error
+}
+
+// releaseStock deletes the reservations of the cart, of the products only
+// when any are given.
+func releaseStock(db *gorm.DB, cartID uint, productIDs ...uint) error {
+	query := db.Where("cart_id = ?", cartID)
+	if len(productIDs) > 0 {
+		query = query.Where("product_id IN ?", productIDs)
+	}
+
+	return query.Delete(&models.InventoryReservation{}).Error
+}
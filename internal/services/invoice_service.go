@@ -0,0 +1,198 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"net/textproto"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/interfaces"
+	"github.com/kuldeepstechwork/gocart-api/internal/invoices"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ InvoiceServiceInterface = (*InvoiceService)(nil)
+
+type InvoiceService struct {
+	db             *gorm.DB
+	uploadProvider interfaces.UploadProvider
+	renderer       invoices.Renderer
+}
+
+// NewInvoiceService creates the invoice service type
+func NewInvoiceService(db *gorm.DB, uploadProvider interfaces.UploadProvider, renderer invoices.Renderer) *InvoiceService {
+	return &InvoiceService{db: db, uploadProvider: uploadProvider, renderer: renderer}
+}
+
+// GetInvoice returns the invoice of one of the user's orders. A document
+// that could not be rendered when the order was confirmed is rendered now.
+func (s *InvoiceService) GetInvoice(userID, orderID uint) (*dto.InvoiceResponse, error) {
+	var order models.Order
+	if err := s.db.Where("id = ? AND user_id = ?", orderID, userID).First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	invoice, err := findInvoice(s.db, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice.DocumentPath == "" {
+		return s.GenerateInvoice(orderID)
+	}
+
+	return convertToInvoiceResponse(invoice), nil
+}
+
+// GenerateInvoice renders the invoice of the order from the order as it is
+// now, its items described by their products as they were ordered, and
+// stores the document, replacing the one stored before. The invoice keeps
+// its number.
+func (s *InvoiceService) GenerateInvoice(orderID uint) (*dto.InvoiceResponse, error) {
+	invoice, err := findInvoice(s.db, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var order models.Order
+	if err := s.db.Preload("OrderItems.Product").First(&order, orderID).Error; err != nil {
+		return nil, err
+	}
+
+	rendered, err := s.renderer.Render(invoices.NewDocument(invoice, &order))
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := newFileHeader(invoice.Number+rendered.Extension, rendered.ContentType, rendered.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	// The random part keeps invoice documents from being guessed from their
+	// number, as uploads may be publicly readable
+	path := fmt.Sprintf("invoices/%d/%s-%s%s", order.ID, invoice.Number, uuid.New().String(), rendered.Extension)
+
+	storedPath, err := s.uploadProvider.UploadFile(file, path)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := invoice.DocumentPath
+	renderedAt := time.Now()
+
+	if err := s.db.Model(invoice).Updates(map[string]interface{}{
+		"document_path": storedPath,
+		"content_type":  rendered.ContentType,
+		"rendered_at":   renderedAt,
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	invoice.DocumentPath = storedPath
+	invoice.ContentType = rendered.ContentType
+	invoice.RenderedAt = &renderedAt
+
+	if previous != "" && previous != storedPath {
+		if err := s.uploadProvider.DeleteFile(previous); err != nil {
+			log.Printf("unable to delete previous invoice document %s: %v", previous, err)
+		}
+	}
+
+	return convertToInvoiceResponse(invoice), nil
+}
+
+func convertToInvoiceResponse(invoice *models.Invoice) *dto.InvoiceResponse {
+	return &dto.InvoiceResponse{
+		ID:           invoice.ID,
+		OrderID:      invoice.OrderID,
+		Number:       invoice.Number,
+		IssuedAt:     invoice.IssuedAt,
+		DocumentPath: invoice.DocumentPath,
+		ContentType:  invoice.ContentType,
+		RenderedAt:   invoice.RenderedAt,
+	}
+}
+
+func findInvoice(db *gorm.DB, orderID uint) (*models.Invoice, error) {
+	var invoice models.Invoice
+	if err := db.Where("order_id = ?", orderID).First(&invoice).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvoiceNotFound
+		}
+		return nil, err
+	}
+
+	return &invoice, nil
+}
+
+// issueInvoice allocates the next invoice number to the order within the
+// caller's transaction. The sequence row stays locked until the transaction
+// ends, and a rolled back transaction gives its number back, so numbers are
+// consecutive without gaps.
+func issueInvoice(tx *gorm.DB, orderID uint) error {
+	sequence := models.InvoiceSequence{Name: models.InvoiceSequenceName}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&sequence).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("name = ?", models.InvoiceSequenceName).
+		First(&sequence).Error; err != nil {
+		return err
+	}
+
+	sequence.LastNumber++
+	if err := tx.Model(&sequence).Update("last_number", sequence.LastNumber).Error; err != nil {
+		return err
+	}
+
+	invoice := models.Invoice{
+		OrderID:  orderID,
+		Number:   models.FormatInvoiceNumber(sequence.LastNumber),
+		IssuedAt: time.Now(),
+	}
+
+	return tx.Create(&invoice).Error
+}
+
+// newFileHeader wraps generated content in the multipart file header upload
+// providers take their files as.
+func newFileHeader(filename, contentType string, content []byte) (*multipart.FileHeader, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, filename))
+	header.Set("Content-Type", contentType)
+
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := part.Write(content); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(int64(body.Len()))
+	if err != nil {
+		return nil, err
+	}
+
+	return form.File["file"][0], nil
+}
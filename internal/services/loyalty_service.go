@@ -0,0 +1,434 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ LoyaltyServiceInterface = (*LoyaltyService)(nil)
+
+// loyaltyBatch is how many orders or credits a run loads at a time.
+const loyaltyBatch = 100
+
+// LoyaltyProgram is how customers earn and spend loyalty points.
+type LoyaltyProgram struct {
+	// PointsPerUnit are earned for every unit of the store currency a
+	// delivered order cost
+	PointsPerUnit int64
+
+	// PointValue is what a point takes off an order, in minor units of the
+	// store currency
+	PointValue int64
+
+	// Expiry is how long credited points can be spent; they never expire
+	// when it is 0
+	Expiry time.Duration
+}
+
+type LoyaltyService struct {
+	db       *gorm.DB
+	currency money.Currency
+	program  LoyaltyProgram
+}
+
+// NewLoyaltyService creates the loyalty service type
+func NewLoyaltyService(db *gorm.DB, currency money.Currency, program LoyaltyProgram) *LoyaltyService {
+	return &LoyaltyService{db: db, currency: currency, program: program}
+}
+
+// GetLoyalty returns the points the user can spend now, and what they are
+// worth, with a page of the user's points ledger, newest first.
+func (s *LoyaltyService) GetLoyalty(userID uint, page, limit int) (*dto.LoyaltyResponse, *utils.PaginationMeta, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = 10
+	}
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	balance, err := s.balance(s.db, userID, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	offset := (page - 1) * limit
+	var entries []models.LoyaltyEntry
+	var total int64
+
+	if err := s.db.Model(&models.LoyaltyEntry{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.db.Where("user_id = ?", userID).
+		Order("id DESC").
+		Offset(offset).Limit(limit).
+		Find(&entries).Error; err != nil {
+		return nil, nil, err
+	}
+
+	response := &dto.LoyaltyResponse{
+		Balance:      balance,
+		BalanceValue: money.New(int64(balance)*s.program.PointValue, s.currency),
+		Entries:      make([]dto.LoyaltyEntryResponse, len(entries)),
+	}
+	for i := range entries {
+		response.Entries[i] = convertToLoyaltyEntryResponse(&entries[i])
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	meta := &utils.PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	return response, meta, nil
+}
+
+// AwardPoints credits the delivered orders that have not earned their points
+// yet with the points they earn, and returns how many orders earned points.
+// Every order is marked once awarded, so it never earns twice.
+func (s *LoyaltyService) AwardPoints(now time.Time) (int, error) {
+	awarded := 0
+
+	var lastID uint
+	for {
+		var ids []uint
+		if err := s.db.Model(&models.Order{}).
+			Where("status = ? AND user_id IS NOT NULL AND loyalty_awarded_at IS NULL AND id > ?", models.OrderStatusDelivered, lastID).
+			Order("id").
+			Limit(loyaltyBatch).
+			Pluck("id", &ids).Error; err != nil {
+			return awarded, err
+		}
+
+		for _, id := range ids {
+			points, err := s.awardOrder(id, now)
+			if err != nil {
+				return awarded, err
+			}
+
+			if points > 0 {
+				awarded++
+			}
+		}
+
+		if len(ids) < loyaltyBatch {
+			return awarded, nil
+		}
+		lastID = ids[len(ids)-1]
+	}
+}
+
+// awardOrder credits the delivered order with the points earned for what
+// was paid for it, less what its refunded returns paid back.
+func (s *LoyaltyService) awardOrder(orderID uint, now time.Time) (int, error) {
+	var points int
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var order models.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND status = ? AND loyalty_awarded_at IS NULL", orderID, models.OrderStatusDelivered).
+			First(&order).Error; err != nil {
+			// Awarded by another run in the meantime
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		var refunded int64
+		if err := tx.Model(&models.Return{}).
+			Where("order_id = ? AND status = ?", order.ID, models.ReturnStatusRefunded).
+			Select("COALESCE(SUM(refund_amount), 0)").
+			Scan(&refunded).Error; err != nil {
+			return err
+		}
+
+		paid := order.TotalAmount.Sub(order.LoyaltyDiscount)
+		paid = paid.Sub(money.New(refunded, paid.Currency))
+		points = s.earnedPoints(storeAmount(paid, s.currency, order.ExchangeRate))
+
+		if points > 0 {
+			if err := s.credit(tx, order.CustomerID(), &order.ID, models.LoyaltyEntryEarned, points, now); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.Order{}).Where("id = ?", order.ID).Update("loyalty_awarded_at", now).Error
+	})
+
+	return points, err
+}
+
+// ExpirePoints expires what remains of the credits that lapsed by now, and
+// returns how many points expired.
+func (s *LoyaltyService) ExpirePoints(now time.Time) (int, error) {
+	expired := 0
+
+	var lastID uint
+	for {
+		var ids []uint
+		if err := s.db.Model(&models.LoyaltyEntry{}).
+			Where("remaining > 0 AND expires_at <= ? AND id > ?", now, lastID).
+			Order("id").
+			Limit(loyaltyBatch).
+			Pluck("id", &ids).Error; err != nil {
+			return expired, err
+		}
+
+		for _, id := range ids {
+			points, err := s.expireCredit(id, now)
+			if err != nil {
+				return expired, err
+			}
+
+			expired += points
+		}
+
+		if len(ids) < loyaltyBatch {
+			return expired, nil
+		}
+		lastID = ids[len(ids)-1]
+	}
+}
+
+// expireCredit debits what remains of the lapsed credit.
+func (s *LoyaltyService) expireCredit(entryID uint, now time.Time) (int, error) {
+	var points int
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var credit models.LoyaltyEntry
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND remaining > 0 AND expires_at <= ?", entryID, now).
+			First(&credit).Error; err != nil {
+			// Spent or expired in the meantime
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		points = credit.Remaining
+
+		if err := s.debit(tx, &credit, points); err != nil {
+			return err
+		}
+
+		return tx.Create(&models.LoyaltyEntry{
+			UserID:  credit.UserID,
+			OrderID: credit.OrderID,
+			Type:    models.LoyaltyEntryExpired,
+			Points:  -points,
+		}).Error
+	})
+
+	return points, err
+}
+
+// earnedPoints returns the points earned for an amount of the store currency.
+func (s *LoyaltyService) earnedPoints(amount money.Money) int {
+	if !amount.IsPositive() || s.program.PointsPerUnit <= 0 {
+		return 0
+	}
+
+	unit := int64(math.Pow10(amount.Currency.Exponent()))
+
+	return int(amount.Amount * s.program.PointsPerUnit / unit)
+}
+
+// redemption works out how many of the points to spend on an order total,
+// in the order currency at rate units per unit of the store currency, and
+// what they take off it. No more points are spent than the total needs.
+func (s *LoyaltyService) redemption(points int, total money.Money, rate float64) (int, money.Money) {
+	discount := money.Zero(total.Currency)
+	if points <= 0 || s.program.PointValue <= 0 || !total.IsPositive() {
+		return 0, discount
+	}
+
+	needed := (storeAmount(total, s.currency, rate).Amount + s.program.PointValue - 1) / s.program.PointValue
+	points = int(min(int64(points), needed))
+
+	value := money.New(int64(points)*s.program.PointValue, s.currency)
+	if value.Currency != total.Currency {
+		value = value.Convert(total.Currency, rate)
+	}
+
+	return points, money.Min(value, total)
+}
+
+// balance returns the points the user can spend at now.
+func (s *LoyaltyService) balance(tx *gorm.DB, userID uint, now time.Time) (int, error) {
+	var balance int64
+	if err := tx.Model(&models.LoyaltyEntry{}).
+		Where("user_id = ? AND remaining > 0 AND (expires_at IS NULL OR expires_at > ?)", userID, now).
+		Select("COALESCE(SUM(remaining), 0)").
+		Scan(&balance).Error; err != nil {
+		return 0, err
+	}
+
+	return int(balance), nil
+}
+
+// spend debits points spent on the order from the user's spendable credits,
+// those expiring first spent first.
+func (s *LoyaltyService) spend(tx *gorm.DB, userID, orderID uint, points int, now time.Time) error {
+	var credits []models.LoyaltyEntry
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND remaining > 0 AND (expires_at IS NULL OR expires_at > ?)", userID, now).
+		Order("expires_at, id").
+		Find(&credits).Error; err != nil {
+		return err
+	}
+
+	available := 0
+	for i := range credits {
+		available += credits[i].Remaining
+	}
+
+	if available < points {
+		return ErrInsufficientLoyaltyPoints
+	}
+
+	left := points
+	for i := 0; left > 0; i++ {
+		taken := min(credits[i].Remaining, left)
+		if err := s.debit(tx, &credits[i], taken); err != nil {
+			return err
+		}
+		left -= taken
+	}
+
+	return tx.Create(&models.LoyaltyEntry{
+		UserID:  userID,
+		OrderID: &orderID,
+		Type:    models.LoyaltyEntryRedeemed,
+		Points:  -points,
+	}).Error
+}
+
+// restoreSpent credits back points spent on the order, spendable for as
+// long as newly earned points.
+func (s *LoyaltyService) restoreSpent(tx *gorm.DB, order *models.Order, points int, now time.Time) error {
+	return s.credit(tx, order.CustomerID(), &order.ID, models.LoyaltyEntryRestored, points, now)
+}
+
+// restoreRefund credits back the points spent on the order that paid amount,
+// in the order currency, of a refund. No more points are given back than
+// were spent on the order.
+func (s *LoyaltyService) restoreRefund(tx *gorm.DB, order *models.Order, amount money.Money, now time.Time) (int, error) {
+	var restored int64
+	if order.LoyaltyPoints > 0 {
+		if err := tx.Model(&models.LoyaltyEntry{}).
+			Where("order_id = ? AND type = ?", order.ID, models.LoyaltyEntryRestored).
+			Select("COALESCE(SUM(points), 0)").
+			Scan(&restored).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	available := int64(order.LoyaltyPoints) - restored
+	if available <= 0 || s.program.PointValue <= 0 {
+		return 0, ErrRefundExceedsPayments
+	}
+
+	needed := (storeAmount(amount, s.currency, order.ExchangeRate).Amount + s.program.PointValue - 1) / s.program.PointValue
+	points := int(min(needed, available))
+
+	if err := s.restoreSpent(tx, order, points, now); err != nil {
+		return 0, err
+	}
+
+	return points, nil
+}
+
+// reverseEarned takes back the share of the points the order earned that
+// refund, in the order currency, paid back. Points of the credit already
+// spent are not taken back.
+func (s *LoyaltyService) reverseEarned(tx *gorm.DB, order *models.Order, refund money.Money) error {
+	if order.LoyaltyAwardedAt == nil {
+		return nil
+	}
+
+	var earned models.LoyaltyEntry
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ? AND type = ?", order.ID, models.LoyaltyEntryEarned).
+		First(&earned).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	paid := order.TotalAmount.Sub(order.LoyaltyDiscount)
+	if !paid.IsPositive() {
+		return nil
+	}
+
+	points := int(min(int64(earned.Points)*refund.Amount/paid.Amount, int64(earned.Remaining)))
+	if points <= 0 {
+		return nil
+	}
+
+	if err := s.debit(tx, &earned, points); err != nil {
+		return err
+	}
+
+	return tx.Create(&models.LoyaltyEntry{
+		UserID:  order.CustomerID(),
+		OrderID: &order.ID,
+		Type:    models.LoyaltyEntryReversed,
+		Points:  -points,
+	}).Error
+}
+
+// credit posts a credit of points to the user, spendable until the
+// program's expiry.
+func (s *LoyaltyService) credit(tx *gorm.DB, userID uint, orderID *uint, entryType models.LoyaltyEntryType, points int, now time.Time) error {
+	entry := models.LoyaltyEntry{
+		UserID:    userID,
+		OrderID:   orderID,
+		Type:      entryType,
+		Points:    points,
+		Remaining: points,
+	}
+
+	if s.program.Expiry > 0 {
+		expiresAt := now.Add(s.program.Expiry)
+		entry.ExpiresAt = &expiresAt
+	}
+
+	return tx.Create(&entry).Error
+}
+
+// debit takes points off what remains of the locked credit.
+func (s *LoyaltyService) debit(tx *gorm.DB, credit *models.LoyaltyEntry, points int) error {
+	credit.Remaining -= points
+
+	return tx.Model(&models.LoyaltyEntry{}).Where("id = ?", credit.ID).Update("remaining", credit.Remaining).Error
+}
+
+func convertToLoyaltyEntryResponse(entry *models.LoyaltyEntry) dto.LoyaltyEntryResponse {
+	return dto.LoyaltyEntryResponse{
+		ID:        entry.ID,
+		Type:      string(entry.Type),
+		Points:    entry.Points,
+		OrderID:   entry.OrderID,
+		ExpiresAt: entry.ExpiresAt,
+		CreatedAt: entry.CreatedAt,
+	}
+}
@@ -3,101 +3,677 @@ package services
 import (
 	"errors"
 	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/events"
+	"github.com/kuldeepstechwork/gocart-api/internal/exports"
 	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/notifications"
+	"github.com/kuldeepstechwork/gocart-api/internal/payments"
+	"github.com/kuldeepstechwork/gocart-api/internal/tax"
 	"github.com/kuldeepstechwork/gocart-api/internal/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var _ OrderServiceInterface = (*OrderService)(nil)
 
 type OrderService struct {
-	db *gorm.DB
+	db              *gorm.DB
+	currency        money.Currency
+	eventPublisher  events.Publisher
+	paymentProvider payments.PaymentProvider
+	taxCalculator   tax.TaxCalculator
+	invoiceService  InvoiceServiceInterface
+	loyalty         *LoyaltyService
 }
 
 // NewOrderService creates the order service type
-func NewOrderService(db *gorm.DB) *OrderService {
-	return &OrderService{db: db}
+func NewOrderService(db *gorm.DB, currency money.Currency, eventPublisher events.Publisher, paymentProvider payments.PaymentProvider, taxCalculator tax.TaxCalculator, invoiceService InvoiceServiceInterface, loyalty *LoyaltyService) *OrderService {
+	return &OrderService{db: db, currency: currency, eventPublisher: eventPublisher, paymentProvider: paymentProvider, taxCalculator: taxCalculator, invoiceService: invoiceService, loyalty: loyalty}
 }
 
-func (s *OrderService) CreateOrder(userID uint) (*dto.OrderResponse, error) {
+// CreateOrder turns the user's cart into an order. The chosen shipping and
+// billing addresses are copied onto the order; the billing address defaults
+// to the shipping address. The shipping method selected on the cart is
+// re-quoted for the shipping address and its cost added to the order total.
+// The coupon applied to the cart is checked again and redeemed, and every
+// line is taxed, after its discount, for the shipping address. The order is
+// priced in the currency at the current exchange rate, which is kept on the
+// order.
+func (s *OrderService) CreateOrder(userID uint, req *dto.CreateOrderRequest, currency money.Currency) (*dto.OrderResponse, error) {
 	var orderResponse *dto.OrderResponse
+	var quote *orderQuote
 
 	err := s.db.Transaction(func(tx *gorm.DB) error {
+		prices, err := newPricing(tx, s.currency, currency)
+		if err != nil {
+			return err
+		}
+
+		shippingAddress, billingAddress, err := s.orderAddresses(tx, userID, req)
+		if err != nil {
+			return err
+		}
 
 		var cart models.Cart
 		if err := tx.Preload("CartItems.Product").Where("user_id = ?", userID).First(&cart).Error; err != nil {
 			return errors.New("cart not found")
 		}
 
-		if len(cart.CartItems) == 0 {
-			return errors.New("cart is empty")
+		order := models.Order{
+			UserID:          &userID,
+			ShippingAddress: shippingAddress,
+			BillingAddress:  billingAddress,
+		}
+		quote, err = s.priceOrder(tx, &order, &cart, prices)
+		if err != nil {
+			return err
 		}
+		quote.useWallet = req.UseWallet
+		quote.redeemPoints = req.RedeemPoints
 
-		// Calculate total and validate stock
-		var totalAmount float64
-		var orderItems []models.OrderItem
+		if err := s.placeOrder(tx, &order, &cart, quote); err != nil {
+			return err
+		}
 
-		for i := range cart.CartItems {
-			cartItem := &cart.CartItems[i]
+		response, err := s.getOrderResponse(tx, order.ID)
+		if err != nil {
+			return err
+		}
 
-			if cartItem.Product.Stock < cartItem.Quantity {
-				return fmt.Errorf("insufficient stock for product: %s", cartItem.Product.Name)
-			}
+		orderResponse = response
 
-			itemTotal := float64(cartItem.Quantity) * cartItem.Product.Price
-			totalAmount += itemTotal
+		return nil // Transaction successful
+	})
 
-			orderItems = append(orderItems, models.OrderItem{
-				ProductID: cartItem.ProductID,
-				Quantity:  cartItem.Quantity,
-				Price:     cartItem.Product.Price,
-			})
+	if err != nil {
+		s.voidAuthorization(quote)
+		return nil, err
+	}
 
-			// Update product stock
-			cartItem.Product.Stock -= cartItem.Quantity
-			if err := tx.Save(&cartItem.Product).Error; err != nil {
-				return err
-			}
+	return orderResponse, nil
 
-			// Create order
-			order := models.Order{
-				UserID:      userID,
-				Status:      models.OrderStatusPending,
-				TotalAmount: totalAmount,
-				OrderItems:  orderItems,
-			}
+}
 
-			if err := tx.Create(&order).Error; err != nil {
-				return err
-			}
+// CreateGuestOrder turns the guest cart with the token into an order placed
+// with the guest's email and addresses, priced like any other order. The
+// order number is emailed to the guest, who can look the order up with it.
+func (s *OrderService) CreateGuestOrder(token string, req *dto.GuestOrderRequest, currency money.Currency) (*dto.OrderResponse, error) {
+	var orderResponse *dto.OrderResponse
+	var quote *orderQuote
 
-			// Clear cart
-			if err := tx.Unscoped().Where("cart_id = ?", cart.ID).Delete(&models.CartItem{}).Error; err != nil {
-				return err
-			}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		prices, err := newPricing(tx, s.currency, currency)
+		if err != nil {
+			return err
+		}
 
-			response, err := s.getOrderResponse(tx, order.ID)
-			if err != nil {
-				return err
+		var cart models.Cart
+		if err := tx.Preload("CartItems.Product").Where("token = ?", token).First(&cart).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCartNotFound
 			}
+			return err
+		}
+		cart.ShippingRateID = req.ShippingRateID
+
+		var shipping models.Address
+		applyAddressRequest(&shipping, &req.ShippingAddress)
 
-			orderResponse = response
+		billing := shipping
+		if req.BillingAddress != nil {
+			applyAddressRequest(&billing, req.BillingAddress)
 		}
 
-		return nil // Transaction successful
+		order := models.Order{
+			GuestEmail:      strings.ToLower(strings.TrimSpace(req.Email)),
+			ShippingAddress: shipping.Snapshot(),
+			BillingAddress:  billing.Snapshot(),
+		}
+		quote, err = s.priceOrder(tx, &order, &cart, prices)
+		if err != nil {
+			return err
+		}
+
+		if err := s.placeOrder(tx, &order, &cart, quote); err != nil {
+			return err
+		}
+
+		response, err := s.getOrderResponse(tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		orderResponse = response
+
+		return nil
 	})
 
 	if err != nil {
+		s.voidAuthorization(quote)
 		return nil, err
 	}
 
+	s.publishGuestOrderPlaced(orderResponse)
+
 	return orderResponse, nil
+}
+
+// LookupGuestOrder finds a guest order by its number and the email it was
+// placed with. Both must match, so an order number alone reveals nothing.
+func (s *OrderService) LookupGuestOrder(req *dto.GuestOrderLookupRequest) (*dto.OrderResponse, error) {
+	var order models.Order
+	if err := s.db.Select("id").
+		Where("number = ? AND LOWER(guest_email) = ?", strings.TrimSpace(req.Number), strings.ToLower(strings.TrimSpace(req.Email))).
+		First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return s.getOrderResponse(s.db, order.ID)
+}
+
+// publishGuestOrderPlaced asks for the order number to be emailed to the
+// guest. A failure is only logged as the order is already placed.
+func (s *OrderService) publishGuestOrderPlaced(order *dto.OrderResponse) {
+	metadata := map[string]string{
+		"order_id":     strconv.FormatUint(uint64(order.ID), 10),
+		"order_number": order.Number,
+		"email":        order.GuestEmail,
+	}
 
+	if err := s.eventPublisher.Publish(notifications.GuestOrderPlaced, order, metadata); err != nil {
+		log.Printf("unable to publish guest order placed event: %v", err)
+	}
+}
+
+// orderQuote is what pricing a cart yields besides the order itself: the
+// coupon to redeem and the promotions to record once the order is placed.
+// useWallet is set by the caller to pay what it can of the order from the
+// customer's wallet, redeemPoints to spend up to that many of the customer's
+// loyalty points on it, and idempotencyKey to have the payment provider
+// authorize the order only once however often it is placed; without one the
+// order itself keys its authorization. placeOrder sets authorization to the
+// reference of the payment it authorized, which voidAuthorization voids
+// should the order be rolled back.
+type orderQuote struct {
+	couponID       *uint
+	couponDiscount money.Money
+	promotions     []models.OrderPromotion
+	useWallet      bool
+	redeemPoints   int
+	idempotencyKey string
+	authorization  string
+}
+
+// priceOrder prices the cart into the order, which comes with its customer
+// and addresses set, checking that its items are in stock. Nothing is
+// written; placeOrder places the priced order.
+func (s *OrderService) priceOrder(tx *gorm.DB, order *models.Order, cart *models.Cart, prices *pricing) (*orderQuote, error) {
+	if len(cart.CartItems) == 0 {
+		return nil, errors.New("cart is empty")
+	}
+
+	if err := prices.cart(tx, cart); err != nil {
+		return nil, err
+	}
+
+	shipping, err := s.orderShipping(tx, cart, &order.ShippingAddress, prices)
+	if err != nil {
+		return nil, err
+	}
+
+	shippingCost := money.Zero(prices.currency)
+	if shipping != nil {
+		shippingCost = shipping.Cost
+	}
+
+	coupon, err := s.orderCoupon(tx, order.CustomerID(), cart, prices)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := activePromotions(tx)
+	if err != nil {
+		return nil, err
+	}
+	prices.promotions(rules)
+
+	discount := cartDiscounts(cart, rules, coupon, shippingCost)
+
+	if err := checkStock(tx, cart); err != nil {
+		return nil, err
+	}
+
+	var orderItems []models.OrderItem
+	for i := range cart.CartItems {
+		cartItem := &cart.CartItems[i]
+
+		orderItems = append(orderItems, models.OrderItem{
+			ProductID: cartItem.ProductID,
+			Quantity:  cartItem.Quantity,
+			Price:     cartItem.Product.Price,
+			Discount:  discount.lines[i],
+			TaxClass:  taxClassOrDefault(cartItem.Product.TaxClass),
+		})
+	}
+
+	taxes, err := s.applyTaxes(&order.ShippingAddress, orderItems)
+	if err != nil {
+		return nil, err
+	}
+
+	order.TotalAmount = taxes.GrossTotal
+	order.TaxAmount = taxes.TaxTotal
+	order.PricesIncludeTax = s.taxCalculator.Pricing() == tax.PricingInclusive
+	order.ExchangeRate = prices.rate
+	order.OrderItems = orderItems
+	order.DiscountAmount = discount.total
+
+	if shipping != nil {
+		order.ShippingMethod = shipping.Method
+		order.ShippingCost = shipping.Cost
+		order.TotalAmount = order.TotalAmount.Add(shipping.Cost)
+	}
+
+	quote := &orderQuote{couponDiscount: money.Zero(prices.currency)}
+
+	if coupon != nil {
+		order.CouponCode = coupon.Code
+		order.TotalAmount = order.TotalAmount.Sub(discount.shipping)
+		quote.couponID = &coupon.ID
+		quote.couponDiscount = discount.coupon
+	}
+
+	for _, applied := range discount.promotions.Applied {
+		quote.promotions = append(quote.promotions, models.OrderPromotion{
+			PromotionID: applied.PromotionID,
+			Name:        applied.Name,
+			Discount:    applied.Discount,
+		})
+	}
+
+	return quote, nil
+}
+
+// placeOrder places the order priced from the cart: it redeems the coupon,
+// takes the items out of stock, marking those that wait for stock and
+// keeping the name and SKU their product had, spends
+// the loyalty points the quote asks for, pays what it asks of the wallet and
+// authorizes the payment of the rest. The cart is emptied once the order is
+// placed.
+func (s *OrderService) placeOrder(tx *gorm.DB, order *models.Order, cart *models.Cart, quote *orderQuote) error {
+	if quote.couponID != nil {
+		if err := claimCoupon(tx, *quote.couponID, order.CustomerID()); err != nil {
+			return err
+		}
+	}
+
+	// The order items are matched to the cart items by product, as an order
+	// placed from a checkout session lists them in the order of the session
+	cartItems := make(map[uint]*models.CartItem, len(cart.CartItems))
+	for i := range cart.CartItems {
+		cartItems[cart.CartItems[i].ProductID] = &cart.CartItems[i]
+	}
+
+	now := time.Now()
+	for i := range order.OrderItems {
+		cartItem, ok := cartItems[order.OrderItems[i].ProductID]
+		if !ok {
+			return ErrCheckoutCartChanged
+		}
+
+		stockLeft, err := takeStock(tx, cartItem)
+		if err != nil {
+			return err
+		}
+
+		markStockStatus(&order.OrderItems[i], &cartItem.Product, stockLeft, now)
+		order.OrderItems[i].ProductName = cartItem.Product.Name
+		order.OrderItems[i].SKU = cartItem.Product.SKU
+	}
+
+	order.WalletAmount = money.Zero(order.TotalAmount.Currency)
+	order.LoyaltyDiscount = money.Zero(order.TotalAmount.Currency)
+
+	if quote.redeemPoints > 0 {
+		order.LoyaltyPoints, order.LoyaltyDiscount = s.loyalty.redemption(quote.redeemPoints, order.TotalAmount, order.ExchangeRate)
+	}
+
+	var wallet *models.Wallet
+	var walletDebit money.Money
+	if quote.useWallet {
+		var err error
+		wallet, err = lockWallet(tx, order.CustomerID(), s.currency)
+		if err != nil {
+			return err
+		}
+
+		order.WalletAmount, walletDebit = walletPayment(wallet.Balance, order.TotalAmount.Sub(order.LoyaltyDiscount), order.ExchangeRate)
+	}
+
+	number, err := models.NewOrderNumber()
+	if err != nil {
+		return err
+	}
+
+	order.Number = number
+	order.Status = models.OrderStatusPending
+
+	if err := tx.Create(order).Error; err != nil {
+		return err
+	}
+
+	if quote.couponID != nil {
+		redemption := models.CouponRedemption{
+			CouponID: *quote.couponID,
+			UserID:   order.CustomerID(),
+			OrderID:  order.ID,
+			Discount: quote.couponDiscount,
+		}
+		if err := tx.Create(&redemption).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, promotion := range quote.promotions {
+		promotion.OrderID = order.ID
+		if err := tx.Create(&promotion).Error; err != nil {
+			return err
+		}
+	}
+
+	if order.LoyaltyPoints > 0 {
+		if err := s.loyalty.spend(tx, order.CustomerID(), order.ID, order.LoyaltyPoints, now); err != nil {
+			return err
+		}
+	}
+
+	if walletDebit.IsPositive() {
+		if err := postWalletEntry(tx, wallet, &models.WalletEntry{
+			Type:    models.WalletEntryDebit,
+			Source:  models.WalletEntryOrder,
+			Amount:  walletDebit,
+			OrderID: &order.ID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	idempotencyKey := quote.idempotencyKey
+	if idempotencyKey == "" {
+		idempotencyKey = fmt.Sprintf("order-%d", order.ID)
+	}
+
+	authorization, err := s.authorizePayment(tx, order, idempotencyKey)
+	quote.authorization = authorization
+	if err != nil {
+		return err
+	}
+
+	// A cart that is not stored, like a subscription's, has nothing to clear
+	if cart.ID == 0 {
+		return nil
+	}
+
+	// Clear cart
+	if err := tx.Unscoped().Where("cart_id = ?", cart.ID).Delete(&models.CartItem{}).Error; err != nil {
+		return err
+	}
+
+	// The stock is taken, so the cart no longer needs to hold it
+	if err := releaseStock(tx, cart.ID); err != nil {
+		return err
+	}
+
+	if cart.ShippingRateID != nil {
+		if err := tx.Model(&models.Cart{}).Where("id = ?", cart.ID).Update("shipping_rate_id", nil).Error; err != nil {
+			return err
+		}
+	}
+
+	if cart.CouponID != nil {
+		if err := tx.Model(&models.Cart{}).Where("id = ?", cart.ID).Update("coupon_id", nil).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (s *OrderService) GetOrders(userID uint, page, limit int) ([]dto.OrderResponse, *utils.PaginationMeta, error) {
+	return s.listOrders(func(db *gorm.DB) *gorm.DB {
+		return db.Where("user_id = ?", userID)
+	}, "created_at DESC", page, limit)
+}
+
+// orderSorts maps the sort keys of ListOrders to their columns.
+var orderSorts = map[string]string{
+	"created_at": "orders.created_at",
+	"total":      "orders.total_amount",
+	"status":     "orders.status",
+}
+
+// ListOrders lists the orders of every customer matching the request's
+// filters.
+func (s *OrderService) ListOrders(req *dto.ListOrdersRequest) ([]dto.OrderResponse, *utils.PaginationMeta, error) {
+	filter, sort, err := orderFilter(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.listOrders(filter, sort, req.Page, req.Limit)
+}
+
+// ExportOrders writes every order matching the request's filters, with its
+// items, sorted like ListOrders; paging is ignored. The orders are read row
+// by row from a single cursor over orders joined to their items and each
+// order is written as soon as its last item is read, so an export of any
+// size only holds one order in memory.
+func (s *OrderService) ExportOrders(req *dto.ListOrdersRequest, w exports.Writer) error {
+	filter, sort, err := orderFilter(req)
+	if err != nil {
+		return err
+	}
+
+	rows, err := s.db.Model(&models.Order{}).
+		Select(`orders.id, orders.status, orders.user_id, COALESCE(users.email, orders.guest_email) AS email,
+			orders.total_amount, orders.total_currency, orders.shipping_cost_amount, orders.shipping_cost_currency,
+			orders.discount_amount, orders.discount_currency, orders.tax_amount, orders.tax_currency,
+			orders.exchange_rate, orders.created_at,
+			COALESCE(order_items.id, 0) AS item_id, COALESCE(order_items.product_id, 0) AS product_id,
+			COALESCE(products.sku, '') AS sku, COALESCE(products.name, '') AS product_name,
+			COALESCE(order_items.quantity, 0) AS quantity,
+			COALESCE(order_items.price_amount, 0) AS item_price_amount, COALESCE(order_items.price_currency, '') AS item_price_currency,
+			COALESCE(order_items.discount_amount, 0) AS item_discount_amount, COALESCE(order_items.discount_currency, '') AS item_discount_currency,
+			COALESCE(order_items.tax_rate, 0) AS item_tax_rate,
+			COALESCE(order_items.tax_amount, 0) AS item_tax_amount, COALESCE(order_items.tax_currency, '') AS item_tax_currency`).
+		Joins("LEFT JOIN users ON users.id = orders.user_id").
+		Joins("LEFT JOIN order_items ON order_items.order_id = orders.id AND order_items.deleted_at IS NULL").
+		Joins("LEFT JOIN products ON products.id = order_items.product_id").
+		Scopes(filter).
+		Order(sort + ", order_items.id").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var order *exports.Order
+	for rows.Next() {
+		var row orderExportRow
+		if err := s.db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+
+		if order == nil || order.ID != row.ID {
+			if order != nil {
+				if err := w.Write(order); err != nil {
+					return err
+				}
+			}
+			order = row.order()
+		}
+
+		if row.ItemID != 0 {
+			order.Items = append(order.Items, row.item())
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if order != nil {
+		if err := w.Write(order); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// orderExportRow is one row of the order export cursor: an order with one of
+// its items, or with an empty item when it has none.
+type orderExportRow struct {
+	ID             uint
+	Status         string
+	UserID         uint
+	Email          string
+	TotalAmount    money.Money `gorm:"embedded;embeddedPrefix:total_"`
+	ShippingCost   money.Money `gorm:"embedded;embeddedPrefix:shipping_cost_"`
+	DiscountAmount money.Money `gorm:"embedded;embeddedPrefix:discount_"`
+	TaxAmount      money.Money `gorm:"embedded;embeddedPrefix:tax_"`
+	ExchangeRate   float64
+	CreatedAt      time.Time
+	ItemID         uint
+	ProductID      uint
+	SKU            string
+	ProductName    string
+	Quantity       int
+	ItemPrice      money.Money `gorm:"embedded;embeddedPrefix:item_price_"`
+	ItemDiscount   money.Money `gorm:"embedded;embeddedPrefix:item_discount_"`
+	ItemTaxRate    float64
+	ItemTax        money.Money `gorm:"embedded;embeddedPrefix:item_tax_"`
+}
+
+func (r *orderExportRow) order() *exports.Order {
+	return &exports.Order{
+		ID:             r.ID,
+		Status:         r.Status,
+		CustomerID:     r.UserID,
+		CustomerEmail:  r.Email,
+		TotalAmount:    r.TotalAmount,
+		ShippingCost:   r.ShippingCost,
+		DiscountAmount: r.DiscountAmount,
+		TaxAmount:      r.TaxAmount,
+		ExchangeRate:   r.ExchangeRate,
+		CreatedAt:      r.CreatedAt,
+	}
+}
+
+func (r *orderExportRow) item() exports.Item {
+	return exports.Item{
+		ID:          r.ItemID,
+		ProductID:   r.ProductID,
+		SKU:         r.SKU,
+		ProductName: r.ProductName,
+		Quantity:    r.Quantity,
+		UnitPrice:   r.ItemPrice,
+		Discount:    r.ItemDiscount,
+		TaxRate:     r.ItemTaxRate,
+		TaxAmount:   r.ItemTax,
+	}
+}
+
+// orderFilter checks the filters and sort of an order listing and turns them
+// into a scope and an ORDER BY clause.
+func orderFilter(req *dto.ListOrdersRequest) (func(db *gorm.DB) *gorm.DB, string, error) {
+	if req.Status != "" && !models.OrderStatus(req.Status).IsValid() {
+		return nil, "", ErrInvalidOrderStatus
+	}
+
+	if req.CreatedFrom != nil && req.CreatedTo != nil && req.CreatedFrom.After(*req.CreatedTo) {
+		return nil, "", fmt.Errorf("%w: created_from is after created_to", ErrInvalidOrderFilter)
+	}
+
+	if req.MinTotal != nil && req.MaxTotal != nil {
+		if !req.MinTotal.SameCurrency(*req.MaxTotal) {
+			return nil, "", fmt.Errorf("%w: min_total and max_total are in different currencies", ErrInvalidOrderFilter)
+		}
+		if req.MinTotal.Amount > req.MaxTotal.Amount {
+			return nil, "", fmt.Errorf("%w: min_total is above max_total", ErrInvalidOrderFilter)
+		}
+	}
+
+	sort := strings.TrimPrefix(req.Sort, "-")
+	if sort == "" {
+		sort = "created_at"
+	}
+
+	column, ok := orderSorts[sort]
+	if !ok {
+		return nil, "", fmt.Errorf("%w: cannot sort by %q", ErrInvalidOrderFilter, req.Sort)
+	}
+
+	direction := "ASC"
+	if req.Sort == "" || strings.HasPrefix(req.Sort, "-") {
+		direction = "DESC"
+	}
+
+	filter := func(db *gorm.DB) *gorm.DB {
+		if req.Status != "" {
+			db = db.Where("orders.status = ?", req.Status)
+		}
+
+		if req.CreatedFrom != nil {
+			db = db.Where("orders.created_at >= ?", *req.CreatedFrom)
+		}
+
+		if req.CreatedTo != nil {
+			db = db.Where("orders.created_at <= ?", *req.CreatedTo)
+		}
+
+		if email := strings.TrimSpace(req.Email); email != "" {
+			db = db.Where("orders.user_id IN (SELECT id FROM users WHERE LOWER(email) = LOWER(?)) OR LOWER(orders.guest_email) = LOWER(?)", email, email)
+		}
+
+		for _, total := range []*money.Money{req.MinTotal, req.MaxTotal} {
+			if total != nil && total.Currency != "" {
+				db = db.Where("orders.total_currency = ?", total.Currency)
+				break
+			}
+		}
+
+		if req.MinTotal != nil {
+			db = db.Where("orders.total_amount >= ?", req.MinTotal.Amount)
+		}
+
+		if req.MaxTotal != nil {
+			db = db.Where("orders.total_amount <= ?", req.MaxTotal.Amount)
+		}
+
+		if sku := strings.TrimSpace(req.SKU); sku != "" {
+			db = db.Where(`EXISTS (SELECT 1 FROM order_items JOIN products ON products.id = order_items.product_id
+				WHERE order_items.order_id = orders.id AND order_items.deleted_at IS NULL AND products.sku = ?)`, sku)
+		}
+
+		return db
+	}
+
+	return filter, column + " " + direction + ", orders.id " + direction, nil
+}
+
+// listOrders pages through the orders matching filter, sorted by sort.
+func (s *OrderService) listOrders(filter func(db *gorm.DB) *gorm.DB, sort string, page, limit int) ([]dto.OrderResponse, *utils.PaginationMeta, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -114,11 +690,13 @@ func (s *OrderService) GetOrders(userID uint, page, limit int) ([]dto.OrderRespo
 	var orders []models.Order
 	var total int64
 
-	s.db.Model(&models.Order{}).Where("user_id = ?", userID).Count(&total)
+	if err := s.db.Model(&models.Order{}).Scopes(filter).Count(&total).Error; err != nil {
+		return nil, nil, err
+	}
 
-	if err := s.db.Preload("OrderItems.Product.Category").
-		Where("user_id = ?", userID).
-		Order("created_at DESC").
+	if err := s.db.Preload("OrderItems.Product.Category").Preload("Payments").Preload("Promotions").
+		Scopes(filter).
+		Order(sort).
 		Offset(offset).Limit(limit).
 		Find(&orders).Error; err != nil {
 		return nil, nil, err
@@ -143,7 +721,7 @@ func (s *OrderService) GetOrders(userID uint, page, limit int) ([]dto.OrderRespo
 
 func (s *OrderService) GetOrder(userID, orderID uint) (*dto.OrderResponse, error) {
 	var order models.Order
-	if err := s.db.Preload("OrderItems.Product.Category").
+	if err := s.db.Preload("OrderItems.Product.Category").Preload("Payments").Preload("Promotions").
 		Where("id = ? AND user_id = ?", orderID, userID).
 		First(&order).Error; err != nil {
 		return nil, err
@@ -154,9 +732,542 @@ func (s *OrderService) GetOrder(userID, orderID uint) (*dto.OrderResponse, error
 	return &response, nil
 }
 
+// GetOrderDetail returns any order with its customer and the full history of
+// its status changes, oldest first.
+func (s *OrderService) GetOrderDetail(orderID uint) (*dto.AdminOrderResponse, error) {
+	var order models.Order
+	if err := s.db.Preload("OrderItems.Product.Category").Preload("Payments").Preload("Promotions").
+		Preload("StatusHistory", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at, id")
+		}).
+		Preload("User").
+		First(&order, orderID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	history := make([]dto.OrderStatusHistoryResponse, len(order.StatusHistory))
+	for i := range order.StatusHistory {
+		change := &order.StatusHistory[i]
+
+		history[i] = dto.OrderStatusHistoryResponse{
+			ID:         change.ID,
+			FromStatus: string(change.FromStatus),
+			ToStatus:   string(change.ToStatus),
+			ChangedBy:  change.ChangedBy,
+			Reason:     change.Reason,
+			CreatedAt:  change.CreatedAt,
+		}
+	}
+
+	return &dto.AdminOrderResponse{
+		OrderResponse: s.convertToOrderResponse(&order),
+		Customer: dto.UserResponse{
+			ID:        order.User.ID,
+			Email:     order.User.Email,
+			FirstName: order.User.FirstName,
+			LastName:  order.User.LastName,
+			Phone:     order.User.Phone,
+			Role:      string(order.User.Role),
+			IsActive:  order.User.IsActive,
+			CreatedAt: order.User.CreatedAt,
+			UpdatedAt: order.User.UpdatedAt,
+		},
+		StatusHistory: history,
+	}, nil
+}
+
+// UpdateOrderStatus moves an order to a new status if the transition table
+// allows it and records the change in the order's status history.
+// Confirming an order issues its invoice. The statuses that follow from the
+// order's shipments cannot be set by hand.
+func (s *OrderService) UpdateOrderStatus(orderID, changedBy uint, req *dto.UpdateOrderStatusRequest) (*dto.OrderResponse, error) {
+	next := models.OrderStatus(req.Status)
+	if !next.IsValid() {
+		return nil, ErrInvalidOrderStatus
+	}
+	if next.IsShipmentStatus() {
+		return nil, fmt.Errorf("%w: %s is set by the order's shipments", ErrInvalidOrderStatus, next)
+	}
+
+	var orderResponse *dto.OrderResponse
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var order models.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrOrderNotFound
+			}
+			return err
+		}
+
+		var err error
+		if next == models.OrderStatusCancelled {
+			err = s.cancelOrder(tx, &order, changedBy, req.Reason)
+		} else {
+			err = s.transitionOrder(tx, &order, next, changedBy, req.Reason)
+		}
+		if err != nil {
+			return err
+		}
+
+		response, err := s.getOrderResponse(tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		orderResponse = response
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch next {
+	case models.OrderStatusCancelled:
+		s.publishOrderCancelled(orderResponse, changedBy)
+	case models.OrderStatusConfirmed:
+		s.renderInvoice(orderID)
+	}
+
+	return orderResponse, nil
+}
+
+// CancelOrder lets a customer cancel one of their own orders while it is
+// still pending or confirmed. The ordered quantities are put back on stock.
+func (s *OrderService) CancelOrder(userID, orderID uint, req *dto.CancelOrderRequest) (*dto.OrderResponse, error) {
+	var orderResponse *dto.OrderResponse
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var order models.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", orderID, userID).
+			First(&order).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrOrderNotFound
+			}
+			return err
+		}
+
+		if err := s.cancelOrder(tx, &order, userID, req.Reason); err != nil {
+			return err
+		}
+
+		response, err := s.getOrderResponse(tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		orderResponse = response
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishOrderCancelled(orderResponse, userID)
+
+	return orderResponse, nil
+}
+
+// cancelOrder moves a locked order to cancelled and restores the stock of
+// every item on it within the caller's transaction.
+func (s *OrderService) cancelOrder(tx *gorm.DB, order *models.Order, changedBy uint, reason string) error {
+	if err := s.transitionOrder(tx, order, models.OrderStatusCancelled, changedBy, reason); err != nil {
+		return err
+	}
+
+	var items []models.OrderItem
+	if err := tx.Where("order_id = ?", order.ID).Find(&items).Error; err != nil {
+		return err
+	}
+
+	for i := range items {
+		if err := tx.Model(&models.Product{}).
+			Where("id = ?", items[i].ProductID).
+			UpdateColumn("stock", gorm.Expr("stock + ?", items[i].Quantity)).Error; err != nil {
+			return err
+		}
+	}
+
+	return releaseCoupon(tx, order.ID)
+}
+
+// releaseCoupon gives back the coupon use the order claimed, so that it
+// counts neither towards the coupon's usage limit nor towards the customer's.
+func releaseCoupon(tx *gorm.DB, orderID uint) error {
+	var redemptions []models.CouponRedemption
+	if err := tx.Clauses(clause.Returning{}).Where("order_id = ?", orderID).Delete(&redemptions).Error; err != nil {
+		return err
+	}
+
+	for _, redemption := range redemptions {
+		if err := tx.Model(&models.Coupon{}).
+			Where("id = ? AND used_count > 0", redemption.CouponID).
+			UpdateColumn("used_count", gorm.Expr("used_count - 1")).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *OrderService) publishOrderCancelled(order *dto.OrderResponse, cancelledBy uint) {
+	metadata := map[string]string{
+		"order_id":     strconv.FormatUint(uint64(order.ID), 10),
+		"user_id":      strconv.FormatUint(uint64(order.UserID), 10),
+		"cancelled_by": strconv.FormatUint(uint64(cancelledBy), 10),
+	}
+
+	if err := s.eventPublisher.Publish(notifications.OrderCancelled, order, metadata); err != nil {
+		log.Printf("unable to publish order cancelled event: %v", err)
+	}
+}
+
+// transitionOrder changes the status of an already locked order, appends
+// the change to its history and settles its payments.
+func (s *OrderService) transitionOrder(tx *gorm.DB, order *models.Order, next models.OrderStatus, changedBy uint, reason string) error {
+	if err := changeOrderStatus(tx, order, next, changedBy, reason); err != nil {
+		return err
+	}
+
+	if err := s.settlePayments(tx, order, next); err != nil {
+		return err
+	}
+
+	// Confirming the order issues its invoice
+	if next == models.OrderStatusConfirmed {
+		return issueInvoice(tx, order.ID)
+	}
+
+	return nil
+}
+
+// changeOrderStatus moves an already locked order to next if the transition
+// table allows it and appends the change to the order's history.
+func changeOrderStatus(tx *gorm.DB, order *models.Order, next models.OrderStatus, changedBy uint, reason string) error {
+	if !order.Status.CanTransitionTo(next) {
+		return &InvalidStatusTransitionError{From: order.Status, To: next}
+	}
+
+	previous := order.Status
+	if err := tx.Model(order).Update("status", next).Error; err != nil {
+		return err
+	}
+
+	history := models.OrderStatusHistory{
+		OrderID:    order.ID,
+		FromStatus: previous,
+		ToStatus:   next,
+		ChangedBy:  changedBy,
+		Reason:     reason,
+	}
+
+	return tx.Create(&history).Error
+}
+
+// renderInvoice renders the document of a newly issued invoice. The order
+// change is already committed, so a failure is only logged; the document is
+// rendered again when the invoice is first fetched or regenerated.
+func (s *OrderService) renderInvoice(orderID uint) {
+	if _, err := s.invoiceService.GenerateInvoice(orderID); err != nil {
+		log.Printf("unable to render the invoice of order %d: %v", orderID, err)
+	}
+}
+
+// orderAddresses snapshots the shipping and billing addresses picked for a new order.
+func (s *OrderService) orderAddresses(tx *gorm.DB, userID uint, req *dto.CreateOrderRequest) (shipping, billing models.OrderAddress, err error) {
+	if req == nil {
+		return shipping, billing, nil
+	}
+
+	if req.ShippingAddressID != nil {
+		address, err := findUserAddress(tx, userID, *req.ShippingAddressID)
+		if err != nil {
+			return shipping, billing, err
+		}
+		shipping = address.Snapshot()
+	}
+
+	billing = shipping
+	if req.BillingAddressID != nil {
+		address, err := findUserAddress(tx, userID, *req.BillingAddressID)
+		if err != nil {
+			return shipping, billing, err
+		}
+		billing = address.Snapshot()
+	}
+
+	return shipping, billing, nil
+}
+
+// orderShipping quotes the shipping method selected on the cart for the
+// order's shipping address. Carts without a selected method ship for free.
+func (s *OrderService) orderShipping(tx *gorm.DB, cart *models.Cart, address *models.OrderAddress, prices *pricing) (*dto.ShippingOptionResponse, error) {
+	if cart.ShippingRateID == nil {
+		return nil, nil
+	}
+
+	if address.IsZero() {
+		return nil, ErrShippingAddressRequired
+	}
+
+	options, err := shippingOptions(tx, cart, address, prices)
+	if err != nil {
+		return nil, err
+	}
+
+	option, ok := findShippingOption(options, *cart.ShippingRateID)
+	if !ok {
+		return nil, ErrShippingUnavailable
+	}
+
+	return option, nil
+}
+
+// orderCoupon checks the coupon applied to the cart. Carts without a coupon
+// get no discount.
+func (s *OrderService) orderCoupon(tx *gorm.DB, userID uint, cart *models.Cart, prices *pricing) (*models.Coupon, error) {
+	if cart.CouponID == nil {
+		return nil, nil
+	}
+
+	var coupon models.Coupon
+	if err := tx.Preload("Products").Preload("Categories").First(&coupon, *cart.CouponID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: the coupon is no longer available", ErrCouponNotApplicable)
+		}
+		return nil, err
+	}
+	prices.coupon(&coupon)
+
+	if err := checkCoupon(tx, &coupon, cart, userID); err != nil {
+		return nil, err
+	}
+
+	return &coupon, nil
+}
+
+// claimCoupon claims one use of the coupon for an order of the user. The
+// coupon is locked while the user's redemptions are counted, so that
+// concurrent orders of the same customer cannot redeem it beyond its
+// per-customer limit, and the use is claimed with a conditional update so
+// that orders cannot redeem it beyond its usage limit.
+func claimCoupon(tx *gorm.DB, couponID, userID uint) error {
+	var coupon models.Coupon
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&coupon, couponID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: the coupon is no longer available", ErrCouponNotApplicable)
+		}
+		return err
+	}
+
+	if coupon.PerCustomerLimit > 0 {
+		var redemptions int64
+		if err := tx.Model(&models.CouponRedemption{}).
+			Where("coupon_id = ? AND user_id = ?", couponID, userID).
+			Count(&redemptions).Error; err != nil {
+			return err
+		}
+		if redemptions >= int64(coupon.PerCustomerLimit) {
+			return fmt.Errorf("%w: the coupon has already been used the maximum number of times", ErrCouponNotApplicable)
+		}
+	}
+
+	result := tx.Model(&models.Coupon{}).
+		Where("id = ? AND (usage_limit = 0 OR used_count < usage_limit)", couponID).
+		UpdateColumn("used_count", gorm.Expr("used_count + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: the coupon has reached its usage limit", ErrCouponNotApplicable)
+	}
+
+	return nil
+}
+
+// applyTaxes taxes the order items, net of their discount, for the shipping
+// address, recording the rate and tax of every line on the item.
+func (s *OrderService) applyTaxes(address *models.OrderAddress, items []models.OrderItem) (*tax.Result, error) {
+	lines := make([]tax.Line, len(items))
+	for i := range items {
+		lines[i] = tax.Line{
+			ID:       items[i].ProductID,
+			TaxClass: items[i].TaxClass,
+			Amount:   items[i].Price.Mul(items[i].Quantity).Sub(items[i].Discount),
+		}
+	}
+
+	result, err := s.taxCalculator.Calculate(&tax.Request{
+		Address: tax.Address{
+			Country:    address.Country,
+			State:      address.State,
+			PostalCode: address.PostalCode,
+		},
+		Lines: lines,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		items[i].TaxRate = result.Lines[i].Rate
+		items[i].TaxAmount = result.Lines[i].TaxAmount
+	}
+
+	return result, nil
+}
+
+// authorizePayment reserves the part of the order total not paid by wallet
+// or loyalty points with the payment provider and records the authorization against the order.
+// It returns the reference of the authorization, if one was needed.
+func (s *OrderService) authorizePayment(tx *gorm.DB, order *models.Order, idempotencyKey string) (string, error) {
+	amount := order.TotalAmount.Sub(order.WalletAmount).Sub(order.LoyaltyDiscount)
+	if !amount.IsPositive() {
+		return "", nil
+	}
+
+	result, err := s.paymentProvider.Authorize(&payments.AuthorizeRequest{
+		OrderID:        order.ID,
+		UserID:         order.CustomerID(),
+		Amount:         amount,
+		IdempotencyKey: idempotencyKey,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	payment := models.Payment{
+		OrderID:   order.ID,
+		Provider:  s.paymentProvider.Name(),
+		Reference: result.Reference,
+		Status:    models.PaymentStatusAuthorized,
+		Amount:    result.Amount,
+	}
+
+	if err := tx.Create(&payment).Error; err != nil {
+		return result.Reference, err
+	}
+
+	return result.Reference, nil
+}
+
+// voidAuthorization voids the payment authorized for the quote's order once
+// the order has been rolled back, so that no hold is left on the customer's
+// card for an order that does not exist. A failure is only logged.
+func (s *OrderService) voidAuthorization(quote *orderQuote) {
+	if quote == nil || quote.authorization == "" {
+		return
+	}
+
+	if _, err := s.paymentProvider.Void(quote.authorization); err != nil {
+		log.Printf("unable to void payment authorization %s: %v", quote.authorization, err)
+	}
+	quote.authorization = ""
+}
+
+// settlePayments follows an order status change at the payment provider:
+// confirming an order captures its authorized payments, and cancelling it
+// voids payments that were only authorized and refunds captured ones. What a
+// cancelled order took from the wallet is credited back to it, and the
+// loyalty points spent on it are restored.
+func (s *OrderService) settlePayments(tx *gorm.DB, order *models.Order, next models.OrderStatus) error {
+	if next != models.OrderStatusConfirmed && next != models.OrderStatusCancelled {
+		return nil
+	}
+
+	var orderPayments []models.Payment
+	if err := tx.Where("order_id = ?", order.ID).Find(&orderPayments).Error; err != nil {
+		return err
+	}
+
+	for i := range orderPayments {
+		payment := &orderPayments[i]
+
+		switch {
+		case next == models.OrderStatusConfirmed && payment.Status == models.PaymentStatusAuthorized:
+			result, err := s.paymentProvider.Capture(payment.Reference, payment.Amount)
+			if err != nil {
+				return err
+			}
+			payment.Status = models.PaymentStatusCaptured
+			payment.CapturedAmount = result.Amount
+		case next == models.OrderStatusCancelled && payment.Status == models.PaymentStatusAuthorized:
+			if _, err := s.paymentProvider.Void(payment.Reference); err != nil {
+				return err
+			}
+			payment.Status = models.PaymentStatusVoided
+		case next == models.OrderStatusCancelled && payment.Status == models.PaymentStatusCaptured:
+			result, err := s.paymentProvider.Refund(payment.Reference, payment.CapturedAmount.Sub(payment.RefundedAmount))
+			if err != nil {
+				return err
+			}
+			payment.Status = models.PaymentStatusRefunded
+			payment.RefundedAmount = payment.RefundedAmount.Add(result.Amount)
+		default:
+			continue
+		}
+
+		if err := tx.Save(payment).Error; err != nil {
+			return err
+		}
+	}
+
+	if next != models.OrderStatusCancelled {
+		return nil
+	}
+
+	if order.LoyaltyPoints > 0 {
+		if err := s.loyalty.restoreSpent(tx, order, order.LoyaltyPoints, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	if order.WalletAmount.IsPositive() {
+		return s.refundWalletPayment(tx, order)
+	}
+
+	return nil
+}
+
+// refundWalletPayment credits the wallet with what the order took from it.
+func (s *OrderService) refundWalletPayment(tx *gorm.DB, order *models.Order) error {
+	var debits []models.WalletEntry
+	if err := tx.Where("order_id = ? AND type = ?", order.ID, models.WalletEntryDebit).Find(&debits).Error; err != nil {
+		return err
+	}
+
+	if len(debits) == 0 {
+		return nil
+	}
+
+	wallet, err := lockWallet(tx, order.CustomerID(), s.currency)
+	if err != nil {
+		return err
+	}
+
+	refund := money.Zero(wallet.Balance.Currency)
+	for _, debit := range debits {
+		refund = refund.Add(debit.Amount)
+	}
+
+	return postWalletEntry(tx, wallet, &models.WalletEntry{
+		Type:    models.WalletEntryCredit,
+		Source:  models.WalletEntryOrderCancelled,
+		Amount:  refund,
+		OrderID: &order.ID,
+	})
+}
+
 func (s *OrderService) getOrderResponse(tx *gorm.DB, orderID uint) (*dto.OrderResponse, error) {
 	var order models.Order
-	if err := tx.Preload("OrderItems.Product.Category").First(&order, orderID).Error; err != nil {
+	if err := tx.Preload("OrderItems.Product.Category").Preload("Payments").Preload("Promotions").First(&order, orderID).Error; err != nil {
 		return nil, err
 	}
 
@@ -180,6 +1291,11 @@ func (s *OrderService) convertToOrderResponse(order *models.Order) dto.OrderResp
 				Price:       item.Product.Price,
 				Stock:       item.Product.Stock,
 				SKU:         item.Product.SKU,
+				Weight:      item.Product.Weight,
+				Length:      item.Product.Length,
+				Width:       item.Product.Width,
+				Height:      item.Product.Height,
+				TaxClass:    item.Product.TaxClass,
 				IsActive:    item.Product.IsActive,
 				Category: dto.CategoryResponse{
 					ID:          item.Product.Category.ID,
@@ -188,20 +1304,87 @@ func (s *OrderService) convertToOrderResponse(order *models.Order) dto.OrderResp
 					IsActive:    item.Product.Category.IsActive,
 				},
 			},
-			Quantity: item.Quantity,
-			Price:    item.Price,
+			Quantity:  item.Quantity,
+			Price:     item.Price,
+			Discount:  item.Discount,
+			TaxClass:  item.TaxClass,
+			TaxRate:   item.TaxRate,
+			TaxAmount: item.TaxAmount,
 
 			CreatedAt: item.CreatedAt,
+
+			StockStatus:         string(item.StockStatus),
+			BackorderedQuantity: item.BackorderedQuantity,
+			AvailableAt:         item.AvailableAt,
+		}
+	}
+
+	orderPayments := make([]dto.PaymentResponse, len(order.Payments))
+	for i := range order.Payments {
+		payment := &order.Payments[i]
+
+		orderPayments[i] = dto.PaymentResponse{
+			ID:             payment.ID,
+			Provider:       payment.Provider,
+			Reference:      payment.Reference,
+			Status:         string(payment.Status),
+			Amount:         payment.Amount,
+			CapturedAmount: payment.CapturedAmount,
+			RefundedAmount: payment.RefundedAmount,
+			CreatedAt:      payment.CreatedAt,
+		}
+	}
+
+	orderPromotions := make([]dto.AppliedPromotionResponse, len(order.Promotions))
+	for i := range order.Promotions {
+		orderPromotions[i] = dto.AppliedPromotionResponse{
+			PromotionID: order.Promotions[i].PromotionID,
+			Name:        order.Promotions[i].Name,
+			Discount:    order.Promotions[i].Discount,
 		}
 	}
 
 	return dto.OrderResponse{
-		ID:          order.ID,
-		UserID:      order.UserID,
-		Status:      string(order.Status),
-		TotalAmount: order.TotalAmount,
-		OrderItems:  orderItems,
-		CreatedAt:   order.CreatedAt,
-		UpdatedAt:   order.UpdatedAt,
+		ID:               order.ID,
+		Number:           order.Number,
+		UserID:           order.CustomerID(),
+		GuestEmail:       order.GuestEmail,
+		Status:           string(order.Status),
+		TotalAmount:      order.TotalAmount,
+		ShippingMethod:   order.ShippingMethod,
+		ShippingCost:     order.ShippingCost,
+		CouponCode:       order.CouponCode,
+		DiscountAmount:   order.DiscountAmount,
+		TaxAmount:        order.TaxAmount,
+		WalletAmount:     order.WalletAmount,
+		LoyaltyPoints:    order.LoyaltyPoints,
+		LoyaltyDiscount:  order.LoyaltyDiscount,
+		PricesIncludeTax: order.PricesIncludeTax,
+		ExchangeRate:     order.ExchangeRate,
+		ShippingAddress:  convertToOrderAddressResponse(order.ShippingAddress),
+		BillingAddress:   convertToOrderAddressResponse(order.BillingAddress),
+		OrderItems:       orderItems,
+		Payments:         orderPayments,
+		Promotions:       orderPromotions,
+		CreatedAt:        order.CreatedAt,
+		UpdatedAt:        order.UpdatedAt,
+	}
+}
+
+func convertToOrderAddressResponse(address models.OrderAddress) *dto.OrderAddressResponse {
+	if address.IsZero() {
+		return nil
+	}
+
+	return &dto.OrderAddressResponse{
+		FirstName:  address.FirstName,
+		LastName:   address.LastName,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		State:      address.State,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+		Phone:      address.Phone,
 	}
 }
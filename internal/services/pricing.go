@@ -0,0 +1,150 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"gorm.io/gorm"
+)
+
+// pricing prices the catalogue, which is kept in the store currency, in the
+// currency a customer shops in. Prices are converted at the exchange rate an
+// admin set for the currency, unless the product has its own price in it.
+// Shipping rates, coupons and promotions are always converted.
+//
+// Amounts are converted on the loaded models only, which must not be saved
+// afterwards.
+type pricing struct {
+	store    money.Currency
+	currency money.Currency
+	rate     float64
+}
+
+// newPricing looks up the exchange rate of the currency. An empty currency
+// is the store currency, which needs no rate.
+func newPricing(db *gorm.DB, store, currency money.Currency) (*pricing, error) {
+	if currency == "" || currency == store {
+		return &pricing{store: store, currency: store, rate: 1}, nil
+	}
+
+	var rate models.ExchangeRate
+	if err := db.Where("currency = ?", currency).First(&rate).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrCurrencyNotAvailable, currency)
+		}
+		return nil, err
+	}
+
+	return &pricing{store: store, currency: currency, rate: rate.Rate}, nil
+}
+
+// converts reports whether prices change currency at all.
+func (p *pricing) converts() bool {
+	return p.currency != p.store
+}
+
+// convert converts an amount kept in the store currency.
+func (p *pricing) convert(amount money.Money) money.Money {
+	if !p.converts() || amount.Currency == p.currency {
+		return amount
+	}
+
+	if amount.Currency == "" {
+		amount.Currency = p.store
+	}
+
+	return amount.Convert(p.currency, p.rate)
+}
+
+// products prices the products in the currency.
+func (p *pricing) products(db *gorm.DB, products ...*models.Product) error {
+	if !p.converts() || len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(products))
+	for i := range products {
+		ids[i] = products[i].ID
+	}
+
+	var overrides []models.ProductPrice
+	if err := db.Where("product_id IN ? AND currency = ?", ids, p.currency).Find(&overrides).Error; err != nil {
+		return err
+	}
+
+	prices := make(map[uint]money.Money, len(overrides))
+	for i := range overrides {
+		prices[overrides[i].ProductID] = overrides[i].Price()
+	}
+
+	for _, product := range products {
+		if price, ok := prices[product.ID]; ok {
+			product.Price = price
+		} else {
+			product.Price = p.convert(product.Price)
+		}
+	}
+
+	return nil
+}
+
+// cart prices the cart's products, its selected shipping rate and its
+// coupon, as far as they are loaded, in the currency.
+func (p *pricing) cart(db *gorm.DB, cart *models.Cart) error {
+	products := make([]*models.Product, len(cart.CartItems))
+	for i := range cart.CartItems {
+		products[i] = &cart.CartItems[i].Product
+	}
+
+	if err := p.products(db, products...); err != nil {
+		return err
+	}
+
+	if cart.ShippingRate != nil {
+		p.shippingRate(cart.ShippingRate)
+	}
+
+	if cart.Coupon != nil {
+		p.coupon(cart.Coupon)
+	}
+
+	return nil
+}
+
+func (p *pricing) shippingRate(rate *models.ShippingRate) {
+	rate.Price = p.convert(rate.Price)
+	rate.FreeOver = p.convert(rate.FreeOver)
+}
+
+func (p *pricing) coupon(coupon *models.Coupon) {
+	coupon.FixedAmount = p.convert(coupon.FixedAmount)
+	coupon.MinSubtotal = p.convert(coupon.MinSubtotal)
+}
+
+func (p *pricing) promotions(rules []models.Promotion) {
+	for i := range rules {
+		rules[i].BundlePrice = p.convert(rules[i].BundlePrice)
+		for j := range rules[i].Tiers {
+			rules[i].Tiers[j].Threshold = p.convert(rules[i].Tiers[j].Threshold)
+			rules[i].Tiers[j].Amount = p.convert(rules[i].Tiers[j].Amount)
+		}
+	}
+}
+
+// priceColumn returns the SQL expression of a product's price in minor units
+// of the currency, for filtering on price, and its arguments. Converted
+// prices are only rounded by the database, so they may differ by a minor
+// unit from the prices shown.
+func (p *pricing) priceColumn() (string, []interface{}) {
+	if !p.converts() {
+		return "price_amount", nil
+	}
+
+	factor := p.rate * math.Pow10(p.currency.Exponent()-p.store.Exponent())
+
+	return "COALESCE((SELECT product_prices.amount FROM product_prices WHERE product_prices.product_id = products.id AND product_prices.currency = ?), ROUND(price_amount * CAST(? AS numeric)))",
+		[]interface{}{p.currency, factor}
+}
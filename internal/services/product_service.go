@@ -1,8 +1,12 @@
 package services
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/kuldeepstechwork/gocart-api/internal/dto"
 	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
 	"github.com/kuldeepstechwork/gocart-api/internal/utils"
 	"gorm.io/gorm"
 )
@@ -10,11 +14,12 @@ import (
 var _ ProductServiceInterface = (*ProductService)(nil)
 
 type ProductService struct {
-	db *gorm.DB
+	db       *gorm.DB
+	currency money.Currency
 }
 
-func NewProductService(db *gorm.DB) *ProductService {
-	return &ProductService{db: db}
+func NewProductService(db *gorm.DB, currency money.Currency) *ProductService {
+	return &ProductService{db: db, currency: currency}
 }
 
 func (s *ProductService) CreateCategory(req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error) {
@@ -92,23 +97,42 @@ func (s *ProductService) DeleteCategory(id uint) error {
 }
 
 func (s *ProductService) CreateProduct(req *dto.CreateProductRequest) (*dto.ProductResponse, error) {
+	if err := s.validatePrice(&req.Price); err != nil {
+		return nil, err
+	}
+
 	product := models.Product{
 		CategoryID:  req.CategoryID,
 		Name:        req.Name,
 		Description: req.Description,
 		Price:       req.Price,
 		Stock:       req.Stock,
+		Weight:      req.Weight,
+		Length:      req.Length,
+		Width:       req.Width,
+		Height:      req.Height,
+		TaxClass:    taxClassOrDefault(req.TaxClass),
 		SKU:         req.SKU,
 	}
 
+	if err := setInventoryPolicy(&product, req.InventoryPolicy, req.BackorderLimit, req.AvailableAt); err != nil {
+		return nil, err
+	}
+
 	if err := s.db.Create(&product).Error; err != nil {
 		return nil, err
 	}
 
-	return s.GetProduct(product.ID)
+	return s.GetProduct(product.ID, s.currency)
 }
 
-func (s *ProductService) GetProducts(page, limit int) ([]dto.ProductResponse, *utils.PaginationMeta, error) {
+// GetProducts lists the active products priced in the currency.
+func (s *ProductService) GetProducts(page, limit int, currency money.Currency) ([]dto.ProductResponse, *utils.PaginationMeta, error) {
+	prices, err := newPricing(s.db, s.currency, currency)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if page < 1 {
 		page = 1
 	}
@@ -130,6 +154,17 @@ func (s *ProductService) GetProducts(page, limit int) ([]dto.ProductResponse, *u
 		return nil, nil, err
 	}
 
+	priced := make([]*models.Product, len(products))
+	for i := range products {
+		priced[i] = &products[i]
+	}
+	if err := prices.products(s.db, priced...); err != nil {
+		return nil, nil, err
+	}
+	if err := availableStock(s.db, 0, priced...); err != nil {
+		return nil, nil, err
+	}
+
 	response := make([]dto.ProductResponse, len(products))
 	for i := range products {
 		response[i] = s.convertToProductResponse(&products[i])
@@ -146,17 +181,34 @@ func (s *ProductService) GetProducts(page, limit int) ([]dto.ProductResponse, *u
 	return response, meta, nil
 }
 
-func (s *ProductService) GetProduct(id uint) (*dto.ProductResponse, error) {
+// GetProduct returns the product priced in the currency.
+func (s *ProductService) GetProduct(id uint, currency money.Currency) (*dto.ProductResponse, error) {
+	prices, err := newPricing(s.db, s.currency, currency)
+	if err != nil {
+		return nil, err
+	}
+
 	var product models.Product
 	if err := s.db.Preload("Category").Preload("Images").First(&product, id).Error; err != nil {
 		return nil, err
 	}
 
+	if err := prices.products(s.db, &product); err != nil {
+		return nil, err
+	}
+	if err := availableStock(s.db, 0, &product); err != nil {
+		return nil, err
+	}
+
 	response := s.convertToProductResponse(&product)
 	return &response, nil
 }
 
 func (s *ProductService) UpdateProduct(id uint, req *dto.UpdateProductRequest) (*dto.ProductResponse, error) {
+	if err := s.validatePrice(&req.Price); err != nil {
+		return nil, err
+	}
+
 	var product models.Product
 	if err := s.db.First(&product, id).Error; err != nil {
 		return nil, err
@@ -167,15 +219,24 @@ func (s *ProductService) UpdateProduct(id uint, req *dto.UpdateProductRequest) (
 	product.Description = req.Description
 	product.Price = req.Price
 	product.Stock = req.Stock
+	product.Weight = req.Weight
+	product.Length = req.Length
+	product.Width = req.Width
+	product.Height = req.Height
+	product.TaxClass = taxClassOrDefault(req.TaxClass)
 	if req.IsActive != nil {
 		product.IsActive = *req.IsActive
 	}
 
+	if err := setInventoryPolicy(&product, req.InventoryPolicy, req.BackorderLimit, req.AvailableAt); err != nil {
+		return nil, err
+	}
+
 	if err := s.db.Save(&product).Error; err != nil {
 		return nil, err
 	}
 
-	return s.GetProduct(id)
+	return s.GetProduct(id, s.currency)
 }
 
 func (s *ProductService) DeleteProduct(id uint) error {
@@ -197,7 +258,13 @@ func (s *ProductService) AddProductImage(productID uint, url, altText string) er
 	return s.db.Create(&image).Error
 }
 
-func (s *ProductService) SearchProducts(req *dto.SearchProductsRequest) ([]dto.ProductSearchResult, *utils.PaginationMeta, error) {
+// SearchProducts searches the active products, priced in the currency. The
+// price range is in minor units of the currency too.
+func (s *ProductService) SearchProducts(req *dto.SearchProductsRequest, currency money.Currency) ([]dto.ProductSearchResult, *utils.PaginationMeta, error) {
+	prices, err := newPricing(s.db, s.currency, currency)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	if req.Page < 1 {
 		req.Page = 1
@@ -219,12 +286,18 @@ func (s *ProductService) SearchProducts(req *dto.SearchProductsRequest) ([]dto.P
 		query = query.Where("category_id = ?", *req.CategoryID)
 	}
 
+	if req.MinPrice != nil || req.MaxPrice != nil {
+		query = query.Where("price_currency = ?", s.currency)
+	}
+
+	price, args := prices.priceColumn()
+
 	if req.MinPrice != nil {
-		query = query.Where("price >= ?", *req.MinPrice)
+		query = query.Where(price+" >= ?", append(args, req.MinPrice.Amount)...)
 	}
 
 	if req.MaxPrice != nil {
-		query = query.Where("price <= ?", *req.MaxPrice)
+		query = query.Where(price+" <= ?", append(args, req.MaxPrice.Amount)...)
 	}
 
 	// Count total results
@@ -247,6 +320,17 @@ func (s *ProductService) SearchProducts(req *dto.SearchProductsRequest) ([]dto.P
 		return nil, nil, err
 	}
 
+	priced := make([]*models.Product, len(rows))
+	for i := range rows {
+		priced[i] = &rows[i].Product
+	}
+	if err := prices.products(s.db, priced...); err != nil {
+		return nil, nil, err
+	}
+	if err := availableStock(s.db, 0, priced...); err != nil {
+		return nil, nil, err
+	}
+
 	// Build output response
 	results := make([]dto.ProductSearchResult, len(rows))
 	for i := range rows {
@@ -288,6 +372,11 @@ func (s *ProductService) convertToProductResponse(product *models.Product) dto.P
 		Price:       product.Price,
 		Stock:       product.Stock,
 		SKU:         product.SKU,
+		Weight:      product.Weight,
+		Length:      product.Length,
+		Width:       product.Width,
+		Height:      product.Height,
+		TaxClass:    product.TaxClass,
 		IsActive:    product.IsActive,
 		Category: dto.CategoryResponse{
 			ID:          product.Category.ID,
@@ -300,5 +389,71 @@ func (s *ProductService) convertToProductResponse(product *models.Product) dto.P
 		Images:    images,
 		CreatedAt: product.CreatedAt,
 		UpdatedAt: product.UpdatedAt,
+
+		InventoryPolicy: string(product.InventoryPolicy),
+		BackorderLimit:  product.BackorderLimit,
+		AvailableAt:     product.AvailableAt,
+	}
+}
+
+// validatePrice puts the price in the store currency and checks it is positive.
+func (s *ProductService) validatePrice(price *money.Money) error {
+	if err := inCurrency(s.currency, price); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidProduct, err)
+	}
+
+	if !price.IsPositive() {
+		return fmt.Errorf("%w: price must be greater than 0", ErrInvalidProduct)
+	}
+
+	return nil
+}
+
+// setInventoryPolicy sets the inventory policy of the product, deny when none
+// is given. Products sold on backorder or preorder need a positive backorder
+// limit, and preordered ones an availability date; the others keep neither.
+func setInventoryPolicy(product *models.Product, policy string, backorderLimit int, availableAt *time.Time) error {
+	product.InventoryPolicy = models.InventoryPolicy(policy)
+	if policy == "" {
+		product.InventoryPolicy = models.InventoryPolicyDeny
+	}
+
+	if !product.InventoryPolicy.IsValid() {
+		return fmt.Errorf("%w: unknown inventory policy %q", ErrInvalidProduct, policy)
 	}
+
+	product.BackorderLimit = 0
+	product.AvailableAt = nil
+
+	if product.InventoryPolicy == models.InventoryPolicyDeny {
+		return nil
+	}
+
+	if backorderLimit < 1 {
+		return fmt.Errorf("%w: backorder limit must be greater than 0", ErrInvalidProduct)
+	}
+	product.BackorderLimit = backorderLimit
+
+	if product.InventoryPolicy == models.InventoryPolicyPreorder {
+		if availableAt == nil {
+			return fmt.Errorf("%w: preordered products need an availability date", ErrInvalidProduct)
+		}
+		product.AvailableAt = availableAt
+	}
+
+	return nil
+}
+
+// inCurrency puts amounts given without a currency in the store currency and
+// fails for amounts in any other currency.
+func inCurrency(currency money.Currency, amounts ...*money.Money) error {
+	for _, amount := range amounts {
+		converted, err := amount.In(currency)
+		if err != nil {
+			return err
+		}
+		*amount = converted
+	}
+
+	return nil
 }
@@ -0,0 +1,303 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/promotions"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ PromotionServiceInterface = (*PromotionService)(nil)
+
+type PromotionService struct {
+	db       *gorm.DB
+	currency money.Currency
+}
+
+// NewPromotionService creates the promotion service type
+func NewPromotionService(db *gorm.DB, currency money.Currency) *PromotionService {
+	return &PromotionService{db: db, currency: currency}
+}
+
+func (s *PromotionService) GetPromotions() ([]dto.PromotionResponse, error) {
+	var rules []models.Promotion
+	if err := s.db.Preload("Tiers", func(db *gorm.DB) *gorm.DB {
+		return db.Order("threshold")
+	}).Order("priority DESC, id").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.PromotionResponse, len(rules))
+	for i := range rules {
+		response[i] = s.convertToPromotionResponse(&rules[i])
+	}
+
+	return response, nil
+}
+
+func (s *PromotionService) CreatePromotion(req *dto.PromotionRequest) (*dto.PromotionResponse, error) {
+	if err := validatePromotion(req, s.currency); err != nil {
+		return nil, err
+	}
+
+	rule := models.Promotion{IsActive: true, Stackable: true}
+	applyPromotionRequest(&rule, req)
+	rule.Tiers = newPromotionTiers(req.Tiers)
+
+	if err := s.db.Create(&rule).Error; err != nil {
+		return nil, err
+	}
+
+	response := s.convertToPromotionResponse(&rule)
+
+	return &response, nil
+}
+
+// UpdatePromotion changes the promotion and replaces its tiers with the
+// requested ones.
+func (s *PromotionService) UpdatePromotion(promotionID uint, req *dto.PromotionRequest) (*dto.PromotionResponse, error) {
+	if err := validatePromotion(req, s.currency); err != nil {
+		return nil, err
+	}
+
+	var rule models.Promotion
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&rule, promotionID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrPromotionNotFound
+			}
+			return err
+		}
+
+		applyPromotionRequest(&rule, req)
+
+		if err := tx.Omit(clause.Associations).Save(&rule).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("promotion_id = ?", rule.ID).Delete(&models.PromotionTier{}).Error; err != nil {
+			return err
+		}
+
+		rule.Tiers = newPromotionTiers(req.Tiers)
+		for i := range rule.Tiers {
+			rule.Tiers[i].PromotionID = rule.ID
+		}
+
+		if len(rule.Tiers) > 0 {
+			return tx.Create(&rule.Tiers).Error
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.convertToPromotionResponse(&rule)
+
+	return &response, nil
+}
+
+func (s *PromotionService) DeletePromotion(promotionID uint) error {
+	result := s.db.Delete(&models.Promotion{}, promotionID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrPromotionNotFound
+	}
+
+	return nil
+}
+
+func (s *PromotionService) convertToPromotionResponse(rule *models.Promotion) dto.PromotionResponse {
+	tiers := make([]dto.PromotionTierResponse, len(rule.Tiers))
+	for i := range rule.Tiers {
+		tiers[i] = dto.PromotionTierResponse{
+			ID:        rule.Tiers[i].ID,
+			Threshold: rule.Tiers[i].Threshold,
+			Amount:    rule.Tiers[i].Amount,
+		}
+	}
+
+	return dto.PromotionResponse{
+		ID:              rule.ID,
+		Name:            rule.Name,
+		Description:     rule.Description,
+		Type:            string(rule.Type),
+		ProductID:       rule.ProductID,
+		CategoryID:      rule.CategoryID,
+		BuyQuantity:     rule.BuyQuantity,
+		GetQuantity:     rule.GetQuantity,
+		DiscountPercent: rule.DiscountPercent,
+		BundleQuantity:  rule.BundleQuantity,
+		BundlePrice:     rule.BundlePrice,
+		Tiers:           tiers,
+		Priority:        rule.Priority,
+		Stackable:       rule.Stackable,
+		StartsAt:        rule.StartsAt,
+		EndsAt:          rule.EndsAt,
+		IsActive:        rule.IsActive,
+		CreatedAt:       rule.CreatedAt,
+		UpdatedAt:       rule.UpdatedAt,
+	}
+}
+
+func validatePromotion(req *dto.PromotionRequest, currency money.Currency) error {
+	if err := inCurrency(currency, &req.BundlePrice); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPromotion, err)
+	}
+
+	for i := range req.Tiers {
+		if err := inCurrency(currency, &req.Tiers[i].Threshold, &req.Tiers[i].Amount); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPromotion, err)
+		}
+		if req.Tiers[i].Threshold.IsNegative() || !req.Tiers[i].Amount.IsPositive() {
+			return fmt.Errorf("%w: tiers need a threshold of at least 0 and an amount greater than 0", ErrInvalidPromotion)
+		}
+	}
+
+	switch models.PromotionType(req.Type) {
+	case models.PromotionBuyXGetY:
+		if req.BuyQuantity < 1 || req.GetQuantity < 1 {
+			return fmt.Errorf("%w: buy_quantity and get_quantity must be at least 1", ErrInvalidPromotion)
+		}
+		if req.DiscountPercent <= 0 {
+			return fmt.Errorf("%w: discount_percent must be greater than 0", ErrInvalidPromotion)
+		}
+	case models.PromotionSpendThreshold:
+		if len(req.Tiers) == 0 {
+			return fmt.Errorf("%w: at least one tier is required", ErrInvalidPromotion)
+		}
+	case models.PromotionBundle:
+		if req.BundleQuantity < 2 {
+			return fmt.Errorf("%w: bundle_quantity must be at least 2", ErrInvalidPromotion)
+		}
+		if req.BundlePrice.IsNegative() {
+			return fmt.Errorf("%w: bundle_price must not be negative", ErrInvalidPromotion)
+		}
+	case models.PromotionFlashSale:
+		if req.DiscountPercent <= 0 {
+			return fmt.Errorf("%w: discount_percent must be greater than 0", ErrInvalidPromotion)
+		}
+	default:
+		return fmt.Errorf("%w: unknown promotion type %q", ErrInvalidPromotion, req.Type)
+	}
+
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		return fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidPromotion)
+	}
+
+	return nil
+}
+
+func applyPromotionRequest(rule *models.Promotion, req *dto.PromotionRequest) {
+	rule.Name = req.Name
+	rule.Description = req.Description
+	rule.Type = models.PromotionType(req.Type)
+	rule.ProductID = req.ProductID
+	rule.CategoryID = req.CategoryID
+	rule.BuyQuantity = req.BuyQuantity
+	rule.GetQuantity = req.GetQuantity
+	rule.DiscountPercent = req.DiscountPercent
+	rule.BundleQuantity = req.BundleQuantity
+	rule.BundlePrice = req.BundlePrice
+	rule.Priority = req.Priority
+	rule.StartsAt = req.StartsAt
+	rule.EndsAt = req.EndsAt
+	if req.Stackable != nil {
+		rule.Stackable = *req.Stackable
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+}
+
+func newPromotionTiers(requests []dto.PromotionTierRequest) []models.PromotionTier {
+	tiers := make([]models.PromotionTier, len(requests))
+	for i, req := range requests {
+		tiers[i] = models.PromotionTier{Threshold: req.Threshold, Amount: req.Amount}
+	}
+
+	return tiers
+}
+
+// activePromotions loads the promotions switched on by an admin. Whether
+// they are running is decided when they are evaluated.
+func activePromotions(db *gorm.DB) ([]models.Promotion, error) {
+	var rules []models.Promotion
+	if err := db.Preload("Tiers").Where("is_active = ?", true).Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// cartDiscount is what the promotions and the coupon take off a cart.
+type cartDiscount struct {
+	promotions *promotions.Result
+
+	// lines holds the discount of every cart item, promotions and coupon together
+	lines []money.Money
+
+	// coupon is the coupon's part of the discount, including waived shipping
+	coupon   money.Money
+	shipping money.Money
+	total    money.Money
+}
+
+// cartDiscounts evaluates the promotions on the cart and then takes the
+// coupon, if any, off what the promotions left of the item prices. The
+// amounts are in the currency of the shipping cost, which is the cart's.
+func cartDiscounts(cart *models.Cart, rules []models.Promotion, coupon *models.Coupon, shippingCost money.Money) *cartDiscount {
+	result := promotions.Evaluate(cart, rules, time.Now())
+	zero := money.Zero(shippingCost.Currency)
+
+	discount := &cartDiscount{
+		promotions: result,
+		lines:      make([]money.Money, len(cart.CartItems)),
+		coupon:     zero,
+		shipping:   zero,
+		total:      zero.Add(result.Total),
+	}
+	copy(discount.lines, result.Lines)
+
+	if coupon != nil {
+		for i, line := range coupon.LineDiscounts(cart.CartItems, result.Lines) {
+			discount.lines[i] = discount.lines[i].Add(line)
+			discount.coupon = discount.coupon.Add(line)
+		}
+
+		if coupon.Type == models.CouponFreeShipping {
+			discount.shipping = shippingCost
+			discount.coupon = discount.coupon.Add(shippingCost)
+		}
+
+		discount.total = discount.total.Add(discount.coupon)
+	}
+
+	return discount
+}
+
+func convertToAppliedPromotions(applied []promotions.Applied) []dto.AppliedPromotionResponse {
+	response := make([]dto.AppliedPromotionResponse, len(applied))
+	for i := range applied {
+		response[i] = dto.AppliedPromotionResponse{
+			PromotionID: applied[i].PromotionID,
+			Name:        applied[i].Name,
+			Discount:    applied[i].Discount,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,529 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/payments"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ ReturnServiceInterface = (*ReturnService)(nil)
+
+type ReturnService struct {
+	db              *gorm.DB
+	currency        money.Currency
+	paymentProvider payments.PaymentProvider
+	loyalty         *LoyaltyService
+}
+
+// NewReturnService creates the return service type
+func NewReturnService(db *gorm.DB, currency money.Currency, paymentProvider payments.PaymentProvider, loyalty *LoyaltyService) *ReturnService {
+	return &ReturnService{db: db, currency: currency, paymentProvider: paymentProvider, loyalty: loyalty}
+}
+
+// RequestReturn opens a return for items of a delivered order. Each line is
+// refunded at the price paid for the order item, less its share of the
+// item's discount and including its share of the item's tax when that was
+// charged on top of the price. An item can never be returned more times than
+// it was ordered across all of the order's returns, nor be refunded more than
+// was paid for it, however the shares of its separate returns round.
+func (s *ReturnService) RequestReturn(userID, orderID uint, req *dto.CreateReturnRequest) (*dto.ReturnResponse, error) {
+	var returnResponse *dto.ReturnResponse
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var order models.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", orderID, userID).
+			First(&order).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrOrderNotFound
+			}
+			return err
+		}
+
+		if order.Status != models.OrderStatusDelivered {
+			return ErrOrderNotReturnable
+		}
+
+		var items []models.OrderItem
+		if err := tx.Where("order_id = ?", order.ID).Find(&items).Error; err != nil {
+			return err
+		}
+
+		orderItems := make(map[uint]*models.OrderItem, len(items))
+		for i := range items {
+			orderItems[items[i].ID] = &items[i]
+		}
+
+		returned, err := s.returnedItems(tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		refundAmount := money.Zero(order.TotalAmount.Currency)
+		lines := make([]models.ReturnLine, 0, len(req.Items))
+
+		for _, line := range req.Items {
+			item, ok := orderItems[line.OrderItemID]
+			if !ok {
+				return fmt.Errorf("%w: order item %d is not part of this order", ErrInvalidReturnItem, line.OrderItemID)
+			}
+
+			itemReturned := returned[item.ID]
+			returnable := item.Quantity - itemReturned.Quantity
+			if line.Quantity < 1 || line.Quantity > returnable {
+				return fmt.Errorf("%w: at most %d of order item %d can be returned", ErrInvalidReturnItem, returnable, item.ID)
+			}
+
+			quantity := int64(line.Quantity)
+			lineRefund := item.Price.Mul(line.Quantity).Sub(item.Discount.MulRat(quantity, int64(item.Quantity)))
+			itemPaid := item.Price.Mul(item.Quantity).Sub(item.Discount)
+			if !order.PricesIncludeTax {
+				lineRefund = lineRefund.Add(item.TaxAmount.MulRat(quantity, int64(item.Quantity)))
+				itemPaid = itemPaid.Add(item.TaxAmount)
+			}
+			lineRefund = money.Min(lineRefund, itemPaid.Sub(money.New(itemReturned.RefundAmount, itemPaid.Currency)))
+			refundAmount = refundAmount.Add(lineRefund)
+
+			itemReturned.Quantity += line.Quantity
+			itemReturned.RefundAmount += lineRefund.Amount
+			returned[item.ID] = itemReturned
+
+			lines = append(lines, models.ReturnLine{
+				OrderItemID:  item.ID,
+				Quantity:     line.Quantity,
+				Reason:       line.Reason,
+				RefundAmount: lineRefund,
+			})
+		}
+
+		ret := models.Return{
+			OrderID:            order.ID,
+			UserID:             userID,
+			Status:             models.ReturnStatusRequested,
+			Reason:             req.Reason,
+			RefundAmount:       refundAmount,
+			WalletRefundAmount: money.Zero(refundAmount.Currency),
+			Lines:              lines,
+		}
+
+		if err := tx.Create(&ret).Error; err != nil {
+			return err
+		}
+
+		response, err := s.getReturnResponse(tx, ret.ID)
+		if err != nil {
+			return err
+		}
+
+		returnResponse = response
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return returnResponse, nil
+}
+
+func (s *ReturnService) GetReturns(userID uint, page, limit int) ([]dto.ReturnResponse, *utils.PaginationMeta, error) {
+	return s.listReturns(func(db *gorm.DB) *gorm.DB {
+		return db.Where("user_id = ?", userID)
+	}, page, limit)
+}
+
+// ListReturns lists the returns of every customer, optionally narrowed to one status.
+func (s *ReturnService) ListReturns(status string, page, limit int) ([]dto.ReturnResponse, *utils.PaginationMeta, error) {
+	return s.listReturns(func(db *gorm.DB) *gorm.DB {
+		if status != "" {
+			return db.Where("status = ?", status)
+		}
+		return db
+	}, page, limit)
+}
+
+func (s *ReturnService) ApproveReturn(returnID uint) (*dto.ReturnResponse, error) {
+	return s.transitionReturn(returnID, models.ReturnStatusApproved, nil)
+}
+
+// RejectReturn turns down a requested return. Its items may be returned again.
+func (s *ReturnService) RejectReturn(returnID uint) (*dto.ReturnResponse, error) {
+	return s.transitionReturn(returnID, models.ReturnStatusRejected, nil)
+}
+
+// CancelReturn withdraws one of the user's returns that has not been received
+// yet. Its items may be returned again.
+func (s *ReturnService) CancelReturn(userID, returnID uint) (*dto.ReturnResponse, error) {
+	return s.transitionUserReturn(userID, returnID, models.ReturnStatusCancelled)
+}
+
+// ReceiveReturn records that the returned items arrived back, optionally
+// putting the returned quantities back on stock.
+func (s *ReturnService) ReceiveReturn(returnID uint, req *dto.ReceiveReturnRequest) (*dto.ReturnResponse, error) {
+	return s.transitionReturn(returnID, models.ReturnStatusReceived, func(tx *gorm.DB, ret *models.Return) error {
+		if !req.Restock {
+			return nil
+		}
+
+		var lines []models.ReturnLine
+		if err := tx.Preload("OrderItem").Where("return_id = ?", ret.ID).Find(&lines).Error; err != nil {
+			return err
+		}
+
+		for i := range lines {
+			if err := tx.Model(&models.Product{}).
+				Where("id = ?", lines[i].OrderItem.ProductID).
+				UpdateColumn("stock", gorm.Expr("stock + ?", lines[i].Quantity)).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(ret).Update("restocked", true).Error
+	})
+}
+
+// RefundReturn pays the return's refund amount back through the order's
+// captured payments. The part of the order paid by wallet, which no payment
+// can take back, is credited back to the user's wallet, and the part paid
+// with loyalty points is given back as points. With ToWallet the whole
+// refund is credited to the wallet instead. Either way the share of the
+// points the order earned that the refund pays back is taken back.
+// The refunds at the payment provider are sent last, once the rest of the
+// refund has been recorded, so that a refund the order cannot cover fails
+// before any money is paid out.
+func (s *ReturnService) RefundReturn(returnID uint, req *dto.RefundReturnRequest) (*dto.ReturnResponse, error) {
+	return s.transitionReturn(returnID, models.ReturnStatusRefunded, func(tx *gorm.DB, ret *models.Return) error {
+		var order models.Order
+		if err := tx.First(&order, ret.OrderID).Error; err != nil {
+			return err
+		}
+
+		if req.ToWallet {
+			if err := s.refundToWallet(tx, &order, ret, ret.RefundAmount); err != nil {
+				return err
+			}
+
+			return s.loyalty.reverseEarned(tx, &order, ret.RefundAmount)
+		}
+
+		refunds, remaining, err := s.planPaymentRefunds(tx, ret.OrderID, ret.RefundAmount)
+		if err != nil {
+			return err
+		}
+
+		if remaining.IsPositive() {
+			var walletRefunded int64
+			if err := tx.Model(&models.Return{}).
+				Where("order_id = ? AND id <> ?", ret.OrderID, ret.ID).
+				Select("COALESCE(SUM(wallet_refund_amount), 0)").
+				Scan(&walletRefunded).Error; err != nil {
+				return err
+			}
+
+			toWallet := money.Min(remaining, money.New(order.WalletAmount.Amount-walletRefunded, remaining.Currency))
+			if toWallet.IsPositive() {
+				if err := s.refundToWallet(tx, &order, ret, toWallet); err != nil {
+					return err
+				}
+				remaining = remaining.Sub(toWallet)
+			}
+		}
+
+		if remaining.IsPositive() {
+			if err := s.restorePoints(tx, &order, ret, remaining); err != nil {
+				return err
+			}
+		}
+
+		if err := s.loyalty.reverseEarned(tx, &order, ret.RefundAmount); err != nil {
+			return err
+		}
+
+		return s.refundPayments(tx, refunds)
+	})
+}
+
+// restorePoints gives back the loyalty points spent on the order for amount
+// of the return's refund.
+func (s *ReturnService) restorePoints(tx *gorm.DB, order *models.Order, ret *models.Return, amount money.Money) error {
+	points, err := s.loyalty.restoreRefund(tx, order, amount, time.Now())
+	if err != nil {
+		return err
+	}
+
+	ret.LoyaltyPointsRestored = points
+
+	return tx.Model(&models.Return{}).Where("id = ?", ret.ID).Update("loyalty_points_restored", points).Error
+}
+
+// refundToWallet credits amount of the return's refund, in the order
+// currency, to the user's wallet.
+func (s *ReturnService) refundToWallet(tx *gorm.DB, order *models.Order, ret *models.Return, amount money.Money) error {
+	wallet, err := lockWallet(tx, ret.UserID, s.currency)
+	if err != nil {
+		return err
+	}
+
+	if err := postWalletEntry(tx, wallet, &models.WalletEntry{
+		Type:     models.WalletEntryCredit,
+		Source:   models.WalletEntryReturn,
+		Amount:   storeAmount(amount, s.currency, order.ExchangeRate),
+		OrderID:  &ret.OrderID,
+		ReturnID: &ret.ID,
+	}); err != nil {
+		return err
+	}
+
+	ret.WalletRefundAmount = amount
+
+	return tx.Model(&models.Return{}).Where("id = ?", ret.ID).Updates(map[string]interface{}{
+		"wallet_refund_amount":   amount.Amount,
+		"wallet_refund_currency": amount.Currency,
+	}).Error
+}
+
+// transitionReturn locks a return, moves it to next and runs apply within the
+// same transaction so that a failing side effect leaves the status unchanged.
+func (s *ReturnService) transitionReturn(returnID uint, next models.ReturnStatus, apply func(tx *gorm.DB, ret *models.Return) error) (*dto.ReturnResponse, error) {
+	return s.transitionReturnWhere(func(db *gorm.DB) *gorm.DB {
+		return db
+	}, returnID, next, apply)
+}
+
+// transitionUserReturn moves one of the user's returns to next.
+func (s *ReturnService) transitionUserReturn(userID, returnID uint, next models.ReturnStatus) (*dto.ReturnResponse, error) {
+	return s.transitionReturnWhere(func(db *gorm.DB) *gorm.DB {
+		return db.Where("user_id = ?", userID)
+	}, returnID, next, nil)
+}
+
+func (s *ReturnService) transitionReturnWhere(filter func(db *gorm.DB) *gorm.DB, returnID uint, next models.ReturnStatus, apply func(tx *gorm.DB, ret *models.Return) error) (*dto.ReturnResponse, error) {
+	var returnResponse *dto.ReturnResponse
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var ret models.Return
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(filter).First(&ret, returnID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrReturnNotFound
+			}
+			return err
+		}
+
+		if !ret.Status.CanTransitionTo(next) {
+			return &InvalidReturnTransitionError{From: ret.Status, To: next}
+		}
+
+		if err := tx.Model(&ret).Update("status", next).Error; err != nil {
+			return err
+		}
+
+		if apply != nil {
+			if err := apply(tx, &ret); err != nil {
+				return err
+			}
+		}
+
+		response, err := s.getReturnResponse(tx, ret.ID)
+		if err != nil {
+			return err
+		}
+
+		returnResponse = response
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return returnResponse, nil
+}
+
+// paymentRefund is the part of a refund one captured payment pays back.
+type paymentRefund struct {
+	payment *models.Payment
+	amount  money.Money
+}
+
+// planPaymentRefunds splits amount across the order's captured payments,
+// oldest first, and returns what the payments cannot take back.
+func (s *ReturnService) planPaymentRefunds(tx *gorm.DB, orderID uint, amount money.Money) ([]paymentRefund, money.Money, error) {
+	var orderPayments []models.Payment
+	if err := tx.Where("order_id = ? AND status = ?", orderID, models.PaymentStatusCaptured).
+		Order("id").
+		Find(&orderPayments).Error; err != nil {
+		return nil, money.Money{}, err
+	}
+
+	var refunds []paymentRefund
+	remaining := amount
+	for i := range orderPayments {
+		if !remaining.IsPositive() {
+			break
+		}
+
+		payment := &orderPayments[i]
+		refundable := payment.CapturedAmount.Sub(payment.RefundedAmount)
+		if !refundable.IsPositive() {
+			continue
+		}
+
+		refund := money.Min(refundable, remaining)
+		refunds = append(refunds, paymentRefund{payment: payment, amount: refund})
+		remaining = remaining.Sub(refund)
+	}
+
+	return refunds, remaining, nil
+}
+
+// refundPayments sends the planned refunds to the payment provider and
+// records them on the payments.
+func (s *ReturnService) refundPayments(tx *gorm.DB, refunds []paymentRefund) error {
+	for _, refund := range refunds {
+		payment := refund.payment
+
+		result, err := s.paymentProvider.Refund(payment.Reference, refund.amount)
+		if err != nil {
+			return err
+		}
+
+		payment.RefundedAmount = payment.RefundedAmount.Add(result.Amount)
+		if payment.RefundedAmount.Cmp(payment.CapturedAmount) >= 0 {
+			payment.Status = models.PaymentStatusRefunded
+		}
+
+		if err := tx.Save(payment).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// returnedItem is how much of an order item is already on the order's returns.
+type returnedItem struct {
+	Quantity     int
+	RefundAmount int64
+}
+
+// returnedItems sums, per order item, the quantities already on returns for
+// the order and their refunds, leaving out returns that were rejected or
+// cancelled.
+func (s *ReturnService) returnedItems(tx *gorm.DB, orderID uint) (map[uint]returnedItem, error) {
+	var rows []struct {
+		OrderItemID  uint
+		Quantity     int
+		RefundAmount int64
+	}
+
+	if err := tx.Model(&models.ReturnLine{}).
+		Select("return_lines.order_item_id, SUM(return_lines.quantity) AS quantity, SUM(return_lines.refund_amount) AS refund_amount").
+		Joins("JOIN returns ON returns.id = return_lines.return_id AND returns.deleted_at IS NULL").
+		Where("returns.order_id = ? AND returns.status NOT IN ?", orderID, []models.ReturnStatus{models.ReturnStatusRejected, models.ReturnStatusCancelled}).
+		Group("return_lines.order_item_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	returned := make(map[uint]returnedItem, len(rows))
+	for _, row := range rows {
+		returned[row.OrderItemID] = returnedItem{Quantity: row.Quantity, RefundAmount: row.RefundAmount}
+	}
+
+	return returned, nil
+}
+
+func (s *ReturnService) listReturns(filter func(db *gorm.DB) *gorm.DB, page, limit int) ([]dto.ReturnResponse, *utils.PaginationMeta, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = 10
+	}
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+	var returns []models.Return
+	var total int64
+
+	if err := s.db.Model(&models.Return{}).Scopes(filter).Count(&total).Error; err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.db.Scopes(filter).Preload("Lines.OrderItem").
+		Order("created_at DESC").
+		Offset(offset).Limit(limit).
+		Find(&returns).Error; err != nil {
+		return nil, nil, err
+	}
+
+	response := make([]dto.ReturnResponse, len(returns))
+	for i := range returns {
+		response[i] = s.convertToReturnResponse(&returns[i])
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	meta := &utils.PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	return response, meta, nil
+}
+
+func (s *ReturnService) getReturnResponse(tx *gorm.DB, returnID uint) (*dto.ReturnResponse, error) {
+	var ret models.Return
+	if err := tx.Preload("Lines.OrderItem").First(&ret, returnID).Error; err != nil {
+		return nil, err
+	}
+
+	response := s.convertToReturnResponse(&ret)
+
+	return &response, nil
+}
+
+func (s *ReturnService) convertToReturnResponse(ret *models.Return) dto.ReturnResponse {
+	lines := make([]dto.ReturnLineResponse, len(ret.Lines))
+	for i := range ret.Lines {
+		line := &ret.Lines[i]
+
+		lines[i] = dto.ReturnLineResponse{
+			ID:           line.ID,
+			OrderItemID:  line.OrderItemID,
+			ProductID:    line.OrderItem.ProductID,
+			Quantity:     line.Quantity,
+			Price:        line.OrderItem.Price,
+			RefundAmount: line.RefundAmount,
+			Reason:       line.Reason,
+		}
+	}
+
+	return dto.ReturnResponse{
+		ID:                    ret.ID,
+		OrderID:               ret.OrderID,
+		UserID:                ret.UserID,
+		Status:                string(ret.Status),
+		Reason:                ret.Reason,
+		RefundAmount:          ret.RefundAmount,
+		WalletRefundAmount:    ret.WalletRefundAmount,
+		LoyaltyPointsRestored: ret.LoyaltyPointsRestored,
+		Restocked:             ret.Restocked,
+		Lines:                 lines,
+		CreatedAt:             ret.CreatedAt,
+		UpdatedAt:             ret.UpdatedAt,
+	}
+}
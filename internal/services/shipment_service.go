@@ -0,0 +1,354 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ ShipmentServiceInterface = (*ShipmentService)(nil)
+
+type ShipmentService struct {
+	db *gorm.DB
+}
+
+// NewShipmentService creates the shipment service type
+func NewShipmentService(db *gorm.DB) *ShipmentService {
+	return &ShipmentService{db: db}
+}
+
+// CreateShipment records a parcel sent for items of a confirmed or partially
+// shipped order. An item can never be shipped more times than it was ordered
+// across all of the order's shipments. The order becomes shipped once every
+// item is covered and partially shipped until then.
+func (s *ShipmentService) CreateShipment(orderID, adminID uint, req *dto.CreateShipmentRequest) (*dto.ShipmentResponse, error) {
+	var shipmentResponse *dto.ShipmentResponse
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var order models.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrOrderNotFound
+			}
+			return err
+		}
+
+		if order.Status != models.OrderStatusConfirmed && order.Status != models.OrderStatusPartiallyShipped {
+			return ErrOrderNotShippable
+		}
+
+		var items []models.OrderItem
+		if err := tx.Where("order_id = ?", order.ID).Find(&items).Error; err != nil {
+			return err
+		}
+
+		shipped, err := s.shippedQuantities(tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		lines, err := s.shipmentLines(items, shipped, req.Items)
+		if err != nil {
+			return err
+		}
+
+		shippedAt := time.Now()
+		if req.ShippedAt != nil {
+			shippedAt = *req.ShippedAt
+		}
+
+		shipment := models.Shipment{
+			OrderID:        order.ID,
+			Carrier:        req.Carrier,
+			TrackingNumber: req.TrackingNumber,
+			ShippedAt:      shippedAt,
+			Lines:          lines,
+		}
+
+		if err := tx.Create(&shipment).Error; err != nil {
+			return err
+		}
+
+		reason := fmt.Sprintf("shipment %d sent with %s", shipment.ID, shipment.Carrier)
+		if err := s.syncOrderStatus(tx, &order, adminID, reason); err != nil {
+			return err
+		}
+
+		response, err := s.getShipmentResponse(tx, shipment.ID)
+		if err != nil {
+			return err
+		}
+
+		shipmentResponse = response
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return shipmentResponse, nil
+}
+
+// UpdateShipment changes the carrier and tracking number of a shipment or
+// marks it delivered. The order becomes delivered once it is fully shipped
+// and every one of its shipments is delivered.
+func (s *ShipmentService) UpdateShipment(shipmentID, adminID uint, req *dto.UpdateShipmentRequest) (*dto.ShipmentResponse, error) {
+	var shipmentResponse *dto.ShipmentResponse
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var shipment models.Shipment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&shipment, shipmentID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrShipmentNotFound
+			}
+			return err
+		}
+
+		if req.Carrier != nil {
+			shipment.Carrier = *req.Carrier
+		}
+
+		if req.TrackingNumber != nil {
+			shipment.TrackingNumber = *req.TrackingNumber
+		}
+
+		delivered := req.DeliveredAt != nil && !shipment.IsDelivered()
+		if req.DeliveredAt != nil {
+			shipment.DeliveredAt = req.DeliveredAt
+		}
+
+		if err := tx.Save(&shipment).Error; err != nil {
+			return err
+		}
+
+		if delivered {
+			var order models.Order
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, shipment.OrderID).Error; err != nil {
+				return err
+			}
+
+			reason := fmt.Sprintf("shipment %d delivered", shipment.ID)
+			if err := s.syncOrderStatus(tx, &order, adminID, reason); err != nil {
+				return err
+			}
+		}
+
+		response, err := s.getShipmentResponse(tx, shipment.ID)
+		if err != nil {
+			return err
+		}
+
+		shipmentResponse = response
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return shipmentResponse, nil
+}
+
+// ListShipments lists the shipments of any order.
+func (s *ShipmentService) ListShipments(orderID uint) ([]dto.ShipmentResponse, error) {
+	var order models.Order
+	if err := s.db.Select("id").First(&order, orderID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return s.listShipments(order.ID)
+}
+
+// GetShipments lists the shipments of one of the user's own orders.
+func (s *ShipmentService) GetShipments(userID, orderID uint) ([]dto.ShipmentResponse, error) {
+	var order models.Order
+	if err := s.db.Select("id").Where("id = ? AND user_id = ?", orderID, userID).First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return s.listShipments(order.ID)
+}
+
+// shipmentLines checks the requested items against what is left to ship of
+// the order. Without any requested items, everything left is shipped.
+func (s *ShipmentService) shipmentLines(items []models.OrderItem, shipped map[uint]int, requested []dto.ShipmentItemRequest) ([]models.ShipmentLine, error) {
+	if len(requested) == 0 {
+		lines := make([]models.ShipmentLine, 0, len(items))
+		for i := range items {
+			if remaining := items[i].Quantity - shipped[items[i].ID]; remaining > 0 {
+				lines = append(lines, models.ShipmentLine{OrderItemID: items[i].ID, Quantity: remaining})
+			}
+		}
+
+		if len(lines) == 0 {
+			return nil, fmt.Errorf("%w: every item of the order has been shipped", ErrInvalidShipmentItem)
+		}
+
+		return lines, nil
+	}
+
+	orderItems := make(map[uint]*models.OrderItem, len(items))
+	for i := range items {
+		orderItems[items[i].ID] = &items[i]
+	}
+
+	lines := make([]models.ShipmentLine, 0, len(requested))
+	for _, line := range requested {
+		item, ok := orderItems[line.OrderItemID]
+		if !ok {
+			return nil, fmt.Errorf("%w: order item %d is not part of this order", ErrInvalidShipmentItem, line.OrderItemID)
+		}
+
+		remaining := item.Quantity - shipped[item.ID]
+		if line.Quantity < 1 || line.Quantity > remaining {
+			return nil, fmt.Errorf("%w: at most %d of order item %d can be shipped", ErrInvalidShipmentItem, remaining, item.ID)
+		}
+		shipped[item.ID] += line.Quantity
+
+		lines = append(lines, models.ShipmentLine{OrderItemID: item.ID, Quantity: line.Quantity})
+	}
+
+	return lines, nil
+}
+
+// syncOrderStatus derives the status of a locked order from its shipments:
+// partially shipped while items are left to ship, shipped once every item is
+// covered and delivered once every shipment is delivered too.
+func (s *ShipmentService) syncOrderStatus(tx *gorm.DB, order *models.Order, changedBy uint, reason string) error {
+	var items []models.OrderItem
+	if err := tx.Where("order_id = ?", order.ID).Find(&items).Error; err != nil {
+		return err
+	}
+
+	var shipments []models.Shipment
+	if err := tx.Preload("Lines").Where("order_id = ?", order.ID).Find(&shipments).Error; err != nil {
+		return err
+	}
+
+	shipped := make(map[uint]int, len(items))
+	delivered := true
+	for i := range shipments {
+		for _, line := range shipments[i].Lines {
+			shipped[line.OrderItemID] += line.Quantity
+		}
+		delivered = delivered && shipments[i].IsDelivered()
+	}
+
+	complete := true
+	for i := range items {
+		if shipped[items[i].ID] < items[i].Quantity {
+			complete = false
+			break
+		}
+	}
+
+	next := models.OrderStatusPartiallyShipped
+	switch {
+	case complete && delivered:
+		next = models.OrderStatusDelivered
+	case complete:
+		next = models.OrderStatusShipped
+	}
+
+	if next == order.Status {
+		return nil
+	}
+
+	return changeOrderStatus(tx, order, next, changedBy, reason)
+}
+
+// shippedQuantities sums, per order item, the quantities already on shipments for the order.
+func (s *ShipmentService) shippedQuantities(tx *gorm.DB, orderID uint) (map[uint]int, error) {
+	var rows []struct {
+		OrderItemID uint
+		Quantity    int
+	}
+
+	if err := tx.Model(&models.ShipmentLine{}).
+		Select("shipment_lines.order_item_id, SUM(shipment_lines.quantity) AS quantity").
+		Joins("JOIN shipments ON shipments.id = shipment_lines.shipment_id AND shipments.deleted_at IS NULL").
+		Where("shipments.order_id = ?", orderID).
+		Group("shipment_lines.order_item_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	shipped := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		shipped[row.OrderItemID] = row.Quantity
+	}
+
+	return shipped, nil
+}
+
+func (s *ShipmentService) listShipments(orderID uint) ([]dto.ShipmentResponse, error) {
+	var shipments []models.Shipment
+	if err := s.db.Preload("Lines.OrderItem").
+		Where("order_id = ?", orderID).
+		Order("shipped_at, id").
+		Find(&shipments).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.ShipmentResponse, len(shipments))
+	for i := range shipments {
+		response[i] = s.convertToShipmentResponse(&shipments[i])
+	}
+
+	return response, nil
+}
+
+func (s *ShipmentService) getShipmentResponse(tx *gorm.DB, shipmentID uint) (*dto.ShipmentResponse, error) {
+	var shipment models.Shipment
+	if err := tx.Preload("Lines.OrderItem").First(&shipment, shipmentID).Error; err != nil {
+		return nil, err
+	}
+
+	response := s.convertToShipmentResponse(&shipment)
+
+	return &response, nil
+}
+
+func (s *ShipmentService) convertToShipmentResponse(shipment *models.Shipment) dto.ShipmentResponse {
+	lines := make([]dto.ShipmentLineResponse, len(shipment.Lines))
+	for i := range shipment.Lines {
+		line := &shipment.Lines[i]
+
+		lines[i] = dto.ShipmentLineResponse{
+			ID:          line.ID,
+			OrderItemID: line.OrderItemID,
+			ProductID:   line.OrderItem.ProductID,
+			Quantity:    line.Quantity,
+		}
+	}
+
+	status := string(models.OrderStatusShipped)
+	if shipment.IsDelivered() {
+		status = string(models.OrderStatusDelivered)
+	}
+
+	return dto.ShipmentResponse{
+		ID:             shipment.ID,
+		OrderID:        shipment.OrderID,
+		Status:         status,
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		ShippedAt:      shipment.ShippedAt,
+		DeliveredAt:    shipment.DeliveredAt,
+		Lines:          lines,
+		CreatedAt:      shipment.CreatedAt,
+		UpdatedAt:      shipment.UpdatedAt,
+	}
+}
@@ -0,0 +1,327 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"gorm.io/gorm"
+)
+
+var _ ShippingServiceInterface = (*ShippingService)(nil)
+
+type ShippingService struct {
+	db       *gorm.DB
+	currency money.Currency
+}
+
+// NewShippingService creates the shipping service type
+func NewShippingService(db *gorm.DB, currency money.Currency) *ShippingService {
+	return &ShippingService{db: db, currency: currency}
+}
+
+func (s *ShippingService) GetZones() ([]dto.ShippingZoneResponse, error) {
+	var zones []models.ShippingZone
+	if err := s.db.Preload("Regions").Preload("Rates").Order("id").Find(&zones).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.ShippingZoneResponse, len(zones))
+	for i := range zones {
+		response[i] = s.convertToZoneResponse(&zones[i])
+	}
+
+	return response, nil
+}
+
+func (s *ShippingService) CreateZone(req *dto.ShippingZoneRequest) (*dto.ShippingZoneResponse, error) {
+	zone := models.ShippingZone{
+		Name:     req.Name,
+		IsActive: true,
+		Regions:  newZoneRegions(req.Regions),
+	}
+	if req.IsActive != nil {
+		zone.IsActive = *req.IsActive
+	}
+
+	if err := s.db.Create(&zone).Error; err != nil {
+		return nil, err
+	}
+
+	response := s.convertToZoneResponse(&zone)
+
+	return &response, nil
+}
+
+// UpdateZone renames the zone and replaces its regions with the requested ones.
+func (s *ShippingService) UpdateZone(zoneID uint, req *dto.ShippingZoneRequest) (*dto.ShippingZoneResponse, error) {
+	var zone models.ShippingZone
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&zone, zoneID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrShippingZoneNotFound
+			}
+			return err
+		}
+
+		zone.Name = req.Name
+		if req.IsActive != nil {
+			zone.IsActive = *req.IsActive
+		}
+
+		if err := tx.Save(&zone).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("shipping_zone_id = ?", zone.ID).Delete(&models.ShippingZoneRegion{}).Error; err != nil {
+			return err
+		}
+
+		regions := newZoneRegions(req.Regions)
+		for i := range regions {
+			regions[i].ShippingZoneID = zone.ID
+		}
+
+		if err := tx.Create(&regions).Error; err != nil {
+			return err
+		}
+
+		return tx.Preload("Regions").Preload("Rates").First(&zone, zone.ID).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.convertToZoneResponse(&zone)
+
+	return &response, nil
+}
+
+func (s *ShippingService) DeleteZone(zoneID uint) error {
+	result := s.db.Delete(&models.ShippingZone{}, zoneID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrShippingZoneNotFound
+	}
+
+	return nil
+}
+
+func (s *ShippingService) CreateRate(zoneID uint, req *dto.ShippingRateRequest) (*dto.ShippingRateResponse, error) {
+	if err := validateShippingRate(req, s.currency); err != nil {
+		return nil, err
+	}
+
+	var zone models.ShippingZone
+	if err := s.db.First(&zone, zoneID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrShippingZoneNotFound
+		}
+		return nil, err
+	}
+
+	rate := models.ShippingRate{ShippingZoneID: zone.ID, IsActive: true}
+	applyShippingRateRequest(&rate, req)
+
+	if err := s.db.Create(&rate).Error; err != nil {
+		return nil, err
+	}
+
+	response := s.convertToRateResponse(&rate)
+
+	return &response, nil
+}
+
+func (s *ShippingService) UpdateRate(rateID uint, req *dto.ShippingRateRequest) (*dto.ShippingRateResponse, error) {
+	if err := validateShippingRate(req, s.currency); err != nil {
+		return nil, err
+	}
+
+	var rate models.ShippingRate
+	if err := s.db.First(&rate, rateID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrShippingRateNotFound
+		}
+		return nil, err
+	}
+
+	applyShippingRateRequest(&rate, req)
+
+	if err := s.db.Save(&rate).Error; err != nil {
+		return nil, err
+	}
+
+	response := s.convertToRateResponse(&rate)
+
+	return &response, nil
+}
+
+func (s *ShippingService) DeleteRate(rateID uint) error {
+	result := s.db.Delete(&models.ShippingRate{}, rateID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrShippingRateNotFound
+	}
+
+	return nil
+}
+
+func (s *ShippingService) convertToZoneResponse(zone *models.ShippingZone) dto.ShippingZoneResponse {
+	regions := make([]dto.ShippingZoneRegionResponse, len(zone.Regions))
+	for i := range zone.Regions {
+		regions[i] = dto.ShippingZoneRegionResponse{
+			ID:             zone.Regions[i].ID,
+			Country:        zone.Regions[i].Country,
+			State:          zone.Regions[i].State,
+			PostcodePrefix: zone.Regions[i].PostcodePrefix,
+		}
+	}
+
+	rates := make([]dto.ShippingRateResponse, len(zone.Rates))
+	for i := range zone.Rates {
+		rates[i] = s.convertToRateResponse(&zone.Rates[i])
+	}
+
+	return dto.ShippingZoneResponse{
+		ID:        zone.ID,
+		Name:      zone.Name,
+		IsActive:  zone.IsActive,
+		Regions:   regions,
+		Rates:     rates,
+		CreatedAt: zone.CreatedAt,
+		UpdatedAt: zone.UpdatedAt,
+	}
+}
+
+func (s *ShippingService) convertToRateResponse(rate *models.ShippingRate) dto.ShippingRateResponse {
+	return dto.ShippingRateResponse{
+		ID:        rate.ID,
+		ZoneID:    rate.ShippingZoneID,
+		Name:      rate.Name,
+		Type:      string(rate.Type),
+		Price:     rate.Price,
+		MinWeight: rate.MinWeight,
+		MaxWeight: rate.MaxWeight,
+		FreeOver:  rate.FreeOver,
+		IsActive:  rate.IsActive,
+		CreatedAt: rate.CreatedAt,
+		UpdatedAt: rate.UpdatedAt,
+	}
+}
+
+// shippingOptions quotes, for the cart, the active rates of the zone that
+// covers the address most specifically. When several rates of one method
+// apply, the cheapest is offered. The cart must be priced already; the rates
+// are priced in the same currency.
+func shippingOptions(db *gorm.DB, cart *models.Cart, address *models.OrderAddress, prices *pricing) ([]dto.ShippingOptionResponse, error) {
+	var zones []models.ShippingZone
+	if err := db.Preload("Regions").
+		Preload("Rates", func(db *gorm.DB) *gorm.DB {
+			return db.Where("is_active = ?", true).Order("id")
+		}).
+		Where("is_active = ?", true).
+		Order("id").
+		Find(&zones).Error; err != nil {
+		return nil, err
+	}
+
+	options := []dto.ShippingOptionResponse{}
+
+	zone := models.MatchShippingZone(zones, address)
+	if zone == nil {
+		return options, nil
+	}
+
+	subtotal, weight := cart.Totals()
+	methods := make(map[string]int)
+
+	for i := range zone.Rates {
+		rate := &zone.Rates[i]
+		prices.shippingRate(rate)
+
+		cost, ok := rate.Cost(weight, subtotal)
+		if !ok {
+			continue
+		}
+
+		if j, seen := methods[rate.Name]; seen {
+			if cost.Cmp(options[j].Cost) < 0 {
+				options[j] = dto.ShippingOptionResponse{RateID: rate.ID, Method: rate.Name, Cost: cost}
+			}
+			continue
+		}
+
+		methods[rate.Name] = len(options)
+		options = append(options, dto.ShippingOptionResponse{RateID: rate.ID, Method: rate.Name, Cost: cost})
+	}
+
+	return options, nil
+}
+
+// findShippingOption returns the quoted option for rateID, if it was quoted.
+func findShippingOption(options []dto.ShippingOptionResponse, rateID uint) (*dto.ShippingOptionResponse, bool) {
+	for i := range options {
+		if options[i].RateID == rateID {
+			return &options[i], true
+		}
+	}
+
+	return nil, false
+}
+
+func validateShippingRate(req *dto.ShippingRateRequest, currency money.Currency) error {
+	if err := inCurrency(currency, &req.Price, &req.FreeOver); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidShippingRate, err)
+	}
+
+	if req.Price.IsNegative() || req.FreeOver.IsNegative() {
+		return fmt.Errorf("%w: price and free_over must not be negative", ErrInvalidShippingRate)
+	}
+
+	rateType := models.ShippingRateType(req.Type)
+	if !rateType.IsValid() {
+		return fmt.Errorf("%w: unknown rate type %q", ErrInvalidShippingRate, req.Type)
+	}
+
+	if rateType == models.ShippingRateWeight && req.MaxWeight > 0 && req.MaxWeight <= req.MinWeight {
+		return fmt.Errorf("%w: max_weight must be greater than min_weight", ErrInvalidShippingRate)
+	}
+
+	return nil
+}
+
+func applyShippingRateRequest(rate *models.ShippingRate, req *dto.ShippingRateRequest) {
+	rate.Name = req.Name
+	rate.Type = models.ShippingRateType(req.Type)
+	rate.Price = req.Price
+	rate.MinWeight = req.MinWeight
+	rate.MaxWeight = req.MaxWeight
+	rate.FreeOver = req.FreeOver
+	if req.IsActive != nil {
+		rate.IsActive = *req.IsActive
+	}
+}
+
+func newZoneRegions(requests []dto.ShippingZoneRegionRequest) []models.ShippingZoneRegion {
+	regions := make([]models.ShippingZoneRegion, len(requests))
+	for i, req := range requests {
+		regions[i] = models.ShippingZoneRegion{
+			Country:        strings.ToUpper(req.Country),
+			State:          req.State,
+			PostcodePrefix: req.PostcodePrefix,
+		}
+	}
+
+	return regions
+}
@@ -0,0 +1,409 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ SubscriptionServiceInterface = (*SubscriptionService)(nil)
+
+// subscriptionRunBatch is how many due subscriptions are read at a time.
+const subscriptionRunBatch = 100
+
+// subscriptionRunsListed is how many of its latest runs a subscription lists.
+const subscriptionRunsListed = 10
+
+type SubscriptionService struct {
+	db     *gorm.DB
+	orders *OrderService
+}
+
+// NewSubscriptionService creates the subscription service type
+func NewSubscriptionService(db *gorm.DB, orders *OrderService) *SubscriptionService {
+	return &SubscriptionService{db: db, orders: orders}
+}
+
+func (s *SubscriptionService) GetSubscriptions(userID uint) ([]dto.SubscriptionResponse, error) {
+	var subscriptions []models.Subscription
+	if err := s.db.Preload("Product").Where("user_id = ?", userID).Order("created_at").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.SubscriptionResponse, len(subscriptions))
+	for i := range subscriptions {
+		response[i] = convertToSubscriptionResponse(&subscriptions[i])
+	}
+
+	return response, nil
+}
+
+// GetSubscription returns one of the user's subscriptions with its latest
+// runs.
+func (s *SubscriptionService) GetSubscription(userID, subscriptionID uint) (*dto.SubscriptionResponse, error) {
+	var subscription models.Subscription
+	if err := s.db.Preload("Product").
+		Preload("Runs", func(db *gorm.DB) *gorm.DB {
+			return db.Order("run_at DESC").Limit(subscriptionRunsListed)
+		}).
+		Where("id = ? AND user_id = ?", subscriptionID, userID).
+		First(&subscription).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSubscriptionNotFound
+		}
+		return nil, err
+	}
+
+	response := convertToSubscriptionResponse(&subscription)
+
+	return &response, nil
+}
+
+// CreateSubscription subscribes the user to an active product. The first
+// order is placed at StartsAt, or by the next run of the subscriptions when
+// no start is given.
+func (s *SubscriptionService) CreateSubscription(userID uint, req *dto.CreateSubscriptionRequest) (*dto.SubscriptionResponse, error) {
+	var product models.Product
+	if err := s.db.Where("id = ? AND is_active = ?", req.ProductID, true).First(&product).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	subscription := models.Subscription{
+		UserID:    userID,
+		ProductID: product.ID,
+		Status:    models.SubscriptionStatusActive,
+		NextRunAt: time.Now(),
+	}
+	if req.StartsAt != nil {
+		subscription.NextRunAt = *req.StartsAt
+	}
+
+	if err := s.applySubscriptionRequest(&subscription, req.Quantity, req.IntervalWeeks, req.ShippingAddressID, req.BillingAddressID, req.ShippingRateID); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&subscription).Error; err != nil {
+		return nil, err
+	}
+
+	subscription.Product = product
+	response := convertToSubscriptionResponse(&subscription)
+
+	return &response, nil
+}
+
+// UpdateSubscription changes the quantity, interval and addresses of a
+// subscription that has not been cancelled, and moves its next order when
+// NextRunAt is given.
+func (s *SubscriptionService) UpdateSubscription(userID, subscriptionID uint, req *dto.UpdateSubscriptionRequest) (*dto.SubscriptionResponse, error) {
+	subscription, err := s.findOpenSubscription(userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applySubscriptionRequest(subscription, req.Quantity, req.IntervalWeeks, req.ShippingAddressID, req.BillingAddressID, req.ShippingRateID); err != nil {
+		return nil, err
+	}
+
+	if req.NextRunAt != nil {
+		if !req.NextRunAt.After(time.Now()) {
+			return nil, fmt.Errorf("%w: the next order must be in the future", ErrInvalidSubscription)
+		}
+		subscription.NextRunAt = *req.NextRunAt
+	}
+
+	if err := s.db.Save(subscription).Error; err != nil {
+		return nil, err
+	}
+
+	return s.GetSubscription(userID, subscriptionID)
+}
+
+// PauseSubscription stops a subscription from placing orders until it is
+// resumed.
+func (s *SubscriptionService) PauseSubscription(userID, subscriptionID uint) (*dto.SubscriptionResponse, error) {
+	subscription, err := s.findOpenSubscription(userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Model(subscription).Update("status", models.SubscriptionStatusPaused).Error; err != nil {
+		return nil, err
+	}
+
+	return s.GetSubscription(userID, subscriptionID)
+}
+
+// ResumeSubscription lets a paused subscription place orders again. The
+// orders it missed while paused are skipped; the next one follows its
+// schedule.
+func (s *SubscriptionService) ResumeSubscription(userID, subscriptionID uint) (*dto.SubscriptionResponse, error) {
+	subscription, err := s.findOpenSubscription(userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.db.Model(subscription).Updates(map[string]interface{}{
+		"status":      models.SubscriptionStatusActive,
+		"next_run_at": subscription.NextRunAfter(now),
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	return s.GetSubscription(userID, subscriptionID)
+}
+
+// CancelSubscription ends a subscription for good; it places no more orders
+// and can no longer be changed.
+func (s *SubscriptionService) CancelSubscription(userID, subscriptionID uint) (*dto.SubscriptionResponse, error) {
+	subscription, err := s.findOpenSubscription(userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Model(subscription).Updates(map[string]interface{}{
+		"status":       models.SubscriptionStatusCancelled,
+		"cancelled_at": time.Now(),
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	return s.GetSubscription(userID, subscriptionID)
+}
+
+// RunDueSubscriptions places the orders of the active subscriptions that are
+// due by now, each in a transaction of its own. A subscription whose order
+// cannot be placed records the error on its run and moves on to its next
+// date, so it is never retried for the same date; failed counts those.
+func (s *SubscriptionService) RunDueSubscriptions(now time.Time) (ordered, failed int, err error) {
+	var lastID uint
+	for {
+		var ids []uint
+		if err := s.db.Model(&models.Subscription{}).
+			Where("status = ? AND next_run_at <= ? AND id > ?", models.SubscriptionStatusActive, now, lastID).
+			Order("id").
+			Limit(subscriptionRunBatch).
+			Pluck("id", &ids).Error; err != nil {
+			return ordered, failed, err
+		}
+
+		for _, id := range ids {
+			run, err := s.runSubscription(id, now)
+			if err != nil {
+				return ordered, failed, err
+			}
+
+			switch {
+			case run == nil:
+			case run.OrderID != nil:
+				ordered++
+			default:
+				failed++
+			}
+		}
+
+		if len(ids) < subscriptionRunBatch {
+			return ordered, failed, nil
+		}
+		lastID = ids[len(ids)-1]
+	}
+}
+
+// runSubscription places the order of a due subscription and records the
+// run. The subscription is locked and checked to be still due, and the run is
+// recorded with the next date in the same transaction, so concurrent or
+// repeated runs never order twice for a date. It returns no run when the
+// subscription is no longer due.
+func (s *SubscriptionService) runSubscription(subscriptionID uint, now time.Time) (*models.SubscriptionRun, error) {
+	var run *models.SubscriptionRun
+	var quote *orderQuote
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var subscription models.Subscription
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND status = ? AND next_run_at <= ?", subscriptionID, models.SubscriptionStatusActive, now).
+			First(&subscription).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		run = &models.SubscriptionRun{SubscriptionID: subscription.ID, RunAt: subscription.NextRunAt}
+
+		// A failed order is rolled back on its own, leaving the run to be
+		// recorded
+		err := tx.Transaction(func(tx *gorm.DB) error {
+			var order *models.Order
+			var err error
+			order, quote, err = s.placeSubscriptionOrder(tx, &subscription)
+			if err != nil {
+				return err
+			}
+			run.OrderID = &order.ID
+
+			return nil
+		})
+		if err != nil {
+			s.orders.voidAuthorization(quote)
+			run.Error = err.Error()
+		}
+
+		if err := tx.Create(run).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Subscription{}).
+			Where("id = ?", subscription.ID).
+			Update("next_run_at", subscription.NextRunAfter(now)).Error
+	})
+
+	if err != nil {
+		s.orders.voidAuthorization(quote)
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// placeSubscriptionOrder orders what the subscription holds like CreateOrder
+// orders a cart, in the store currency. The quote it returns, even with an
+// error, holds the payment authorized for the order.
+func (s *SubscriptionService) placeSubscriptionOrder(tx *gorm.DB, subscription *models.Subscription) (*models.Order, *orderQuote, error) {
+	if err := tx.Where("id = ? AND is_active = ?", subscription.ProductID, true).First(&subscription.Product).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errors.New("product is no longer sold")
+		}
+		return nil, nil, err
+	}
+
+	prices, err := newPricing(tx, s.orders.currency, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shippingAddress, billingAddress, err := s.orders.orderAddresses(tx, subscription.UserID, &dto.CreateOrderRequest{
+		ShippingAddressID: subscription.ShippingAddressID,
+		BillingAddressID:  subscription.BillingAddressID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cart := subscription.Cart()
+	order := models.Order{
+		UserID:          &subscription.UserID,
+		ShippingAddress: shippingAddress,
+		BillingAddress:  billingAddress,
+	}
+	quote, err := s.orders.priceOrder(tx, &order, &cart, prices)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// A run retried after the payment was authorized, but before the run was
+	// recorded, gets the same authorization back rather than a second one
+	quote.idempotencyKey = fmt.Sprintf("subscription-%d-%d", subscription.ID, subscription.NextRunAt.Unix())
+
+	if err := s.orders.placeOrder(tx, &order, &cart, quote); err != nil {
+		return nil, quote, err
+	}
+
+	return &order, quote, nil
+}
+
+// findOpenSubscription returns one of the user's subscriptions that has not
+// been cancelled.
+func (s *SubscriptionService) findOpenSubscription(userID, subscriptionID uint) (*models.Subscription, error) {
+	var subscription models.Subscription
+	if err := s.db.Where("id = ? AND user_id = ?", subscriptionID, userID).First(&subscription).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSubscriptionNotFound
+		}
+		return nil, err
+	}
+
+	if subscription.Status == models.SubscriptionStatusCancelled {
+		return nil, ErrSubscriptionCancelled
+	}
+
+	return &subscription, nil
+}
+
+// applySubscriptionRequest sets what the subscription orders and where to,
+// checking that the addresses are the user's and that the shipping rate
+// exists.
+func (s *SubscriptionService) applySubscriptionRequest(subscription *models.Subscription, quantity, intervalWeeks int, shippingAddressID uint, billingAddressID, shippingRateID *uint) error {
+	if quantity < 1 {
+		return fmt.Errorf("%w: quantity must be at least 1", ErrInvalidSubscription)
+	}
+	if intervalWeeks < 1 {
+		return fmt.Errorf("%w: interval must be at least a week", ErrInvalidSubscription)
+	}
+
+	if _, err := findUserAddress(s.db, subscription.UserID, shippingAddressID); err != nil {
+		return err
+	}
+	if billingAddressID != nil {
+		if _, err := findUserAddress(s.db, subscription.UserID, *billingAddressID); err != nil {
+			return err
+		}
+	}
+
+	if shippingRateID != nil {
+		var rate models.ShippingRate
+		if err := s.db.First(&rate, *shippingRateID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrShippingRateNotFound
+			}
+			return err
+		}
+	}
+
+	subscription.Quantity = quantity
+	subscription.IntervalWeeks = intervalWeeks
+	subscription.ShippingAddressID = &shippingAddressID
+	subscription.BillingAddressID = billingAddressID
+	subscription.ShippingRateID = shippingRateID
+
+	return nil
+}
+
+func convertToSubscriptionResponse(subscription *models.Subscription) dto.SubscriptionResponse {
+	response := dto.SubscriptionResponse{
+		ID:                subscription.ID,
+		ProductID:         subscription.ProductID,
+		ProductName:       subscription.Product.Name,
+		Quantity:          subscription.Quantity,
+		IntervalWeeks:     subscription.IntervalWeeks,
+		ShippingAddressID: subscription.ShippingAddressID,
+		BillingAddressID:  subscription.BillingAddressID,
+		ShippingRateID:    subscription.ShippingRateID,
+		Status:            string(subscription.Status),
+		NextRunAt:         subscription.NextRunAt,
+		CancelledAt:       subscription.CancelledAt,
+		CreatedAt:         subscription.CreatedAt,
+		UpdatedAt:         subscription.UpdatedAt,
+	}
+
+	for _, run := range subscription.Runs {
+		response.Runs = append(response.Runs, dto.SubscriptionRunResponse{
+			ID:        run.ID,
+			RunAt:     run.RunAt,
+			OrderID:   run.OrderID,
+			Error:     run.Error,
+			CreatedAt: run.CreatedAt,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"gorm.io/gorm"
+)
+
+var _ TaxServiceInterface = (*TaxService)(nil)
+
+type TaxService struct {
+	db *gorm.DB
+}
+
+// NewTaxService creates the tax service type
+func NewTaxService(db *gorm.DB) *TaxService {
+	return &TaxService{db: db}
+}
+
+func (s *TaxService) GetTaxRates() ([]dto.TaxRateResponse, error) {
+	var rates []models.TaxRate
+	if err := s.db.Order("country, state, tax_class").Find(&rates).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.TaxRateResponse, len(rates))
+	for i := range rates {
+		response[i] = s.convertToTaxRateResponse(&rates[i])
+	}
+
+	return response, nil
+}
+
+func (s *TaxService) CreateTaxRate(req *dto.TaxRateRequest) (*dto.TaxRateResponse, error) {
+	rate := models.TaxRate{IsActive: true}
+	applyTaxRateRequest(&rate, req)
+
+	if err := s.db.Create(&rate).Error; err != nil {
+		return nil, err
+	}
+
+	response := s.convertToTaxRateResponse(&rate)
+
+	return &response, nil
+}
+
+func (s *TaxService) UpdateTaxRate(rateID uint, req *dto.TaxRateRequest) (*dto.TaxRateResponse, error) {
+	var rate models.TaxRate
+	if err := s.db.First(&rate, rateID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTaxRateNotFound
+		}
+		return nil, err
+	}
+
+	applyTaxRateRequest(&rate, req)
+
+	if err := s.db.Save(&rate).Error; err != nil {
+		return nil, err
+	}
+
+	response := s.convertToTaxRateResponse(&rate)
+
+	return &response, nil
+}
+
+func (s *TaxService) DeleteTaxRate(rateID uint) error {
+	result := s.db.Delete(&models.TaxRate{}, rateID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrTaxRateNotFound
+	}
+
+	return nil
+}
+
+func (s *TaxService) convertToTaxRateResponse(rate *models.TaxRate) dto.TaxRateResponse {
+	return dto.TaxRateResponse{
+		ID:        rate.ID,
+		Name:      rate.Name,
+		Country:   rate.Country,
+		State:     rate.State,
+		TaxClass:  rate.TaxClass,
+		Rate:      rate.Rate,
+		IsActive:  rate.IsActive,
+		CreatedAt: rate.CreatedAt,
+		UpdatedAt: rate.UpdatedAt,
+	}
+}
+
+func applyTaxRateRequest(rate *models.TaxRate, req *dto.TaxRateRequest) {
+	rate.Name = req.Name
+	rate.Country = strings.ToUpper(req.Country)
+	rate.State = req.State
+	rate.TaxClass = taxClassOrDefault(req.TaxClass)
+	rate.Rate = req.Rate
+	if req.IsActive != nil {
+		rate.IsActive = *req.IsActive
+	}
+}
+
+// taxClassOrDefault normalizes a tax class, falling back to the default class.
+func taxClassOrDefault(taxClass string) string {
+	taxClass = strings.ToLower(strings.TrimSpace(taxClass))
+	if taxClass == "" {
+		return models.DefaultTaxClass
+	}
+
+	return taxClass
+}
@@ -0,0 +1,371 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ WalletServiceInterface = (*WalletService)(nil)
+
+type WalletService struct {
+	db       *gorm.DB
+	currency money.Currency
+}
+
+// NewWalletService creates the wallet service type
+func NewWalletService(db *gorm.DB, currency money.Currency) *WalletService {
+	return &WalletService{db: db, currency: currency}
+}
+
+// GetWallet returns the balance of the user's wallet, which is empty until
+// the first credit.
+func (s *WalletService) GetWallet(userID uint) (*dto.WalletResponse, error) {
+	var wallet models.Wallet
+	if err := s.db.Where("user_id = ?", userID).First(&wallet).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &dto.WalletResponse{Balance: money.Zero(s.currency)}, nil
+		}
+		return nil, err
+	}
+
+	return &dto.WalletResponse{Balance: wallet.Balance}, nil
+}
+
+// GetWalletEntries lists the entries of the user's wallet, newest first.
+func (s *WalletService) GetWalletEntries(userID uint, page, limit int) ([]dto.WalletEntryResponse, *utils.PaginationMeta, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = 10
+	}
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	filter := func(db *gorm.DB) *gorm.DB {
+		return db.Where("wallet_id IN (?)", s.db.Model(&models.Wallet{}).Select("id").Where("user_id = ?", userID))
+	}
+
+	offset := (page - 1) * limit
+	var entries []models.WalletEntry
+	var total int64
+
+	if err := s.db.Model(&models.WalletEntry{}).Scopes(filter).Count(&total).Error; err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.db.Scopes(filter).
+		Order("id DESC").
+		Offset(offset).Limit(limit).
+		Find(&entries).Error; err != nil {
+		return nil, nil, err
+	}
+
+	response := make([]dto.WalletEntryResponse, len(entries))
+	for i := range entries {
+		response[i] = convertToWalletEntryResponse(&entries[i])
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	meta := &utils.PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	return response, meta, nil
+}
+
+// RedeemGiftCard credits the value of the gift card with the code to the
+// user's wallet. A card is redeemed once, by whoever redeems it first.
+func (s *WalletService) RedeemGiftCard(userID uint, req *dto.RedeemGiftCardRequest) (*dto.WalletResponse, error) {
+	var balance money.Money
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var card models.GiftCard
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("code_hash = ?", models.HashGiftCardCode(req.Code)).
+			First(&card).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrGiftCardNotFound
+			}
+			return err
+		}
+
+		now := time.Now()
+		if !card.IsRedeemable(now) {
+			return ErrGiftCardNotRedeemable
+		}
+
+		if err := tx.Model(&models.GiftCard{}).Where("id = ?", card.ID).Updates(map[string]interface{}{
+			"redeemed_by": userID,
+			"redeemed_at": now,
+		}).Error; err != nil {
+			return err
+		}
+
+		wallet, err := lockWallet(tx, userID, s.currency)
+		if err != nil {
+			return err
+		}
+
+		if err := postWalletEntry(tx, wallet, &models.WalletEntry{
+			Type:       models.WalletEntryCredit,
+			Source:     models.WalletEntryGiftCard,
+			Amount:     card.InitialValue,
+			GiftCardID: &card.ID,
+		}); err != nil {
+			return err
+		}
+
+		balance = wallet.Balance
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.WalletResponse{Balance: balance}, nil
+}
+
+// GrantCredit credits store credit granted by an admin to the user's wallet.
+func (s *WalletService) GrantCredit(adminID, userID uint, req *dto.GrantCreditRequest) (*dto.WalletEntryResponse, error) {
+	if err := inCurrency(s.currency, &req.Amount); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWalletCredit, err)
+	}
+
+	if !req.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: the amount must be greater than 0", ErrInvalidWalletCredit)
+	}
+
+	entry := models.WalletEntry{
+		Type:      models.WalletEntryCredit,
+		Source:    models.WalletEntryGrant,
+		Amount:    req.Amount,
+		Note:      req.Note,
+		CreatedBy: &adminID,
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Select("id").First(&user, userID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserNotFound
+			}
+			return err
+		}
+
+		wallet, err := lockWallet(tx, userID, s.currency)
+		if err != nil {
+			return err
+		}
+
+		return postWalletEntry(tx, wallet, &entry)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := convertToWalletEntryResponse(&entry)
+
+	return &response, nil
+}
+
+// IssueGiftCard issues a gift card worth the requested value. The response
+// carries the card's code, which is not kept and cannot be shown again.
+func (s *WalletService) IssueGiftCard(adminID uint, req *dto.GiftCardRequest) (*dto.GiftCardResponse, error) {
+	if err := inCurrency(s.currency, &req.Value); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGiftCard, err)
+	}
+
+	if !req.Value.IsPositive() {
+		return nil, fmt.Errorf("%w: the value must be greater than 0", ErrInvalidGiftCard)
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidGiftCard)
+	}
+
+	code, err := models.NewGiftCardCode()
+	if err != nil {
+		return nil, err
+	}
+
+	card := models.GiftCard{
+		CodeHash:     models.HashGiftCardCode(code),
+		CodeHint:     models.GiftCardCodeHint(code),
+		InitialValue: req.Value,
+		Note:         req.Note,
+		ExpiresAt:    req.ExpiresAt,
+		IssuedBy:     adminID,
+	}
+
+	if err := s.db.Create(&card).Error; err != nil {
+		return nil, err
+	}
+
+	response := convertToGiftCardResponse(&card)
+	response.Code = code
+
+	return &response, nil
+}
+
+// GetGiftCards lists the issued gift cards, newest first.
+func (s *WalletService) GetGiftCards(page, limit int) ([]dto.GiftCardResponse, *utils.PaginationMeta, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = 10
+	}
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+	var cards []models.GiftCard
+	var total int64
+
+	if err := s.db.Model(&models.GiftCard{}).Count(&total).Error; err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.db.Order("id DESC").Offset(offset).Limit(limit).Find(&cards).Error; err != nil {
+		return nil, nil, err
+	}
+
+	response := make([]dto.GiftCardResponse, len(cards))
+	for i := range cards {
+		response[i] = convertToGiftCardResponse(&cards[i])
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	meta := &utils.PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	return response, meta, nil
+}
+
+// lockWallet locks the user's wallet to post entries to it, opening an empty
+// wallet in the store currency the first time.
+func lockWallet(tx *gorm.DB, userID uint, currency money.Currency) (*models.Wallet, error) {
+	if err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "user_id"}}, DoNothing: true}).
+		Create(&models.Wallet{UserID: userID, Balance: money.Zero(currency)}).Error; err != nil {
+		return nil, err
+	}
+
+	var wallet models.Wallet
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userID).First(&wallet).Error; err != nil {
+		return nil, err
+	}
+
+	return &wallet, nil
+}
+
+// postWalletEntry posts the entry to the locked wallet and moves the wallet's
+// balance along. A debit never takes the balance below zero.
+func postWalletEntry(tx *gorm.DB, wallet *models.Wallet, entry *models.WalletEntry) error {
+	balance := wallet.Balance.Add(entry.Amount)
+	if entry.Type == models.WalletEntryDebit {
+		balance = wallet.Balance.Sub(entry.Amount)
+		if balance.IsNegative() {
+			return ErrInsufficientWalletBalance
+		}
+	}
+
+	entry.WalletID = wallet.ID
+	entry.Balance = balance
+
+	if err := tx.Create(entry).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&models.Wallet{}).Where("id = ?", wallet.ID).Updates(map[string]interface{}{
+		"balance_amount":   balance.Amount,
+		"balance_currency": balance.Currency,
+	}).Error; err != nil {
+		return err
+	}
+
+	wallet.Balance = balance
+
+	return nil
+}
+
+// walletPayment works out how much of an order total the wallet balance
+// pays, in the order currency at rate units per unit of the store currency,
+// and how much that takes from the wallet.
+func walletPayment(balance, total money.Money, rate float64) (paid, debit money.Money) {
+	if balance.SameCurrency(total) {
+		paid = money.Min(balance, total)
+		return paid, paid
+	}
+
+	available := balance.Convert(total.Currency, rate)
+	if available.Cmp(total) <= 0 {
+		return available, balance
+	}
+
+	return total, money.Min(total.Convert(balance.Currency, 1/rate), balance)
+}
+
+// storeAmount converts an amount of an order, at the order's exchange rate,
+// back into the store currency.
+func storeAmount(amount money.Money, store money.Currency, rate float64) money.Money {
+	if amount.Currency == "" || amount.Currency == store || rate <= 0 {
+		amount.Currency = store
+		return amount
+	}
+
+	return amount.Convert(store, 1/rate)
+}
+
+func convertToWalletEntryResponse(entry *models.WalletEntry) dto.WalletEntryResponse {
+	return dto.WalletEntryResponse{
+		ID:         entry.ID,
+		Type:       string(entry.Type),
+		Source:     string(entry.Source),
+		Amount:     entry.Amount,
+		Balance:    entry.Balance,
+		GiftCardID: entry.GiftCardID,
+		OrderID:    entry.OrderID,
+		ReturnID:   entry.ReturnID,
+		Note:       entry.Note,
+		CreatedAt:  entry.CreatedAt,
+	}
+}
+
+func convertToGiftCardResponse(card *models.GiftCard) dto.GiftCardResponse {
+	return dto.GiftCardResponse{
+		ID:           card.ID,
+		CodeHint:     card.CodeHint,
+		InitialValue: card.InitialValue,
+		Note:         card.Note,
+		ExpiresAt:    card.ExpiresAt,
+		IssuedBy:     card.IssuedBy,
+		RedeemedBy:   card.RedeemedBy,
+		RedeemedAt:   card.RedeemedAt,
+		CreatedAt:    card.CreatedAt,
+	}
+}
@@ -0,0 +1,78 @@
+// Package tax defines the contract tax calculators implement and the
+// calculators that ship with the API.
+package tax
+
+import "github.com/kuldeepstechwork/gocart-api/internal/money"
+
+// PricingMode tells whether catalogue prices already include tax.
+type PricingMode string
+
+const (
+	// PricingExclusive adds tax on top of the catalogue price.
+	PricingExclusive PricingMode = "exclusive"
+	// PricingInclusive treats the catalogue price as tax included and extracts the tax from it.
+	PricingInclusive PricingMode = "inclusive"
+)
+
+// IsValid reports whether the mode is one of the known pricing modes.
+func (m PricingMode) IsValid() bool {
+	return m == PricingExclusive || m == PricingInclusive
+}
+
+// TaxCalculator is implemented by every engine order taxes can be computed with.
+type TaxCalculator interface {
+	Name() string
+	Pricing() PricingMode
+	Calculate(req *Request) (*Result, error)
+}
+
+// Address is the destination taxes are calculated for.
+type Address struct {
+	Country    string
+	State      string
+	PostalCode string
+}
+
+// Line is one taxable amount, typically an order line.
+type Line struct {
+	// ID lets the caller match results back to its lines.
+	ID       uint
+	TaxClass string
+	// Amount is the price charged for the line, quantity times unit price.
+	Amount money.Money
+}
+
+type Request struct {
+	Address Address
+	Lines   []Line
+}
+
+// LineResult is the tax breakdown of one line.
+type LineResult struct {
+	ID       uint
+	TaxClass string
+	// Rate is the percentage applied to the line.
+	Rate        float64
+	NetAmount   money.Money
+	TaxAmount   money.Money
+	GrossAmount money.Money
+}
+
+// Result holds the breakdown of every requested line, in request order, and their totals.
+type Result struct {
+	Lines      []LineResult
+	NetTotal   money.Money
+	TaxTotal   money.Money
+	GrossTotal money.Money
+}
+
+// Apply computes the breakdown of amount taxed at rate percent under the pricing mode.
+func Apply(amount money.Money, rate float64, pricing PricingMode) (net, tax, gross money.Money) {
+	if pricing == PricingInclusive {
+		net = amount.Percent(100 * 100 / (100 + rate))
+		return net, amount.Sub(net), amount
+	}
+
+	tax = amount.Percent(rate)
+	return amount, tax, amount.Add(tax)
+}
@@ -0,0 +1,88 @@
+package tax
+
+import (
+	"strings"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+)
+
+var _ TaxCalculator = (*TableCalculator)(nil)
+
+// RateSource looks up the active tax rates configured for a destination.
+type RateSource interface {
+	FindTaxRates(country, state string) ([]models.TaxRate, error)
+}
+
+// TableCalculator taxes lines with the admin managed tax rate table. A rate
+// for the destination's state takes precedence over the country wide rate of
+// the same tax class; lines without a matching rate are not taxed.
+type TableCalculator struct {
+	rates   RateSource
+	pricing PricingMode
+}
+
+func NewTableCalculator(rates RateSource, pricing PricingMode) *TableCalculator {
+	return &TableCalculator{rates: rates, pricing: pricing}
+}
+
+func (c *TableCalculator) Name() string {
+	return "table"
+}
+
+func (c *TableCalculator) Pricing() PricingMode {
+	return c.pricing
+}
+
+func (c *TableCalculator) Calculate(req *Request) (*Result, error) {
+	var rates []models.TaxRate
+	if req.Address.Country != "" {
+		found, err := c.rates.FindTaxRates(strings.ToUpper(req.Address.Country), req.Address.State)
+		if err != nil {
+			return nil, err
+		}
+		rates = found
+	}
+
+	result := &Result{Lines: make([]LineResult, len(req.Lines))}
+	for i, line := range req.Lines {
+		rate := matchRate(rates, line.TaxClass, req.Address.State)
+		net, tax, gross := Apply(line.Amount, rate, c.pricing)
+
+		result.Lines[i] = LineResult{
+			ID:          line.ID,
+			TaxClass:    line.TaxClass,
+			Rate:        rate,
+			NetAmount:   net,
+			TaxAmount:   tax,
+			GrossAmount: gross,
+		}
+		result.NetTotal = result.NetTotal.Add(net)
+		result.TaxTotal = result.TaxTotal.Add(tax)
+		result.GrossTotal = result.GrossTotal.Add(gross)
+	}
+
+	return result, nil
+}
+
+// matchRate returns the percentage for the tax class, preferring a rate
+// specific to the state over the country wide one.
+func matchRate(rates []models.TaxRate, taxClass, state string) float64 {
+	var rate float64
+	matched := false
+
+	for i := range rates {
+		if !strings.EqualFold(rates[i].TaxClass, taxClass) {
+			continue
+		}
+
+		switch {
+		case rates[i].State != "" && strings.EqualFold(rates[i].State, state):
+			return rates[i].Rate
+		case rates[i].State == "" && !matched:
+			rate = rates[i].Rate
+			matched = true
+		}
+	}
+
+	return rate
+}
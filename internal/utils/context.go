@@ -7,4 +7,5 @@ const (
 	UserEmailKey  ContextKey = "user_email"
 	UserRoleKey   ContextKey = "user_role"
 	GinContextKey ContextKey = "gin_context"
+	CurrencyKey   ContextKey = "currency"
 )
@@ -70,6 +70,14 @@ func NotFoundResponse(c *gin.Context, message string) {
 	ErrorResponse(c, http.StatusNotFound, message, nil)
 }
 
+func ConflictResponse(c *gin.Context, message string, err error) {
+	ErrorResponse(c, http.StatusConflict, message, err)
+}
+
+func UnprocessableEntityResponse(c *gin.Context, message string, err error) {
+	ErrorResponse(c, http.StatusUnprocessableEntity, message, err)
+}
+
 func InternalServerErrorResponse(c *gin.Context, message string, err error) {
 	ErrorResponse(c, http.StatusInternalServerError, message, err)
 }
@@ -0,0 +1,63 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/rs/zerolog"
+)
+
+// LoyaltyRunner periodically credits delivered orders with the loyalty
+// points they earned and expires the points that lapsed. An order is marked
+// once it earns its points, so a restarted runner never credits it twice.
+type LoyaltyRunner struct {
+	loyalty  services.LoyaltyServiceInterface
+	interval time.Duration
+	log      *zerolog.Logger
+}
+
+// NewLoyaltyRunner creates the loyalty runner type
+func NewLoyaltyRunner(loyalty services.LoyaltyServiceInterface, interval time.Duration, log *zerolog.Logger) *LoyaltyRunner {
+	if interval <= 0 {
+		interval = defaultRunInterval
+	}
+
+	return &LoyaltyRunner{loyalty: loyalty, interval: interval, log: log}
+}
+
+// Run awards and expires points straight away, catching up on what happened
+// while nothing ran, and then every interval until the context is done.
+func (w *LoyaltyRunner) Run(ctx context.Context) {
+	w.RunOnce(time.Now())
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			w.RunOnce(now)
+		}
+	}
+}
+
+// RunOnce awards the points of the orders delivered by now and expires the
+// points that lapsed by then. Failures are logged and left to the next run.
+func (w *LoyaltyRunner) RunOnce(now time.Time) {
+	awarded, err := w.loyalty.AwardPoints(now)
+	if err != nil {
+		w.log.Error().Err(err).Msg("failed to award loyalty points")
+	} else if awarded > 0 {
+		w.log.Debug().Int("orders", awarded).Msg("awarded loyalty points to delivered orders")
+	}
+
+	expired, err := w.loyalty.ExpirePoints(now)
+	if err != nil {
+		w.log.Error().Err(err).Msg("failed to expire loyalty points")
+	} else if expired > 0 {
+		w.log.Debug().Int("points", expired).Msg("expired lapsed loyalty points")
+	}
+}
@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/rs/zerolog"
+)
+
+// defaultSweepInterval is used when no positive interval is configured.
+const defaultSweepInterval = time.Minute
+
+// ReservationSweeper periodically releases the inventory reservations that
+// have expired.
+type ReservationSweeper struct {
+	inventory services.InventoryServiceInterface
+	interval  time.Duration
+	log       *zerolog.Logger
+}
+
+// NewReservationSweeper creates the reservation sweeper type
+func NewReservationSweeper(inventory services.InventoryServiceInterface, interval time.Duration, log *zerolog.Logger) *ReservationSweeper {
+	if interval <= 0 {
+		interval = defaultSweepInterval
+	}
+
+	return &ReservationSweeper{inventory: inventory, interval: interval, log: log}
+}
+
+// Run sweeps every interval until the context is done.
+func (w *ReservationSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			w.Sweep(now)
+		}
+	}
+}
+
+// Sweep releases the reservations that expired by now. Failures are logged
+// and left to the next sweep.
+func (w *ReservationSweeper) Sweep(now time.Time) {
+	released, err := w.inventory.ReleaseExpiredReservations(now)
+	if err != nil {
+		w.log.Error().Err(err).Msg("failed to release expired inventory reservations")
+		return
+	}
+
+	if released > 0 {
+		w.log.Debug().Int64("released", released).Msg("released expired inventory reservations")
+	}
+}
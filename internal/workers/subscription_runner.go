@@ -0,0 +1,65 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/rs/zerolog"
+)
+
+// defaultRunInterval is used when no positive interval is configured.
+const defaultRunInterval = time.Minute
+
+// SubscriptionRunner periodically places the orders of the subscriptions
+// that are due. Every run is recorded with the subscription's next date, so
+// a restarted runner picks up where it left off without ordering twice.
+type SubscriptionRunner struct {
+	subscriptions services.SubscriptionServiceInterface
+	interval      time.Duration
+	log           *zerolog.Logger
+}
+
+// NewSubscriptionRunner creates the subscription runner type
+func NewSubscriptionRunner(subscriptions services.SubscriptionServiceInterface, interval time.Duration, log *zerolog.Logger) *SubscriptionRunner {
+	if interval <= 0 {
+		interval = defaultRunInterval
+	}
+
+	return &SubscriptionRunner{subscriptions: subscriptions, interval: interval, log: log}
+}
+
+// Run runs the due subscriptions straight away, catching up on those that
+// fell due while nothing ran them, and then every interval until the context
+// is done.
+func (w *SubscriptionRunner) Run(ctx context.Context) {
+	w.RunDue(time.Now())
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			w.RunDue(now)
+		}
+	}
+}
+
+// RunDue places the orders of the subscriptions due by now. Failures are
+// logged and left to the next run.
+func (w *SubscriptionRunner) RunDue(now time.Time) {
+	ordered, failed, err := w.subscriptions.RunDueSubscriptions(now)
+	if err != nil {
+		w.log.Error().Err(err).Msg("failed to run due subscriptions")
+		return
+	}
+
+	if failed > 0 {
+		w.log.Warn().Int("ordered", ordered).Int("failed", failed).Msg("some due subscriptions could not place their orders")
+	} else if ordered > 0 {
+		w.log.Debug().Int("ordered", ordered).Msg("placed the orders of due subscriptions")
+	}
+}
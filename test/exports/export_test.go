@@ -0,0 +1,141 @@
+package exports_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/exports"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+func usd(amount int64) money.Money {
+	return money.New(amount, "USD")
+}
+
+func newOrder(id uint, items ...exports.Item) *exports.Order {
+	return &exports.Order{
+		ID:             id,
+		Status:         "confirmed",
+		CustomerID:     2,
+		CustomerEmail:  "ada@example.com",
+		TotalAmount:    usd(2698),
+		ShippingCost:   usd(500),
+		DiscountAmount: usd(0),
+		TaxAmount:      usd(200),
+		ExchangeRate:   1,
+		CreatedAt:      time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC),
+		Items:          items,
+	}
+}
+
+var item = exports.Item{
+	ID: 7, ProductID: 1, SKU: "MUG-1", ProductName: "Mug, large", Quantity: 2,
+	UnitPrice: usd(999), Discount: usd(0), TaxRate: 10, TaxAmount: usd(200),
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := exports.NewCSVWriter(&buf)
+
+	second := item
+	second.ID = 8
+	second.SKU = "CUP-1"
+
+	if err := w.Write(newOrder(500, item, second)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := w.Write(newOrder(501)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("expected a header and 3 rows, got %d: %v", len(records), records)
+	}
+
+	header := strings.Join(records[0][:3], ",")
+	if header != "order_id,order_date,status" {
+		t.Errorf("unexpected header %v", records[0])
+	}
+
+	row := records[1]
+	if row[0] != "500" || row[1] != "2026-03-14T10:00:00Z" || row[5] != "USD" || row[6] != "26.98" {
+		t.Errorf("unexpected order columns %v", row)
+	}
+	if row[13] != "MUG-1" || row[14] != "Mug, large" || row[15] != "2" || row[16] != "9.99" || row[18] != "10" {
+		t.Errorf("unexpected item columns %v", row)
+	}
+	if records[2][0] != "500" || records[2][13] != "CUP-1" {
+		t.Errorf("expected the second item of order 500, got %v", records[2])
+	}
+	if records[3][0] != "501" || records[3][11] != "" {
+		t.Errorf("expected order 501 without item columns, got %v", records[3])
+	}
+}
+
+func TestCSVWriter_NoOrders(t *testing.T) {
+	var buf bytes.Buffer
+	w := exports.NewCSVWriter(&buf)
+
+	if err := w.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "order_id,") || strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("expected only the header, got %q", buf.String())
+	}
+}
+
+func TestJSONLWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := exports.NewJSONLWriter(&buf)
+
+	if err := w.Write(newOrder(500, item)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := w.Write(newOrder(501)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected the orders to stay buffered until flushed, got %q", buf.String())
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+
+	var first exports.Order
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("invalid JSON line: %v", err)
+	}
+	if first.ID != 500 || first.TotalAmount != usd(2698) || len(first.Items) != 1 || first.Items[0].SKU != "MUG-1" {
+		t.Errorf("unexpected order %+v", first)
+	}
+	if !strings.Contains(lines[1], `"items":[]`) {
+		t.Errorf("expected an empty item list, got %s", lines[1])
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	if _, err := exports.NewWriter(exports.FormatJSONL, &bytes.Buffer{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, err := exports.NewWriter("xlsx", &bytes.Buffer{}); !errors.Is(err, exports.ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/kuldeepstechwork/gocart-api/graph/resolver"
 	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
 	"github.com/kuldeepstechwork/gocart-api/test/mocks"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -17,7 +18,7 @@ func TestQueryResolver_Categories(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockProductService := mocks.NewMockProductServiceInterface(ctrl)
-	r := resolver.NewResolver(nil, nil, mockProductService, nil, nil)
+	r := resolver.NewResolver(nil, nil, nil, mockProductService, nil, nil, nil)
 	query := r.Query()
 
 	t.Run("success", func(t *testing.T) {
@@ -49,7 +50,7 @@ func TestMutationResolver_Login(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockAuthService := mocks.NewMockAuthServiceInterface(ctrl)
-	r := resolver.NewResolver(mockAuthService, nil, nil, nil, nil)
+	r := resolver.NewResolver(mockAuthService, nil, nil, nil, nil, nil, nil)
 	mutation := r.Mutation()
 
 	t.Run("success", func(t *testing.T) {
@@ -74,7 +75,7 @@ func TestQueryResolver_Me(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserService := mocks.NewMockUserServiceInterface(ctrl)
-	r := resolver.NewResolver(nil, mockUserService, nil, nil, nil)
+	r := resolver.NewResolver(nil, mockUserService, nil, nil, nil, nil, nil)
 	query := r.Query()
 
 	t.Run("success", func(t *testing.T) {
@@ -99,3 +100,81 @@ func TestQueryResolver_Me(t *testing.T) {
 		assert.Nil(t, res)
 	})
 }
+
+func TestMutationResolver_UpdateOrderStatus(t *testing.T) {
+	// The order service rejects the statuses set by shipments before it
+	// touches the database
+	orderService := services.NewOrderService(nil, "USD", nil, nil, nil, nil, nil)
+	r := resolver.NewResolver(nil, nil, nil, nil, nil, orderService, nil)
+	mutation := r.Mutation()
+
+	t.Run("shipment status", func(t *testing.T) {
+		ctx := createAuthContext(1, "admin")
+
+		for _, status := range []string{"partially_shipped", "shipped", "delivered"} {
+			res, err := mutation.UpdateOrderStatus(ctx, "100", dto.UpdateOrderStatusRequest{Status: status})
+
+			assert.ErrorIs(t, err, services.ErrInvalidOrderStatus, status)
+			assert.Nil(t, res)
+		}
+	})
+}
+
+func TestMutationResolver_RejectReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReturnService := mocks.NewMockReturnServiceInterface(ctrl)
+	r := resolver.NewResolver(nil, nil, nil, nil, nil, nil, mockReturnService)
+	mutation := r.Mutation()
+
+	t.Run("success", func(t *testing.T) {
+		mockReturnService.EXPECT().RejectReturn(uint(7)).Return(&dto.ReturnResponse{ID: 7, Status: "rejected"}, nil)
+
+		res, err := mutation.RejectReturn(createAuthContext(1, "admin"), "7")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "rejected", res.Status)
+	})
+
+	t.Run("not admin", func(t *testing.T) {
+		res, err := mutation.RejectReturn(createAuthContext(1, "customer"), "7")
+
+		assert.ErrorIs(t, err, resolver.ErrUnauthorized)
+		assert.Nil(t, res)
+	})
+}
+
+func TestMutationResolver_CancelReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReturnService := mocks.NewMockReturnServiceInterface(ctrl)
+	r := resolver.NewResolver(nil, nil, nil, nil, nil, nil, mockReturnService)
+	mutation := r.Mutation()
+
+	t.Run("success", func(t *testing.T) {
+		mockReturnService.EXPECT().CancelReturn(uint(3), uint(7)).Return(&dto.ReturnResponse{ID: 7, Status: "cancelled"}, nil)
+
+		res, err := mutation.CancelReturn(createAuthContext(3, "customer"), "7")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "cancelled", res.Status)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockReturnService.EXPECT().CancelReturn(uint(3), uint(8)).Return(nil, services.ErrReturnNotFound)
+
+		res, err := mutation.CancelReturn(createAuthContext(3, "customer"), "8")
+
+		assert.ErrorIs(t, err, services.ErrReturnNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		res, err := mutation.CancelReturn(context.Background(), "7")
+
+		assert.ErrorIs(t, err, resolver.ErrUnauthorized)
+		assert.Nil(t, res)
+	})
+}
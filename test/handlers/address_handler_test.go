@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAddressHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	userID := uint(1)
+	token := createTestToken(userID)
+
+	newRequest := func(method, path, body string) *http.Request {
+		req := httptest.NewRequest(method, "/api/v1/users/addresses"+path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	validBody := `{"first_name":"Ada","last_name":"Lovelace","line1":"1 Main St","city":"Springfield","postal_code":"12345","country":"US"}`
+
+	t.Run("GetAddresses_Success", func(t *testing.T) {
+		ts.AddressService.EXPECT().GetAddresses(userID).Return([]dto.AddressResponse{{ID: 30}}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodGet, "", ""))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("CreateAddress_Success", func(t *testing.T) {
+		ts.AddressService.EXPECT().CreateAddress(userID, gomock.Any()).Return(&dto.AddressResponse{ID: 30}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "", validBody))
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("CreateAddress_InvalidCountry", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "", `{"first_name":"Ada","last_name":"Lovelace","line1":"1 Main St","city":"Springfield","postal_code":"12345","country":"USA"}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("UpdateAddress_NotFound", func(t *testing.T) {
+		ts.AddressService.EXPECT().UpdateAddress(userID, uint(99), gomock.Any()).Return(nil, services.ErrAddressNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPut, "/99", validBody))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("DeleteAddress_Success", func(t *testing.T) {
+		ts.AddressService.EXPECT().DeleteAddress(userID, uint(30)).Return(nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodDelete, "/30", ""))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", w.Code)
+		}
+	})
+}
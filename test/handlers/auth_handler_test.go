@@ -162,3 +162,40 @@ func TestAuthHandler_Logout(t *testing.T) {
 		}
 	})
 }
+
+func TestAuthHandler_VerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+
+	newRequest := func(token string) *http.Request {
+		body, _ := json.Marshal(dto.VerifyEmailRequest{Token: token})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/verify-email", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		ts.AuthService.EXPECT().VerifyEmail(&dto.VerifyEmailRequest{Token: "verify-1"}).Return(&dto.UserResponse{ID: 1}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("verify-1"))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		ts.AuthService.EXPECT().VerifyEmail(gomock.Any()).Return(nil, errors.New("invalid verification token"))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("unknown"))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
 	"go.uber.org/mock/gomock"
 )
 
@@ -22,7 +23,7 @@ func TestCartHandler_GetCart(t *testing.T) {
 	token := createTestToken(userID)
 
 	t.Run("Success", func(t *testing.T) {
-		ts.CartService.EXPECT().GetCart(userID).Return(&dto.CartResponse{UserID: userID}, nil)
+		ts.CartService.EXPECT().GetCart(userID, money.Currency("")).Return(&dto.CartResponse{UserID: userID}, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/cart/", nil)
 		req.Header.Set("Authorization", "Bearer "+token)
@@ -35,7 +36,7 @@ func TestCartHandler_GetCart(t *testing.T) {
 	})
 
 	t.Run("NotFound", func(t *testing.T) {
-		ts.CartService.EXPECT().GetCart(userID).Return(nil, errors.New("not found"))
+		ts.CartService.EXPECT().GetCart(userID, money.Currency("")).Return(nil, errors.New("not found"))
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/cart/", nil)
 		req.Header.Set("Authorization", "Bearer "+token)
@@ -61,7 +62,7 @@ func TestCartHandler_AddToCart(t *testing.T) {
 		reqBody := dto.AddToCartRequest{ProductID: 1, Quantity: 2}
 		body, _ := json.Marshal(reqBody)
 
-		ts.CartService.EXPECT().AddToCart(userID, gomock.Any()).Return(&dto.CartResponse{}, nil)
+		ts.CartService.EXPECT().AddToCart(userID, gomock.Any(), money.Currency("")).Return(&dto.CartResponse{}, nil)
 
 		req := httptest.NewRequest(http.MethodPost, "/api/v1/cart/items", bytes.NewBuffer(body))
 		req.Header.Set("Authorization", "Bearer "+token)
@@ -88,7 +89,7 @@ func TestCartHandler_UpdateCartItem(t *testing.T) {
 		reqBody := dto.UpdateCartItemRequest{Quantity: 5}
 		body, _ := json.Marshal(reqBody)
 
-		ts.CartService.EXPECT().UpdateCartItem(userID, uint(10), gomock.Any()).Return(&dto.CartResponse{}, nil)
+		ts.CartService.EXPECT().UpdateCartItem(userID, uint(10), gomock.Any(), money.Currency("")).Return(&dto.CartResponse{}, nil)
 
 		req := httptest.NewRequest(http.MethodPut, "/api/v1/cart/items/10", bytes.NewBuffer(body))
 		req.Header.Set("Authorization", "Bearer "+token)
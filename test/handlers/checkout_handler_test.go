@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/payments"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCheckoutHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	userID := uint(1)
+	token := createTestToken(userID)
+
+	t.Run("CreateSession", func(t *testing.T) {
+		ts.CheckoutService.EXPECT().CreateCheckoutSession(userID, gomock.Any(), money.Currency("")).
+			Return(&dto.CheckoutSessionResponse{ID: 5, Status: "open"}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/checkout/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("CreateSession_CartNotFound", func(t *testing.T) {
+		ts.CheckoutService.EXPECT().CreateCheckoutSession(userID, gomock.Any(), money.Currency("")).
+			Return(nil, services.ErrCartNotFound)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/checkout/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("GetSession", func(t *testing.T) {
+		ts.CheckoutService.EXPECT().GetCheckoutSession(userID, uint(5)).
+			Return(&dto.CheckoutSessionResponse{ID: 5, Status: "open"}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/checkout/5", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("GetSession_NotFound", func(t *testing.T) {
+		ts.CheckoutService.EXPECT().GetCheckoutSession(userID, uint(6)).
+			Return(nil, services.ErrCheckoutSessionNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/checkout/6", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("CompleteSession", func(t *testing.T) {
+		ts.CheckoutService.EXPECT().CompleteCheckoutSession(userID, uint(5)).
+			Return(&dto.OrderResponse{ID: 40}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/checkout/5/complete", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"Expired", services.ErrCheckoutSessionExpired, http.StatusGone},
+		{"CartChanged", services.ErrCheckoutCartChanged, http.StatusConflict},
+		{"Closed", fmt.Errorf("%w: the session is completed", services.ErrCheckoutSessionClosed), http.StatusConflict},
+		{"PaymentDeclined", payments.ErrPaymentDeclined, http.StatusPaymentRequired},
+		{"InsufficientStock", fmt.Errorf("insufficient stock for product: Mug"), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run("CompleteSession_"+tt.name, func(t *testing.T) {
+			ts.CheckoutService.EXPECT().CompleteCheckoutSession(userID, uint(5)).Return(nil, tt.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/checkout/5/complete", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("expected status %d, got %d. Body: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	t.Run("CompleteSession_InvalidID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/checkout/abc/complete", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+}
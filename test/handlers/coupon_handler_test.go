@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCartCouponHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	userID := uint(1)
+	token := createTestToken(userID)
+
+	newRequest := func(method, body string) *http.Request {
+		req := httptest.NewRequest(method, "/api/v1/cart/coupon", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("ApplyCoupon_Success", func(t *testing.T) {
+		ts.CartService.EXPECT().
+			ApplyCoupon(userID, &dto.ApplyCouponRequest{Code: "SAVE10"}, money.Currency("")).
+			Return(&dto.CartResponse{ID: 10, Coupon: &dto.AppliedCouponResponse{Code: "SAVE10", Type: "percentage", Discount: usd(600)}, Discount: usd(600)}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, `{"code":"SAVE10"}`))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("ApplyCoupon_MissingCode", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, `{}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("ApplyCoupon_NotFound", func(t *testing.T) {
+		ts.CartService.EXPECT().ApplyCoupon(userID, gomock.Any(), money.Currency("")).Return(nil, services.ErrCouponNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, `{"code":"NOPE"}`))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("ApplyCoupon_NotApplicable", func(t *testing.T) {
+		ts.CartService.EXPECT().ApplyCoupon(userID, gomock.Any(), money.Currency("")).
+			Return(nil, fmt.Errorf("%w: the coupon has expired or is no longer available", services.ErrCouponNotApplicable))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, `{"code":"EXPIRED"}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("RemoveCoupon_Success", func(t *testing.T) {
+		ts.CartService.EXPECT().RemoveCoupon(userID, money.Currency("")).Return(&dto.CartResponse{ID: 10}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodDelete, ""))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+}
+
+func TestAdminCouponHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	adminToken := createAdminToken(1)
+	customerToken := createTestToken(2)
+
+	newRequest := func(method, path, token, body string) *http.Request {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("CreateCoupon_Success", func(t *testing.T) {
+		ts.CouponService.EXPECT().
+			CreateCoupon(&dto.CouponRequest{Code: "SUMMER25", Type: "percentage", Percent: 25, UsageLimit: 100, CategoryIDs: []uint{50}}).
+			Return(&dto.CouponResponse{ID: 5, Code: "SUMMER25"}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/admin/coupons/", adminToken,
+			`{"code":"SUMMER25","type":"percentage","percent":25,"usage_limit":100,"category_ids":[50]}`))
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("CreateCoupon_UnknownType", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/admin/coupons/", adminToken, `{"code":"BOGO","type":"bogo"}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("CreateCoupon_Invalid", func(t *testing.T) {
+		ts.CouponService.EXPECT().CreateCoupon(gomock.Any()).Return(nil, services.ErrInvalidCoupon)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/admin/coupons/", adminToken, `{"code":"ZERO","type":"fixed","fixed_amount":{"amount":0}}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("DeleteCoupon_NotFound", func(t *testing.T) {
+		ts.CouponService.EXPECT().DeleteCoupon(uint(99)).Return(services.ErrCouponNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodDelete, "/api/v1/admin/coupons/99", adminToken, ""))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("Forbidden_NonAdmin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodGet, "/api/v1/admin/coupons/", customerToken, ""))
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", w.Code)
+		}
+	})
+}
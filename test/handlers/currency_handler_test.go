@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCurrencyMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+
+	t.Run("QueryParameter", func(t *testing.T) {
+		ts.ProductService.EXPECT().GetProduct(uint(1), money.Currency("EUR")).
+			Return(&dto.ProductResponse{ID: 1, Price: money.New(1839, "EUR")}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products/1?currency=eur", nil)
+		req.Header.Set("X-Currency", "USD")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Header", func(t *testing.T) {
+		ts.ProductService.EXPECT().GetProduct(uint(1), money.Currency("EUR")).
+			Return(&dto.ProductResponse{ID: 1, Price: money.New(1839, "EUR")}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products/1", nil)
+		req.Header.Set("X-Currency", "EUR")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("UnsupportedCurrency", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products/1?currency=GBP", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("CurrencyWithoutRate", func(t *testing.T) {
+		ts.ProductService.EXPECT().GetProduct(uint(1), money.Currency("EUR")).
+			Return(nil, services.ErrCurrencyNotAvailable)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products/1?currency=EUR", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("GetCurrencies", func(t *testing.T) {
+		ts.CurrencyService.EXPECT().GetCurrencies().
+			Return([]dto.CurrencyResponse{{Code: "USD", Rate: 1, IsDefault: true}, {Code: "EUR", Rate: 0.92}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/currencies", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+}
+
+func TestAdminCurrencyHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	adminToken := createAdminToken(1)
+	customerToken := createTestToken(2)
+
+	newRequest := func(method, path, token, body string) *http.Request {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("SetExchangeRate_Success", func(t *testing.T) {
+		ts.CurrencyService.EXPECT().
+			SetExchangeRate(money.Currency("EUR"), &dto.ExchangeRateRequest{Rate: 0.92}).
+			Return(&dto.ExchangeRateResponse{Currency: "EUR", Rate: 0.92}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPut, "/api/v1/admin/currencies/rates/eur", adminToken, `{"rate":0.92}`))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("SetExchangeRate_InvalidRate", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPut, "/api/v1/admin/currencies/rates/EUR", adminToken, `{"rate":-1}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("SetExchangeRate_Unsupported", func(t *testing.T) {
+		ts.CurrencyService.EXPECT().SetExchangeRate(money.Currency("GBP"), gomock.Any()).
+			Return(nil, services.ErrCurrencyNotSupported)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPut, "/api/v1/admin/currencies/rates/GBP", adminToken, `{"rate":0.79}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("DeleteExchangeRate_NotFound", func(t *testing.T) {
+		ts.CurrencyService.EXPECT().DeleteExchangeRate(money.Currency("EUR")).Return(services.ErrExchangeRateNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodDelete, "/api/v1/admin/currencies/rates/EUR", adminToken, ""))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("SetProductPrice_Success", func(t *testing.T) {
+		ts.CurrencyService.EXPECT().
+			SetProductPrice(uint(1), money.Currency("EUR"), &dto.ProductPriceRequest{Amount: 1900}).
+			Return(&dto.ProductPriceResponse{ProductID: 1, Price: money.New(1900, "EUR")}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPut, "/api/v1/admin/products/1/prices/EUR", adminToken, `{"amount":1900}`))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("SetProductPrice_ProductNotFound", func(t *testing.T) {
+		ts.CurrencyService.EXPECT().SetProductPrice(uint(99), money.Currency("EUR"), gomock.Any()).
+			Return(nil, services.ErrProductNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPut, "/api/v1/admin/products/99/prices/EUR", adminToken, `{"amount":1900}`))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("Forbidden_NonAdmin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodGet, "/api/v1/admin/currencies/rates", customerToken, ""))
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", w.Code)
+		}
+	})
+}
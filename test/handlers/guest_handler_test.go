@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGuestHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	cartToken := "3f0c8a4e-5d1b-4f6a-9c2e-7b8d9e0f1a2b"
+
+	t.Run("CreateCart", func(t *testing.T) {
+		ts.CartService.EXPECT().CreateGuestCart(money.Currency("")).Return(&dto.CartResponse{ID: 20, Token: cartToken}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/guest/cart", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("GetCart", func(t *testing.T) {
+		ts.CartService.EXPECT().GetGuestCart(cartToken, money.Currency("")).Return(&dto.CartResponse{ID: 20}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/guest/cart", nil)
+		req.Header.Set("X-Cart-Token", cartToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("MissingCartToken", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/guest/cart", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("AddToCart_UnknownCart", func(t *testing.T) {
+		body, _ := json.Marshal(dto.AddToCartRequest{ProductID: 1, Quantity: 2})
+
+		ts.CartService.EXPECT().AddToGuestCart("unknown", gomock.Any(), money.Currency("")).Return(nil, services.ErrCartNotFound)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/guest/cart/items", bytes.NewBuffer(body))
+		req.Header.Set("X-Cart-Token", "unknown")
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("CreateOrder", func(t *testing.T) {
+		body, _ := json.Marshal(dto.GuestOrderRequest{
+			Email: "ada@example.com",
+			ShippingAddress: dto.AddressRequest{
+				FirstName: "Ada", LastName: "Lovelace", Line1: "1 Main St",
+				City: "Springfield", PostalCode: "12345", Country: "US",
+			},
+		})
+
+		ts.OrderService.EXPECT().CreateGuestOrder(cartToken, gomock.Any(), money.Currency("")).
+			DoAndReturn(func(_ string, req *dto.GuestOrderRequest, _ money.Currency) (*dto.OrderResponse, error) {
+				if req.Email != "ada@example.com" || req.ShippingAddress.City != "Springfield" {
+					t.Errorf("unexpected request %+v", req)
+				}
+				return &dto.OrderResponse{ID: 510, Number: "GC-7KQ2M9XW4P"}, nil
+			})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/guest/orders", bytes.NewBuffer(body))
+		req.Header.Set("X-Cart-Token", cartToken)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("CreateOrder_InvalidEmail", func(t *testing.T) {
+		body, _ := json.Marshal(dto.GuestOrderRequest{Email: "not-an-email"})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/guest/orders", bytes.NewBuffer(body))
+		req.Header.Set("X-Cart-Token", cartToken)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("LookupOrder", func(t *testing.T) {
+		body, _ := json.Marshal(dto.GuestOrderLookupRequest{Number: "GC-7KQ2M9XW4P", Email: "ada@example.com"})
+
+		ts.OrderService.EXPECT().LookupGuestOrder(&dto.GuestOrderLookupRequest{Number: "GC-7KQ2M9XW4P", Email: "ada@example.com"}).
+			Return(&dto.OrderResponse{ID: 510}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/guest/orders/lookup", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("LookupOrder_NotFound", func(t *testing.T) {
+		body, _ := json.Marshal(dto.GuestOrderLookupRequest{Number: "GC-7KQ2M9XW4P", Email: "eve@example.com"})
+
+		ts.OrderService.EXPECT().LookupGuestOrder(gomock.Any()).Return(nil, services.ErrOrderNotFound)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/guest/orders/lookup", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+}
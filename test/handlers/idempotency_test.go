@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/repositories"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	userID := uint(1)
+	token := createTestToken(userID)
+
+	newRequest := func(key, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/cart/items", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", key)
+		return req
+	}
+
+	var stored models.IdempotencyKey
+
+	t.Run("FirstRequest_StoresResponse", func(t *testing.T) {
+		ts.IdempotencyRepo.EXPECT().Get(userID, "", "key-1").Return(nil, gorm.ErrRecordNotFound)
+		ts.IdempotencyRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(record *models.IdempotencyKey) error {
+			record.ID = 7
+			stored = *record
+			return nil
+		})
+		ts.CartService.EXPECT().AddToCart(userID, gomock.Any(), money.Currency("")).Return(&dto.CartResponse{ID: 10}, nil)
+		ts.IdempotencyRepo.EXPECT().Complete(uint(7), http.StatusOK, gomock.Any()).
+			DoAndReturn(func(_ uint, status int, body []byte) error {
+				now := time.Now()
+				stored.StatusCode = status
+				stored.ResponseBody = body
+				stored.CompletedAt = &now
+				return nil
+			})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("key-1", `{"product_id":1,"quantity":2}`))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Retry_ReplaysStoredResponse", func(t *testing.T) {
+		ts.IdempotencyRepo.EXPECT().Get(userID, "", "key-1").Return(&stored, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("key-1", `{"product_id":1,"quantity":2}`))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", w.Code)
+		}
+		if w.Header().Get("Idempotent-Replayed") != "true" {
+			t.Error("expected replayed response header")
+		}
+		if w.Body.String() != string(stored.ResponseBody) {
+			t.Errorf("expected stored body %s, got %s", stored.ResponseBody, w.Body.String())
+		}
+	})
+
+	t.Run("Retry_DifferentPayload", func(t *testing.T) {
+		ts.IdempotencyRepo.EXPECT().Get(userID, "", "key-1").Return(&stored, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("key-1", `{"product_id":1,"quantity":5}`))
+
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("expected status 422, got %d", w.Code)
+		}
+	})
+
+	t.Run("Retry_DifferentCurrency", func(t *testing.T) {
+		ts.IdempotencyRepo.EXPECT().Get(userID, "", "key-1").Return(&stored, nil)
+
+		req := newRequest("key-1", `{"product_id":1,"quantity":2}`)
+		req.Header.Set("X-Currency", "EUR")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("expected status 422, got %d", w.Code)
+		}
+	})
+
+	t.Run("Retry_StillInProgress", func(t *testing.T) {
+		inProgress := stored
+		inProgress.CompletedAt = nil
+		ts.IdempotencyRepo.EXPECT().Get(userID, "", "key-1").Return(&inProgress, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("key-1", `{"product_id":1,"quantity":2}`))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("ConcurrentClaim", func(t *testing.T) {
+		ts.IdempotencyRepo.EXPECT().Get(userID, "", "key-2").Return(nil, gorm.ErrRecordNotFound)
+		ts.IdempotencyRepo.EXPECT().Create(gomock.Any()).Return(repositories.ErrIdempotencyKeyExists)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("key-2", `{"product_id":1,"quantity":2}`))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("HandlerPanics_ReleasesKey", func(t *testing.T) {
+		ts.IdempotencyRepo.EXPECT().Get(userID, "", "key-3").Return(nil, gorm.ErrRecordNotFound)
+		ts.IdempotencyRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(record *models.IdempotencyKey) error {
+			record.ID = 9
+			return nil
+		})
+		ts.CartService.EXPECT().AddToCart(userID, gomock.Any(), money.Currency("")).
+			DoAndReturn(func(uint, *dto.AddToCartRequest, money.Currency) (*dto.CartResponse, error) {
+				panic("cart service panicked")
+			})
+		// The key is released so that the client can retry with it
+		ts.IdempotencyRepo.EXPECT().Delete(uint(9)).Return(nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("key-3", `{"product_id":1,"quantity":2}`))
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("expected status 500, got %d", w.Code)
+		}
+	})
+
+	t.Run("Guest_KeyedByCart", func(t *testing.T) {
+		// Guests have no user, so their keys are kept per cart
+		ts.IdempotencyRepo.EXPECT().Get(uint(0), "guest-token", "key-1").Return(nil, gorm.ErrRecordNotFound)
+		ts.IdempotencyRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(record *models.IdempotencyKey) error {
+			if record.UserID != 0 || record.CartToken != "guest-token" {
+				t.Errorf("expected the key to belong to the guest cart, got user %d and cart %q", record.UserID, record.CartToken)
+			}
+			record.ID = 8
+			return nil
+		})
+		ts.CartService.EXPECT().AddToGuestCart("guest-token", gomock.Any(), money.Currency("")).Return(&dto.CartResponse{ID: 20}, nil)
+		ts.IdempotencyRepo.EXPECT().Complete(uint(8), http.StatusOK, gomock.Any()).Return(nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/guest/cart/items", strings.NewReader(`{"product_id":1,"quantity":2}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Cart-Token", "guest-token")
+		req.Header.Set("Idempotency-Key", "key-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("NoHeader_Bypasses", func(t *testing.T) {
+		ts.CartService.EXPECT().AddToCart(userID, gomock.Any(), money.Currency("")).Return(&dto.CartResponse{ID: 10}, nil)
+
+		req := newRequest("", `{"product_id":1,"quantity":2}`)
+		req.Header.Del("Idempotency-Key")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", w.Code)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAdminInventoryHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	adminToken := createAdminToken(1)
+	customerToken := createTestToken(2)
+
+	newRequest := func(token, query string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/backorders/"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		return req
+	}
+
+	t.Run("ListBackorders_Success", func(t *testing.T) {
+		productID := uint(1000)
+		ts.InventoryService.EXPECT().
+			ListBackorders(&dto.ListBackordersRequest{ProductID: &productID, StockStatus: "backordered", Page: 1, Limit: 10}).
+			Return([]dto.BackorderResponse{{OrderItemID: 600, StockStatus: "backordered", BackorderedQuantity: 2}}, &utils.PaginationMeta{Page: 1, Limit: 10, Total: 1, TotalPages: 1}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(adminToken, "?product_id=1000&stock_status=backordered&page=1&limit=10"))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("ListBackorders_InvalidStockStatus", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(adminToken, "?stock_status=in_stock"))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("ListBackorders_NotAdmin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(customerToken, ""))
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", w.Code)
+		}
+	})
+}
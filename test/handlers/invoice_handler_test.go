@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInvoiceHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	userID := uint(1)
+	token := createTestToken(userID)
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/100/invoice", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		return req
+	}
+
+	t.Run("GetInvoice_Success", func(t *testing.T) {
+		ts.InvoiceService.EXPECT().
+			GetInvoice(userID, uint(100)).
+			Return(&dto.InvoiceResponse{ID: 1, OrderID: 100, Number: "INV-000001", DocumentPath: "/uploads/invoices/100/INV-000001-a.pdf"}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest())
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("GetInvoice_NotInvoiced", func(t *testing.T) {
+		ts.InvoiceService.EXPECT().GetInvoice(userID, uint(100)).Return(nil, services.ErrInvoiceNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest())
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("GetInvoice_OrderNotFound", func(t *testing.T) {
+		ts.InvoiceService.EXPECT().GetInvoice(userID, uint(100)).Return(nil, services.ErrOrderNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest())
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+}
+
+func TestAdminInvoiceHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+
+	newRequest := func(token string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/orders/100/invoice", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		return req
+	}
+
+	t.Run("Regenerate_Success", func(t *testing.T) {
+		ts.InvoiceService.EXPECT().
+			GenerateInvoice(uint(100)).
+			Return(&dto.InvoiceResponse{ID: 1, OrderID: 100, Number: "INV-000001"}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(createAdminToken(1)))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Regenerate_NotInvoiced", func(t *testing.T) {
+		ts.InvoiceService.EXPECT().GenerateInvoice(uint(100)).Return(nil, services.ErrInvoiceNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(createAdminToken(1)))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("Regenerate_Forbidden", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(createTestToken(2)))
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", w.Code)
+		}
+	})
+}
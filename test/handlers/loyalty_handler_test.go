@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLoyaltyHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	userID := uint(1)
+	token := createTestToken(userID)
+
+	newRequest := func(token, path string) *http.Request {
+		req := httptest.NewRequest("GET", "/api/v1"+path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		return req
+	}
+
+	t.Run("GetLoyalty", func(t *testing.T) {
+		ts.LoyaltyService.EXPECT().
+			GetLoyalty(userID, 2, 5).
+			Return(&dto.LoyaltyResponse{Balance: 1500, BalanceValue: money.New(1500, "USD")}, &utils.PaginationMeta{Page: 2, Limit: 5}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(token, "/users/loyalty?page=2&limit=5"))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("GetLoyalty_Unauthorized", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/users/loyalty", nil))
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("expected status 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("GetLoyalty_Error", func(t *testing.T) {
+		ts.LoyaltyService.EXPECT().GetLoyalty(userID, 1, 10).Return(nil, nil, errors.New("db error"))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(token, "/users/loyalty"))
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("expected status 500, got %d", w.Code)
+		}
+	})
+}
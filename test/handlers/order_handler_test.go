@@ -2,11 +2,19 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/exports"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/payments"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
 	"github.com/kuldeepstechwork/gocart-api/internal/utils"
 	"go.uber.org/mock/gomock"
 )
@@ -21,7 +29,7 @@ func TestOrderHandler(t *testing.T) {
 	token := createTestToken(userID)
 
 	t.Run("CreateOrder_Success", func(t *testing.T) {
-		ts.OrderService.EXPECT().CreateOrder(userID).Return(&dto.OrderResponse{ID: 1}, nil)
+		ts.OrderService.EXPECT().CreateOrder(userID, gomock.Any(), money.Currency("")).Return(&dto.OrderResponse{ID: 1}, nil)
 
 		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/", nil)
 		req.Header.Set("Authorization", "Bearer "+token)
@@ -33,6 +41,63 @@ func TestOrderHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("CreateOrder_WithAddresses", func(t *testing.T) {
+		shippingID, billingID := uint(30), uint(31)
+		ts.OrderService.EXPECT().
+			CreateOrder(userID, &dto.CreateOrderRequest{ShippingAddressID: &shippingID, BillingAddressID: &billingID}, money.Currency("")).
+			Return(&dto.OrderResponse{ID: 2}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/", strings.NewReader(`{"shipping_address_id":30,"billing_address_id":31}`))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("CreateOrder_AddressNotFound", func(t *testing.T) {
+		ts.OrderService.EXPECT().CreateOrder(userID, gomock.Any(), money.Currency("")).Return(nil, services.ErrAddressNotFound)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/", strings.NewReader(`{"shipping_address_id":99}`))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("CreateOrder_PaymentDeclined", func(t *testing.T) {
+		ts.OrderService.EXPECT().CreateOrder(userID, gomock.Any(), money.Currency("")).Return(nil, payments.ErrPaymentDeclined)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusPaymentRequired {
+			t.Errorf("expected status 402, got %d", w.Code)
+		}
+	})
+
+	t.Run("CreateOrder_PaymentTimeout", func(t *testing.T) {
+		ts.OrderService.EXPECT().CreateOrder(userID, gomock.Any(), money.Currency("")).Return(nil, payments.ErrPaymentTimeout)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusGatewayTimeout {
+			t.Errorf("expected status 504, got %d", w.Code)
+		}
+	})
+
 	t.Run("GetOrders_Success", func(t *testing.T) {
 		ts.OrderService.EXPECT().GetOrders(userID, 1, 10).Return([]dto.OrderResponse{}, &utils.PaginationMeta{}, nil)
 
@@ -59,6 +124,52 @@ func TestOrderHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("CancelOrder_Success", func(t *testing.T) {
+		ts.OrderService.EXPECT().
+			CancelOrder(userID, uint(100), &dto.CancelOrderRequest{Reason: "ordered by mistake"}).
+			Return(&dto.OrderResponse{ID: 100, Status: "cancelled"}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/100/cancel", strings.NewReader(`{"reason":"ordered by mistake"}`))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("CancelOrder_NoBody", func(t *testing.T) {
+		ts.OrderService.EXPECT().
+			CancelOrder(userID, uint(100), &dto.CancelOrderRequest{}).
+			Return(&dto.OrderResponse{ID: 100, Status: "cancelled"}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/100/cancel", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("CancelOrder_AlreadyShipped", func(t *testing.T) {
+		ts.OrderService.EXPECT().
+			CancelOrder(userID, uint(100), gomock.Any()).
+			Return(nil, &services.InvalidStatusTransitionError{From: models.OrderStatusShipped, To: models.OrderStatusCancelled})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/100/cancel", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %d", w.Code)
+		}
+	})
+
 	t.Run("GetOrder_NotFound", func(t *testing.T) {
 		ts.OrderService.EXPECT().GetOrder(userID, uint(999)).Return(nil, errors.New("not found"))
 
@@ -71,4 +182,333 @@ func TestOrderHandler(t *testing.T) {
 			t.Errorf("expected status 404, got %d", w.Code)
 		}
 	})
+
+	t.Run("Reorder_Success", func(t *testing.T) {
+		ts.CartService.EXPECT().Reorder(userID, uint(100), money.Currency("")).Return(&dto.ReorderResponse{
+			Cart: &dto.CartResponse{ID: 10},
+			Lines: []dto.ReorderLineResponse{
+				{OrderItemID: 1, ProductID: 1, RequestedQuantity: 2, AddedQuantity: 2, Status: dto.ReorderLineAdded},
+				{OrderItemID: 2, ProductID: 2, RequestedQuantity: 1, Status: dto.ReorderLineDropped, Reason: "product is out of stock"},
+			},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/100/reorder", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		if !strings.Contains(w.Body.String(), `"status":"dropped"`) {
+			t.Errorf("expected the dropped line in the report, got %s", w.Body.String())
+		}
+	})
+
+	t.Run("Reorder_NotFound", func(t *testing.T) {
+		ts.CartService.EXPECT().Reorder(userID, uint(999), money.Currency("")).Return(nil, services.ErrOrderNotFound)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/999/reorder", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+}
+
+func TestAdminOrderHandler_UpdateOrderStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	adminID := uint(1)
+	adminToken := createAdminToken(adminID)
+	customerToken := createTestToken(2)
+
+	newRequest := func(token, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/orders/100/status", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		ts.OrderService.EXPECT().
+			UpdateOrderStatus(uint(100), adminID, &dto.UpdateOrderStatusRequest{Status: "confirmed", Reason: "paid"}).
+			Return(&dto.OrderResponse{ID: 100, Status: "confirmed"}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(adminToken, `{"status":"confirmed","reason":"paid"}`))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("IllegalTransition", func(t *testing.T) {
+		ts.OrderService.EXPECT().
+			UpdateOrderStatus(uint(100), adminID, gomock.Any()).
+			Return(nil, &services.InvalidStatusTransitionError{From: models.OrderStatusDelivered, To: models.OrderStatusPending})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(adminToken, `{"status":"pending"}`))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("ShipmentStatus", func(t *testing.T) {
+		ts.OrderService.EXPECT().
+			UpdateOrderStatus(uint(100), adminID, gomock.Any()).
+			Return(nil, fmt.Errorf("%w: shipped is set by the order's shipments", services.ErrInvalidOrderStatus))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(adminToken, `{"status":"shipped"}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("OrderNotFound", func(t *testing.T) {
+		ts.OrderService.EXPECT().
+			UpdateOrderStatus(uint(100), adminID, gomock.Any()).
+			Return(nil, services.ErrOrderNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(adminToken, `{"status":"cancelled"}`))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("Forbidden_NonAdmin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(customerToken, `{"status":"confirmed"}`))
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", w.Code)
+		}
+	})
+}
+
+func TestAdminOrderHandler_ListOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	adminToken := createAdminToken(1)
+	customerToken := createTestToken(2)
+
+	newRequest := func(path, token string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		return req
+	}
+
+	t.Run("ListOrders_Filters", func(t *testing.T) {
+		from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		ts.OrderService.EXPECT().ListOrders(&dto.ListOrdersRequest{
+			Status:      "confirmed",
+			CreatedFrom: &from,
+			Email:       "jane@example.com",
+			MinTotal:    &money.Money{Amount: 1000},
+			SKU:         "SKU-1",
+			Sort:        "-total",
+			Page:        2,
+			Limit:       5,
+		}).Return([]dto.OrderResponse{{ID: 500}}, &utils.PaginationMeta{Page: 2, Limit: 5, Total: 6, TotalPages: 2}, nil)
+
+		path := "/api/v1/admin/orders/?status=confirmed&created_from=2026-01-01T00:00:00Z&email=jane@example.com" +
+			"&min_total=1000&sku=SKU-1&sort=-total&page=2&limit=5"
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(path, adminToken))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("ListOrders_MalformedTotal", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("/api/v1/admin/orders/?min_total=12.50", adminToken))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("ListOrders_InvalidFilter", func(t *testing.T) {
+		ts.OrderService.EXPECT().ListOrders(gomock.Any()).
+			Return(nil, nil, fmt.Errorf("%w: cannot sort by %q", services.ErrInvalidOrderFilter, "email"))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("/api/v1/admin/orders/?sort=email", adminToken))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("GetOrderDetail_Success", func(t *testing.T) {
+		ts.OrderService.EXPECT().GetOrderDetail(uint(500)).Return(&dto.AdminOrderResponse{
+			OrderResponse: dto.OrderResponse{ID: 500},
+			Customer:      dto.UserResponse{ID: 2, Email: "jane@example.com"},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("/api/v1/admin/orders/500", adminToken))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("GetOrderDetail_NotFound", func(t *testing.T) {
+		ts.OrderService.EXPECT().GetOrderDetail(uint(99)).Return(nil, services.ErrOrderNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("/api/v1/admin/orders/99", adminToken))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("Forbidden_NonAdmin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("/api/v1/admin/orders/", customerToken))
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", w.Code)
+		}
+	})
+}
+
+func TestAdminOrderHandler_ExportOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	adminToken := createAdminToken(1)
+	customerToken := createTestToken(2)
+
+	newRequest := func(path, token string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		return req
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		ts.OrderService.EXPECT().
+			ExportOrders(&dto.ListOrdersRequest{Status: "delivered", SKU: "MUG-1"}, gomock.Any()).
+			DoAndReturn(func(_ *dto.ListOrdersRequest, w exports.Writer) error {
+				order := &exports.Order{ID: 500, Status: "delivered", TotalAmount: money.New(999, "USD")}
+				if err := w.Write(order); err != nil {
+					return err
+				}
+				return w.Flush()
+			})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("/api/v1/admin/orders/export?status=delivered&sku=MUG-1", adminToken))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/csv") {
+			t.Errorf("expected a CSV file, got %q", contentType)
+		}
+		if disposition := w.Header().Get("Content-Disposition"); !strings.Contains(disposition, `.csv"`) {
+			t.Errorf("expected a .csv attachment, got %q", disposition)
+		}
+		if lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[1], "500,") {
+			t.Errorf("expected the header and order 500, got %q", w.Body.String())
+		}
+	})
+
+	t.Run("JSONL", func(t *testing.T) {
+		ts.OrderService.EXPECT().ExportOrders(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *dto.ListOrdersRequest, w exports.Writer) error {
+				return w.Flush()
+			})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("/api/v1/admin/orders/export?format=jsonl", adminToken))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "application/jsonl") {
+			t.Errorf("expected a JSON Lines file, got %q", contentType)
+		}
+	})
+
+	t.Run("StreamsAsWritten", func(t *testing.T) {
+		// The orders reach the client in batches while the export runs,
+		// before it finishes
+		ts.OrderService.EXPECT().ExportOrders(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *dto.ListOrdersRequest, w exports.Writer) error {
+				for id := uint(1); id <= 100; id++ {
+					if err := w.Write(&exports.Order{ID: id, TotalAmount: money.New(999, "USD")}); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("/api/v1/admin/orders/export", adminToken))
+
+		if !w.Flushed {
+			t.Error("expected the export to be flushed to the client")
+		}
+		if lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n"); len(lines) != 101 {
+			t.Errorf("expected the header and 100 orders, got %d lines", len(lines))
+		}
+	})
+
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("/api/v1/admin/orders/export?format=xlsx", adminToken))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("InvalidFilter", func(t *testing.T) {
+		ts.OrderService.EXPECT().ExportOrders(gomock.Any(), gomock.Any()).Return(services.ErrInvalidOrderStatus)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("/api/v1/admin/orders/export?status=lost", adminToken))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+		if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "application/json") {
+			t.Errorf("expected a JSON error, got %q", contentType)
+		}
+		if disposition := w.Header().Get("Content-Disposition"); disposition != "" {
+			t.Errorf("expected no attachment, got %q", disposition)
+		}
+	})
+
+	t.Run("Forbidden_NonAdmin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("/api/v1/admin/orders/export", customerToken))
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", w.Code)
+		}
+	})
 }
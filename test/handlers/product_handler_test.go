@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
 	"github.com/kuldeepstechwork/gocart-api/internal/utils"
 	"go.uber.org/mock/gomock"
 )
@@ -20,7 +22,7 @@ func TestProductHandler_Public(t *testing.T) {
 	router := ts.Server.SetupRoutes()
 
 	t.Run("GetProducts", func(t *testing.T) {
-		ts.ProductService.EXPECT().GetProducts(gomock.Any(), gomock.Any()).Return([]dto.ProductResponse{}, &utils.PaginationMeta{}, nil)
+		ts.ProductService.EXPECT().GetProducts(gomock.Any(), gomock.Any(), money.Currency("")).Return([]dto.ProductResponse{}, &utils.PaginationMeta{}, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
 		w := httptest.NewRecorder()
@@ -32,7 +34,7 @@ func TestProductHandler_Public(t *testing.T) {
 	})
 
 	t.Run("GetProduct", func(t *testing.T) {
-		ts.ProductService.EXPECT().GetProduct(uint(1)).Return(&dto.ProductResponse{ID: 1}, nil)
+		ts.ProductService.EXPECT().GetProduct(uint(1), money.Currency("")).Return(&dto.ProductResponse{ID: 1}, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/products/1", nil)
 		w := httptest.NewRecorder()
@@ -44,7 +46,7 @@ func TestProductHandler_Public(t *testing.T) {
 	})
 
 	t.Run("SearchProducts", func(t *testing.T) {
-		ts.ProductService.EXPECT().SearchProducts(gomock.Any()).Return([]dto.ProductSearchResult{}, &utils.PaginationMeta{}, nil)
+		ts.ProductService.EXPECT().SearchProducts(gomock.Any(), money.Currency("")).Return([]dto.ProductSearchResult{}, &utils.PaginationMeta{}, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/search?q=test", nil)
 		w := httptest.NewRecorder()
@@ -55,6 +57,30 @@ func TestProductHandler_Public(t *testing.T) {
 		}
 	})
 
+	t.Run("SearchProducts_PriceRange", func(t *testing.T) {
+		ts.ProductService.EXPECT().
+			SearchProducts(&dto.SearchProductsRequest{Query: "test", MinPrice: &money.Money{Amount: 1000}, MaxPrice: &money.Money{Amount: 5000}}, money.Currency("")).
+			Return([]dto.ProductSearchResult{}, &utils.PaginationMeta{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/search?q=test&min_price=1000&max_price=5000", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("SearchProducts_PriceNotInMinorUnits", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/search?q=test&min_price=10.50", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
 	t.Run("GetCategories", func(t *testing.T) {
 		ts.ProductService.EXPECT().GetCategories().Return([]dto.CategoryResponse{}, nil)
 
@@ -80,7 +106,7 @@ func TestProductHandler_Admin(t *testing.T) {
 		reqBody := dto.CreateProductRequest{
 			CategoryID: 1,
 			Name:       "New Product",
-			Price:      100.0,
+			Price:      usd(10000),
 			Stock:      10,
 			SKU:        "TEST-SKU-1",
 		}
@@ -99,11 +125,26 @@ func TestProductHandler_Admin(t *testing.T) {
 		}
 	})
 
+	t.Run("CreateProduct_OtherCurrency", func(t *testing.T) {
+		ts.ProductService.EXPECT().CreateProduct(gomock.Any()).Return(nil, services.ErrInvalidProduct)
+
+		body := `{"category_id":1,"name":"New Product","price":{"amount":10000,"currency":"EUR"},"stock":10,"sku":"TEST-SKU-1"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/products/", bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
 	t.Run("UpdateProduct_Success", func(t *testing.T) {
 		reqBody := dto.UpdateProductRequest{
 			CategoryID: 1,
 			Name:       "Updated Product",
-			Price:      150.0,
+			Price:      usd(15000),
 			Stock:      5,
 		}
 		body, _ := json.Marshal(reqBody)
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAdminPromotionHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	adminToken := createAdminToken(1)
+	customerToken := createTestToken(2)
+
+	newRequest := func(method, path, token, body string) *http.Request {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("CreatePromotion_Success", func(t *testing.T) {
+		categoryID := uint(10)
+		ts.PromotionService.EXPECT().
+			CreatePromotion(&dto.PromotionRequest{Name: "Buy 2 get 1 free", Type: "buy_x_get_y", CategoryID: &categoryID,
+				BuyQuantity: 2, GetQuantity: 1, DiscountPercent: 100, Priority: 5}).
+			Return(&dto.PromotionResponse{ID: 7, Name: "Buy 2 get 1 free"}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/admin/promotions/", adminToken,
+			`{"name":"Buy 2 get 1 free","type":"buy_x_get_y","category_id":10,"buy_quantity":2,"get_quantity":1,"discount_percent":100,"priority":5}`))
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("CreatePromotion_UnknownType", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/admin/promotions/", adminToken, `{"name":"Mystery","type":"mystery"}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("CreatePromotion_Invalid", func(t *testing.T) {
+		ts.PromotionService.EXPECT().CreatePromotion(gomock.Any()).Return(nil, services.ErrInvalidPromotion)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/admin/promotions/", adminToken, `{"name":"Tiers","type":"spend_threshold"}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("UpdatePromotion_NotFound", func(t *testing.T) {
+		ts.PromotionService.EXPECT().UpdatePromotion(uint(99), gomock.Any()).Return(nil, services.ErrPromotionNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPut, "/api/v1/admin/promotions/99", adminToken,
+			`{"name":"Flash","type":"flash_sale","discount_percent":20}`))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("Forbidden_NonAdmin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodGet, "/api/v1/admin/promotions/", customerToken, ""))
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", w.Code)
+		}
+	})
+}
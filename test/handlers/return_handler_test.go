@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/payments"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReturnHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	userID := uint(1)
+	token := createTestToken(userID)
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/100/returns", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("RequestReturn_Success", func(t *testing.T) {
+		ts.ReturnService.EXPECT().
+			RequestReturn(userID, uint(100), &dto.CreateReturnRequest{
+				Reason: "damaged",
+				Items:  []dto.ReturnItemRequest{{OrderItemID: 5, Quantity: 1}},
+			}).
+			Return(&dto.ReturnResponse{ID: 1, Status: "requested"}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(`{"reason":"damaged","items":[{"order_item_id":5,"quantity":1}]}`))
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("RequestReturn_NoItems", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(`{"items":[]}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("RequestReturn_NotDelivered", func(t *testing.T) {
+		ts.ReturnService.EXPECT().
+			RequestReturn(userID, uint(100), gomock.Any()).
+			Return(nil, services.ErrOrderNotReturnable)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(`{"items":[{"order_item_id":5,"quantity":1}]}`))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("RequestReturn_TooMany", func(t *testing.T) {
+		ts.ReturnService.EXPECT().
+			RequestReturn(userID, uint(100), gomock.Any()).
+			Return(nil, fmt.Errorf("%w: at most 1 of order item 5 can be returned", services.ErrInvalidReturnItem))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(`{"items":[{"order_item_id":5,"quantity":3}]}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("GetReturns_Success", func(t *testing.T) {
+		ts.ReturnService.EXPECT().GetReturns(userID, 1, 10).Return([]dto.ReturnResponse{}, &utils.PaginationMeta{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/returns/?page=1&limit=10", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("CancelReturn_Success", func(t *testing.T) {
+		ts.ReturnService.EXPECT().CancelReturn(userID, uint(7)).Return(&dto.ReturnResponse{ID: 7, Status: "cancelled"}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/returns/7/cancel", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("CancelReturn_AlreadyReceived", func(t *testing.T) {
+		ts.ReturnService.EXPECT().
+			CancelReturn(userID, uint(7)).
+			Return(nil, &services.InvalidReturnTransitionError{From: models.ReturnStatusReceived, To: models.ReturnStatusCancelled})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/returns/7/cancel", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %d", w.Code)
+		}
+	})
+}
+
+func TestAdminReturnHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	adminToken := createAdminToken(1)
+	customerToken := createTestToken(2)
+
+	newRequest := func(token, path, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/returns/7/"+path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("Approve_Success", func(t *testing.T) {
+		ts.ReturnService.EXPECT().ApproveReturn(uint(7)).Return(&dto.ReturnResponse{ID: 7, Status: "approved"}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(adminToken, "approve", ""))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Reject_Success", func(t *testing.T) {
+		ts.ReturnService.EXPECT().RejectReturn(uint(7)).Return(&dto.ReturnResponse{ID: 7, Status: "rejected"}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(adminToken, "reject", ""))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Receive_WithRestock", func(t *testing.T) {
+		ts.ReturnService.EXPECT().
+			ReceiveReturn(uint(7), &dto.ReceiveReturnRequest{Restock: true}).
+			Return(&dto.ReturnResponse{ID: 7, Status: "received", Restocked: true}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(adminToken, "receive", `{"restock":true}`))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Refund_IllegalTransition", func(t *testing.T) {
+		ts.ReturnService.EXPECT().
+			RefundReturn(uint(7), &dto.RefundReturnRequest{}).
+			Return(nil, &services.InvalidReturnTransitionError{From: models.ReturnStatusRequested, To: models.ReturnStatusRefunded})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(adminToken, "refund", ""))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("Refund_ToWallet", func(t *testing.T) {
+		ts.ReturnService.EXPECT().
+			RefundReturn(uint(7), &dto.RefundReturnRequest{ToWallet: true}).
+			Return(&dto.ReturnResponse{ID: 7, Status: "refunded"}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(adminToken, "refund", `{"to_wallet":true}`))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Refund_Declined", func(t *testing.T) {
+		ts.ReturnService.EXPECT().RefundReturn(uint(7), &dto.RefundReturnRequest{}).Return(nil, payments.ErrPaymentDeclined)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(adminToken, "refund", ""))
+
+		if w.Code != http.StatusPaymentRequired {
+			t.Errorf("expected status 402, got %d", w.Code)
+		}
+	})
+
+	t.Run("Approve_NotFound", func(t *testing.T) {
+		ts.ReturnService.EXPECT().ApproveReturn(uint(7)).Return(nil, services.ErrReturnNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(adminToken, "approve", ""))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("Forbidden_NonAdmin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(customerToken, "approve", ""))
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", w.Code)
+		}
+	})
+}
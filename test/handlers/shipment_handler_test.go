@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"go.uber.org/mock/gomock"
+)
+
+func TestShipmentHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	adminID := uint(1)
+	userID := uint(2)
+	adminToken := createAdminToken(adminID)
+	customerToken := createTestToken(userID)
+
+	newRequest := func(method, path, token, body string) *http.Request {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("CreateShipment_Success", func(t *testing.T) {
+		ts.ShipmentService.EXPECT().
+			CreateShipment(uint(500), adminID, &dto.CreateShipmentRequest{
+				Carrier:        "UPS",
+				TrackingNumber: "1Z999",
+				Items:          []dto.ShipmentItemRequest{{OrderItemID: 600, Quantity: 2}},
+			}).
+			Return(&dto.ShipmentResponse{ID: 700, OrderID: 500, Status: "shipped"}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/admin/orders/500/shipments", adminToken,
+			`{"carrier":"UPS","tracking_number":"1Z999","items":[{"order_item_id":600,"quantity":2}]}`))
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("CreateShipment_MissingCarrier", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/admin/orders/500/shipments", adminToken, `{}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("CreateShipment_NotShippable", func(t *testing.T) {
+		ts.ShipmentService.EXPECT().CreateShipment(uint(500), adminID, gomock.Any()).Return(nil, services.ErrOrderNotShippable)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/admin/orders/500/shipments", adminToken, `{"carrier":"UPS"}`))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("UpdateShipment_Delivered", func(t *testing.T) {
+		ts.ShipmentService.EXPECT().UpdateShipment(uint(700), adminID, gomock.Any()).
+			Return(&dto.ShipmentResponse{ID: 700, OrderID: 500, Status: "delivered"}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPut, "/api/v1/admin/shipments/700", adminToken, `{"delivered_at":"2026-03-02T14:00:00Z"}`))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("UpdateShipment_IllegalTransition", func(t *testing.T) {
+		ts.ShipmentService.EXPECT().UpdateShipment(uint(700), adminID, gomock.Any()).
+			Return(nil, &services.InvalidStatusTransitionError{From: models.OrderStatusCancelled, To: models.OrderStatusDelivered})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPut, "/api/v1/admin/shipments/700", adminToken, `{"delivered_at":"2026-03-02T14:00:00Z"}`))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("GetOrderShipments_Customer", func(t *testing.T) {
+		ts.ShipmentService.EXPECT().GetShipments(userID, uint(500)).
+			Return([]dto.ShipmentResponse{{ID: 700, OrderID: 500, Carrier: "UPS", TrackingNumber: "1Z999"}}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodGet, "/api/v1/orders/500/shipments", customerToken, ""))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("GetOrderShipments_NotFound", func(t *testing.T) {
+		ts.ShipmentService.EXPECT().GetShipments(userID, uint(501)).Return(nil, services.ErrOrderNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodGet, "/api/v1/orders/501/shipments", customerToken, ""))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("Forbidden_NonAdmin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/admin/orders/500/shipments", customerToken, `{"carrier":"UPS"}`))
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", w.Code)
+		}
+	})
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCartShippingHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	userID := uint(1)
+	token := createTestToken(userID)
+
+	t.Run("GetShippingOptions_Success", func(t *testing.T) {
+		ts.CartService.EXPECT().GetShippingOptions(userID, uint(30), money.Currency("")).
+			Return([]dto.ShippingOptionResponse{{RateID: 21, Method: "Standard", Cost: usd(600)}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/cart/shipping-options?address_id=30", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("GetShippingOptions_MissingAddress", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/cart/shipping-options", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("GetShippingOptions_AddressNotFound", func(t *testing.T) {
+		ts.CartService.EXPECT().GetShippingOptions(userID, uint(99), money.Currency("")).Return(nil, services.ErrAddressNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/cart/shipping-options?address_id=99", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("SelectShippingMethod_Success", func(t *testing.T) {
+		ts.CartService.EXPECT().
+			SelectShippingMethod(userID, &dto.SelectShippingMethodRequest{AddressID: 30, ShippingRateID: 21}, money.Currency("")).
+			Return(&dto.CartResponse{ID: 10, Shipping: &dto.ShippingOptionResponse{RateID: 21, Method: "Standard", Cost: usd(600)}}, nil)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/cart/shipping", strings.NewReader(`{"address_id":30,"shipping_rate_id":21}`))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("SelectShippingMethod_Unavailable", func(t *testing.T) {
+		ts.CartService.EXPECT().SelectShippingMethod(userID, gomock.Any(), money.Currency("")).Return(nil, services.ErrShippingUnavailable)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/cart/shipping", strings.NewReader(`{"address_id":30,"shipping_rate_id":10}`))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestAdminShippingHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	adminToken := createAdminToken(1)
+	customerToken := createTestToken(2)
+
+	newRequest := func(method, path, token, body string) *http.Request {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("CreateZone_Success", func(t *testing.T) {
+		ts.ShippingService.EXPECT().
+			CreateZone(&dto.ShippingZoneRequest{
+				Name:    "Domestic",
+				Regions: []dto.ShippingZoneRegionRequest{{Country: "US", PostcodePrefix: "941"}},
+			}).
+			Return(&dto.ShippingZoneResponse{ID: 1, Name: "Domestic"}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/admin/shipping/zones", adminToken,
+			`{"name":"Domestic","regions":[{"country":"US","postcode_prefix":"941"}]}`))
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("CreateZone_NoRegions", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/admin/shipping/zones", adminToken, `{"name":"Domestic","regions":[]}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("CreateRate_UnknownType", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/admin/shipping/zones/1/rates", adminToken, `{"name":"Standard","type":"volume","price":{"amount":500}}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("CreateRate_ZoneNotFound", func(t *testing.T) {
+		ts.ShippingService.EXPECT().CreateRate(uint(99), gomock.Any()).Return(nil, services.ErrShippingZoneNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/admin/shipping/zones/99/rates", adminToken, `{"name":"Standard","type":"flat","price":{"amount":500}}`))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("UpdateRate_Invalid", func(t *testing.T) {
+		ts.ShippingService.EXPECT().UpdateRate(uint(10), gomock.Any()).Return(nil, services.ErrInvalidShippingRate)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPut, "/api/v1/admin/shipping/rates/10", adminToken,
+			`{"name":"Standard","type":"weight","price":{"amount":500},"min_weight":10,"max_weight":2}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("DeleteRate_NotFound", func(t *testing.T) {
+		ts.ShippingService.EXPECT().DeleteRate(uint(99)).Return(services.ErrShippingRateNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodDelete, "/api/v1/admin/shipping/rates/99", adminToken, ""))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("Forbidden_NonAdmin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodGet, "/api/v1/admin/shipping/zones", customerToken, ""))
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", w.Code)
+		}
+	})
+}
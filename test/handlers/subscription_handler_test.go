@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSubscriptionHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	userID := uint(1)
+	token := createTestToken(userID)
+
+	newRequest := func(method, path, body string) *http.Request {
+		req := httptest.NewRequest(method, "/api/v1/subscriptions"+path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	validBody := `{"product_id":1000,"quantity":2,"interval_weeks":4,"shipping_address_id":30}`
+
+	t.Run("GetSubscriptions_Success", func(t *testing.T) {
+		ts.SubscriptionService.EXPECT().GetSubscriptions(userID).Return([]dto.SubscriptionResponse{{ID: 7}}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodGet, "/", ""))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("CreateSubscription_Success", func(t *testing.T) {
+		ts.SubscriptionService.EXPECT().CreateSubscription(userID, gomock.Any()).
+			DoAndReturn(func(_ uint, req *dto.CreateSubscriptionRequest) (*dto.SubscriptionResponse, error) {
+				if req.ProductID != 1000 || req.Quantity != 2 || req.IntervalWeeks != 4 || req.ShippingAddressID != 30 {
+					t.Errorf("unexpected request: %+v", req)
+				}
+				return &dto.SubscriptionResponse{ID: 7}, nil
+			})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/", validBody))
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("CreateSubscription_IntervalTooLong", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/", `{"product_id":1000,"quantity":2,"interval_weeks":60,"shipping_address_id":30}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("CreateSubscription_ProductNotFound", func(t *testing.T) {
+		ts.SubscriptionService.EXPECT().CreateSubscription(userID, gomock.Any()).Return(nil, services.ErrProductNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/", validBody))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("GetSubscription_NotFound", func(t *testing.T) {
+		ts.SubscriptionService.EXPECT().GetSubscription(userID, uint(99)).Return(nil, services.ErrSubscriptionNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodGet, "/99", ""))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("PauseSubscription_Success", func(t *testing.T) {
+		ts.SubscriptionService.EXPECT().PauseSubscription(userID, uint(7)).Return(&dto.SubscriptionResponse{ID: 7, Status: "paused"}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/7/pause", ""))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("ResumeSubscription_Cancelled", func(t *testing.T) {
+		ts.SubscriptionService.EXPECT().ResumeSubscription(userID, uint(7)).Return(nil, services.ErrSubscriptionCancelled)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/7/resume", ""))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("UpdateSubscription_PastRun", func(t *testing.T) {
+		ts.SubscriptionService.EXPECT().UpdateSubscription(userID, uint(7), gomock.Any()).Return(nil, services.ErrInvalidSubscription)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPut, "/7", `{"quantity":2,"interval_weeks":4,"shipping_address_id":30,"next_run_at":"2020-01-01T00:00:00Z"}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("CancelSubscription_Success", func(t *testing.T) {
+		ts.SubscriptionService.EXPECT().CancelSubscription(userID, uint(7)).Return(&dto.SubscriptionResponse{ID: 7, Status: "cancelled"}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/7/cancel", ""))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", w.Code)
+		}
+	})
+}
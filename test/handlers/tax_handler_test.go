@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAdminTaxHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	adminToken := createAdminToken(1)
+	customerToken := createTestToken(2)
+
+	newRequest := func(method, path, token, body string) *http.Request {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("CreateTaxRate_Success", func(t *testing.T) {
+		ts.TaxService.EXPECT().
+			CreateTaxRate(&dto.TaxRateRequest{Name: "California", Country: "US", State: "CA", Rate: 7.25}).
+			Return(&dto.TaxRateResponse{ID: 1, Name: "California", Country: "US", State: "CA", TaxClass: "standard", Rate: 7.25}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/admin/tax/rates", adminToken,
+			`{"name":"California","country":"US","state":"CA","rate":7.25}`))
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("CreateTaxRate_InvalidRate", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/admin/tax/rates", adminToken, `{"name":"Bogus","country":"US","rate":150}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("UpdateTaxRate_NotFound", func(t *testing.T) {
+		ts.TaxService.EXPECT().UpdateTaxRate(uint(99), gomock.Any()).Return(nil, services.ErrTaxRateNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodPut, "/api/v1/admin/tax/rates/99", adminToken, `{"name":"UK","country":"GB","rate":20}`))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("DeleteTaxRate_Success", func(t *testing.T) {
+		ts.TaxService.EXPECT().DeleteTaxRate(uint(1)).Return(nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodDelete, "/api/v1/admin/tax/rates/1", adminToken, ""))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Forbidden_NonAdmin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(http.MethodGet, "/api/v1/admin/tax/rates", customerToken, ""))
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", w.Code)
+		}
+	})
+}
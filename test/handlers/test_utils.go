@@ -6,8 +6,10 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/kuldeepstechwork/gocart-api/internal/config"
 	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
 	"github.com/kuldeepstechwork/gocart-api/internal/server"
 	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+	repomocks "github.com/kuldeepstechwork/gocart-api/test/mocks/repositories"
 	mocks "github.com/kuldeepstechwork/gocart-api/test/mocks/services"
 	"go.uber.org/mock/gomock"
 )
@@ -15,23 +17,54 @@ import (
 const TestJWTSecret = "test-secret"
 
 type TestServer struct {
-	Server         *server.Server
-	AuthService    *mocks.MockAuthServiceInterface
-	UserService    *mocks.MockUserServiceInterface
-	ProductService *mocks.MockProductServiceInterface
-	CartService    *mocks.MockCartServiceInterface
-	OrderService   *mocks.MockOrderServiceInterface
-	UploadService  *mocks.MockUploadServiceInterface
-	Config         *config.Config
+	Server              *server.Server
+	AuthService         *mocks.MockAuthServiceInterface
+	UserService         *mocks.MockUserServiceInterface
+	AddressService      *mocks.MockAddressServiceInterface
+	ProductService      *mocks.MockProductServiceInterface
+	CartService         *mocks.MockCartServiceInterface
+	OrderService        *mocks.MockOrderServiceInterface
+	CheckoutService     *mocks.MockCheckoutServiceInterface
+	SubscriptionService *mocks.MockSubscriptionServiceInterface
+	WalletService       *mocks.MockWalletServiceInterface
+	LoyaltyService      *mocks.MockLoyaltyServiceInterface
+	ReturnService       *mocks.MockReturnServiceInterface
+	ShipmentService     *mocks.MockShipmentServiceInterface
+	ShippingService     *mocks.MockShippingServiceInterface
+	TaxService          *mocks.MockTaxServiceInterface
+	CouponService       *mocks.MockCouponServiceInterface
+	PromotionService    *mocks.MockPromotionServiceInterface
+	InvoiceService      *mocks.MockInvoiceServiceInterface
+	CurrencyService     *mocks.MockCurrencyServiceInterface
+	InventoryService    *mocks.MockInventoryServiceInterface
+	UploadService       *mocks.MockUploadServiceInterface
+	Config              *config.Config
+
+	IdempotencyRepo *repomocks.MockIdempotencyRepositoryInterface
 }
 
 func setupTestServer(ctrl *gomock.Controller) *TestServer {
 	authService := mocks.NewMockAuthServiceInterface(ctrl)
 	userService := mocks.NewMockUserServiceInterface(ctrl)
+	addressService := mocks.NewMockAddressServiceInterface(ctrl)
 	productService := mocks.NewMockProductServiceInterface(ctrl)
 	cartService := mocks.NewMockCartServiceInterface(ctrl)
 	orderService := mocks.NewMockOrderServiceInterface(ctrl)
+	checkoutService := mocks.NewMockCheckoutServiceInterface(ctrl)
+	subscriptionService := mocks.NewMockSubscriptionServiceInterface(ctrl)
+	walletService := mocks.NewMockWalletServiceInterface(ctrl)
+	loyaltyService := mocks.NewMockLoyaltyServiceInterface(ctrl)
+	returnService := mocks.NewMockReturnServiceInterface(ctrl)
+	shipmentService := mocks.NewMockShipmentServiceInterface(ctrl)
+	shippingService := mocks.NewMockShippingServiceInterface(ctrl)
+	taxService := mocks.NewMockTaxServiceInterface(ctrl)
+	couponService := mocks.NewMockCouponServiceInterface(ctrl)
+	promotionService := mocks.NewMockPromotionServiceInterface(ctrl)
+	invoiceService := mocks.NewMockInvoiceServiceInterface(ctrl)
+	currencyService := mocks.NewMockCurrencyServiceInterface(ctrl)
+	inventoryService := mocks.NewMockInventoryServiceInterface(ctrl)
 	uploadService := mocks.NewMockUploadServiceInterface(ctrl)
+	idempotencyRepo := repomocks.NewMockIdempotencyRepositoryInterface(ctrl)
 
 	cfg := &config.Config{
 		JWT: config.JWTConfig{
@@ -39,6 +72,10 @@ func setupTestServer(ctrl *gomock.Controller) *TestServer {
 			ExpiresIn:           time.Hour,
 			RefreshTokenExpires: time.Hour * 24,
 		},
+		Store: config.StoreConfig{
+			Currency:   "USD",
+			Currencies: []string{"EUR"},
+		},
 	}
 	gin.SetMode(gin.TestMode)
 
@@ -48,20 +85,51 @@ func setupTestServer(ctrl *gomock.Controller) *TestServer {
 		authService,
 		productService,
 		userService,
+		addressService,
 		uploadService,
 		cartService,
 		orderService,
+		checkoutService,
+		subscriptionService,
+		walletService,
+		loyaltyService,
+		returnService,
+		shipmentService,
+		shippingService,
+		taxService,
+		couponService,
+		promotionService,
+		invoiceService,
+		currencyService,
+		inventoryService,
+		idempotencyRepo,
 	)
 
 	return &TestServer{
-		Server:         srv,
-		AuthService:    authService,
-		UserService:    userService,
-		ProductService: productService,
-		CartService:    cartService,
-		OrderService:   orderService,
-		UploadService:  uploadService,
-		Config:         cfg,
+		Server:              srv,
+		AuthService:         authService,
+		UserService:         userService,
+		AddressService:      addressService,
+		ProductService:      productService,
+		CartService:         cartService,
+		OrderService:        orderService,
+		CheckoutService:     checkoutService,
+		SubscriptionService: subscriptionService,
+		WalletService:       walletService,
+		LoyaltyService:      loyaltyService,
+		ReturnService:       returnService,
+		ShipmentService:     shipmentService,
+		ShippingService:     shippingService,
+		TaxService:          taxService,
+		CouponService:       couponService,
+		PromotionService:    promotionService,
+		InvoiceService:      invoiceService,
+		CurrencyService:     currencyService,
+		InventoryService:    inventoryService,
+		UploadService:       uploadService,
+		Config:              cfg,
+
+		IdempotencyRepo: idempotencyRepo,
 	}
 }
 
@@ -84,3 +152,7 @@ func createAdminToken(userID uint) string {
 	accessToken, _, _ := utils.GenerateTokenPair(jwtCfg, userID, "admin@example.com", string(models.UserRoleAdmin))
 	return accessToken
 }
+
+func usd(amount int64) money.Money {
+	return money.New(amount, "USD")
+}
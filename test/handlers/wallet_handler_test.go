@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWalletHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := setupTestServer(ctrl)
+	router := ts.Server.SetupRoutes()
+	userID := uint(1)
+	token := createTestToken(userID)
+	adminToken := createAdminToken(9)
+
+	newRequest := func(token, method, path, body string) *http.Request {
+		req := httptest.NewRequest(method, "/api/v1"+path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("GetWallet", func(t *testing.T) {
+		ts.WalletService.EXPECT().GetWallet(userID).Return(&dto.WalletResponse{Balance: money.New(2500, "USD")}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(token, "GET", "/users/wallet", ""))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("GetWalletEntries", func(t *testing.T) {
+		ts.WalletService.EXPECT().
+			GetWalletEntries(userID, 2, 5).
+			Return([]dto.WalletEntryResponse{}, &utils.PaginationMeta{Page: 2, Limit: 5}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(token, "GET", "/users/wallet/entries?page=2&limit=5", ""))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Redeem_Success", func(t *testing.T) {
+		ts.WalletService.EXPECT().
+			RedeemGiftCard(userID, &dto.RedeemGiftCardRequest{Code: "7KQ2-M9XW-4PHT-C3RD"}).
+			Return(&dto.WalletResponse{Balance: money.New(5000, "USD")}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(token, "POST", "/users/wallet/redeem", `{"code":"7KQ2-M9XW-4PHT-C3RD"}`))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Redeem_MissingCode", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(token, "POST", "/users/wallet/redeem", `{}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("Redeem_NotFound", func(t *testing.T) {
+		ts.WalletService.EXPECT().RedeemGiftCard(userID, gomock.Any()).Return(nil, services.ErrGiftCardNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(token, "POST", "/users/wallet/redeem", `{"code":"AAAA"}`))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("Redeem_AlreadyRedeemed", func(t *testing.T) {
+		ts.WalletService.EXPECT().RedeemGiftCard(userID, gomock.Any()).Return(nil, services.ErrGiftCardNotRedeemable)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(token, "POST", "/users/wallet/redeem", `{"code":"7KQ2-M9XW-4PHT-C3RD"}`))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("IssueGiftCard", func(t *testing.T) {
+		ts.WalletService.EXPECT().
+			IssueGiftCard(uint(9), &dto.GiftCardRequest{Value: money.New(5000, "USD"), Note: "prize"}).
+			Return(&dto.GiftCardResponse{ID: 30, Code: "7KQ2-M9XW-4PHT-C3RD", CodeHint: "C3RD"}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(adminToken, "POST", "/admin/gift-cards/", `{"value":{"amount":5000,"currency":"USD"},"note":"prize"}`))
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("IssueGiftCard_NotAdmin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(token, "POST", "/admin/gift-cards/", `{"value":{"amount":5000,"currency":"USD"}}`))
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("GrantCredit_UserNotFound", func(t *testing.T) {
+		ts.WalletService.EXPECT().GrantCredit(uint(9), uint(2), gomock.Any()).Return(nil, services.ErrUserNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(adminToken, "POST", "/admin/users/2/wallet/credits", `{"amount":{"amount":2500,"currency":"USD"}}`))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("GrantCredit_Invalid", func(t *testing.T) {
+		ts.WalletService.EXPECT().GrantCredit(uint(9), uint(1), gomock.Any()).Return(nil, services.ErrInvalidWalletCredit)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(adminToken, "POST", "/admin/users/1/wallet/credits", `{"amount":{"amount":0,"currency":"USD"}}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+}
@@ -0,0 +1,220 @@
+package invoices_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/invoices"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+func usd(amount int64) money.Money {
+	return money.New(amount, "USD")
+}
+
+var issuedAt = time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+
+func newOrder(items ...models.OrderItem) *models.Order {
+	return &models.Order{
+		ID:             500,
+		CreatedAt:      issuedAt.Add(-24 * time.Hour),
+		ShippingMethod: "Standard",
+		ShippingCost:   usd(500),
+		CouponCode:     "SAVE10",
+		DiscountAmount: usd(1000),
+		TaxAmount:      usd(1920),
+		TotalAmount:    usd(13420),
+		ShippingAddress: models.OrderAddress{
+			FirstName: "Ada", LastName: "Lovelace", Line1: "1 Main St", City: "Springfield", PostalCode: "12345", Country: "US",
+		},
+		BillingAddress: models.OrderAddress{
+			FirstName: "Ada", LastName: "Lovelace", Line1: "1 Main St", City: "Springfield", PostalCode: "12345", Country: "US",
+		},
+		OrderItems: items,
+	}
+}
+
+func defaultItems() []models.OrderItem {
+	return []models.OrderItem{
+		{Quantity: 2, Price: usd(5000), Discount: usd(1000), TaxClass: "standard", TaxRate: 20, TaxAmount: usd(1800), Product: models.Product{Name: "Shirt", SKU: "SHIRT-1"}},
+		{Quantity: 1, Price: usd(2000), TaxClass: "reduced", TaxRate: 5, TaxAmount: usd(100), Product: models.Product{Name: "Book", SKU: "BOOK-1"}},
+		{Quantity: 1, Price: usd(400), TaxClass: "standard", TaxRate: 5, TaxAmount: usd(20), Product: models.Product{Name: "Card", SKU: "CARD-1"}},
+	}
+}
+
+var invoice = &models.Invoice{OrderID: 500, Number: "INV-000007", IssuedAt: issuedAt}
+
+func TestNewDocument(t *testing.T) {
+	doc := invoices.NewDocument(invoice, newOrder(defaultItems()...))
+
+	if doc.Subtotal != usd(12400) {
+		t.Errorf("expected subtotal 124, got %v", doc.Subtotal)
+	}
+
+	// Exclusive pricing adds the tax to the discounted line
+	if doc.Lines[0].Total != usd(10800) || doc.Lines[1].Total != usd(2100) {
+		t.Errorf("unexpected line totals: %+v", doc.Lines)
+	}
+
+	expected := []invoices.TaxLine{
+		{TaxClass: "standard", Rate: 20, NetAmount: usd(9000), TaxAmount: usd(1800)},
+		{TaxClass: "reduced", Rate: 5, NetAmount: usd(2000), TaxAmount: usd(100)},
+		{TaxClass: "standard", Rate: 5, NetAmount: usd(400), TaxAmount: usd(20)},
+	}
+	if len(doc.TaxLines) != len(expected) {
+		t.Fatalf("expected %d tax lines, got %+v", len(expected), doc.TaxLines)
+	}
+	for i := range expected {
+		if doc.TaxLines[i] != expected[i] {
+			t.Errorf("tax line %d: expected %+v, got %+v", i, expected[i], doc.TaxLines[i])
+		}
+	}
+
+	if strings.Join(doc.BillingAddress, ", ") != "Ada Lovelace, 1 Main St, 12345 Springfield, US" {
+		t.Errorf("unexpected billing address %q", doc.BillingAddress)
+	}
+}
+
+func TestNewDocument_PricesIncludeTax(t *testing.T) {
+	order := newOrder(models.OrderItem{Quantity: 1, Price: usd(12000), TaxClass: "standard", TaxRate: 20, TaxAmount: usd(2000), Product: models.Product{Name: "Lamp"}})
+	order.PricesIncludeTax = true
+
+	doc := invoices.NewDocument(invoice, order)
+
+	if doc.Lines[0].Total != usd(12000) || doc.TaxLines[0].NetAmount != usd(10000) {
+		t.Errorf("expected a line total of 120 with 100 net, got %+v and %+v", doc.Lines[0], doc.TaxLines[0])
+	}
+}
+
+func TestNewDocument_ProductAsOrdered(t *testing.T) {
+	renamed := models.Product{Name: "Oxford Shirt", SKU: "SHIRT-2"}
+	order := newOrder(
+		models.OrderItem{Quantity: 1, Price: usd(5000), ProductName: "Shirt", SKU: "SHIRT-1", Product: renamed},
+		// Ordered before the product was kept on its items
+		models.OrderItem{Quantity: 1, Price: usd(2000), Product: models.Product{Name: "Book", SKU: "BOOK-1"}},
+	)
+
+	doc := invoices.NewDocument(invoice, order)
+
+	if doc.Lines[0].Description != "Shirt" || doc.Lines[0].SKU != "SHIRT-1" {
+		t.Errorf("expected the product as it was ordered, got %+v", doc.Lines[0])
+	}
+	if doc.Lines[1].Description != "Book" || doc.Lines[1].SKU != "BOOK-1" {
+		t.Errorf("expected the current product for an older item, got %+v", doc.Lines[1])
+	}
+}
+
+func TestPDFRenderer(t *testing.T) {
+	rendered, err := invoices.NewPDFRenderer().Render(invoices.NewDocument(invoice, newOrder(defaultItems()...)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rendered.ContentType != "application/pdf" || rendered.Extension != ".pdf" {
+		t.Errorf("expected a PDF, got %s (%s)", rendered.ContentType, rendered.Extension)
+	}
+
+	content := rendered.Content
+	if !bytes.HasPrefix(content, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(content, []byte("%%EOF\n")) {
+		t.Errorf("document is not framed as a PDF: %q ... %q", content[:16], content[len(content)-16:])
+	}
+
+	for _, text := range []string{"(Invoice number: INV-000007)", "(Shirt)", "(SKU SHIRT-1)", "(Discount \\(SAVE10\\))", "(Total \\(USD\\))", "(134.20)"} {
+		if !bytes.Contains(content, []byte(text)) {
+			t.Errorf("expected the document to print %s", text)
+		}
+	}
+
+	expectValidXref(t, content)
+}
+
+func TestPDFRenderer_Pages(t *testing.T) {
+	items := make([]models.OrderItem, 60)
+	for i := range items {
+		items[i] = models.OrderItem{Quantity: 1, Price: usd(100), TaxClass: "standard", Product: models.Product{Name: fmt.Sprintf("Item %d", i)}}
+	}
+
+	rendered, err := invoices.NewPDFRenderer().Render(invoices.NewDocument(invoice, newOrder(items...)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Contains(rendered.Content, []byte("/Count 2")) {
+		t.Errorf("expected the items to run over onto a second page")
+	}
+
+	expectValidXref(t, rendered.Content)
+}
+
+func TestPDFRenderer_UnsupportedText(t *testing.T) {
+	items := []models.OrderItem{{Quantity: 1, Price: usd(3000), Product: models.Product{Name: "茶碗"}}}
+
+	_, err := invoices.NewPDFRenderer().Render(invoices.NewDocument(invoice, newOrder(items...)))
+	if !errors.Is(err, invoices.ErrUnsupportedText) {
+		t.Errorf("expected ErrUnsupportedText, got %v", err)
+	}
+}
+
+func TestRenderer_FallsBackToHTML(t *testing.T) {
+	renderer := invoices.NewRenderer()
+
+	t.Run("PDF", func(t *testing.T) {
+		items := []models.OrderItem{{Quantity: 1, Price: usd(3000), Product: models.Product{Name: "Crème brûlée set"}}}
+
+		rendered, err := renderer.Render(invoices.NewDocument(invoice, newOrder(items...)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rendered.ContentType != "application/pdf" {
+			t.Errorf("expected Latin-1 text to render to PDF, got %s", rendered.ContentType)
+		}
+	})
+
+	t.Run("HTML", func(t *testing.T) {
+		items := []models.OrderItem{{Quantity: 1, Price: usd(3000), Product: models.Product{Name: "茶碗 <large>"}}}
+
+		rendered, err := renderer.Render(invoices.NewDocument(invoice, newOrder(items...)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rendered.ContentType != "text/html; charset=utf-8" || rendered.Extension != ".html" {
+			t.Errorf("expected an HTML document, got %s (%s)", rendered.ContentType, rendered.Extension)
+		}
+
+		html := string(rendered.Content)
+		if !strings.Contains(html, "茶碗 &lt;large&gt;") || !strings.Contains(html, "INV-000007") {
+			t.Errorf("expected the escaped item and the invoice number in the document")
+		}
+	})
+}
+
+// expectValidXref checks that every object offset in the cross-reference
+// table points at the start of that object.
+func expectValidXref(t *testing.T, content []byte) {
+	t.Helper()
+
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(content)
+	if match == nil {
+		t.Fatal("missing startxref")
+	}
+
+	xref, _ := strconv.Atoi(string(match[1]))
+	if !bytes.HasPrefix(content[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the xref table", xref)
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(content[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(content[offset:], []byte(want)) {
+			t.Errorf("object %d is not at offset %d", i+1, offset)
+		}
+	}
+}
@@ -140,6 +140,21 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) GetByEmailAndActive(email, is
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmailAndActive", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetByEmailAndActive), email, isActive)
 }
 
+// GetByEmailVerificationToken mocks base method.
+func (m *MockUserRepositoryInterface) GetByEmailVerificationToken(token string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmailVerificationToken", token)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmailVerificationToken indicates an expected call of GetByEmailVerificationToken.
+func (mr *MockUserRepositoryInterfaceMockRecorder) GetByEmailVerificationToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmailVerificationToken", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetByEmailVerificationToken), token)
+}
+
 // GetByID mocks base method.
 func (m *MockUserRepositoryInterface) GetByID(id uint) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -264,3 +279,162 @@ func (mr *MockCartRepositoryInterfaceMockRecorder) Update(cart any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCartRepositoryInterface)(nil).Update), cart)
 }
+
+// MockOrderRepositoryInterface is a mock of OrderRepositoryInterface interface.
+type MockOrderRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderRepositoryInterfaceMockRecorder is the mock recorder for MockOrderRepositoryInterface.
+type MockOrderRepositoryInterfaceMockRecorder struct {
+	mock *MockOrderRepositoryInterface
+}
+
+// NewMockOrderRepositoryInterface creates a new mock instance.
+func NewMockOrderRepositoryInterface(ctrl *gomock.Controller) *MockOrderRepositoryInterface {
+	mock := &MockOrderRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockOrderRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderRepositoryInterface) EXPECT() *MockOrderRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AttachGuestOrders mocks base method.
+func (m *MockOrderRepositoryInterface) AttachGuestOrders(userID uint, email string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachGuestOrders", userID, email)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachGuestOrders indicates an expected call of AttachGuestOrders.
+func (mr *MockOrderRepositoryInterfaceMockRecorder) AttachGuestOrders(userID, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachGuestOrders", reflect.TypeOf((*MockOrderRepositoryInterface)(nil).AttachGuestOrders), userID, email)
+}
+
+// MockIdempotencyRepositoryInterface is a mock of IdempotencyRepositoryInterface interface.
+type MockIdempotencyRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockIdempotencyRepositoryInterfaceMockRecorder is the mock recorder for MockIdempotencyRepositoryInterface.
+type MockIdempotencyRepositoryInterfaceMockRecorder struct {
+	mock *MockIdempotencyRepositoryInterface
+}
+
+// NewMockIdempotencyRepositoryInterface creates a new mock instance.
+func NewMockIdempotencyRepositoryInterface(ctrl *gomock.Controller) *MockIdempotencyRepositoryInterface {
+	mock := &MockIdempotencyRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyRepositoryInterface) EXPECT() *MockIdempotencyRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockIdempotencyRepositoryInterface) Complete(id uint, statusCode int, body []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", id, statusCode, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIdempotencyRepositoryInterfaceMockRecorder) Complete(id, statusCode, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIdempotencyRepositoryInterface)(nil).Complete), id, statusCode, body)
+}
+
+// Create mocks base method.
+func (m *MockIdempotencyRepositoryInterface) Create(record *models.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIdempotencyRepositoryInterfaceMockRecorder) Create(record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIdempotencyRepositoryInterface)(nil).Create), record)
+}
+
+// Delete mocks base method.
+func (m *MockIdempotencyRepositoryInterface) Delete(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIdempotencyRepositoryInterfaceMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIdempotencyRepositoryInterface)(nil).Delete), id)
+}
+
+// Get mocks base method.
+func (m *MockIdempotencyRepositoryInterface) Get(userID uint, cartToken, key string) (*models.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", userID, cartToken, key)
+	ret0, _ := ret[0].(*models.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIdempotencyRepositoryInterfaceMockRecorder) Get(userID, cartToken, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIdempotencyRepositoryInterface)(nil).Get), userID, cartToken, key)
+}
+
+// MockTaxRateRepositoryInterface is a mock of TaxRateRepositoryInterface interface.
+type MockTaxRateRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxRateRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTaxRateRepositoryInterfaceMockRecorder is the mock recorder for MockTaxRateRepositoryInterface.
+type MockTaxRateRepositoryInterfaceMockRecorder struct {
+	mock *MockTaxRateRepositoryInterface
+}
+
+// NewMockTaxRateRepositoryInterface creates a new mock instance.
+func NewMockTaxRateRepositoryInterface(ctrl *gomock.Controller) *MockTaxRateRepositoryInterface {
+	mock := &MockTaxRateRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockTaxRateRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxRateRepositoryInterface) EXPECT() *MockTaxRateRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// FindTaxRates mocks base method.
+func (m *MockTaxRateRepositoryInterface) FindTaxRates(country, state string) ([]models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTaxRates", country, state)
+	ret0, _ := ret[0].([]models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTaxRates indicates an expected call of FindTaxRates.
+func (mr *MockTaxRateRepositoryInterfaceMockRecorder) FindTaxRates(country, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTaxRates", reflect.TypeOf((*MockTaxRateRepositoryInterface)(nil).FindTaxRates), country, state)
+}
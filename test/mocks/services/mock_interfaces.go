@@ -12,8 +12,11 @@ package mocks
 import (
 	multipart "mime/multipart"
 	reflect "reflect"
+	time "time"
 
 	dto "github.com/kuldeepstechwork/gocart-api/internal/dto"
+	exports "github.com/kuldeepstechwork/gocart-api/internal/exports"
+	money "github.com/kuldeepstechwork/gocart-api/internal/money"
 	utils "github.com/kuldeepstechwork/gocart-api/internal/utils"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -101,6 +104,21 @@ func (mr *MockAuthServiceInterfaceMockRecorder) Register(req any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAuthServiceInterface)(nil).Register), req)
 }
 
+// VerifyEmail mocks base method.
+func (m *MockAuthServiceInterface) VerifyEmail(req *dto.VerifyEmailRequest) (*dto.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", req)
+	ret0, _ := ret[0].(*dto.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockAuthServiceInterfaceMockRecorder) VerifyEmail(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockAuthServiceInterface)(nil).VerifyEmail), req)
+}
+
 // MockUserServiceInterface is a mock of UserServiceInterface interface.
 type MockUserServiceInterface struct {
 	ctrl     *gomock.Controller
@@ -155,6 +173,104 @@ func (mr *MockUserServiceInterfaceMockRecorder) UpdateProfile(userID, req any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUserServiceInterface)(nil).UpdateProfile), userID, req)
 }
 
+// MockAddressServiceInterface is a mock of AddressServiceInterface interface.
+type MockAddressServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAddressServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAddressServiceInterfaceMockRecorder is the mock recorder for MockAddressServiceInterface.
+type MockAddressServiceInterfaceMockRecorder struct {
+	mock *MockAddressServiceInterface
+}
+
+// NewMockAddressServiceInterface creates a new mock instance.
+func NewMockAddressServiceInterface(ctrl *gomock.Controller) *MockAddressServiceInterface {
+	mock := &MockAddressServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockAddressServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAddressServiceInterface) EXPECT() *MockAddressServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateAddress mocks base method.
+func (m *MockAddressServiceInterface) CreateAddress(userID uint, req *dto.AddressRequest) (*dto.AddressResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAddress", userID, req)
+	ret0, _ := ret[0].(*dto.AddressResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAddress indicates an expected call of CreateAddress.
+func (mr *MockAddressServiceInterfaceMockRecorder) CreateAddress(userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAddress", reflect.TypeOf((*MockAddressServiceInterface)(nil).CreateAddress), userID, req)
+}
+
+// DeleteAddress mocks base method.
+func (m *MockAddressServiceInterface) DeleteAddress(userID, addressID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAddress", userID, addressID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAddress indicates an expected call of DeleteAddress.
+func (mr *MockAddressServiceInterfaceMockRecorder) DeleteAddress(userID, addressID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddress", reflect.TypeOf((*MockAddressServiceInterface)(nil).DeleteAddress), userID, addressID)
+}
+
+// GetAddress mocks base method.
+func (m *MockAddressServiceInterface) GetAddress(userID, addressID uint) (*dto.AddressResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddress", userID, addressID)
+	ret0, _ := ret[0].(*dto.AddressResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddress indicates an expected call of GetAddress.
+func (mr *MockAddressServiceInterfaceMockRecorder) GetAddress(userID, addressID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddress", reflect.TypeOf((*MockAddressServiceInterface)(nil).GetAddress), userID, addressID)
+}
+
+// GetAddresses mocks base method.
+func (m *MockAddressServiceInterface) GetAddresses(userID uint) ([]dto.AddressResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddresses", userID)
+	ret0, _ := ret[0].([]dto.AddressResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddresses indicates an expected call of GetAddresses.
+func (mr *MockAddressServiceInterfaceMockRecorder) GetAddresses(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddresses", reflect.TypeOf((*MockAddressServiceInterface)(nil).GetAddresses), userID)
+}
+
+// UpdateAddress mocks base method.
+func (m *MockAddressServiceInterface) UpdateAddress(userID, addressID uint, req *dto.AddressRequest) (*dto.AddressResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAddress", userID, addressID, req)
+	ret0, _ := ret[0].(*dto.AddressResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAddress indicates an expected call of UpdateAddress.
+func (mr *MockAddressServiceInterfaceMockRecorder) UpdateAddress(userID, addressID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAddress", reflect.TypeOf((*MockAddressServiceInterface)(nil).UpdateAddress), userID, addressID, req)
+}
+
 // MockProductServiceInterface is a mock of ProductServiceInterface interface.
 type MockProductServiceInterface struct {
 	ctrl     *gomock.Controller
@@ -267,24 +383,24 @@ func (mr *MockProductServiceInterfaceMockRecorder) GetCategories() *gomock.Call
 }
 
 // GetProduct mocks base method.
-func (m *MockProductServiceInterface) GetProduct(id uint) (*dto.ProductResponse, error) {
+func (m *MockProductServiceInterface) GetProduct(id uint, currency money.Currency) (*dto.ProductResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProduct", id)
+	ret := m.ctrl.Call(m, "GetProduct", id, currency)
 	ret0, _ := ret[0].(*dto.ProductResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProduct indicates an expected call of GetProduct.
-func (mr *MockProductServiceInterfaceMockRecorder) GetProduct(id any) *gomock.Call {
+func (mr *MockProductServiceInterfaceMockRecorder) GetProduct(id, currency any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockProductServiceInterface)(nil).GetProduct), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockProductServiceInterface)(nil).GetProduct), id, currency)
 }
 
 // GetProducts mocks base method.
-func (m *MockProductServiceInterface) GetProducts(page, limit int) ([]dto.ProductResponse, *utils.PaginationMeta, error) {
+func (m *MockProductServiceInterface) GetProducts(page, limit int, currency money.Currency) ([]dto.ProductResponse, *utils.PaginationMeta, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProducts", page, limit)
+	ret := m.ctrl.Call(m, "GetProducts", page, limit, currency)
 	ret0, _ := ret[0].([]dto.ProductResponse)
 	ret1, _ := ret[1].(*utils.PaginationMeta)
 	ret2, _ := ret[2].(error)
@@ -292,15 +408,15 @@ func (m *MockProductServiceInterface) GetProducts(page, limit int) ([]dto.Produc
 }
 
 // GetProducts indicates an expected call of GetProducts.
-func (mr *MockProductServiceInterfaceMockRecorder) GetProducts(page, limit any) *gomock.Call {
+func (mr *MockProductServiceInterfaceMockRecorder) GetProducts(page, limit, currency any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProducts", reflect.TypeOf((*MockProductServiceInterface)(nil).GetProducts), page, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProducts", reflect.TypeOf((*MockProductServiceInterface)(nil).GetProducts), page, limit, currency)
 }
 
 // SearchProducts mocks base method.
-func (m *MockProductServiceInterface) SearchProducts(req *dto.SearchProductsRequest) ([]dto.ProductSearchResult, *utils.PaginationMeta, error) {
+func (m *MockProductServiceInterface) SearchProducts(req *dto.SearchProductsRequest, currency money.Currency) ([]dto.ProductSearchResult, *utils.PaginationMeta, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchProducts", req)
+	ret := m.ctrl.Call(m, "SearchProducts", req, currency)
 	ret0, _ := ret[0].([]dto.ProductSearchResult)
 	ret1, _ := ret[1].(*utils.PaginationMeta)
 	ret2, _ := ret[2].(error)
@@ -308,9 +424,9 @@ func (m *MockProductServiceInterface) SearchProducts(req *dto.SearchProductsRequ
 }
 
 // SearchProducts indicates an expected call of SearchProducts.
-func (mr *MockProductServiceInterfaceMockRecorder) SearchProducts(req any) *gomock.Call {
+func (mr *MockProductServiceInterfaceMockRecorder) SearchProducts(req, currency any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProducts", reflect.TypeOf((*MockProductServiceInterface)(nil).SearchProducts), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProducts", reflect.TypeOf((*MockProductServiceInterface)(nil).SearchProducts), req, currency)
 }
 
 // UpdateCategory mocks base method.
@@ -343,6 +459,133 @@ func (mr *MockProductServiceInterfaceMockRecorder) UpdateProduct(id, req any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockProductServiceInterface)(nil).UpdateProduct), id, req)
 }
 
+// MockCurrencyServiceInterface is a mock of CurrencyServiceInterface interface.
+type MockCurrencyServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCurrencyServiceInterfaceMockRecorder is the mock recorder for MockCurrencyServiceInterface.
+type MockCurrencyServiceInterfaceMockRecorder struct {
+	mock *MockCurrencyServiceInterface
+}
+
+// NewMockCurrencyServiceInterface creates a new mock instance.
+func NewMockCurrencyServiceInterface(ctrl *gomock.Controller) *MockCurrencyServiceInterface {
+	mock := &MockCurrencyServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockCurrencyServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyServiceInterface) EXPECT() *MockCurrencyServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteExchangeRate mocks base method.
+func (m *MockCurrencyServiceInterface) DeleteExchangeRate(currency money.Currency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExchangeRate", currency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExchangeRate indicates an expected call of DeleteExchangeRate.
+func (mr *MockCurrencyServiceInterfaceMockRecorder) DeleteExchangeRate(currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExchangeRate", reflect.TypeOf((*MockCurrencyServiceInterface)(nil).DeleteExchangeRate), currency)
+}
+
+// DeleteProductPrice mocks base method.
+func (m *MockCurrencyServiceInterface) DeleteProductPrice(productID uint, currency money.Currency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductPrice", productID, currency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductPrice indicates an expected call of DeleteProductPrice.
+func (mr *MockCurrencyServiceInterfaceMockRecorder) DeleteProductPrice(productID, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductPrice", reflect.TypeOf((*MockCurrencyServiceInterface)(nil).DeleteProductPrice), productID, currency)
+}
+
+// GetCurrencies mocks base method.
+func (m *MockCurrencyServiceInterface) GetCurrencies() ([]dto.CurrencyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrencies")
+	ret0, _ := ret[0].([]dto.CurrencyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrencies indicates an expected call of GetCurrencies.
+func (mr *MockCurrencyServiceInterfaceMockRecorder) GetCurrencies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrencies", reflect.TypeOf((*MockCurrencyServiceInterface)(nil).GetCurrencies))
+}
+
+// GetExchangeRates mocks base method.
+func (m *MockCurrencyServiceInterface) GetExchangeRates() ([]dto.ExchangeRateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRates")
+	ret0, _ := ret[0].([]dto.ExchangeRateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRates indicates an expected call of GetExchangeRates.
+func (mr *MockCurrencyServiceInterfaceMockRecorder) GetExchangeRates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRates", reflect.TypeOf((*MockCurrencyServiceInterface)(nil).GetExchangeRates))
+}
+
+// GetProductPrices mocks base method.
+func (m *MockCurrencyServiceInterface) GetProductPrices(productID uint) ([]dto.ProductPriceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductPrices", productID)
+	ret0, _ := ret[0].([]dto.ProductPriceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductPrices indicates an expected call of GetProductPrices.
+func (mr *MockCurrencyServiceInterfaceMockRecorder) GetProductPrices(productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductPrices", reflect.TypeOf((*MockCurrencyServiceInterface)(nil).GetProductPrices), productID)
+}
+
+// SetExchangeRate mocks base method.
+func (m *MockCurrencyServiceInterface) SetExchangeRate(currency money.Currency, req *dto.ExchangeRateRequest) (*dto.ExchangeRateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExchangeRate", currency, req)
+	ret0, _ := ret[0].(*dto.ExchangeRateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetExchangeRate indicates an expected call of SetExchangeRate.
+func (mr *MockCurrencyServiceInterfaceMockRecorder) SetExchangeRate(currency, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExchangeRate", reflect.TypeOf((*MockCurrencyServiceInterface)(nil).SetExchangeRate), currency, req)
+}
+
+// SetProductPrice mocks base method.
+func (m *MockCurrencyServiceInterface) SetProductPrice(productID uint, currency money.Currency, req *dto.ProductPriceRequest) (*dto.ProductPriceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductPrice", productID, currency, req)
+	ret0, _ := ret[0].(*dto.ProductPriceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetProductPrice indicates an expected call of SetProductPrice.
+func (mr *MockCurrencyServiceInterfaceMockRecorder) SetProductPrice(productID, currency, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductPrice", reflect.TypeOf((*MockCurrencyServiceInterface)(nil).SetProductPrice), productID, currency, req)
+}
+
 // MockCartServiceInterface is a mock of CartServiceInterface interface.
 type MockCartServiceInterface struct {
 	ctrl     *gomock.Controller
@@ -368,33 +611,123 @@ func (m *MockCartServiceInterface) EXPECT() *MockCartServiceInterfaceMockRecorde
 }
 
 // AddToCart mocks base method.
-func (m *MockCartServiceInterface) AddToCart(userID uint, req *dto.AddToCartRequest) (*dto.CartResponse, error) {
+func (m *MockCartServiceInterface) AddToCart(userID uint, req *dto.AddToCartRequest, currency money.Currency) (*dto.CartResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddToCart", userID, req)
+	ret := m.ctrl.Call(m, "AddToCart", userID, req, currency)
 	ret0, _ := ret[0].(*dto.CartResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddToCart indicates an expected call of AddToCart.
-func (mr *MockCartServiceInterfaceMockRecorder) AddToCart(userID, req any) *gomock.Call {
+func (mr *MockCartServiceInterfaceMockRecorder) AddToCart(userID, req, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToCart", reflect.TypeOf((*MockCartServiceInterface)(nil).AddToCart), userID, req, currency)
+}
+
+// AddToGuestCart mocks base method.
+func (m *MockCartServiceInterface) AddToGuestCart(token string, req *dto.AddToCartRequest, currency money.Currency) (*dto.CartResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToGuestCart", token, req, currency)
+	ret0, _ := ret[0].(*dto.CartResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddToGuestCart indicates an expected call of AddToGuestCart.
+func (mr *MockCartServiceInterfaceMockRecorder) AddToGuestCart(token, req, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToGuestCart", reflect.TypeOf((*MockCartServiceInterface)(nil).AddToGuestCart), token, req, currency)
+}
+
+// ApplyCoupon mocks base method.
+func (m *MockCartServiceInterface) ApplyCoupon(userID uint, req *dto.ApplyCouponRequest, currency money.Currency) (*dto.CartResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyCoupon", userID, req, currency)
+	ret0, _ := ret[0].(*dto.CartResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyCoupon indicates an expected call of ApplyCoupon.
+func (mr *MockCartServiceInterfaceMockRecorder) ApplyCoupon(userID, req, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyCoupon", reflect.TypeOf((*MockCartServiceInterface)(nil).ApplyCoupon), userID, req, currency)
+}
+
+// CreateGuestCart mocks base method.
+func (m *MockCartServiceInterface) CreateGuestCart(currency money.Currency) (*dto.CartResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGuestCart", currency)
+	ret0, _ := ret[0].(*dto.CartResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGuestCart indicates an expected call of CreateGuestCart.
+func (mr *MockCartServiceInterfaceMockRecorder) CreateGuestCart(currency any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToCart", reflect.TypeOf((*MockCartServiceInterface)(nil).AddToCart), userID, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGuestCart", reflect.TypeOf((*MockCartServiceInterface)(nil).CreateGuestCart), currency)
 }
 
 // GetCart mocks base method.
-func (m *MockCartServiceInterface) GetCart(userID uint) (*dto.CartResponse, error) {
+func (m *MockCartServiceInterface) GetCart(userID uint, currency money.Currency) (*dto.CartResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCart", userID)
+	ret := m.ctrl.Call(m, "GetCart", userID, currency)
 	ret0, _ := ret[0].(*dto.CartResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCart indicates an expected call of GetCart.
-func (mr *MockCartServiceInterfaceMockRecorder) GetCart(userID any) *gomock.Call {
+func (mr *MockCartServiceInterfaceMockRecorder) GetCart(userID, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCart", reflect.TypeOf((*MockCartServiceInterface)(nil).GetCart), userID, currency)
+}
+
+// GetGuestCart mocks base method.
+func (m *MockCartServiceInterface) GetGuestCart(token string, currency money.Currency) (*dto.CartResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuestCart", token, currency)
+	ret0, _ := ret[0].(*dto.CartResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuestCart indicates an expected call of GetGuestCart.
+func (mr *MockCartServiceInterfaceMockRecorder) GetGuestCart(token, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuestCart", reflect.TypeOf((*MockCartServiceInterface)(nil).GetGuestCart), token, currency)
+}
+
+// GetShippingOptions mocks base method.
+func (m *MockCartServiceInterface) GetShippingOptions(userID, addressID uint, currency money.Currency) ([]dto.ShippingOptionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShippingOptions", userID, addressID, currency)
+	ret0, _ := ret[0].([]dto.ShippingOptionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShippingOptions indicates an expected call of GetShippingOptions.
+func (mr *MockCartServiceInterfaceMockRecorder) GetShippingOptions(userID, addressID, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShippingOptions", reflect.TypeOf((*MockCartServiceInterface)(nil).GetShippingOptions), userID, addressID, currency)
+}
+
+// RemoveCoupon mocks base method.
+func (m *MockCartServiceInterface) RemoveCoupon(userID uint, currency money.Currency) (*dto.CartResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCoupon", userID, currency)
+	ret0, _ := ret[0].(*dto.CartResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveCoupon indicates an expected call of RemoveCoupon.
+func (mr *MockCartServiceInterfaceMockRecorder) RemoveCoupon(userID, currency any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCart", reflect.TypeOf((*MockCartServiceInterface)(nil).GetCart), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCoupon", reflect.TypeOf((*MockCartServiceInterface)(nil).RemoveCoupon), userID, currency)
 }
 
 // RemoveFromCart mocks base method.
@@ -411,89 +744,1368 @@ func (mr *MockCartServiceInterfaceMockRecorder) RemoveFromCart(userID, itemID an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromCart", reflect.TypeOf((*MockCartServiceInterface)(nil).RemoveFromCart), userID, itemID)
 }
 
+// RemoveFromGuestCart mocks base method.
+func (m *MockCartServiceInterface) RemoveFromGuestCart(token string, itemID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromGuestCart", token, itemID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromGuestCart indicates an expected call of RemoveFromGuestCart.
+func (mr *MockCartServiceInterfaceMockRecorder) RemoveFromGuestCart(token, itemID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromGuestCart", reflect.TypeOf((*MockCartServiceInterface)(nil).RemoveFromGuestCart), token, itemID)
+}
+
+// Reorder mocks base method.
+func (m *MockCartServiceInterface) Reorder(userID, orderID uint, currency money.Currency) (*dto.ReorderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", userID, orderID, currency)
+	ret0, _ := ret[0].(*dto.ReorderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockCartServiceInterfaceMockRecorder) Reorder(userID, orderID, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockCartServiceInterface)(nil).Reorder), userID, orderID, currency)
+}
+
+// SelectShippingMethod mocks base method.
+func (m *MockCartServiceInterface) SelectShippingMethod(userID uint, req *dto.SelectShippingMethodRequest, currency money.Currency) (*dto.CartResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectShippingMethod", userID, req, currency)
+	ret0, _ := ret[0].(*dto.CartResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectShippingMethod indicates an expected call of SelectShippingMethod.
+func (mr *MockCartServiceInterfaceMockRecorder) SelectShippingMethod(userID, req, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectShippingMethod", reflect.TypeOf((*MockCartServiceInterface)(nil).SelectShippingMethod), userID, req, currency)
+}
+
 // UpdateCartItem mocks base method.
-func (m *MockCartServiceInterface) UpdateCartItem(userID, itemID uint, req *dto.UpdateCartItemRequest) (*dto.CartResponse, error) {
+func (m *MockCartServiceInterface) UpdateCartItem(userID, itemID uint, req *dto.UpdateCartItemRequest, currency money.Currency) (*dto.CartResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCartItem", userID, itemID, req)
+	ret := m.ctrl.Call(m, "UpdateCartItem", userID, itemID, req, currency)
 	ret0, _ := ret[0].(*dto.CartResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateCartItem indicates an expected call of UpdateCartItem.
-func (mr *MockCartServiceInterfaceMockRecorder) UpdateCartItem(userID, itemID, req any) *gomock.Call {
+func (mr *MockCartServiceInterfaceMockRecorder) UpdateCartItem(userID, itemID, req, currency any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCartItem", reflect.TypeOf((*MockCartServiceInterface)(nil).UpdateCartItem), userID, itemID, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCartItem", reflect.TypeOf((*MockCartServiceInterface)(nil).UpdateCartItem), userID, itemID, req, currency)
 }
 
-// MockOrderServiceInterface is a mock of OrderServiceInterface interface.
-type MockOrderServiceInterface struct {
+// UpdateGuestCartItem mocks base method.
+func (m *MockCartServiceInterface) UpdateGuestCartItem(token string, itemID uint, req *dto.UpdateCartItemRequest, currency money.Currency) (*dto.CartResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGuestCartItem", token, itemID, req, currency)
+	ret0, _ := ret[0].(*dto.CartResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGuestCartItem indicates an expected call of UpdateGuestCartItem.
+func (mr *MockCartServiceInterfaceMockRecorder) UpdateGuestCartItem(token, itemID, req, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGuestCartItem", reflect.TypeOf((*MockCartServiceInterface)(nil).UpdateGuestCartItem), token, itemID, req, currency)
+}
+
+// MockCheckoutServiceInterface is a mock of CheckoutServiceInterface interface.
+type MockCheckoutServiceInterface struct {
 	ctrl     *gomock.Controller
-	recorder *MockOrderServiceInterfaceMockRecorder
+	recorder *MockCheckoutServiceInterfaceMockRecorder
 	isgomock struct{}
 }
 
-// MockOrderServiceInterfaceMockRecorder is the mock recorder for MockOrderServiceInterface.
-type MockOrderServiceInterfaceMockRecorder struct {
-	mock *MockOrderServiceInterface
+// MockCheckoutServiceInterfaceMockRecorder is the mock recorder for MockCheckoutServiceInterface.
+type MockCheckoutServiceInterfaceMockRecorder struct {
+	mock *MockCheckoutServiceInterface
 }
 
-// NewMockOrderServiceInterface creates a new mock instance.
-func NewMockOrderServiceInterface(ctrl *gomock.Controller) *MockOrderServiceInterface {
-	mock := &MockOrderServiceInterface{ctrl: ctrl}
-	mock.recorder = &MockOrderServiceInterfaceMockRecorder{mock}
+// NewMockCheckoutServiceInterface creates a new mock instance.
+func NewMockCheckoutServiceInterface(ctrl *gomock.Controller) *MockCheckoutServiceInterface {
+	mock := &MockCheckoutServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockCheckoutServiceInterfaceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockOrderServiceInterface) EXPECT() *MockOrderServiceInterfaceMockRecorder {
+func (m *MockCheckoutServiceInterface) EXPECT() *MockCheckoutServiceInterfaceMockRecorder {
 	return m.recorder
 }
 
-// CreateOrder mocks base method.
-func (m *MockOrderServiceInterface) CreateOrder(userID uint) (*dto.OrderResponse, error) {
+// CompleteCheckoutSession mocks base method.
+func (m *MockCheckoutServiceInterface) CompleteCheckoutSession(userID, sessionID uint) (*dto.OrderResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrder", userID)
+	ret := m.ctrl.Call(m, "CompleteCheckoutSession", userID, sessionID)
 	ret0, _ := ret[0].(*dto.OrderResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateOrder indicates an expected call of CreateOrder.
-func (mr *MockOrderServiceInterfaceMockRecorder) CreateOrder(userID any) *gomock.Call {
+// CompleteCheckoutSession indicates an expected call of CompleteCheckoutSession.
+func (mr *MockCheckoutServiceInterfaceMockRecorder) CompleteCheckoutSession(userID, sessionID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderServiceInterface)(nil).CreateOrder), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteCheckoutSession", reflect.TypeOf((*MockCheckoutServiceInterface)(nil).CompleteCheckoutSession), userID, sessionID)
 }
 
-// GetOrder mocks base method.
-func (m *MockOrderServiceInterface) GetOrder(userID, orderID uint) (*dto.OrderResponse, error) {
+// CreateCheckoutSession mocks base method.
+func (m *MockCheckoutServiceInterface) CreateCheckoutSession(userID uint, req *dto.CreateOrderRequest, currency money.Currency) (*dto.CheckoutSessionResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrder", userID, orderID)
-	ret0, _ := ret[0].(*dto.OrderResponse)
+	ret := m.ctrl.Call(m, "CreateCheckoutSession", userID, req, currency)
+	ret0, _ := ret[0].(*dto.CheckoutSessionResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOrder indicates an expected call of GetOrder.
-func (mr *MockOrderServiceInterfaceMockRecorder) GetOrder(userID, orderID any) *gomock.Call {
+// CreateCheckoutSession indicates an expected call of CreateCheckoutSession.
+func (mr *MockCheckoutServiceInterfaceMockRecorder) CreateCheckoutSession(userID, req, currency any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderServiceInterface)(nil).GetOrder), userID, orderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCheckoutSession", reflect.TypeOf((*MockCheckoutServiceInterface)(nil).CreateCheckoutSession), userID, req, currency)
 }
 
-// GetOrders mocks base method.
-func (m *MockOrderServiceInterface) GetOrders(userID uint, page, limit int) ([]dto.OrderResponse, *utils.PaginationMeta, error) {
+// GetCheckoutSession mocks base method.
+func (m *MockCheckoutServiceInterface) GetCheckoutSession(userID, sessionID uint) (*dto.CheckoutSessionResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrders", userID, page, limit)
-	ret0, _ := ret[0].([]dto.OrderResponse)
-	ret1, _ := ret[1].(*utils.PaginationMeta)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "GetCheckoutSession", userID, sessionID)
+	ret0, _ := ret[0].(*dto.CheckoutSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetOrders indicates an expected call of GetOrders.
-func (mr *MockOrderServiceInterfaceMockRecorder) GetOrders(userID, page, limit any) *gomock.Call {
+// GetCheckoutSession indicates an expected call of GetCheckoutSession.
+func (mr *MockCheckoutServiceInterfaceMockRecorder) GetCheckoutSession(userID, sessionID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockOrderServiceInterface)(nil).GetOrders), userID, page, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckoutSession", reflect.TypeOf((*MockCheckoutServiceInterface)(nil).GetCheckoutSession), userID, sessionID)
+}
+
+// MockSubscriptionServiceInterface is a mock of SubscriptionServiceInterface interface.
+type MockSubscriptionServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionServiceInterfaceMockRecorder is the mock recorder for MockSubscriptionServiceInterface.
+type MockSubscriptionServiceInterfaceMockRecorder struct {
+	mock *MockSubscriptionServiceInterface
+}
+
+// NewMockSubscriptionServiceInterface creates a new mock instance.
+func NewMockSubscriptionServiceInterface(ctrl *gomock.Controller) *MockSubscriptionServiceInterface {
+	mock := &MockSubscriptionServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionServiceInterface) EXPECT() *MockSubscriptionServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CancelSubscription mocks base method.
+func (m *MockSubscriptionServiceInterface) CancelSubscription(userID, subscriptionID uint) (*dto.SubscriptionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSubscription", userID, subscriptionID)
+	ret0, _ := ret[0].(*dto.SubscriptionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSubscription indicates an expected call of CancelSubscription.
+func (mr *MockSubscriptionServiceInterfaceMockRecorder) CancelSubscription(userID, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSubscription", reflect.TypeOf((*MockSubscriptionServiceInterface)(nil).CancelSubscription), userID, subscriptionID)
+}
+
+// CreateSubscription mocks base method.
+func (m *MockSubscriptionServiceInterface) CreateSubscription(userID uint, req *dto.CreateSubscriptionRequest) (*dto.SubscriptionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", userID, req)
+	ret0, _ := ret[0].(*dto.SubscriptionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockSubscriptionServiceInterfaceMockRecorder) CreateSubscription(userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockSubscriptionServiceInterface)(nil).CreateSubscription), userID, req)
+}
+
+// GetSubscription mocks base method.
+func (m *MockSubscriptionServiceInterface) GetSubscription(userID, subscriptionID uint) (*dto.SubscriptionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscription", userID, subscriptionID)
+	ret0, _ := ret[0].(*dto.SubscriptionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscription indicates an expected call of GetSubscription.
+func (mr *MockSubscriptionServiceInterfaceMockRecorder) GetSubscription(userID, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockSubscriptionServiceInterface)(nil).GetSubscription), userID, subscriptionID)
+}
+
+// GetSubscriptions mocks base method.
+func (m *MockSubscriptionServiceInterface) GetSubscriptions(userID uint) ([]dto.SubscriptionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptions", userID)
+	ret0, _ := ret[0].([]dto.SubscriptionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptions indicates an expected call of GetSubscriptions.
+func (mr *MockSubscriptionServiceInterfaceMockRecorder) GetSubscriptions(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptions", reflect.TypeOf((*MockSubscriptionServiceInterface)(nil).GetSubscriptions), userID)
+}
+
+// PauseSubscription mocks base method.
+func (m *MockSubscriptionServiceInterface) PauseSubscription(userID, subscriptionID uint) (*dto.SubscriptionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseSubscription", userID, subscriptionID)
+	ret0, _ := ret[0].(*dto.SubscriptionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PauseSubscription indicates an expected call of PauseSubscription.
+func (mr *MockSubscriptionServiceInterfaceMockRecorder) PauseSubscription(userID, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseSubscription", reflect.TypeOf((*MockSubscriptionServiceInterface)(nil).PauseSubscription), userID, subscriptionID)
+}
+
+// ResumeSubscription mocks base method.
+func (m *MockSubscriptionServiceInterface) ResumeSubscription(userID, subscriptionID uint) (*dto.SubscriptionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeSubscription", userID, subscriptionID)
+	ret0, _ := ret[0].(*dto.SubscriptionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeSubscription indicates an expected call of ResumeSubscription.
+func (mr *MockSubscriptionServiceInterfaceMockRecorder) ResumeSubscription(userID, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeSubscription", reflect.TypeOf((*MockSubscriptionServiceInterface)(nil).ResumeSubscription), userID, subscriptionID)
+}
+
+// RunDueSubscriptions mocks base method.
+func (m *MockSubscriptionServiceInterface) RunDueSubscriptions(now time.Time) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunDueSubscriptions", now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunDueSubscriptions indicates an expected call of RunDueSubscriptions.
+func (mr *MockSubscriptionServiceInterfaceMockRecorder) RunDueSubscriptions(now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunDueSubscriptions", reflect.TypeOf((*MockSubscriptionServiceInterface)(nil).RunDueSubscriptions), now)
+}
+
+// UpdateSubscription mocks base method.
+func (m *MockSubscriptionServiceInterface) UpdateSubscription(userID, subscriptionID uint, req *dto.UpdateSubscriptionRequest) (*dto.SubscriptionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscription", userID, subscriptionID, req)
+	ret0, _ := ret[0].(*dto.SubscriptionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubscription indicates an expected call of UpdateSubscription.
+func (mr *MockSubscriptionServiceInterfaceMockRecorder) UpdateSubscription(userID, subscriptionID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockSubscriptionServiceInterface)(nil).UpdateSubscription), userID, subscriptionID, req)
+}
+
+// MockShippingServiceInterface is a mock of ShippingServiceInterface interface.
+type MockShippingServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockShippingServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockShippingServiceInterfaceMockRecorder is the mock recorder for MockShippingServiceInterface.
+type MockShippingServiceInterfaceMockRecorder struct {
+	mock *MockShippingServiceInterface
+}
+
+// NewMockShippingServiceInterface creates a new mock instance.
+func NewMockShippingServiceInterface(ctrl *gomock.Controller) *MockShippingServiceInterface {
+	mock := &MockShippingServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockShippingServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShippingServiceInterface) EXPECT() *MockShippingServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateRate mocks base method.
+func (m *MockShippingServiceInterface) CreateRate(zoneID uint, req *dto.ShippingRateRequest) (*dto.ShippingRateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRate", zoneID, req)
+	ret0, _ := ret[0].(*dto.ShippingRateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRate indicates an expected call of CreateRate.
+func (mr *MockShippingServiceInterfaceMockRecorder) CreateRate(zoneID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRate", reflect.TypeOf((*MockShippingServiceInterface)(nil).CreateRate), zoneID, req)
+}
+
+// CreateZone mocks base method.
+func (m *MockShippingServiceInterface) CreateZone(req *dto.ShippingZoneRequest) (*dto.ShippingZoneResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateZone", req)
+	ret0, _ := ret[0].(*dto.ShippingZoneResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateZone indicates an expected call of CreateZone.
+func (mr *MockShippingServiceInterfaceMockRecorder) CreateZone(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateZone", reflect.TypeOf((*MockShippingServiceInterface)(nil).CreateZone), req)
+}
+
+// DeleteRate mocks base method.
+func (m *MockShippingServiceInterface) DeleteRate(rateID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRate", rateID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRate indicates an expected call of DeleteRate.
+func (mr *MockShippingServiceInterfaceMockRecorder) DeleteRate(rateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRate", reflect.TypeOf((*MockShippingServiceInterface)(nil).DeleteRate), rateID)
+}
+
+// DeleteZone mocks base method.
+func (m *MockShippingServiceInterface) DeleteZone(zoneID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteZone", zoneID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteZone indicates an expected call of DeleteZone.
+func (mr *MockShippingServiceInterfaceMockRecorder) DeleteZone(zoneID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteZone", reflect.TypeOf((*MockShippingServiceInterface)(nil).DeleteZone), zoneID)
+}
+
+// GetZones mocks base method.
+func (m *MockShippingServiceInterface) GetZones() ([]dto.ShippingZoneResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetZones")
+	ret0, _ := ret[0].([]dto.ShippingZoneResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetZones indicates an expected call of GetZones.
+func (mr *MockShippingServiceInterfaceMockRecorder) GetZones() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetZones", reflect.TypeOf((*MockShippingServiceInterface)(nil).GetZones))
+}
+
+// UpdateRate mocks base method.
+func (m *MockShippingServiceInterface) UpdateRate(rateID uint, req *dto.ShippingRateRequest) (*dto.ShippingRateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRate", rateID, req)
+	ret0, _ := ret[0].(*dto.ShippingRateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRate indicates an expected call of UpdateRate.
+func (mr *MockShippingServiceInterfaceMockRecorder) UpdateRate(rateID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRate", reflect.TypeOf((*MockShippingServiceInterface)(nil).UpdateRate), rateID, req)
+}
+
+// UpdateZone mocks base method.
+func (m *MockShippingServiceInterface) UpdateZone(zoneID uint, req *dto.ShippingZoneRequest) (*dto.ShippingZoneResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateZone", zoneID, req)
+	ret0, _ := ret[0].(*dto.ShippingZoneResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateZone indicates an expected call of UpdateZone.
+func (mr *MockShippingServiceInterfaceMockRecorder) UpdateZone(zoneID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateZone", reflect.TypeOf((*MockShippingServiceInterface)(nil).UpdateZone), zoneID, req)
+}
+
+// MockOrderServiceInterface is a mock of OrderServiceInterface interface.
+type MockOrderServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderServiceInterfaceMockRecorder is the mock recorder for MockOrderServiceInterface.
+type MockOrderServiceInterfaceMockRecorder struct {
+	mock *MockOrderServiceInterface
+}
+
+// NewMockOrderServiceInterface creates a new mock instance.
+func NewMockOrderServiceInterface(ctrl *gomock.Controller) *MockOrderServiceInterface {
+	mock := &MockOrderServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockOrderServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderServiceInterface) EXPECT() *MockOrderServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CancelOrder mocks base method.
+func (m *MockOrderServiceInterface) CancelOrder(userID, orderID uint, req *dto.CancelOrderRequest) (*dto.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", userID, orderID, req)
+	ret0, _ := ret[0].(*dto.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrderServiceInterfaceMockRecorder) CancelOrder(userID, orderID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderServiceInterface)(nil).CancelOrder), userID, orderID, req)
+}
+
+// CreateGuestOrder mocks base method.
+func (m *MockOrderServiceInterface) CreateGuestOrder(token string, req *dto.GuestOrderRequest, currency money.Currency) (*dto.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGuestOrder", token, req, currency)
+	ret0, _ := ret[0].(*dto.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGuestOrder indicates an expected call of CreateGuestOrder.
+func (mr *MockOrderServiceInterfaceMockRecorder) CreateGuestOrder(token, req, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGuestOrder", reflect.TypeOf((*MockOrderServiceInterface)(nil).CreateGuestOrder), token, req, currency)
+}
+
+// CreateOrder mocks base method.
+func (m *MockOrderServiceInterface) CreateOrder(userID uint, req *dto.CreateOrderRequest, currency money.Currency) (*dto.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", userID, req, currency)
+	ret0, _ := ret[0].(*dto.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockOrderServiceInterfaceMockRecorder) CreateOrder(userID, req, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderServiceInterface)(nil).CreateOrder), userID, req, currency)
+}
+
+// ExportOrders mocks base method.
+func (m *MockOrderServiceInterface) ExportOrders(req *dto.ListOrdersRequest, w exports.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportOrders", req, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportOrders indicates an expected call of ExportOrders.
+func (mr *MockOrderServiceInterfaceMockRecorder) ExportOrders(req, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportOrders", reflect.TypeOf((*MockOrderServiceInterface)(nil).ExportOrders), req, w)
+}
+
+// GetOrder mocks base method.
+func (m *MockOrderServiceInterface) GetOrder(userID, orderID uint) (*dto.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", userID, orderID)
+	ret0, _ := ret[0].(*dto.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockOrderServiceInterfaceMockRecorder) GetOrder(userID, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderServiceInterface)(nil).GetOrder), userID, orderID)
+}
+
+// GetOrderDetail mocks base method.
+func (m *MockOrderServiceInterface) GetOrderDetail(orderID uint) (*dto.AdminOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderDetail", orderID)
+	ret0, _ := ret[0].(*dto.AdminOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderDetail indicates an expected call of GetOrderDetail.
+func (mr *MockOrderServiceInterfaceMockRecorder) GetOrderDetail(orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderDetail", reflect.TypeOf((*MockOrderServiceInterface)(nil).GetOrderDetail), orderID)
+}
+
+// GetOrders mocks base method.
+func (m *MockOrderServiceInterface) GetOrders(userID uint, page, limit int) ([]dto.OrderResponse, *utils.PaginationMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrders", userID, page, limit)
+	ret0, _ := ret[0].([]dto.OrderResponse)
+	ret1, _ := ret[1].(*utils.PaginationMeta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOrders indicates an expected call of GetOrders.
+func (mr *MockOrderServiceInterfaceMockRecorder) GetOrders(userID, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockOrderServiceInterface)(nil).GetOrders), userID, page, limit)
+}
+
+// ListOrders mocks base method.
+func (m *MockOrderServiceInterface) ListOrders(req *dto.ListOrdersRequest) ([]dto.OrderResponse, *utils.PaginationMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", req)
+	ret0, _ := ret[0].([]dto.OrderResponse)
+	ret1, _ := ret[1].(*utils.PaginationMeta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockOrderServiceInterfaceMockRecorder) ListOrders(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockOrderServiceInterface)(nil).ListOrders), req)
+}
+
+// LookupGuestOrder mocks base method.
+func (m *MockOrderServiceInterface) LookupGuestOrder(req *dto.GuestOrderLookupRequest) (*dto.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupGuestOrder", req)
+	ret0, _ := ret[0].(*dto.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupGuestOrder indicates an expected call of LookupGuestOrder.
+func (mr *MockOrderServiceInterfaceMockRecorder) LookupGuestOrder(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupGuestOrder", reflect.TypeOf((*MockOrderServiceInterface)(nil).LookupGuestOrder), req)
+}
+
+// UpdateOrderStatus mocks base method.
+func (m *MockOrderServiceInterface) UpdateOrderStatus(orderID, changedBy uint, req *dto.UpdateOrderStatusRequest) (*dto.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", orderID, changedBy, req)
+	ret0, _ := ret[0].(*dto.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
+func (mr *MockOrderServiceInterfaceMockRecorder) UpdateOrderStatus(orderID, changedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockOrderServiceInterface)(nil).UpdateOrderStatus), orderID, changedBy, req)
+}
+
+// MockTaxServiceInterface is a mock of TaxServiceInterface interface.
+type MockTaxServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTaxServiceInterfaceMockRecorder is the mock recorder for MockTaxServiceInterface.
+type MockTaxServiceInterfaceMockRecorder struct {
+	mock *MockTaxServiceInterface
+}
+
+// NewMockTaxServiceInterface creates a new mock instance.
+func NewMockTaxServiceInterface(ctrl *gomock.Controller) *MockTaxServiceInterface {
+	mock := &MockTaxServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockTaxServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxServiceInterface) EXPECT() *MockTaxServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateTaxRate mocks base method.
+func (m *MockTaxServiceInterface) CreateTaxRate(req *dto.TaxRateRequest) (*dto.TaxRateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaxRate", req)
+	ret0, _ := ret[0].(*dto.TaxRateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaxRate indicates an expected call of CreateTaxRate.
+func (mr *MockTaxServiceInterfaceMockRecorder) CreateTaxRate(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaxRate", reflect.TypeOf((*MockTaxServiceInterface)(nil).CreateTaxRate), req)
+}
+
+// DeleteTaxRate mocks base method.
+func (m *MockTaxServiceInterface) DeleteTaxRate(rateID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaxRate", rateID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaxRate indicates an expected call of DeleteTaxRate.
+func (mr *MockTaxServiceInterfaceMockRecorder) DeleteTaxRate(rateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaxRate", reflect.TypeOf((*MockTaxServiceInterface)(nil).DeleteTaxRate), rateID)
+}
+
+// GetTaxRates mocks base method.
+func (m *MockTaxServiceInterface) GetTaxRates() ([]dto.TaxRateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxRates")
+	ret0, _ := ret[0].([]dto.TaxRateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxRates indicates an expected call of GetTaxRates.
+func (mr *MockTaxServiceInterfaceMockRecorder) GetTaxRates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRates", reflect.TypeOf((*MockTaxServiceInterface)(nil).GetTaxRates))
+}
+
+// UpdateTaxRate mocks base method.
+func (m *MockTaxServiceInterface) UpdateTaxRate(rateID uint, req *dto.TaxRateRequest) (*dto.TaxRateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaxRate", rateID, req)
+	ret0, _ := ret[0].(*dto.TaxRateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaxRate indicates an expected call of UpdateTaxRate.
+func (mr *MockTaxServiceInterfaceMockRecorder) UpdateTaxRate(rateID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaxRate", reflect.TypeOf((*MockTaxServiceInterface)(nil).UpdateTaxRate), rateID, req)
+}
+
+// MockCouponServiceInterface is a mock of CouponServiceInterface interface.
+type MockCouponServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCouponServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCouponServiceInterfaceMockRecorder is the mock recorder for MockCouponServiceInterface.
+type MockCouponServiceInterfaceMockRecorder struct {
+	mock *MockCouponServiceInterface
+}
+
+// NewMockCouponServiceInterface creates a new mock instance.
+func NewMockCouponServiceInterface(ctrl *gomock.Controller) *MockCouponServiceInterface {
+	mock := &MockCouponServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockCouponServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCouponServiceInterface) EXPECT() *MockCouponServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateCoupon mocks base method.
+func (m *MockCouponServiceInterface) CreateCoupon(req *dto.CouponRequest) (*dto.CouponResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCoupon", req)
+	ret0, _ := ret[0].(*dto.CouponResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCoupon indicates an expected call of CreateCoupon.
+func (mr *MockCouponServiceInterfaceMockRecorder) CreateCoupon(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCoupon", reflect.TypeOf((*MockCouponServiceInterface)(nil).CreateCoupon), req)
+}
+
+// DeleteCoupon mocks base method.
+func (m *MockCouponServiceInterface) DeleteCoupon(couponID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCoupon", couponID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCoupon indicates an expected call of DeleteCoupon.
+func (mr *MockCouponServiceInterfaceMockRecorder) DeleteCoupon(couponID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCoupon", reflect.TypeOf((*MockCouponServiceInterface)(nil).DeleteCoupon), couponID)
+}
+
+// GetCoupons mocks base method.
+func (m *MockCouponServiceInterface) GetCoupons() ([]dto.CouponResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoupons")
+	ret0, _ := ret[0].([]dto.CouponResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoupons indicates an expected call of GetCoupons.
+func (mr *MockCouponServiceInterfaceMockRecorder) GetCoupons() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoupons", reflect.TypeOf((*MockCouponServiceInterface)(nil).GetCoupons))
+}
+
+// UpdateCoupon mocks base method.
+func (m *MockCouponServiceInterface) UpdateCoupon(couponID uint, req *dto.CouponRequest) (*dto.CouponResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCoupon", couponID, req)
+	ret0, _ := ret[0].(*dto.CouponResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCoupon indicates an expected call of UpdateCoupon.
+func (mr *MockCouponServiceInterfaceMockRecorder) UpdateCoupon(couponID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCoupon", reflect.TypeOf((*MockCouponServiceInterface)(nil).UpdateCoupon), couponID, req)
+}
+
+// MockPromotionServiceInterface is a mock of PromotionServiceInterface interface.
+type MockPromotionServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockPromotionServiceInterfaceMockRecorder is the mock recorder for MockPromotionServiceInterface.
+type MockPromotionServiceInterfaceMockRecorder struct {
+	mock *MockPromotionServiceInterface
+}
+
+// NewMockPromotionServiceInterface creates a new mock instance.
+func NewMockPromotionServiceInterface(ctrl *gomock.Controller) *MockPromotionServiceInterface {
+	mock := &MockPromotionServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockPromotionServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionServiceInterface) EXPECT() *MockPromotionServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreatePromotion mocks base method.
+func (m *MockPromotionServiceInterface) CreatePromotion(req *dto.PromotionRequest) (*dto.PromotionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromotion", req)
+	ret0, _ := ret[0].(*dto.PromotionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromotion indicates an expected call of CreatePromotion.
+func (mr *MockPromotionServiceInterfaceMockRecorder) CreatePromotion(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotion", reflect.TypeOf((*MockPromotionServiceInterface)(nil).CreatePromotion), req)
+}
+
+// DeletePromotion mocks base method.
+func (m *MockPromotionServiceInterface) DeletePromotion(promotionID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePromotion", promotionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePromotion indicates an expected call of DeletePromotion.
+func (mr *MockPromotionServiceInterfaceMockRecorder) DeletePromotion(promotionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePromotion", reflect.TypeOf((*MockPromotionServiceInterface)(nil).DeletePromotion), promotionID)
+}
+
+// GetPromotions mocks base method.
+func (m *MockPromotionServiceInterface) GetPromotions() ([]dto.PromotionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotions")
+	ret0, _ := ret[0].([]dto.PromotionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotions indicates an expected call of GetPromotions.
+func (mr *MockPromotionServiceInterfaceMockRecorder) GetPromotions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotions", reflect.TypeOf((*MockPromotionServiceInterface)(nil).GetPromotions))
+}
+
+// UpdatePromotion mocks base method.
+func (m *MockPromotionServiceInterface) UpdatePromotion(promotionID uint, req *dto.PromotionRequest) (*dto.PromotionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePromotion", promotionID, req)
+	ret0, _ := ret[0].(*dto.PromotionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePromotion indicates an expected call of UpdatePromotion.
+func (mr *MockPromotionServiceInterfaceMockRecorder) UpdatePromotion(promotionID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePromotion", reflect.TypeOf((*MockPromotionServiceInterface)(nil).UpdatePromotion), promotionID, req)
+}
+
+// MockInvoiceServiceInterface is a mock of InvoiceServiceInterface interface.
+type MockInvoiceServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockInvoiceServiceInterfaceMockRecorder is the mock recorder for MockInvoiceServiceInterface.
+type MockInvoiceServiceInterfaceMockRecorder struct {
+	mock *MockInvoiceServiceInterface
+}
+
+// NewMockInvoiceServiceInterface creates a new mock instance.
+func NewMockInvoiceServiceInterface(ctrl *gomock.Controller) *MockInvoiceServiceInterface {
+	mock := &MockInvoiceServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockInvoiceServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceServiceInterface) EXPECT() *MockInvoiceServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GenerateInvoice mocks base method.
+func (m *MockInvoiceServiceInterface) GenerateInvoice(orderID uint) (*dto.InvoiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateInvoice", orderID)
+	ret0, _ := ret[0].(*dto.InvoiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateInvoice indicates an expected call of GenerateInvoice.
+func (mr *MockInvoiceServiceInterfaceMockRecorder) GenerateInvoice(orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateInvoice", reflect.TypeOf((*MockInvoiceServiceInterface)(nil).GenerateInvoice), orderID)
+}
+
+// GetInvoice mocks base method.
+func (m *MockInvoiceServiceInterface) GetInvoice(userID, orderID uint) (*dto.InvoiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoice", userID, orderID)
+	ret0, _ := ret[0].(*dto.InvoiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoice indicates an expected call of GetInvoice.
+func (mr *MockInvoiceServiceInterfaceMockRecorder) GetInvoice(userID, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockInvoiceServiceInterface)(nil).GetInvoice), userID, orderID)
+}
+
+// MockReturnServiceInterface is a mock of ReturnServiceInterface interface.
+type MockReturnServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockReturnServiceInterfaceMockRecorder is the mock recorder for MockReturnServiceInterface.
+type MockReturnServiceInterfaceMockRecorder struct {
+	mock *MockReturnServiceInterface
+}
+
+// NewMockReturnServiceInterface creates a new mock instance.
+func NewMockReturnServiceInterface(ctrl *gomock.Controller) *MockReturnServiceInterface {
+	mock := &MockReturnServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockReturnServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnServiceInterface) EXPECT() *MockReturnServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ApproveReturn mocks base method.
+func (m *MockReturnServiceInterface) ApproveReturn(returnID uint) (*dto.ReturnResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveReturn", returnID)
+	ret0, _ := ret[0].(*dto.ReturnResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveReturn indicates an expected call of ApproveReturn.
+func (mr *MockReturnServiceInterfaceMockRecorder) ApproveReturn(returnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveReturn", reflect.TypeOf((*MockReturnServiceInterface)(nil).ApproveReturn), returnID)
+}
+
+// CancelReturn mocks base method.
+func (m *MockReturnServiceInterface) CancelReturn(userID, returnID uint) (*dto.ReturnResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelReturn", userID, returnID)
+	ret0, _ := ret[0].(*dto.ReturnResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelReturn indicates an expected call of CancelReturn.
+func (mr *MockReturnServiceInterfaceMockRecorder) CancelReturn(userID, returnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelReturn", reflect.TypeOf((*MockReturnServiceInterface)(nil).CancelReturn), userID, returnID)
+}
+
+// GetReturns mocks base method.
+func (m *MockReturnServiceInterface) GetReturns(userID uint, page, limit int) ([]dto.ReturnResponse, *utils.PaginationMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturns", userID, page, limit)
+	ret0, _ := ret[0].([]dto.ReturnResponse)
+	ret1, _ := ret[1].(*utils.PaginationMeta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetReturns indicates an expected call of GetReturns.
+func (mr *MockReturnServiceInterfaceMockRecorder) GetReturns(userID, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturns", reflect.TypeOf((*MockReturnServiceInterface)(nil).GetReturns), userID, page, limit)
+}
+
+// ListReturns mocks base method.
+func (m *MockReturnServiceInterface) ListReturns(status string, page, limit int) ([]dto.ReturnResponse, *utils.PaginationMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReturns", status, page, limit)
+	ret0, _ := ret[0].([]dto.ReturnResponse)
+	ret1, _ := ret[1].(*utils.PaginationMeta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListReturns indicates an expected call of ListReturns.
+func (mr *MockReturnServiceInterfaceMockRecorder) ListReturns(status, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReturns", reflect.TypeOf((*MockReturnServiceInterface)(nil).ListReturns), status, page, limit)
+}
+
+// ReceiveReturn mocks base method.
+func (m *MockReturnServiceInterface) ReceiveReturn(returnID uint, req *dto.ReceiveReturnRequest) (*dto.ReturnResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveReturn", returnID, req)
+	ret0, _ := ret[0].(*dto.ReturnResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveReturn indicates an expected call of ReceiveReturn.
+func (mr *MockReturnServiceInterfaceMockRecorder) ReceiveReturn(returnID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveReturn", reflect.TypeOf((*MockReturnServiceInterface)(nil).ReceiveReturn), returnID, req)
+}
+
+// RefundReturn mocks base method.
+func (m *MockReturnServiceInterface) RefundReturn(returnID uint, req *dto.RefundReturnRequest) (*dto.ReturnResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundReturn", returnID, req)
+	ret0, _ := ret[0].(*dto.ReturnResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundReturn indicates an expected call of RefundReturn.
+func (mr *MockReturnServiceInterfaceMockRecorder) RefundReturn(returnID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundReturn", reflect.TypeOf((*MockReturnServiceInterface)(nil).RefundReturn), returnID, req)
+}
+
+// RejectReturn mocks base method.
+func (m *MockReturnServiceInterface) RejectReturn(returnID uint) (*dto.ReturnResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectReturn", returnID)
+	ret0, _ := ret[0].(*dto.ReturnResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectReturn indicates an expected call of RejectReturn.
+func (mr *MockReturnServiceInterfaceMockRecorder) RejectReturn(returnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectReturn", reflect.TypeOf((*MockReturnServiceInterface)(nil).RejectReturn), returnID)
+}
+
+// RequestReturn mocks base method.
+func (m *MockReturnServiceInterface) RequestReturn(userID, orderID uint, req *dto.CreateReturnRequest) (*dto.ReturnResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestReturn", userID, orderID, req)
+	ret0, _ := ret[0].(*dto.ReturnResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestReturn indicates an expected call of RequestReturn.
+func (mr *MockReturnServiceInterfaceMockRecorder) RequestReturn(userID, orderID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestReturn", reflect.TypeOf((*MockReturnServiceInterface)(nil).RequestReturn), userID, orderID, req)
+}
+
+// MockWalletServiceInterface is a mock of WalletServiceInterface interface.
+type MockWalletServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockWalletServiceInterfaceMockRecorder is the mock recorder for MockWalletServiceInterface.
+type MockWalletServiceInterfaceMockRecorder struct {
+	mock *MockWalletServiceInterface
+}
+
+// NewMockWalletServiceInterface creates a new mock instance.
+func NewMockWalletServiceInterface(ctrl *gomock.Controller) *MockWalletServiceInterface {
+	mock := &MockWalletServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockWalletServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletServiceInterface) EXPECT() *MockWalletServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetGiftCards mocks base method.
+func (m *MockWalletServiceInterface) GetGiftCards(page, limit int) ([]dto.GiftCardResponse, *utils.PaginationMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGiftCards", page, limit)
+	ret0, _ := ret[0].([]dto.GiftCardResponse)
+	ret1, _ := ret[1].(*utils.PaginationMeta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetGiftCards indicates an expected call of GetGiftCards.
+func (mr *MockWalletServiceInterfaceMockRecorder) GetGiftCards(page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGiftCards", reflect.TypeOf((*MockWalletServiceInterface)(nil).GetGiftCards), page, limit)
+}
+
+// GetWallet mocks base method.
+func (m *MockWalletServiceInterface) GetWallet(userID uint) (*dto.WalletResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallet", userID)
+	ret0, _ := ret[0].(*dto.WalletResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallet indicates an expected call of GetWallet.
+func (mr *MockWalletServiceInterfaceMockRecorder) GetWallet(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallet", reflect.TypeOf((*MockWalletServiceInterface)(nil).GetWallet), userID)
+}
+
+// GetWalletEntries mocks base method.
+func (m *MockWalletServiceInterface) GetWalletEntries(userID uint, page, limit int) ([]dto.WalletEntryResponse, *utils.PaginationMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletEntries", userID, page, limit)
+	ret0, _ := ret[0].([]dto.WalletEntryResponse)
+	ret1, _ := ret[1].(*utils.PaginationMeta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWalletEntries indicates an expected call of GetWalletEntries.
+func (mr *MockWalletServiceInterfaceMockRecorder) GetWalletEntries(userID, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletEntries", reflect.TypeOf((*MockWalletServiceInterface)(nil).GetWalletEntries), userID, page, limit)
+}
+
+// GrantCredit mocks base method.
+func (m *MockWalletServiceInterface) GrantCredit(adminID, userID uint, req *dto.GrantCreditRequest) (*dto.WalletEntryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantCredit", adminID, userID, req)
+	ret0, _ := ret[0].(*dto.WalletEntryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantCredit indicates an expected call of GrantCredit.
+func (mr *MockWalletServiceInterfaceMockRecorder) GrantCredit(adminID, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantCredit", reflect.TypeOf((*MockWalletServiceInterface)(nil).GrantCredit), adminID, userID, req)
+}
+
+// IssueGiftCard mocks base method.
+func (m *MockWalletServiceInterface) IssueGiftCard(adminID uint, req *dto.GiftCardRequest) (*dto.GiftCardResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueGiftCard", adminID, req)
+	ret0, _ := ret[0].(*dto.GiftCardResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueGiftCard indicates an expected call of IssueGiftCard.
+func (mr *MockWalletServiceInterfaceMockRecorder) IssueGiftCard(adminID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueGiftCard", reflect.TypeOf((*MockWalletServiceInterface)(nil).IssueGiftCard), adminID, req)
+}
+
+// RedeemGiftCard mocks base method.
+func (m *MockWalletServiceInterface) RedeemGiftCard(userID uint, req *dto.RedeemGiftCardRequest) (*dto.WalletResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemGiftCard", userID, req)
+	ret0, _ := ret[0].(*dto.WalletResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeemGiftCard indicates an expected call of RedeemGiftCard.
+func (mr *MockWalletServiceInterfaceMockRecorder) RedeemGiftCard(userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemGiftCard", reflect.TypeOf((*MockWalletServiceInterface)(nil).RedeemGiftCard), userID, req)
+}
+
+// MockLoyaltyServiceInterface is a mock of LoyaltyServiceInterface interface.
+type MockLoyaltyServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoyaltyServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockLoyaltyServiceInterfaceMockRecorder is the mock recorder for MockLoyaltyServiceInterface.
+type MockLoyaltyServiceInterfaceMockRecorder struct {
+	mock *MockLoyaltyServiceInterface
+}
+
+// NewMockLoyaltyServiceInterface creates a new mock instance.
+func NewMockLoyaltyServiceInterface(ctrl *gomock.Controller) *MockLoyaltyServiceInterface {
+	mock := &MockLoyaltyServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockLoyaltyServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoyaltyServiceInterface) EXPECT() *MockLoyaltyServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AwardPoints mocks base method.
+func (m *MockLoyaltyServiceInterface) AwardPoints(now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AwardPoints", now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AwardPoints indicates an expected call of AwardPoints.
+func (mr *MockLoyaltyServiceInterfaceMockRecorder) AwardPoints(now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AwardPoints", reflect.TypeOf((*MockLoyaltyServiceInterface)(nil).AwardPoints), now)
+}
+
+// ExpirePoints mocks base method.
+func (m *MockLoyaltyServiceInterface) ExpirePoints(now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePoints", now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePoints indicates an expected call of ExpirePoints.
+func (mr *MockLoyaltyServiceInterfaceMockRecorder) ExpirePoints(now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePoints", reflect.TypeOf((*MockLoyaltyServiceInterface)(nil).ExpirePoints), now)
+}
+
+// GetLoyalty mocks base method.
+func (m *MockLoyaltyServiceInterface) GetLoyalty(userID uint, page, limit int) (*dto.LoyaltyResponse, *utils.PaginationMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoyalty", userID, page, limit)
+	ret0, _ := ret[0].(*dto.LoyaltyResponse)
+	ret1, _ := ret[1].(*utils.PaginationMeta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLoyalty indicates an expected call of GetLoyalty.
+func (mr *MockLoyaltyServiceInterfaceMockRecorder) GetLoyalty(userID, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoyalty", reflect.TypeOf((*MockLoyaltyServiceInterface)(nil).GetLoyalty), userID, page, limit)
+}
+
+// MockShipmentServiceInterface is a mock of ShipmentServiceInterface interface.
+type MockShipmentServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockShipmentServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockShipmentServiceInterfaceMockRecorder is the mock recorder for MockShipmentServiceInterface.
+type MockShipmentServiceInterfaceMockRecorder struct {
+	mock *MockShipmentServiceInterface
+}
+
+// NewMockShipmentServiceInterface creates a new mock instance.
+func NewMockShipmentServiceInterface(ctrl *gomock.Controller) *MockShipmentServiceInterface {
+	mock := &MockShipmentServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockShipmentServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShipmentServiceInterface) EXPECT() *MockShipmentServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateShipment mocks base method.
+func (m *MockShipmentServiceInterface) CreateShipment(orderID, adminID uint, req *dto.CreateShipmentRequest) (*dto.ShipmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShipment", orderID, adminID, req)
+	ret0, _ := ret[0].(*dto.ShipmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShipment indicates an expected call of CreateShipment.
+func (mr *MockShipmentServiceInterfaceMockRecorder) CreateShipment(orderID, adminID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShipment", reflect.TypeOf((*MockShipmentServiceInterface)(nil).CreateShipment), orderID, adminID, req)
+}
+
+// GetShipments mocks base method.
+func (m *MockShipmentServiceInterface) GetShipments(userID, orderID uint) ([]dto.ShipmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShipments", userID, orderID)
+	ret0, _ := ret[0].([]dto.ShipmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShipments indicates an expected call of GetShipments.
+func (mr *MockShipmentServiceInterfaceMockRecorder) GetShipments(userID, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShipments", reflect.TypeOf((*MockShipmentServiceInterface)(nil).GetShipments), userID, orderID)
+}
+
+// ListShipments mocks base method.
+func (m *MockShipmentServiceInterface) ListShipments(orderID uint) ([]dto.ShipmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShipments", orderID)
+	ret0, _ := ret[0].([]dto.ShipmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShipments indicates an expected call of ListShipments.
+func (mr *MockShipmentServiceInterfaceMockRecorder) ListShipments(orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShipments", reflect.TypeOf((*MockShipmentServiceInterface)(nil).ListShipments), orderID)
+}
+
+// UpdateShipment mocks base method.
+func (m *MockShipmentServiceInterface) UpdateShipment(shipmentID, adminID uint, req *dto.UpdateShipmentRequest) (*dto.ShipmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShipment", shipmentID, adminID, req)
+	ret0, _ := ret[0].(*dto.ShipmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateShipment indicates an expected call of UpdateShipment.
+func (mr *MockShipmentServiceInterfaceMockRecorder) UpdateShipment(shipmentID, adminID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShipment", reflect.TypeOf((*MockShipmentServiceInterface)(nil).UpdateShipment), shipmentID, adminID, req)
+}
+
+// MockInventoryServiceInterface is a mock of InventoryServiceInterface interface.
+type MockInventoryServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockInventoryServiceInterfaceMockRecorder is the mock recorder for MockInventoryServiceInterface.
+type MockInventoryServiceInterfaceMockRecorder struct {
+	mock *MockInventoryServiceInterface
+}
+
+// NewMockInventoryServiceInterface creates a new mock instance.
+func NewMockInventoryServiceInterface(ctrl *gomock.Controller) *MockInventoryServiceInterface {
+	mock := &MockInventoryServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockInventoryServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryServiceInterface) EXPECT() *MockInventoryServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ListBackorders mocks base method.
+func (m *MockInventoryServiceInterface) ListBackorders(req *dto.ListBackordersRequest) ([]dto.BackorderResponse, *utils.PaginationMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBackorders", req)
+	ret0, _ := ret[0].([]dto.BackorderResponse)
+	ret1, _ := ret[1].(*utils.PaginationMeta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListBackorders indicates an expected call of ListBackorders.
+func (mr *MockInventoryServiceInterfaceMockRecorder) ListBackorders(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBackorders", reflect.TypeOf((*MockInventoryServiceInterface)(nil).ListBackorders), req)
+}
+
+// ReleaseExpiredReservations mocks base method.
+func (m *MockInventoryServiceInterface) ReleaseExpiredReservations(now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseExpiredReservations", now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseExpiredReservations indicates an expected call of ReleaseExpiredReservations.
+func (mr *MockInventoryServiceInterfaceMockRecorder) ReleaseExpiredReservations(now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseExpiredReservations", reflect.TypeOf((*MockInventoryServiceInterface)(nil).ReleaseExpiredReservations), now)
 }
 
 // MockUploadServiceInterface is a mock of UploadServiceInterface interface.
@@ -0,0 +1,52 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+)
+
+func TestCheckoutSession_CurrentStatus(t *testing.T) {
+	now := time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		status    models.CheckoutSessionStatus
+		expiresAt time.Time
+		want      models.CheckoutSessionStatus
+	}{
+		{models.CheckoutSessionOpen, now.Add(time.Minute), models.CheckoutSessionOpen},
+		{models.CheckoutSessionOpen, now, models.CheckoutSessionExpired},
+		{models.CheckoutSessionCompleted, now.Add(-time.Hour), models.CheckoutSessionCompleted},
+		{models.CheckoutSessionCancelled, now.Add(time.Minute), models.CheckoutSessionCancelled},
+	}
+
+	for _, tt := range tests {
+		session := models.CheckoutSession{Status: tt.status, ExpiresAt: tt.expiresAt}
+		if got := session.CurrentStatus(now); got != tt.want {
+			t.Errorf("%s session expiring at %s: expected %s, got %s", tt.status, tt.expiresAt, tt.want, got)
+		}
+	}
+}
+
+func TestCart_Fingerprint(t *testing.T) {
+	rateID := uint(3)
+
+	cart := models.Cart{CartItems: []models.CartItem{{ProductID: 1, Quantity: 2}, {ProductID: 2, Quantity: 1}}}
+	reordered := models.Cart{CartItems: []models.CartItem{{ProductID: 2, Quantity: 1}, {ProductID: 1, Quantity: 2}}}
+	if cart.Fingerprint() != reordered.Fingerprint() {
+		t.Error("expected the fingerprint not to depend on the item order")
+	}
+
+	changed := []models.Cart{
+		{CartItems: []models.CartItem{{ProductID: 1, Quantity: 3}, {ProductID: 2, Quantity: 1}}},
+		{CartItems: []models.CartItem{{ProductID: 1, Quantity: 2}}},
+		{CartItems: cart.CartItems, ShippingRateID: &rateID},
+		{CartItems: cart.CartItems, CouponID: &rateID},
+	}
+	for i, other := range changed {
+		if other.Fingerprint() == cart.Fingerprint() {
+			t.Errorf("case %d: expected a different fingerprint", i)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+func usd(amount int64) money.Money {
+	return money.New(amount, "USD")
+}
+
+func TestCoupon_IsAvailable(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		coupon    models.Coupon
+		available bool
+	}{
+		{"Active", models.Coupon{IsActive: true}, true},
+		{"Inactive", models.Coupon{}, false},
+		{"NotStarted", models.Coupon{IsActive: true, StartsAt: &after}, false},
+		{"Started", models.Coupon{IsActive: true, StartsAt: &before, EndsAt: &after}, true},
+		{"Ended", models.Coupon{IsActive: true, EndsAt: &now}, false},
+		{"BelowUsageLimit", models.Coupon{IsActive: true, UsageLimit: 2, UsedCount: 1}, true},
+		{"UsedUp", models.Coupon{IsActive: true, UsageLimit: 2, UsedCount: 2}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.coupon.IsAvailable(now); got != tt.available {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.available, got)
+		}
+	}
+}
+
+func TestCoupon_Covers(t *testing.T) {
+	product := &models.Product{ID: 1, CategoryID: 5}
+
+	tests := []struct {
+		name    string
+		coupon  models.Coupon
+		covered bool
+	}{
+		{"Unrestricted", models.Coupon{}, true},
+		{"Product", models.Coupon{Products: []models.Product{{ID: 1}}}, true},
+		{"OtherProduct", models.Coupon{Products: []models.Product{{ID: 2}}}, false},
+		{"Category", models.Coupon{Categories: []models.Category{{ID: 5}}}, true},
+		{"OtherCategory", models.Coupon{Products: []models.Product{{ID: 2}}, Categories: []models.Category{{ID: 6}}}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.coupon.Covers(product); got != tt.covered {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.covered, got)
+		}
+	}
+}
+
+func TestCoupon_LineDiscounts(t *testing.T) {
+	items := []models.CartItem{
+		{Quantity: 1, Product: models.Product{ID: 1, CategoryID: 5, Price: usd(1000)}},
+		{Quantity: 2, Product: models.Product{ID: 2, CategoryID: 6, Price: usd(1000)}},
+		{Quantity: 1, Product: models.Product{ID: 3, CategoryID: 5, Price: usd(2000)}},
+	}
+
+	tests := []struct {
+		name      string
+		coupon    models.Coupon
+		discounts []int64
+	}{
+		{"Percentage", models.Coupon{Type: models.CouponPercentage, Percent: 10}, []int64{100, 200, 200}},
+		{"PercentageRestricted", models.Coupon{Type: models.CouponPercentage, Percent: 50, Categories: []models.Category{{ID: 5}}}, []int64{500, 0, 1000}},
+		// 10.00 split across 10/20/20 rounds to 2.00 and 4.00 with the last line taking the remainder
+		{"FixedSplit", models.Coupon{Type: models.CouponFixed, FixedAmount: usd(1000)}, []int64{200, 400, 400}},
+		{"FixedSplitRemainder", models.Coupon{Type: models.CouponFixed, FixedAmount: usd(100)}, []int64{20, 40, 40}},
+		{"FixedSplitRounding", models.Coupon{Type: models.CouponFixed, FixedAmount: usd(1)}, []int64{0, 0, 1}},
+		{"FixedCappedAtPrice", models.Coupon{Type: models.CouponFixed, FixedAmount: usd(10000), Products: []models.Product{{ID: 1}}}, []int64{1000, 0, 0}},
+		{"FreeShipping", models.Coupon{Type: models.CouponFreeShipping}, []int64{0, 0, 0}},
+		{"NothingCovered", models.Coupon{Type: models.CouponFixed, FixedAmount: usd(500), Products: []models.Product{{ID: 9}}}, []int64{0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		got := tt.coupon.LineDiscounts(items, nil)
+		for i := range tt.discounts {
+			if got[i].Amount != tt.discounts[i] {
+				t.Errorf("%s: expected %v, got %v", tt.name, tt.discounts, got)
+				break
+			}
+		}
+	}
+}
+
+func TestCoupon_LineDiscounts_AddUpToTotal(t *testing.T) {
+	items := []models.CartItem{
+		{Quantity: 1, Product: models.Product{ID: 1, Price: usd(333)}},
+		{Quantity: 1, Product: models.Product{ID: 2, Price: usd(333)}},
+		{Quantity: 1, Product: models.Product{ID: 3, Price: usd(334)}},
+	}
+
+	coupon := models.Coupon{Type: models.CouponFixed, FixedAmount: usd(100)}
+
+	var total money.Money
+	for _, discount := range coupon.LineDiscounts(items, nil) {
+		total = total.Add(discount)
+	}
+
+	if total != usd(100) {
+		t.Errorf("expected the line discounts to add up to 1, got %v", total)
+	}
+}
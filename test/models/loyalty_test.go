@@ -0,0 +1,34 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+)
+
+func TestLoyaltyEntry_IsSpendable(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		entry models.LoyaltyEntry
+		want  bool
+	}{
+		{name: "NoExpiry", entry: models.LoyaltyEntry{Points: 100, Remaining: 100}, want: true},
+		{name: "NotYetExpired", entry: models.LoyaltyEntry{Points: 100, Remaining: 40, ExpiresAt: &future}, want: true},
+		{name: "Expired", entry: models.LoyaltyEntry{Points: 100, Remaining: 40, ExpiresAt: &past}, want: false},
+		{name: "Spent", entry: models.LoyaltyEntry{Points: 100, ExpiresAt: &future}, want: false},
+		{name: "Debit", entry: models.LoyaltyEntry{Points: -100}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.entry.IsSpendable(now); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+)
+
+func TestOrderStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from    models.OrderStatus
+		to      models.OrderStatus
+		allowed bool
+	}{
+		{models.OrderStatusPending, models.OrderStatusConfirmed, true},
+		{models.OrderStatusPending, models.OrderStatusCancelled, true},
+		{models.OrderStatusPending, models.OrderStatusShipped, false},
+		{models.OrderStatusConfirmed, models.OrderStatusShipped, true},
+		{models.OrderStatusConfirmed, models.OrderStatusCancelled, true},
+		{models.OrderStatusConfirmed, models.OrderStatusPartiallyShipped, true},
+		{models.OrderStatusPartiallyShipped, models.OrderStatusShipped, true},
+		{models.OrderStatusPartiallyShipped, models.OrderStatusDelivered, false},
+		{models.OrderStatusPartiallyShipped, models.OrderStatusCancelled, false},
+		{models.OrderStatusShipped, models.OrderStatusDelivered, true},
+		{models.OrderStatusShipped, models.OrderStatusCancelled, false},
+		{models.OrderStatusDelivered, models.OrderStatusPending, false},
+		{models.OrderStatusCancelled, models.OrderStatusConfirmed, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.from.CanTransitionTo(tt.to); got != tt.allowed {
+			t.Errorf("%s -> %s: expected %v, got %v", tt.from, tt.to, tt.allowed, got)
+		}
+	}
+}
+
+func TestOrderStatus_IsValid(t *testing.T) {
+	if !models.OrderStatusShipped.IsValid() {
+		t.Error("expected shipped to be a valid status")
+	}
+
+	if models.OrderStatus("lost").IsValid() {
+		t.Error("expected unknown status to be invalid")
+	}
+}
+
+func TestNewOrderNumber(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		number, err := models.NewOrderNumber()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(number) != 13 || number[:3] != "GC-" {
+			t.Fatalf("unexpected order number %q", number)
+		}
+		if strings.ContainsAny(number[3:], "01IO") {
+			t.Errorf("expected no ambiguous characters in %q", number)
+		}
+		if seen[number] {
+			t.Errorf("order number %q handed out twice", number)
+		}
+		seen[number] = true
+	}
+}
+
+func TestOrder_CustomerID(t *testing.T) {
+	userID := uint(7)
+	if got := (&models.Order{UserID: &userID}).CustomerID(); got != userID {
+		t.Errorf("expected customer %d, got %d", userID, got)
+	}
+
+	guest := models.Order{GuestEmail: "ada@example.com"}
+	if guest.CustomerID() != 0 || !guest.IsGuest() {
+		t.Errorf("expected a guest order without a customer, got %+v", guest)
+	}
+}
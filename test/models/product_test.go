@@ -0,0 +1,53 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+)
+
+func TestProduct_Sellable(t *testing.T) {
+	tests := []struct {
+		stock          int
+		backorderLimit int
+		want           int
+	}{
+		{stock: 3, want: 3},
+		{stock: 0, want: 0},
+		{stock: 2, backorderLimit: 5, want: 7},
+		// 4 of the 5 that may be backordered already are
+		{stock: -4, backorderLimit: 5, want: 1},
+		{stock: -6, backorderLimit: 5, want: 0},
+	}
+
+	for _, tt := range tests {
+		product := models.Product{Stock: tt.stock, BackorderLimit: tt.backorderLimit}
+		if got := product.Sellable(); got != tt.want {
+			t.Errorf("stock %d with backorder limit %d: expected %d, got %d", tt.stock, tt.backorderLimit, tt.want, got)
+		}
+	}
+}
+
+func TestProduct_IsPreorder(t *testing.T) {
+	now := time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+
+	tests := []struct {
+		policy      models.InventoryPolicy
+		availableAt *time.Time
+		want        bool
+	}{
+		{models.InventoryPolicyPreorder, &later, true},
+		{models.InventoryPolicyPreorder, &now, false},
+		{models.InventoryPolicyPreorder, nil, false},
+		{models.InventoryPolicyBackorder, &later, false},
+	}
+
+	for _, tt := range tests {
+		product := models.Product{InventoryPolicy: tt.policy, AvailableAt: tt.availableAt}
+		if got := product.IsPreorder(now); got != tt.want {
+			t.Errorf("%s product available at %v: expected %t, got %t", tt.policy, tt.availableAt, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+)
+
+func TestReturnStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from    models.ReturnStatus
+		to      models.ReturnStatus
+		allowed bool
+	}{
+		{models.ReturnStatusRequested, models.ReturnStatusApproved, true},
+		{models.ReturnStatusRequested, models.ReturnStatusReceived, false},
+		{models.ReturnStatusApproved, models.ReturnStatusReceived, true},
+		{models.ReturnStatusApproved, models.ReturnStatusRefunded, false},
+		{models.ReturnStatusReceived, models.ReturnStatusRefunded, true},
+		{models.ReturnStatusRefunded, models.ReturnStatusRequested, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.from.CanTransitionTo(tt.to); got != tt.allowed {
+			t.Errorf("%s -> %s: expected %v, got %v", tt.from, tt.to, tt.allowed, got)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+)
+
+func TestShippingZoneRegion_Match(t *testing.T) {
+	address := &models.OrderAddress{Country: "US", State: "CA", PostalCode: "94105"}
+
+	tests := []struct {
+		name   string
+		region models.ShippingZoneRegion
+		score  int
+	}{
+		{"Country", models.ShippingZoneRegion{Country: "us"}, 1},
+		{"OtherCountry", models.ShippingZoneRegion{Country: "CA"}, 0},
+		{"State", models.ShippingZoneRegion{Country: "US", State: "ca"}, 2},
+		{"OtherState", models.ShippingZoneRegion{Country: "US", State: "NY"}, 0},
+		{"PostcodePrefix", models.ShippingZoneRegion{Country: "US", PostcodePrefix: "941"}, 5},
+		{"OtherPostcodePrefix", models.ShippingZoneRegion{Country: "US", PostcodePrefix: "100"}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.region.Match(address); got != tt.score {
+			t.Errorf("%s: expected score %d, got %d", tt.name, tt.score, got)
+		}
+	}
+}
+
+func TestShippingZoneRegion_Match_NormalizesPostcode(t *testing.T) {
+	region := models.ShippingZoneRegion{Country: "GB", PostcodePrefix: "sw1a"}
+	address := &models.OrderAddress{Country: "GB", PostalCode: "SW1A 1AA"}
+
+	if region.Match(address) == 0 {
+		t.Error("expected postcode prefix to match regardless of case and spacing")
+	}
+}
+
+func TestMatchShippingZone(t *testing.T) {
+	zones := []models.ShippingZone{
+		{ID: 1, Name: "Domestic", IsActive: true, Regions: []models.ShippingZoneRegion{{Country: "US"}}},
+		{ID: 2, Name: "Bay Area", IsActive: true, Regions: []models.ShippingZoneRegion{{Country: "US", PostcodePrefix: "941"}}},
+		{ID: 3, Name: "Retired", IsActive: false, Regions: []models.ShippingZoneRegion{{Country: "US", PostcodePrefix: "94105"}}},
+	}
+
+	t.Run("MostSpecificWins", func(t *testing.T) {
+		zone := models.MatchShippingZone(zones, &models.OrderAddress{Country: "US", PostalCode: "94105"})
+		if zone == nil || zone.ID != 2 {
+			t.Fatalf("expected zone 2, got %+v", zone)
+		}
+	})
+
+	t.Run("FallsBackToCountry", func(t *testing.T) {
+		zone := models.MatchShippingZone(zones, &models.OrderAddress{Country: "US", PostalCode: "10001"})
+		if zone == nil || zone.ID != 1 {
+			t.Fatalf("expected zone 1, got %+v", zone)
+		}
+	})
+
+	t.Run("NoZone", func(t *testing.T) {
+		if zone := models.MatchShippingZone(zones, &models.OrderAddress{Country: "FR"}); zone != nil {
+			t.Fatalf("expected no zone, got %+v", zone)
+		}
+	})
+}
+
+func TestShippingRate_Cost(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     models.ShippingRate
+		weight   float64
+		subtotal int64
+		cost     int64
+		applies  bool
+	}{
+		{"Flat", models.ShippingRate{Type: models.ShippingRateFlat, Price: usd(500), IsActive: true}, 30, 1000, 500, true},
+		{"Inactive", models.ShippingRate{Type: models.ShippingRateFlat, Price: usd(500)}, 1, 1000, 0, false},
+		{"WeightTier", models.ShippingRate{Type: models.ShippingRateWeight, Price: usd(800), MinWeight: 1, MaxWeight: 5, IsActive: true}, 1, 1000, 800, true},
+		{"WeightTierUpperBoundExcluded", models.ShippingRate{Type: models.ShippingRateWeight, Price: usd(800), MinWeight: 1, MaxWeight: 5, IsActive: true}, 5, 1000, 0, false},
+		{"WeightTierBelowMin", models.ShippingRate{Type: models.ShippingRateWeight, Price: usd(800), MinWeight: 1, MaxWeight: 5, IsActive: true}, 0.5, 1000, 0, false},
+		{"WeightTierUnbounded", models.ShippingRate{Type: models.ShippingRateWeight, Price: usd(2000), MinWeight: 5, IsActive: true}, 50, 1000, 2000, true},
+		{"BelowFreeThreshold", models.ShippingRate{Type: models.ShippingRateFreeOver, Price: usd(700), FreeOver: usd(5000), IsActive: true}, 1, 4999, 700, true},
+		{"FreeOverThreshold", models.ShippingRate{Type: models.ShippingRateFreeOver, Price: usd(700), FreeOver: usd(5000), IsActive: true}, 1, 5000, 0, true},
+	}
+
+	for _, tt := range tests {
+		cost, ok := tt.rate.Cost(tt.weight, usd(tt.subtotal))
+		if ok != tt.applies || cost.Amount != tt.cost {
+			t.Errorf("%s: expected (%v, %v), got (%v, %v)", tt.name, tt.cost, tt.applies, cost, ok)
+		}
+	}
+}
+
+func TestCart_Totals(t *testing.T) {
+	cart := models.Cart{
+		CartItems: []models.CartItem{
+			{Quantity: 2, Product: models.Product{Price: usd(1000), Weight: 0.5}},
+			{Quantity: 1, Product: models.Product{Price: usd(500), Weight: 2}},
+		},
+	}
+
+	subtotal, weight := cart.Totals()
+	if subtotal != usd(2500) {
+		t.Errorf("expected subtotal 25, got %v", subtotal)
+	}
+	if weight != 3 {
+		t.Errorf("expected weight 3, got %v", weight)
+	}
+}
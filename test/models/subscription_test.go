@@ -0,0 +1,57 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+)
+
+func TestSubscription_NextRunAfter(t *testing.T) {
+	runAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		intervalWeeks int
+		now           time.Time
+		want          time.Time
+	}{
+		{name: "Due", intervalWeeks: 2, now: runAt, want: runAt.AddDate(0, 0, 14)},
+		{name: "NotYetDue", intervalWeeks: 2, now: runAt.Add(-time.Hour), want: runAt},
+		// Paused for seven weeks, the runs missed in between are skipped
+		{name: "MissedRuns", intervalWeeks: 2, now: runAt.AddDate(0, 0, 49), want: runAt.AddDate(0, 0, 56)},
+		{name: "NoInterval", intervalWeeks: 0, now: runAt, want: runAt.AddDate(0, 0, 7)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription := models.Subscription{IntervalWeeks: tt.intervalWeeks, NextRunAt: runAt}
+			if got := subscription.NextRunAfter(tt.now); !got.Equal(tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSubscription_Cart(t *testing.T) {
+	rateID := uint(4)
+	subscription := models.Subscription{
+		UserID:         1,
+		ProductID:      1000,
+		Quantity:       3,
+		ShippingRateID: &rateID,
+		Product:        models.Product{ID: 1000, Name: "Coffee Beans"},
+	}
+
+	cart := subscription.Cart()
+
+	if cart.ID != 0 {
+		t.Errorf("expected a cart that is not stored, got ID %d", cart.ID)
+	}
+	if cart.UserID == nil || *cart.UserID != 1 || cart.ShippingRateID != &rateID {
+		t.Errorf("expected the user's cart shipping with rate 4, got %+v", cart)
+	}
+	if len(cart.CartItems) != 1 || cart.CartItems[0].Quantity != 3 || cart.CartItems[0].Product.Name != "Coffee Beans" {
+		t.Errorf("expected one item of 3 Coffee Beans, got %+v", cart.CartItems)
+	}
+}
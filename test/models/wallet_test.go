@@ -0,0 +1,73 @@
+package models_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+)
+
+func TestNewGiftCardCode(t *testing.T) {
+	code, err := models.NewGiftCardCode()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !regexp.MustCompile(`^[0-9A-Z]{4}(-[0-9A-Z]{4}){3}$`).MatchString(code) {
+		t.Errorf("expected a code like XXXX-XXXX-XXXX-XXXX, got %s", code)
+	}
+
+	other, err := models.NewGiftCardCode()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other == code {
+		t.Errorf("expected two different codes, got %s twice", code)
+	}
+}
+
+func TestHashGiftCardCode(t *testing.T) {
+	hash := models.HashGiftCardCode("7KQ2-M9XW-4PHT-C3RD")
+
+	// The code is matched however it was typed in
+	for _, typed := range []string{"7kq2-m9xw-4pht-c3rd", "7KQ2 M9XW 4PHT C3RD", "7KQ2M9XW4PHTC3RD"} {
+		if got := models.HashGiftCardCode(typed); got != hash {
+			t.Errorf("expected %s to hash like the code", typed)
+		}
+	}
+
+	if models.HashGiftCardCode("7KQ2-M9XW-4PHT-C3RE") == hash {
+		t.Error("expected a different code to hash differently")
+	}
+
+	if got := models.GiftCardCodeHint("7kq2-m9xw-4pht-c3rd"); got != "C3RD" {
+		t.Errorf("expected hint C3RD, got %s", got)
+	}
+}
+
+func TestGiftCard_IsRedeemable(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	redeemedBy := uint(1)
+
+	tests := []struct {
+		name string
+		card models.GiftCard
+		want bool
+	}{
+		{name: "NoExpiry", card: models.GiftCard{}, want: true},
+		{name: "NotYetExpired", card: models.GiftCard{ExpiresAt: &future}, want: true},
+		{name: "Expired", card: models.GiftCard{ExpiresAt: &past}, want: false},
+		{name: "Redeemed", card: models.GiftCard{RedeemedBy: &redeemedBy, RedeemedAt: &past}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.card.IsRedeemable(now); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
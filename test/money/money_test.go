@@ -0,0 +1,207 @@
+package money_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+)
+
+func usd(amount int64) money.Money {
+	return money.New(amount, "USD")
+}
+
+func TestParseCurrency(t *testing.T) {
+	currency, err := money.ParseCurrency(" eur ")
+	if err != nil || currency != "EUR" {
+		t.Errorf("expected EUR, got %q (%v)", currency, err)
+	}
+
+	if _, err := money.ParseCurrency("XYZ"); err == nil {
+		t.Error("expected an unsupported currency to be rejected")
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	t.Run("ZeroAdoptsCurrency", func(t *testing.T) {
+		if got := (money.Money{}).Add(usd(150)); got != usd(150) {
+			t.Errorf("expected 1.50 USD, got %v", got)
+		}
+		if got := usd(150).Sub(money.Money{Amount: 50}); got != usd(100) {
+			t.Errorf("expected 1.00 USD, got %v", got)
+		}
+	})
+
+	t.Run("MixedCurrenciesPanic", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected adding EUR to USD to panic")
+			}
+		}()
+
+		usd(100).Add(money.New(100, "EUR"))
+	})
+
+	t.Run("Percent", func(t *testing.T) {
+		tests := []struct {
+			amount  int64
+			percent float64
+			want    int64
+		}{
+			{1999, 10, 200},
+			{1995, 10, 200},
+			{1994, 10, 199},
+			{-1995, 10, -200},
+			{1000, 7.25, 73},
+		}
+		for _, tt := range tests {
+			if got := usd(tt.amount).Percent(tt.percent); got != usd(tt.want) {
+				t.Errorf("%d at %v%%: expected %d, got %v", tt.amount, tt.percent, tt.want, got)
+			}
+		}
+	})
+
+	t.Run("MulRat", func(t *testing.T) {
+		if got := usd(100).MulRat(2, 3); got != usd(67) {
+			t.Errorf("expected 0.67 USD, got %v", got)
+		}
+		if got := usd(-100).MulRat(1, 3); got != usd(-33) {
+			t.Errorf("expected -0.33 USD, got %v", got)
+		}
+	})
+
+	t.Run("MinMax", func(t *testing.T) {
+		if got := money.Min(usd(100), usd(50)); got != usd(50) {
+			t.Errorf("expected 0.50 USD, got %v", got)
+		}
+		if got := money.Max(money.Money{}, usd(50)); got != usd(50) {
+			t.Errorf("expected 0.50 USD, got %v", got)
+		}
+	})
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	shares := usd(100).Allocate([]int64{1, 1, 1})
+
+	var total money.Money
+	for _, share := range shares {
+		total = total.Add(share)
+	}
+	if total != usd(100) {
+		t.Errorf("expected the shares to add up to 1.00 USD, got %v", total)
+	}
+	if shares[0] != usd(33) || shares[2] != usd(34) {
+		t.Errorf("expected the last share to take the remainder, got %v", shares)
+	}
+
+	shares = usd(100).Allocate([]int64{0, 3, 0})
+	if shares[0] != usd(0) || shares[1] != usd(100) || shares[2] != usd(0) {
+		t.Errorf("expected everything on the only weighted share, got %v", shares)
+	}
+
+	shares = usd(100).Allocate([]int64{0, 0})
+	if shares[0] != usd(0) || shares[1] != usd(0) {
+		t.Errorf("expected nothing allocated without weights, got %v", shares)
+	}
+}
+
+func TestMoney_Convert(t *testing.T) {
+	tests := []struct {
+		money money.Money
+		to    money.Currency
+		rate  float64
+		want  money.Money
+	}{
+		{usd(1999), "EUR", 0.92, money.New(1839, "EUR")},
+		{usd(1000), "EUR", 1.1, money.New(1100, "EUR")},
+		{usd(5), "EUR", 0.5, money.New(3, "EUR")},
+		{usd(-5), "EUR", 0.5, money.New(-3, "EUR")},
+		{usd(1999), "JPY", 151.37, money.New(3026, "JPY")},
+		{money.New(3026, "JPY"), "USD", 0.0066, money.New(1997, "USD")},
+		{usd(100), "BHD", 0.376, money.New(376, "BHD")},
+	}
+	for _, tt := range tests {
+		if got := tt.money.Convert(tt.to, tt.rate); got != tt.want {
+			t.Errorf("%v at %v: expected %v, got %v", tt.money, tt.rate, tt.want, got)
+		}
+	}
+}
+
+func TestMoney_Decimal(t *testing.T) {
+	tests := []struct {
+		money money.Money
+		want  string
+	}{
+		{usd(1999), "19.99"},
+		{usd(5), "0.05"},
+		{usd(-5), "-0.05"},
+		{usd(-12345), "-123.45"},
+		{money.New(1500, "JPY"), "1500"},
+		{money.New(1234, "BHD"), "1.234"},
+	}
+	for _, tt := range tests {
+		if got := tt.money.Decimal(); got != tt.want {
+			t.Errorf("expected %s, got %s", tt.want, got)
+		}
+	}
+
+	if got := usd(1999).String(); got != "19.99 USD" {
+		t.Errorf("expected 19.99 USD, got %s", got)
+	}
+}
+
+func TestMoney_Unmarshal(t *testing.T) {
+	t.Run("JSON", func(t *testing.T) {
+		var m money.Money
+		if err := json.Unmarshal([]byte(`{"amount":1999,"currency":"usd"}`), &m); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if m != usd(1999) {
+			t.Errorf("expected 19.99 USD, got %v", m)
+		}
+
+		if err := json.Unmarshal([]byte(`{"amount":1999,"currency":"XYZ"}`), &m); err == nil {
+			t.Error("expected an unsupported currency to be rejected")
+		}
+		if err := json.Unmarshal([]byte(`{"amount":19.99}`), &m); err == nil {
+			t.Error("expected a fractional amount to be rejected")
+		}
+	})
+
+	t.Run("Param", func(t *testing.T) {
+		var m money.Money
+		if err := m.UnmarshalParam("1999"); err != nil || m != (money.Money{Amount: 1999}) {
+			t.Errorf("expected 1999 minor units, got %v (%v)", m, err)
+		}
+		if err := m.UnmarshalParam("19.99"); err == nil {
+			t.Error("expected an amount in major units to be rejected")
+		}
+	})
+
+	t.Run("GraphQL", func(t *testing.T) {
+		var m money.Money
+		if err := m.UnmarshalGQL(map[string]interface{}{"amount": json.Number("1999"), "currency": "USD"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if m != usd(1999) {
+			t.Errorf("expected 19.99 USD, got %v", m)
+		}
+
+		if err := m.UnmarshalGQL(map[string]interface{}{"amount": 19.99}); err == nil {
+			t.Error("expected a fractional amount to be rejected")
+		}
+		if err := m.UnmarshalGQL(1999); err == nil {
+			t.Error("expected a bare number to be rejected")
+		}
+	})
+
+	t.Run("CurrencyMismatch", func(t *testing.T) {
+		if _, err := money.New(100, "EUR").In("USD"); !errors.Is(err, money.ErrCurrencyMismatch) {
+			t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+		}
+		if got, err := (money.Money{Amount: 100}).In("USD"); err != nil || got != usd(100) {
+			t.Errorf("expected 1.00 USD, got %v (%v)", got, err)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package payments_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/payments"
+)
+
+func usd(amount int64) money.Money {
+	return money.New(amount, "USD")
+}
+
+func TestFakeProvider_Outcomes(t *testing.T) {
+	tests := []struct {
+		outcome payments.FakeOutcome
+		wantErr error
+	}{
+		{payments.FakeOutcomeSucceed, nil},
+		{payments.FakeOutcomeDecline, payments.ErrPaymentDeclined},
+		{payments.FakeOutcomeTimeout, payments.ErrPaymentTimeout},
+	}
+
+	for _, tt := range tests {
+		provider := payments.NewFakeProvider(tt.outcome)
+
+		result, err := provider.Authorize(&payments.AuthorizeRequest{OrderID: 1, UserID: 1, Amount: usd(5000)})
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: expected %v, got %v", tt.outcome, tt.wantErr, err)
+		}
+		if tt.wantErr == nil && (result == nil || result.Reference == "") {
+			t.Errorf("%s: expected a payment reference, got %+v", tt.outcome, result)
+		}
+	}
+}
+
+func TestFakeProvider_DeterministicReferences(t *testing.T) {
+	provider := payments.NewFakeProvider("")
+
+	first, _ := provider.Authorize(&payments.AuthorizeRequest{OrderID: 1, Amount: usd(1000)})
+	second, _ := provider.Authorize(&payments.AuthorizeRequest{OrderID: 2, Amount: usd(2000)})
+
+	if first.Reference != "fake_1" || second.Reference != "fake_2" {
+		t.Errorf("expected fake_1 and fake_2, got %s and %s", first.Reference, second.Reference)
+	}
+}
+
+func TestFakeProvider_IdempotencyKey(t *testing.T) {
+	provider := payments.NewFakeProvider("")
+
+	first, _ := provider.Authorize(&payments.AuthorizeRequest{OrderID: 1, Amount: usd(1000), IdempotencyKey: "run-1"})
+	retried, err := provider.Authorize(&payments.AuthorizeRequest{OrderID: 2, Amount: usd(1000), IdempotencyKey: "run-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if retried.Reference != first.Reference || retried.Amount != usd(1000) {
+		t.Errorf("expected the first authorization %s back, got %+v", first.Reference, retried)
+	}
+
+	other, _ := provider.Authorize(&payments.AuthorizeRequest{OrderID: 3, Amount: usd(1000), IdempotencyKey: "run-2"})
+	unkeyed, _ := provider.Authorize(&payments.AuthorizeRequest{OrderID: 4, Amount: usd(1000)})
+	again, _ := provider.Authorize(&payments.AuthorizeRequest{OrderID: 5, Amount: usd(1000)})
+	if other.Reference != "fake_2" || unkeyed.Reference != "fake_3" || again.Reference != "fake_4" {
+		t.Errorf("expected new authorizations for other or no keys, got %s, %s and %s", other.Reference, unkeyed.Reference, again.Reference)
+	}
+	// Once voided, the key authorizes anew
+	if _, err := provider.Void(first.Reference); err != nil {
+		t.Fatalf("unexpected void error: %v", err)
+	}
+	renewed, _ := provider.Authorize(&payments.AuthorizeRequest{OrderID: 6, Amount: usd(1000), IdempotencyKey: "run-1"})
+	if renewed.Reference != "fake_5" {
+		t.Errorf("expected a new authorization after the void, got %s", renewed.Reference)
+	}
+}
+
+func TestFakeProvider_Lifecycle(t *testing.T) {
+	t.Run("CaptureAndRefund", func(t *testing.T) {
+		provider := payments.NewFakeProvider(payments.FakeOutcomeSucceed)
+		auth, _ := provider.Authorize(&payments.AuthorizeRequest{OrderID: 1, Amount: usd(10000)})
+
+		if _, err := provider.Capture(auth.Reference, usd(10000)); err != nil {
+			t.Fatalf("unexpected capture error: %v", err)
+		}
+		if _, err := provider.Void(auth.Reference); !errors.Is(err, payments.ErrInvalidPaymentOperation) {
+			t.Errorf("expected void after capture to fail, got %v", err)
+		}
+		if _, err := provider.Refund(auth.Reference, usd(4000)); err != nil {
+			t.Fatalf("unexpected refund error: %v", err)
+		}
+		if _, err := provider.Refund(auth.Reference, usd(7000)); !errors.Is(err, payments.ErrInvalidPaymentOperation) {
+			t.Errorf("expected refund above captured amount to fail, got %v", err)
+		}
+	})
+
+	t.Run("Void", func(t *testing.T) {
+		provider := payments.NewFakeProvider(payments.FakeOutcomeSucceed)
+		auth, _ := provider.Authorize(&payments.AuthorizeRequest{OrderID: 1, Amount: usd(10000)})
+
+		if _, err := provider.Void(auth.Reference); err != nil {
+			t.Fatalf("unexpected void error: %v", err)
+		}
+		if _, err := provider.Capture(auth.Reference, usd(10000)); !errors.Is(err, payments.ErrInvalidPaymentOperation) {
+			t.Errorf("expected capture after void to fail, got %v", err)
+		}
+	})
+
+	t.Run("OtherCurrency", func(t *testing.T) {
+		provider := payments.NewFakeProvider(payments.FakeOutcomeSucceed)
+		auth, _ := provider.Authorize(&payments.AuthorizeRequest{OrderID: 1, Amount: usd(10000)})
+
+		if _, err := provider.Capture(auth.Reference, money.New(10000, "EUR")); !errors.Is(err, payments.ErrInvalidPaymentOperation) {
+			t.Errorf("expected capture in another currency to fail, got %v", err)
+		}
+	})
+
+	t.Run("UnknownReference", func(t *testing.T) {
+		provider := payments.NewFakeProvider(payments.FakeOutcomeSucceed)
+
+		if _, err := provider.Capture("missing", usd(1000)); !errors.Is(err, payments.ErrPaymentNotFound) {
+			t.Errorf("expected ErrPaymentNotFound, got %v", err)
+		}
+	})
+
+	t.Run("DeclineAfterAuthorize", func(t *testing.T) {
+		provider := payments.NewFakeProvider(payments.FakeOutcomeSucceed)
+		auth, _ := provider.Authorize(&payments.AuthorizeRequest{OrderID: 1, Amount: usd(10000)})
+
+		provider.SetOutcome(payments.FakeOutcomeDecline)
+		if _, err := provider.Capture(auth.Reference, usd(10000)); !errors.Is(err, payments.ErrPaymentDeclined) {
+			t.Errorf("expected ErrPaymentDeclined, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,190 @@
+package promotions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/promotions"
+)
+
+var (
+	shirt = models.Product{ID: 1, CategoryID: 10, Name: "Shirt", Price: usd(2000)}
+	socks = models.Product{ID: 2, CategoryID: 10, Name: "Socks", Price: usd(500)}
+	mug   = models.Product{ID: 3, CategoryID: 20, Name: "Mug", Price: usd(1200)}
+)
+
+var now = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func uintPtr(v uint) *uint { return &v }
+
+func usd(amount int64) money.Money { return money.New(amount, "USD") }
+
+func newCart(items ...models.CartItem) *models.Cart {
+	return &models.Cart{CartItems: items}
+}
+
+// expectLines checks the discounts, given in cents.
+func expectLines(t *testing.T, result *promotions.Result, lines []int64, total int64) {
+	t.Helper()
+
+	for i := range lines {
+		if result.Lines[i].Amount != lines[i] {
+			t.Errorf("line %d: expected discount %v, got %v", i, lines[i], result.Lines[i])
+		}
+	}
+
+	if result.Total.Amount != total {
+		t.Errorf("expected total discount %v, got %v", total, result.Total)
+	}
+}
+
+func TestEvaluate_BuyXGetY(t *testing.T) {
+	rule := models.Promotion{ID: 1, Name: "Buy 2 get 1 free", Type: models.PromotionBuyXGetY, CategoryID: uintPtr(10),
+		BuyQuantity: 2, GetQuantity: 1, DiscountPercent: 100, Stackable: true, IsActive: true}
+
+	cart := newCart(
+		models.CartItem{Quantity: 2, Product: shirt},
+		models.CartItem{Quantity: 2, Product: socks},
+		models.CartItem{Quantity: 1, Product: mug},
+	)
+
+	result := promotions.Evaluate(cart, []models.Promotion{rule}, now)
+
+	// Four eligible units make one group of three, and the cheapest unit is free
+	expectLines(t, result, []int64{0, 500, 0}, 500)
+	if len(result.Applied) != 1 || result.Applied[0].PromotionID != 1 || result.Applied[0].Discount != usd(500) {
+		t.Errorf("expected promotion 1 taking 5 off, got %+v", result.Applied)
+	}
+}
+
+func TestEvaluate_SpendThreshold(t *testing.T) {
+	rule := models.Promotion{ID: 1, Name: "Spend more, save more", Type: models.PromotionSpendThreshold, Stackable: true, IsActive: true,
+		Tiers: []models.PromotionTier{{Threshold: usd(10000), Amount: usd(1500)}, {Threshold: usd(5000), Amount: usd(500)}}}
+
+	t.Run("LowerTier", func(t *testing.T) {
+		cart := newCart(
+			models.CartItem{Quantity: 3, Product: shirt},
+			models.CartItem{Quantity: 1, Product: mug},
+		)
+
+		// 5 off a subtotal of 72, split in proportion to the lines
+		result := promotions.Evaluate(cart, []models.Promotion{rule}, now)
+		expectLines(t, result, []int64{417, 83}, 500)
+	})
+
+	t.Run("HighestTierReached", func(t *testing.T) {
+		cart := newCart(
+			models.CartItem{Quantity: 5, Product: shirt},
+			models.CartItem{Quantity: 1, Product: mug},
+		)
+
+		result := promotions.Evaluate(cart, []models.Promotion{rule}, now)
+		expectLines(t, result, []int64{1339, 161}, 1500)
+	})
+
+	t.Run("BelowThreshold", func(t *testing.T) {
+		cart := newCart(models.CartItem{Quantity: 2, Product: shirt})
+
+		result := promotions.Evaluate(cart, []models.Promotion{rule}, now)
+		expectLines(t, result, []int64{0}, 0)
+		if len(result.Applied) != 0 {
+			t.Errorf("expected no promotion applied, got %+v", result.Applied)
+		}
+	})
+}
+
+func TestEvaluate_Bundle(t *testing.T) {
+	rule := models.Promotion{ID: 1, Name: "Any 3 for 30", Type: models.PromotionBundle, CategoryID: uintPtr(10),
+		BundleQuantity: 3, BundlePrice: usd(3000), Stackable: true, IsActive: true}
+
+	cart := newCart(
+		models.CartItem{Quantity: 2, Product: shirt},
+		models.CartItem{Quantity: 2, Product: socks},
+	)
+
+	// The bundle is made of both shirts and one pair of socks, 45 priced at 30,
+	// and the last pair of socks is left at full price
+	result := promotions.Evaluate(cart, []models.Promotion{rule}, now)
+	expectLines(t, result, []int64{1334, 166}, 1500)
+}
+
+func TestEvaluate_FlashSaleWindow(t *testing.T) {
+	rule := models.Promotion{ID: 1, Name: "Mug madness", Type: models.PromotionFlashSale, ProductID: uintPtr(3),
+		DiscountPercent: 25, Stackable: true, IsActive: true}
+
+	cart := newCart(
+		models.CartItem{Quantity: 1, Product: shirt},
+		models.CartItem{Quantity: 2, Product: mug},
+	)
+
+	t.Run("Running", func(t *testing.T) {
+		starts, ends := now.Add(-time.Hour), now.Add(time.Hour)
+		rule.StartsAt, rule.EndsAt = &starts, &ends
+
+		result := promotions.Evaluate(cart, []models.Promotion{rule}, now)
+		expectLines(t, result, []int64{0, 600}, 600)
+	})
+
+	t.Run("Ended", func(t *testing.T) {
+		starts, ends := now.Add(-2*time.Hour), now.Add(-time.Hour)
+		rule.StartsAt, rule.EndsAt = &starts, &ends
+
+		result := promotions.Evaluate(cart, []models.Promotion{rule}, now)
+		expectLines(t, result, []int64{0, 0}, 0)
+	})
+
+	t.Run("NotStarted", func(t *testing.T) {
+		starts := now.Add(time.Hour)
+		rule.StartsAt, rule.EndsAt = &starts, nil
+
+		result := promotions.Evaluate(cart, []models.Promotion{rule}, now)
+		expectLines(t, result, []int64{0, 0}, 0)
+	})
+}
+
+func TestEvaluate_PriorityAndStacking(t *testing.T) {
+	cart := newCart(models.CartItem{Quantity: 1, Product: shirt})
+
+	tenPercent := models.Promotion{ID: 1, Name: "10% off", Type: models.PromotionFlashSale, DiscountPercent: 10, Priority: 10, Stackable: true, IsActive: true}
+	halfPrice := models.Promotion{ID: 2, Name: "Half price", Type: models.PromotionFlashSale, DiscountPercent: 50, Priority: 5, IsActive: true}
+	extraTen := models.Promotion{ID: 3, Name: "Extra 10% off", Type: models.PromotionFlashSale, DiscountPercent: 10, Priority: 1, Stackable: true, IsActive: true}
+
+	t.Run("NonStackableSkippedAfterOthers", func(t *testing.T) {
+		result := promotions.Evaluate(cart, []models.Promotion{extraTen, halfPrice, tenPercent}, now)
+
+		// 10% of 20, then 10% of the 18 left
+		expectLines(t, result, []int64{380}, 380)
+		if len(result.Applied) != 2 || result.Applied[0].PromotionID != 1 || result.Applied[1].PromotionID != 3 {
+			t.Errorf("expected promotions 1 and 3 applied in order, got %+v", result.Applied)
+		}
+	})
+
+	t.Run("NonStackableStopsEvaluation", func(t *testing.T) {
+		first := halfPrice
+		first.Priority = 20
+
+		result := promotions.Evaluate(cart, []models.Promotion{tenPercent, extraTen, first}, now)
+		expectLines(t, result, []int64{1000}, 1000)
+		if len(result.Applied) != 1 || result.Applied[0].PromotionID != 2 {
+			t.Errorf("expected only promotion 2 applied, got %+v", result.Applied)
+		}
+	})
+}
+
+func TestEvaluate_NeverBelowZero(t *testing.T) {
+	flashSale := models.Promotion{ID: 1, Name: "60% off shirts", Type: models.PromotionFlashSale, ProductID: uintPtr(1),
+		DiscountPercent: 60, Priority: 10, Stackable: true, IsActive: true}
+	buyOneGetTwo := models.Promotion{ID: 2, Name: "Buy 1 get 2 free", Type: models.PromotionBuyXGetY, ProductID: uintPtr(1),
+		BuyQuantity: 1, GetQuantity: 2, DiscountPercent: 100, Stackable: true, IsActive: true}
+
+	cart := newCart(models.CartItem{Quantity: 3, Product: shirt})
+
+	// The free shirts are worth 40, but only 24 of the line is left
+	result := promotions.Evaluate(cart, []models.Promotion{flashSale, buyOneGetTwo}, now)
+	expectLines(t, result, []int64{6000}, 6000)
+	if len(result.Applied) != 2 || result.Applied[1].Discount != usd(2400) {
+		t.Errorf("expected the second promotion capped at 24, got %+v", result.Applied)
+	}
+}
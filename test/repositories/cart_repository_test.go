@@ -44,8 +44,8 @@ func TestCartRepository_GetByUserID(t *testing.T) {
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if cart.UserID != userID {
-			t.Errorf("expected userID %d, got %d", userID, cart.UserID)
+		if cart.CustomerID() != userID {
+			t.Errorf("expected userID %d, got %d", userID, cart.CustomerID())
 		}
 	})
 
@@ -67,7 +67,8 @@ func TestCartRepository_Create(t *testing.T) {
 		t.Fatalf("failed to setup test: %v", err)
 	}
 
-	cart := &models.Cart{UserID: 1}
+	userID := uint(1)
+	cart := &models.Cart{UserID: &userID}
 
 	t.Run("Success", func(t *testing.T) {
 		mock.ExpectBegin()
@@ -88,7 +89,8 @@ func TestCartRepository_Update(t *testing.T) {
 		t.Fatalf("failed to setup test: %v", err)
 	}
 
-	cart := &models.Cart{ID: 1, UserID: 1}
+	userID := uint(1)
+	cart := &models.Cart{ID: 1, UserID: &userID}
 
 	t.Run("Success", func(t *testing.T) {
 		mock.ExpectBegin()
@@ -0,0 +1,78 @@
+package repositories_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/repositories"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupIdempotencyRepositoryTest() (*repositories.IdempotencyRepository, sqlmock.Sqlmock, error) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return repositories.NewIdempotencyRepository(gormDB), mock, nil
+}
+
+func TestIdempotencyRepository_Create(t *testing.T) {
+	repo, mock, err := setupIdempotencyRepositoryTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	newRecord := func() *models.IdempotencyKey {
+		return &models.IdempotencyKey{
+			UserID:      1,
+			Key:         "key-1",
+			Method:      "POST",
+			Path:        "/api/v1/orders/",
+			RequestHash: "abc",
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "idempotency_keys" .* ON CONFLICT DO NOTHING`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectCommit()
+
+		record := newRecord()
+		if err := repo.Create(record); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if record.ID != 1 {
+			t.Errorf("expected ID 1, got %d", record.ID)
+		}
+	})
+
+	t.Run("KeyAlreadyClaimed", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "idempotency_keys" .* ON CONFLICT DO NOTHING`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectCommit()
+
+		err := repo.Create(newRecord())
+		if !errors.Is(err, repositories.ErrIdempotencyKeyExists) {
+			t.Errorf("expected ErrIdempotencyKeyExists, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kuldeepstechwork/gocart-api/internal/repositories"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupOrderRepositoryTest() (*repositories.OrderRepository, sqlmock.Sqlmock, error) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return repositories.NewOrderRepository(gormDB), mock, nil
+}
+
+func TestOrderRepository_AttachGuestOrders(t *testing.T) {
+	repo, mock, err := setupOrderRepositoryTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		// Only orders without a user are attached, matching the email in any case
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "orders" SET "user_id"=\$1,"updated_at"=\$2 WHERE \(user_id IS NULL AND LOWER\(guest_email\) = \$3\)`).
+			WithArgs(uint(7), sqlmock.AnyArg(), "ada@example.com").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		attached, err := repo.AttachGuestOrders(7, "Ada@Example.com")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if attached != 2 {
+			t.Errorf("expected 2 orders attached, got %d", attached)
+		}
+	})
+}
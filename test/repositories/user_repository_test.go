@@ -131,6 +131,38 @@ func TestUserRepository_GetByEmailAndActive(t *testing.T) {
 	})
 }
 
+func TestUserRepository_GetByEmailVerificationToken(t *testing.T) {
+	repo, mock, err := setupUserRepositoryTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "email", "email_verification_token"}).AddRow(1, "test@example.com", "verify-1")
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE email_verification_token = \$1 AND "users"\."deleted_at" IS NULL .* LIMIT .*`).
+			WithArgs("verify-1", 1).
+			WillReturnRows(rows)
+
+		user, err := repo.GetByEmailVerificationToken("verify-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if user.ID != 1 {
+			t.Errorf("expected user 1, got %d", user.ID)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE email_verification_token = \$1 .*`).
+			WithArgs("unknown", 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		if _, err := repo.GetByEmailVerificationToken("unknown"); err != gorm.ErrRecordNotFound {
+			t.Errorf("expected ErrRecordNotFound, got %v", err)
+		}
+	})
+}
+
 func TestUserRepository_Create(t *testing.T) {
 	repo, mock, err := setupUserRepositoryTest()
 	if err != nil {
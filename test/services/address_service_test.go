@@ -0,0 +1,147 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupAddressServiceTest() (*services.AddressService, sqlmock.Sqlmock, error) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return services.NewAddressService(gormDB), mock, nil
+}
+
+func TestAddressService_CreateAddress(t *testing.T) {
+	s, mock, err := setupAddressServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "addresses"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(30))
+		mock.ExpectCommit()
+
+		resp, err := s.CreateAddress(userID, &dto.AddressRequest{
+			FirstName:  "Ada",
+			LastName:   "Lovelace",
+			Line1:      "1 Main St",
+			City:       "Springfield",
+			PostalCode: "12345",
+			Country:    "us",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ID != 30 {
+			t.Errorf("expected address ID 30, got %d", resp.ID)
+		}
+		if resp.Country != "US" {
+			t.Errorf("expected country to be upper cased, got %s", resp.Country)
+		}
+	})
+}
+
+func TestAddressService_UpdateAddress(t *testing.T) {
+	s, mock, err := setupAddressServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+	req := &dto.AddressRequest{
+		FirstName:  "Ada",
+		LastName:   "Lovelace",
+		Line1:      "2 Side St",
+		City:       "Springfield",
+		PostalCode: "12345",
+		Country:    "US",
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "addresses" WHERE \(id = \$1 AND user_id = \$2\)`).
+			WithArgs(30, userID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "line1"}).AddRow(30, userID, "1 Main St"))
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "addresses" SET`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		resp, err := s.UpdateAddress(userID, 30, req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Line1 != "2 Side St" {
+			t.Errorf("expected line1 to be updated, got %s", resp.Line1)
+		}
+	})
+
+	t.Run("NotOwner", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "addresses"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		_, err := s.UpdateAddress(userID, 31, req)
+		if !errors.Is(err, services.ErrAddressNotFound) {
+			t.Errorf("expected ErrAddressNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestAddressService_DeleteAddress(t *testing.T) {
+	s, mock, err := setupAddressServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "addresses" SET "deleted_at"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := s.DeleteAddress(userID, 30); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "addresses" SET "deleted_at"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		if err := s.DeleteAddress(userID, 31); !errors.Is(err, services.ErrAddressNotFound) {
+			t.Errorf("expected ErrAddressNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/kuldeepstechwork/gocart-api/internal/config"
 	"github.com/kuldeepstechwork/gocart-api/internal/dto"
 	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/notifications"
 	"github.com/kuldeepstechwork/gocart-api/internal/services"
 	"github.com/kuldeepstechwork/gocart-api/internal/utils"
 	"github.com/kuldeepstechwork/gocart-api/test/mocks"
@@ -20,6 +21,7 @@ func TestAuthService_Register(t *testing.T) {
 
 	mockUserRepo := mocks.NewMockUserRepositoryInterface(ctrl)
 	mockCartRepo := mocks.NewMockCartRepositoryInterface(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepositoryInterface(ctrl)
 	mockPublisher := mocks.NewMockPublisher(ctrl)
 
 	cfg := &config.Config{
@@ -30,7 +32,7 @@ func TestAuthService_Register(t *testing.T) {
 		},
 	}
 
-	authService := services.NewAuthService(cfg, mockPublisher, mockUserRepo, mockCartRepo)
+	authService := services.NewAuthService(cfg, mockPublisher, mockUserRepo, mockCartRepo, mockOrderRepo)
 
 	req := &dto.RegisterRequest{
 		Email:     "new@example.com",
@@ -40,14 +42,29 @@ func TestAuthService_Register(t *testing.T) {
 	}
 
 	t.Run("Success", func(t *testing.T) {
+		var token string
 		mockUserRepo.EXPECT().GetByEmail(req.Email).Return(nil, errors.New("not found"))
 		mockUserRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(u *models.User) error {
+			if u.EmailVerifiedAt != nil || u.EmailVerificationToken == nil {
+				t.Errorf("expected the user to be created unverified with a token")
+			} else {
+				token = *u.EmailVerificationToken
+			}
 			u.ID = 1
 			return nil
 		})
 		mockCartRepo.EXPECT().Create(gomock.Any()).Return(nil)
+		// The guest orders wait for the email to be verified
+		mockOrderRepo.EXPECT().AttachGuestOrders(gomock.Any(), gomock.Any()).Times(0)
+		mockPublisher.EXPECT().Publish(notifications.EmailVerificationRequested, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ string, _ interface{}, metadata map[string]string) error {
+				if metadata["email"] != req.Email || metadata["token"] != token {
+					t.Errorf("unexpected verification metadata %v", metadata)
+				}
+				return nil
+			})
 		mockUserRepo.EXPECT().CreateRefreshToken(gomock.Any()).Return(nil)
-		mockPublisher.EXPECT().Publish(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockPublisher.EXPECT().Publish(notifications.UserLoggedIn, gomock.Any(), gomock.Any()).Return(nil)
 
 		resp, err := authService.Register(req)
 		if err != nil {
@@ -81,13 +98,79 @@ func TestAuthService_Register(t *testing.T) {
 		mockUserRepo.EXPECT().Create(gomock.Any()).Return(nil)
 		mockCartRepo.EXPECT().Create(gomock.Any()).Return(errors.New("cart error"))
 		mockUserRepo.EXPECT().CreateRefreshToken(gomock.Any()).Return(nil)
-		mockPublisher.EXPECT().Publish(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockPublisher.EXPECT().Publish(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
 
 		_, err := authService.Register(req)
 		if err != nil {
 			t.Errorf("expected no error even if cart fails (per implementation), got %v", err)
 		}
 	})
+
+	t.Run("VerificationEventFailure", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByEmail(req.Email).Return(nil, errors.New("not found"))
+		mockUserRepo.EXPECT().Create(gomock.Any()).Return(nil)
+		mockCartRepo.EXPECT().Create(gomock.Any()).Return(nil)
+		mockPublisher.EXPECT().Publish(notifications.EmailVerificationRequested, gomock.Any(), gomock.Any()).Return(errors.New("publisher error"))
+		mockUserRepo.EXPECT().CreateRefreshToken(gomock.Any()).Return(nil)
+		mockPublisher.EXPECT().Publish(notifications.UserLoggedIn, gomock.Any(), gomock.Any()).Return(nil)
+
+		if _, err := authService.Register(req); err != nil {
+			t.Errorf("expected the user to be registered anyway, got %v", err)
+		}
+	})
+}
+
+func TestAuthService_VerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepositoryInterface(ctrl)
+
+	authService := services.NewAuthService(nil, nil, mockUserRepo, nil, mockOrderRepo)
+
+	newUser := func() *models.User {
+		token := "verify-1"
+		return &models.User{ID: 1, Email: "ada@example.com", EmailVerificationToken: &token}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByEmailVerificationToken("verify-1").Return(newUser(), nil)
+		mockUserRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(u *models.User) error {
+			if u.EmailVerifiedAt == nil || u.EmailVerificationToken != nil {
+				t.Errorf("expected the email to be verified and the token used up")
+			}
+			return nil
+		})
+		mockOrderRepo.EXPECT().AttachGuestOrders(uint(1), "ada@example.com").Return(int64(2), nil)
+
+		resp, err := authService.VerifyEmail(&dto.VerifyEmailRequest{Token: "verify-1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ID != 1 {
+			t.Errorf("expected user 1, got %d", resp.ID)
+		}
+	})
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByEmailVerificationToken("unknown").Return(nil, errors.New("not found"))
+
+		_, err := authService.VerifyEmail(&dto.VerifyEmailRequest{Token: "unknown"})
+		if err == nil || err.Error() != "invalid verification token" {
+			t.Errorf("expected 'invalid verification token' error, got %v", err)
+		}
+	})
+
+	t.Run("GuestOrderAttachFailure", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetByEmailVerificationToken("verify-1").Return(newUser(), nil)
+		mockUserRepo.EXPECT().Update(gomock.Any()).Return(nil)
+		mockOrderRepo.EXPECT().AttachGuestOrders(uint(1), "ada@example.com").Return(int64(0), errors.New("db error"))
+
+		if _, err := authService.VerifyEmail(&dto.VerifyEmailRequest{Token: "verify-1"}); err != nil {
+			t.Errorf("expected the email to be verified anyway, got %v", err)
+		}
+	})
 }
 
 func TestAuthService_Login(t *testing.T) {
@@ -106,7 +189,7 @@ func TestAuthService_Login(t *testing.T) {
 		},
 	}
 
-	authService := services.NewAuthService(cfg, mockPublisher, mockUserRepo, mockCartRepo)
+	authService := services.NewAuthService(cfg, mockPublisher, mockUserRepo, mockCartRepo, nil)
 
 	hashedPassword, _ := utils.HashPassword("password123")
 	user := &models.User{
@@ -163,7 +246,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 		},
 	}
 
-	authService := services.NewAuthService(cfg, mockPublisher, mockUserRepo, mockCartRepo)
+	authService := services.NewAuthService(cfg, mockPublisher, mockUserRepo, mockCartRepo, nil)
 
 	userID := uint(1)
 	_, refreshToken, _ := utils.GenerateTokenPair(&cfg.JWT, userID, "test@example.com", "customer")
@@ -215,7 +298,7 @@ func TestAuthService_Logout(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepositoryInterface(ctrl)
-	authService := services.NewAuthService(nil, nil, mockUserRepo, nil)
+	authService := services.NewAuthService(nil, nil, mockUserRepo, nil, nil)
 
 	token := "some_token"
 	mockUserRepo.EXPECT().DeleteRefreshToken(token).Return(nil)
@@ -242,7 +325,7 @@ func TestAuthService_GenerateAuthResponse_Error(t *testing.T) {
 		},
 	}
 
-	authService := services.NewAuthService(cfg, mockPublisher, mockUserRepo, nil)
+	authService := services.NewAuthService(cfg, mockPublisher, mockUserRepo, nil, nil)
 	user := &models.User{ID: 1, Email: "test@example.com"}
 
 	mockUserRepo.EXPECT().CreateRefreshToken(gomock.Any()).Return(nil)
@@ -274,7 +357,7 @@ func TestAuthService_GenerateAuthResponse_CreateRefreshTokenError(t *testing.T)
 		},
 	}
 
-	authService := services.NewAuthService(cfg, mockPublisher, mockUserRepo, nil)
+	authService := services.NewAuthService(cfg, mockPublisher, mockUserRepo, nil, nil)
 	user := &models.User{ID: 1, Email: "test@example.com"}
 
 	mockUserRepo.EXPECT().CreateRefreshToken(gomock.Any()).Return(errors.New("db error"))
@@ -0,0 +1,94 @@
+package services_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+)
+
+func TestCartService_AddToCart_Concurrent(t *testing.T) {
+	db := openTestDatabase(t)
+
+	const shoppers = 10
+
+	category := models.Category{Name: "Limited"}
+	if err := db.Create(&category).Error; err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+
+	product := models.Product{
+		CategoryID: category.ID,
+		Name:       "Last One",
+		SKU:        "LAST-ONE",
+		Price:      money.New(10000, "USD"),
+		Stock:      1,
+		IsActive:   true,
+	}
+	if err := db.Create(&product).Error; err != nil {
+		t.Fatalf("failed to create product: %v", err)
+	}
+
+	userIDs := make([]uint, shoppers)
+	for i := range userIDs {
+		user := models.User{
+			Email:     fmt.Sprintf("shopper%d@example.com", i),
+			Password:  "secret",
+			FirstName: "Shopper",
+			LastName:  fmt.Sprint(i),
+		}
+		if err := db.Create(&user).Error; err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+
+		userIDs[i] = user.ID
+	}
+
+	s := services.NewCartService(db, "USD", 30*time.Minute)
+
+	// Every shopper has read the single unit as free by the time any of
+	// them holds it
+	start := make(chan struct{})
+	errs := make([]error, shoppers)
+
+	var wg sync.WaitGroup
+	for i, userID := range userIDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, errs[i] = s.AddToCart(userID, &dto.AddToCartRequest{ProductID: product.ID, Quantity: 1}, "")
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	added := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			added++
+		case err.Error() != "insufficient stock":
+			t.Errorf("expected insufficient stock error, got %v", err)
+		}
+	}
+	if added != 1 {
+		t.Errorf("expected exactly one cart to hold the unit, got %d", added)
+	}
+
+	var held int64
+	if err := db.Model(&models.InventoryReservation{}).
+		Where("product_id = ?", product.ID).
+		Select("COALESCE(SUM(quantity), 0)").
+		Scan(&held).Error; err != nil {
+		t.Fatalf("failed to sum reservations: %v", err)
+	}
+	if held != 1 {
+		t.Errorf("expected 1 unit held, got %d", held)
+	}
+}
@@ -1,10 +1,14 @@
 package services_test
 
 import (
+	"database/sql/driver"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
 	"github.com/kuldeepstechwork/gocart-api/internal/services"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -23,7 +27,20 @@ func setupCartServiceTest() (*services.CartService, sqlmock.Sqlmock, error) {
 		return nil, nil, err
 	}
 
-	return services.NewCartService(gormDB), mock, nil
+	return services.NewCartService(gormDB, "USD", 30*time.Minute), mock, nil
+}
+
+// expectStockHeld expects the cart's hold on the stock of a product to be
+// placed or renewed, committing the cart item saved along with it.
+func expectStockHeld(mock sqlmock.Sqlmock, args ...driver.Value) {
+	mock.ExpectQuery(`INSERT INTO "inventory_reservations" .* ON CONFLICT \("cart_id","product_id"\) DO UPDATE`).
+		WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+}
+
+func usd(amount int64) money.Money {
+	return money.New(amount, "USD")
 }
 
 func TestCartService_GetCart(t *testing.T) {
@@ -51,7 +68,12 @@ func TestCartService_GetCart(t *testing.T) {
 			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
 				AddRow(50, "Test Category"))
 
-		resp, err := s.GetCart(userID)
+		expectNoReservedStock(mock)
+
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows(promotionColumns))
+
+		resp, err := s.GetCart(userID, "")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -60,11 +82,38 @@ func TestCartService_GetCart(t *testing.T) {
 		}
 	})
 
+	t.Run("AppliesPromotions", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(cartID, userID))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).
+				AddRow(100, cartID, 1000, 3))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "price_amount", "price_currency"}).AddRow(1000, 50, 2000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "categories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(50))
+		expectNoReservedStock(mock)
+		mock.ExpectQuery(`SELECT .* FROM "promotions" WHERE is_active = \$1`).
+			WithArgs(true).
+			WillReturnRows(sqlmock.NewRows(promotionColumns).
+				AddRow(7, "Buy 2 get 1 free", "buy_x_get_y", 2, 1, 100.0, 0, 0, "USD", 0, true, true))
+		mock.ExpectQuery(`SELECT .* FROM "promotion_tiers"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "promotion_id", "threshold_amount", "threshold_currency", "amount", "currency"}))
+
+		resp, err := s.GetCart(userID, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Discount != usd(2000) || len(resp.Promotions) != 1 || resp.Promotions[0].PromotionID != 7 {
+			t.Errorf("expected promotion 7 taking 20 off, got %+v with discount %v", resp.Promotions, resp.Discount)
+		}
+	})
+
 	t.Run("NotFound", func(t *testing.T) {
 		mock.ExpectQuery(`SELECT .* FROM "carts"`).
 			WillReturnError(gorm.ErrRecordNotFound)
 
-		_, err := s.GetCart(userID)
+		_, err := s.GetCart(userID, "")
 		if err == nil {
 			t.Error("expected error but got nil")
 		}
@@ -85,8 +134,11 @@ func TestCartService_AddToCart(t *testing.T) {
 	}
 
 	t.Run("Success_NewItem", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT .* FROM "products"`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "stock", "price"}).AddRow(productID, 10, 50.0))
+		mock.ExpectBegin()
+
+		// The product is locked while its stock is checked and held
+		mock.ExpectQuery(`SELECT .* FROM "products" WHERE "products"."id" = \$1 .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "stock", "price_amount", "price_currency"}).AddRow(productID, 10, 5000, "USD"))
 
 		mock.ExpectQuery(`SELECT .* FROM "carts"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
@@ -94,24 +146,30 @@ func TestCartService_AddToCart(t *testing.T) {
 		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
 			WillReturnError(gorm.ErrRecordNotFound)
 
-		mock.ExpectBegin()
+		expectNoReservedStock(mock)
+
 		mock.ExpectQuery(`INSERT INTO "cart_items"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(100))
-		mock.ExpectCommit()
+
+		// The cart holds what it contains
+		expectStockHeld(mock, 10, productID, nil, 2, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg())
 
 		mock.ExpectQuery(`SELECT .* FROM "carts"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
 		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows(promotionColumns))
 
-		_, err := s.AddToCart(userID, req)
+		_, err := s.AddToCart(userID, req, "")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 	})
 
 	t.Run("Success_ExistingItem", func(t *testing.T) {
+		mock.ExpectBegin()
 		mock.ExpectQuery(`SELECT .* FROM "products"`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "stock", "price"}).AddRow(productID, 10, 50.0))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "stock", "price_amount", "price_currency"}).AddRow(productID, 10, 5000, "USD"))
 
 		mock.ExpectQuery(`SELECT .* FROM "carts"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
@@ -119,24 +177,29 @@ func TestCartService_AddToCart(t *testing.T) {
 		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "quantity"}).AddRow(100, 1))
 
-		mock.ExpectBegin()
+		expectNoReservedStock(mock)
+
 		mock.ExpectExec(`UPDATE "cart_items" SET .*quantity.*`).
 			WillReturnResult(sqlmock.NewResult(0, 1))
-		mock.ExpectCommit()
+
+		expectStockHeld(mock, 10, productID, nil, 3, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg())
 
 		mock.ExpectQuery(`SELECT .* FROM "carts"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
 		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows(promotionColumns))
 
-		_, err := s.AddToCart(userID, req)
+		_, err := s.AddToCart(userID, req, "")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 	})
 
 	t.Run("InsufficientStock_ExistingItem", func(t *testing.T) {
+		mock.ExpectBegin()
 		mock.ExpectQuery(`SELECT .* FROM "products"`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "stock", "price"}).AddRow(productID, 2, 50.0))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "stock", "price_amount", "price_currency"}).AddRow(productID, 2, 5000, "USD"))
 
 		mock.ExpectQuery(`SELECT .* FROM "carts"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
@@ -144,13 +207,232 @@ func TestCartService_AddToCart(t *testing.T) {
 		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "quantity"}).AddRow(100, 2))
 
-		_, err := s.AddToCart(userID, req)
+		expectNoReservedStock(mock)
+
+		mock.ExpectRollback()
+
+		_, err := s.AddToCart(userID, req, "")
+		if err == nil || err.Error() != "insufficient stock" {
+			t.Errorf("expected 'insufficient stock' error, got %v", err)
+		}
+	})
+
+	t.Run("InsufficientStock_HeldByOtherCarts", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "stock", "price_amount", "price_currency"}).AddRow(productID, 3, 5000, "USD"))
+
+		// A first item goes into a cart created below
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		// 2 of the 3 in stock are held by other carts
+		mock.ExpectQuery(`SELECT product_id, SUM\(quantity\) AS quantity FROM "inventory_reservations" WHERE product_id IN \(\$1\) AND expires_at > \$2 AND cart_id <> \$3`).
+			WithArgs(productID, sqlmock.AnyArg(), 0).
+			WillReturnRows(sqlmock.NewRows([]string{"product_id", "quantity"}).AddRow(productID, 2))
+
+		mock.ExpectRollback()
+
+		_, err := s.AddToCart(userID, req, "")
+		if err == nil || err.Error() != "insufficient stock" {
+			t.Errorf("expected 'insufficient stock' error, got %v", err)
+		}
+	})
+
+	t.Run("Backorder_BeyondStock", func(t *testing.T) {
+		mock.ExpectBegin()
+		// 1 in stock and up to 5 more on backorder
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "stock", "inventory_policy", "backorder_limit", "price_amount", "price_currency"}).
+				AddRow(productID, 1, "backorder", 5, 5000, "USD"))
+
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		expectNoReservedStock(mock)
+
+		mock.ExpectQuery(`INSERT INTO "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(101))
+
+		expectStockHeld(mock, 10, productID, nil, 2, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg())
+
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows(promotionColumns))
+
+		_, err := s.AddToCart(userID, req, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Backorder_BeyondLimit", func(t *testing.T) {
+		mock.ExpectBegin()
+		// 4 of the 5 that may be backordered already are
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "stock", "inventory_policy", "backorder_limit", "price_amount", "price_currency"}).
+				AddRow(productID, -4, "backorder", 5, 5000, "USD"))
+
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		expectNoReservedStock(mock)
+
+		mock.ExpectRollback()
+
+		_, err := s.AddToCart(userID, req, "")
 		if err == nil || err.Error() != "insufficient stock" {
 			t.Errorf("expected 'insufficient stock' error, got %v", err)
 		}
 	})
 }
 
+func TestCartService_Reorder(t *testing.T) {
+	s, mock, err := setupCartServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+	orderID := uint(500)
+	productColumns := []string{"id", "name", "stock", "is_active", "price_amount", "price_currency", "deleted_at"}
+
+	expectCart := func() {
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows(promotionColumns))
+	}
+
+	t.Run("Report", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "orders" WHERE \(id = \$1 AND user_id = \$2\)`).
+			WithArgs(orderID, userID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(orderID, userID))
+		mock.ExpectQuery(`SELECT \* FROM "order_items" WHERE "order_items"."order_id" = \$1 AND "order_items"."deleted_at" IS NULL ORDER BY id`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id", "quantity"}).
+				AddRow(1, orderID, 1, 2).
+				AddRow(2, orderID, 2, 5).
+				AddRow(3, orderID, 3, 1).
+				AddRow(4, orderID, 4, 1).
+				AddRow(5, orderID, 5, 1))
+		mock.ExpectQuery(`SELECT \* FROM "products" WHERE "products"."id" IN \(\$1,\$2,\$3,\$4,\$5\)$`).
+			WillReturnRows(sqlmock.NewRows(productColumns).
+				AddRow(1, "Mug", 10, true, 999, "USD", nil).
+				AddRow(2, "Cup", 3, true, 499, "USD", nil).
+				AddRow(3, "Plate", 10, true, 1299, "USD", time.Now()).
+				AddRow(4, "Bowl", 10, false, 899, "USD", nil).
+				AddRow(5, "Jug", 0, true, 1999, "USD", nil))
+		mock.ExpectQuery(`SELECT "cart_items"."id",.* FROM "cart_items" JOIN carts ON cart_items.cart_id = carts.id WHERE carts.user_id = \$1`).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 2, 1))
+		expectNoReservedStock(mock)
+
+		// Mug is added in full.
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows(productColumns).AddRow(1, "Mug", 10, true, 999, "USD", nil))
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+		expectNoReservedStock(mock)
+		mock.ExpectQuery(`INSERT INTO "cart_items"`).
+			WithArgs(10, 1, 2, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(101))
+		expectStockHeld(mock, 10, 1, nil, 2, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg())
+		expectCart()
+
+		// Only 2 of the 5 cups are left next to the one already in the cart.
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows(productColumns).AddRow(2, "Cup", 3, true, 499, "USD", nil))
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 2, 1))
+		expectNoReservedStock(mock)
+		mock.ExpectExec(`UPDATE "cart_items" SET .*quantity.*`).
+			WithArgs(10, 2, 3, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 100).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectStockHeld(mock, 10, 2, nil, 3, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg())
+		expectCart()
+
+		resp, err := s.Reorder(userID, orderID, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Cart == nil || resp.Cart.ID != 10 {
+			t.Errorf("expected cart 10, got %+v", resp.Cart)
+		}
+
+		want := []struct {
+			status string
+			added  int
+			reason string
+		}{
+			{dto.ReorderLineAdded, 2, ""},
+			{dto.ReorderLineAdjusted, 2, "only 2 left in stock"},
+			{dto.ReorderLineDropped, 0, "product is no longer sold"},
+			{dto.ReorderLineDropped, 0, "product is currently unavailable"},
+			{dto.ReorderLineDropped, 0, "product is out of stock"},
+		}
+		if len(resp.Lines) != len(want) {
+			t.Fatalf("expected %d lines, got %+v", len(want), resp.Lines)
+		}
+		for i, w := range want {
+			line := resp.Lines[i]
+			if line.Status != w.status || line.AddedQuantity != w.added || line.Reason != w.reason {
+				t.Errorf("line %d: expected %s %d (%q), got %+v", i, w.status, w.added, w.reason, line)
+			}
+		}
+	})
+
+	t.Run("NothingAddedWithoutCart", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(orderID, userID))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id", "quantity"}).AddRow(1, orderID, 1, 2))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows(productColumns).AddRow(1, "Mug", 0, true, 999, "USD", nil))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectNoReservedStock(mock)
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		resp, err := s.Reorder(userID, orderID, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Cart != nil || len(resp.Lines) != 1 || resp.Lines[0].Status != dto.ReorderLineDropped {
+			t.Errorf("expected a dropped line and no cart, got %+v", resp)
+		}
+	})
+
+	t.Run("OrderNotFound", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := s.Reorder(userID, 99, "")
+		if !errors.Is(err, services.ErrOrderNotFound) {
+			t.Errorf("expected ErrOrderNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
 func TestCartService_UpdateCartItem(t *testing.T) {
 	s, mock, err := setupCartServiceTest()
 	if err != nil {
@@ -162,22 +444,25 @@ func TestCartService_UpdateCartItem(t *testing.T) {
 	req := &dto.UpdateCartItemRequest{Quantity: 5}
 
 	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
 		mock.ExpectQuery(`SELECT .* FROM "cart_items" JOIN carts`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id"}).AddRow(itemID, 1000))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id"}).AddRow(itemID, 10, 1000))
 
 		mock.ExpectQuery(`SELECT .* FROM "products"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "stock"}).AddRow(1000, 10))
+		expectNoReservedStock(mock)
 
-		mock.ExpectBegin()
 		mock.ExpectExec(`UPDATE "cart_items" SET .*quantity.*`).
 			WillReturnResult(sqlmock.NewResult(0, 1))
-		mock.ExpectCommit()
+		expectStockHeld(mock, 10, 1000, nil, 5, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg())
 
 		mock.ExpectQuery(`SELECT .* FROM "carts"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
 		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows(promotionColumns))
 
-		_, err := s.UpdateCartItem(userID, itemID, req)
+		_, err := s.UpdateCartItem(userID, itemID, req, "")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -194,9 +479,17 @@ func TestCartService_RemoveFromCart(t *testing.T) {
 	itemID := uint(100)
 
 	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "cart_items" JOIN carts ON cart_items.cart_id = carts.id WHERE cart_items.id = \$1 AND carts.user_id = \$2`).
+			WithArgs(itemID, userID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id"}).AddRow(itemID, 10, 1000))
+
+		// The item goes along with the stock held for it
 		mock.ExpectBegin()
 		mock.ExpectExec(`UPDATE "cart_items" SET "deleted_at"`).
 			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "inventory_reservations" WHERE cart_id = \$1 AND product_id IN \(\$2\)`).
+			WithArgs(10, 1000).
+			WillReturnResult(sqlmock.NewResult(0, 1))
 		mock.ExpectCommit()
 
 		err := s.RemoveFromCart(userID, itemID)
@@ -204,4 +497,366 @@ func TestCartService_RemoveFromCart(t *testing.T) {
 			t.Errorf("unexpected error: %v", err)
 		}
 	})
+
+	t.Run("NotInCart", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "cart_items" JOIN carts`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		if err := s.RemoveFromCart(userID, 999); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestCartService_GuestCart(t *testing.T) {
+	s, mock, err := setupCartServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	token := "3f0c8a4e-5d1b-4f6a-9c2e-7b8d9e0f1a2b"
+	req := &dto.AddToCartRequest{ProductID: 1000, Quantity: 2}
+
+	t.Run("Create", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "carts" .*"token"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(20))
+		mock.ExpectCommit()
+
+		mock.ExpectQuery(`SELECT .* FROM "carts" WHERE carts.token = \$1`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "token"}).AddRow(20, token))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows(promotionColumns))
+
+		resp, err := s.CreateGuestCart("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ID != 20 || resp.Token != token || resp.UserID != 0 {
+			t.Errorf("expected guest cart 20 with its token, got %+v", resp)
+		}
+	})
+
+	t.Run("AddItem", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "stock", "price_amount", "price_currency"}).AddRow(1000, 10, 5000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "carts" WHERE carts.token = \$1`).
+			WithArgs(token, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "token"}).AddRow(20, token))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+		expectNoReservedStock(mock)
+
+		mock.ExpectQuery(`INSERT INTO "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(200))
+		expectStockHeld(mock, 20, 1000, nil, 2, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg())
+
+		mock.ExpectQuery(`SELECT .* FROM "carts" WHERE carts.token = \$1`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "token"}).AddRow(20, token))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows(promotionColumns))
+
+		if _, err := s.AddToGuestCart(token, req, ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("AddItem_UnknownToken", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "stock"}).AddRow(1000, 10))
+		mock.ExpectQuery(`SELECT .* FROM "carts" WHERE carts.token = \$1`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		mock.ExpectRollback()
+
+		// A guest cart is never created implicitly
+		if _, err := s.AddToGuestCart("unknown", req, ""); !errors.Is(err, services.ErrCartNotFound) {
+			t.Errorf("expected ErrCartNotFound, got %v", err)
+		}
+	})
+
+	t.Run("RemoveItem", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "cart_items" JOIN carts ON cart_items.cart_id = carts.id WHERE cart_items.id = \$1 AND carts.token = \$2`).
+			WithArgs(200, token, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id"}).AddRow(200, 20, 1000))
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "cart_items" SET "deleted_at"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "inventory_reservations" WHERE cart_id = \$1 AND product_id IN \(\$2\)`).
+			WithArgs(20, 1000).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := s.RemoveFromGuestCart(token, 200); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+var (
+	shippingRateColumns   = []string{"id", "shipping_zone_id", "name", "type", "price_amount", "price_currency", "min_weight", "max_weight", "free_over_amount", "free_over_currency", "is_active"}
+	shippingRegionColumns = []string{"id", "shipping_zone_id", "country", "state", "postcode_prefix"}
+)
+
+// expectShippingQuote expects the cart and shipping configuration lookups
+// behind a shipping quote: a 3kg cart worth 60 shipped to 94105, US.
+func expectShippingQuote(mock sqlmock.Sqlmock, userID, addressID uint) {
+	mock.ExpectQuery(`SELECT .* FROM "addresses"`).
+		WithArgs(addressID, userID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "postal_code", "country"}).AddRow(addressID, userID, "94105", "US"))
+
+	mock.ExpectQuery(`SELECT .* FROM "carts"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+	mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 2))
+	mock.ExpectQuery(`SELECT .* FROM "products"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "weight"}).AddRow(1000, 3000, "USD", 1.5))
+
+	mock.ExpectQuery(`SELECT .* FROM "shipping_zones"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "is_active"}).
+			AddRow(1, "Domestic", true).
+			AddRow(2, "Bay Area", true))
+	mock.ExpectQuery(`SELECT .* FROM "shipping_rates"`).
+		WillReturnRows(sqlmock.NewRows(shippingRateColumns).
+			AddRow(10, 1, "Standard", "flat", 1200, "USD", 0, 0, 0, "USD", true).
+			AddRow(20, 2, "Standard", "weight", 400, "USD", 0, 2, 0, "USD", true).
+			AddRow(21, 2, "Standard", "weight", 600, "USD", 2, 10, 0, "USD", true).
+			AddRow(22, 2, "Standard", "flat", 800, "USD", 0, 0, 0, "USD", true).
+			AddRow(23, 2, "Express", "free_over", 1500, "USD", 0, 0, 5000, "USD", true))
+	mock.ExpectQuery(`SELECT .* FROM "shipping_zone_regions"`).
+		WillReturnRows(sqlmock.NewRows(shippingRegionColumns).
+			AddRow(1, 1, "US", "", "").
+			AddRow(2, 2, "US", "", "941"))
+}
+
+func TestCartService_GetShippingOptions(t *testing.T) {
+	s, mock, err := setupCartServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+	addressID := uint(30)
+
+	t.Run("Success", func(t *testing.T) {
+		expectShippingQuote(mock, userID, addressID)
+
+		options, err := s.GetShippingOptions(userID, addressID, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// The postcode zone wins over the country zone, the cheapest applicable
+		// Standard rate is offered and Express is free over the threshold
+		expected := []dto.ShippingOptionResponse{
+			{RateID: 21, Method: "Standard", Cost: usd(600)},
+			{RateID: 23, Method: "Express", Cost: usd(0)},
+		}
+		if len(options) != len(expected) {
+			t.Fatalf("expected %d options, got %+v", len(expected), options)
+		}
+		for i := range expected {
+			if options[i] != expected[i] {
+				t.Errorf("option %d: expected %+v, got %+v", i, expected[i], options[i])
+			}
+		}
+	})
+
+	t.Run("AddressNotFound", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "addresses"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := s.GetShippingOptions(userID, 99, "")
+		if !errors.Is(err, services.ErrAddressNotFound) {
+			t.Errorf("expected ErrAddressNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestCartService_SelectShippingMethod(t *testing.T) {
+	s, mock, err := setupCartServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+	addressID := uint(30)
+
+	t.Run("Success", func(t *testing.T) {
+		expectShippingQuote(mock, userID, addressID)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "carts" SET "shipping_rate_id"=\$1`).
+			WithArgs(uint(21), sqlmock.AnyArg(), userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "shipping_rate_id"}).AddRow(10, userID, 21))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 2))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "price_amount", "price_currency", "weight"}).AddRow(1000, 50, 3000, "USD", 1.5))
+		mock.ExpectQuery(`SELECT .* FROM "categories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(50))
+		mock.ExpectQuery(`SELECT .* FROM "shipping_rates"`).
+			WillReturnRows(sqlmock.NewRows(shippingRateColumns).AddRow(21, 2, "Standard", "weight", 600, "USD", 2, 10, 0, "USD", true))
+		expectNoReservedStock(mock)
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows(promotionColumns))
+
+		resp, err := s.SelectShippingMethod(userID, &dto.SelectShippingMethodRequest{AddressID: addressID, ShippingRateID: 21}, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Total != usd(6000) {
+			t.Errorf("expected total 60, got %v", resp.Total)
+		}
+		if resp.Shipping == nil || resp.Shipping.Method != "Standard" || resp.Shipping.Cost != usd(600) {
+			t.Errorf("expected Standard shipping for 6, got %+v", resp.Shipping)
+		}
+	})
+
+	t.Run("NotQuotedForAddress", func(t *testing.T) {
+		expectShippingQuote(mock, userID, addressID)
+
+		// Rate 10 belongs to the country zone, which the postcode zone overrides
+		_, err := s.SelectShippingMethod(userID, &dto.SelectShippingMethodRequest{AddressID: addressID, ShippingRateID: 10}, "")
+		if !errors.Is(err, services.ErrShippingUnavailable) {
+			t.Errorf("expected ErrShippingUnavailable, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+var promotionColumns = []string{"id", "name", "type", "buy_quantity", "get_quantity", "discount_percent", "bundle_quantity", "bundle_price_amount", "bundle_price_currency", "priority", "stackable", "is_active"}
+
+var couponColumns = []string{"id", "code", "type", "percent", "fixed_amount", "fixed_currency", "min_subtotal_amount", "min_subtotal_currency", "usage_limit", "per_customer_limit", "used_count", "is_active"}
+
+func TestCartService_ApplyCoupon(t *testing.T) {
+	s, mock, err := setupCartServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+
+	expectCart := func() {
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 2))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "price_amount", "price_currency"}).AddRow(1000, 50, 3000, "USD"))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		expectCart()
+
+		// Codes are matched upper cased
+		mock.ExpectQuery(`SELECT .* FROM "coupons" WHERE code = \$1`).
+			WithArgs("SAVE10", 1).
+			WillReturnRows(sqlmock.NewRows(couponColumns).AddRow(5, "SAVE10", "percentage", 10.0, 0, "USD", 5000, "USD", 0, 1, 0, true))
+		mock.ExpectQuery(`SELECT .* FROM "coupon_categories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"coupon_id", "category_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "coupon_products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"coupon_id", "product_id"}))
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "coupon_redemptions"`).
+			WithArgs(uint(5), userID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "carts" SET "coupon_id"=\$1`).
+			WithArgs(uint(5), sqlmock.AnyArg(), uint(10)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "coupon_id"}).AddRow(10, userID, 5))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 2))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "price_amount", "price_currency"}).AddRow(1000, 50, 3000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "categories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(50))
+		mock.ExpectQuery(`SELECT .* FROM "coupons"`).
+			WillReturnRows(sqlmock.NewRows(couponColumns).AddRow(5, "SAVE10", "percentage", 10.0, 0, "USD", 5000, "USD", 0, 1, 0, true))
+		mock.ExpectQuery(`SELECT .* FROM "coupon_categories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"coupon_id", "category_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "coupon_products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"coupon_id", "product_id"}))
+		expectNoReservedStock(mock)
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows(promotionColumns))
+
+		resp, err := s.ApplyCoupon(userID, &dto.ApplyCouponRequest{Code: " save10 "}, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Coupon == nil || resp.Coupon.Code != "SAVE10" || resp.Discount != usd(600) {
+			t.Errorf("expected SAVE10 taking 6 off, got %+v with discount %v", resp.Coupon, resp.Discount)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		expectCart()
+		mock.ExpectQuery(`SELECT .* FROM "coupons"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		_, err := s.ApplyCoupon(userID, &dto.ApplyCouponRequest{Code: "NOPE"}, "")
+		if !errors.Is(err, services.ErrCouponNotFound) {
+			t.Errorf("expected ErrCouponNotFound, got %v", err)
+		}
+	})
+
+	t.Run("BelowMinSubtotal", func(t *testing.T) {
+		expectCart()
+		mock.ExpectQuery(`SELECT .* FROM "coupons"`).
+			WillReturnRows(sqlmock.NewRows(couponColumns).AddRow(6, "BIGSPENDER", "fixed", 0.0, 2000, "USD", 10000, "USD", 0, 0, 0, true))
+		mock.ExpectQuery(`SELECT .* FROM "coupon_categories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"coupon_id", "category_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "coupon_products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"coupon_id", "product_id"}))
+
+		_, err := s.ApplyCoupon(userID, &dto.ApplyCouponRequest{Code: "BIGSPENDER"}, "")
+		if !errors.Is(err, services.ErrCouponNotApplicable) {
+			t.Errorf("expected ErrCouponNotApplicable, got %v", err)
+		}
+	})
+
+	t.Run("PerCustomerLimitReached", func(t *testing.T) {
+		expectCart()
+		mock.ExpectQuery(`SELECT .* FROM "coupons"`).
+			WillReturnRows(sqlmock.NewRows(couponColumns).AddRow(5, "SAVE10", "percentage", 10.0, 0, "USD", 0, "USD", 0, 1, 3, true))
+		mock.ExpectQuery(`SELECT .* FROM "coupon_categories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"coupon_id", "category_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "coupon_products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"coupon_id", "product_id"}))
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "coupon_redemptions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		_, err := s.ApplyCoupon(userID, &dto.ApplyCouponRequest{Code: "SAVE10"}, "")
+		if !errors.Is(err, services.ErrCouponNotApplicable) {
+			t.Errorf("expected ErrCouponNotApplicable, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
 }
@@ -0,0 +1,342 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/payments"
+	"github.com/kuldeepstechwork/gocart-api/internal/repositories"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/tax"
+	"github.com/kuldeepstechwork/gocart-api/test/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupCheckoutServiceTest(ctrl *gomock.Controller) (*services.CheckoutService, sqlmock.Sqlmock, error) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	taxCalculator := tax.NewTableCalculator(repositories.NewTaxRateRepository(gormDB), tax.PricingExclusive)
+	orders := services.NewOrderService(gormDB, "USD", mocks.NewMockPublisher(ctrl),
+		payments.NewFakeProvider(payments.FakeOutcomeSucceed), taxCalculator, mocks.NewMockInvoiceServiceInterface(ctrl),
+		services.NewLoyaltyService(gormDB, "USD", loyaltyProgram))
+
+	return services.NewCheckoutService(gormDB, orders, 15*time.Minute), mock, nil
+}
+
+var checkoutSessionColumns = []string{"id", "user_id", "cart_id", "cart_fingerprint", "status", "total_amount", "total_currency", "expires_at"}
+
+func TestCheckoutService_CreateCheckoutSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mock, err := setupCheckoutServiceTest(ctrl)
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "carts" WHERE user_id = \$1`).
+			WithArgs(userID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 2))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name"}).AddRow(1000, 10000, "USD", 10, "Prod 1"))
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectNoReservedStock(mock)
+
+		// The user's open session is replaced, releasing what it held, and
+		// the quote held without touching the stock
+		mock.ExpectExec(`DELETE FROM "inventory_reservations" WHERE checkout_session_id IN \(SELECT "id" FROM "checkout_sessions" WHERE user_id = \$1 AND status = \$2.*\)`).
+			WithArgs(userID, models.CheckoutSessionOpen).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`UPDATE "checkout_sessions" SET "status"=\$1,"updated_at"=\$2 WHERE user_id = \$3 AND status = \$4`).
+			WithArgs(models.CheckoutSessionCancelled, sqlmock.AnyArg(), userID, models.CheckoutSessionOpen).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO "checkout_sessions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+		mock.ExpectQuery(`INSERT INTO "checkout_session_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(50))
+
+		// The session takes over the stock held by the cart
+		mock.ExpectQuery(`INSERT INTO "inventory_reservations" .* ON CONFLICT \("cart_id","product_id"\) DO UPDATE SET "checkout_session_id"="excluded"."checkout_session_id"`).
+			WithArgs(10, 1000, 5, 2, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(70))
+		mock.ExpectCommit()
+
+		mock.ExpectQuery(`SELECT .* FROM "checkout_sessions" WHERE id = \$1 AND user_id = \$2`).
+			WithArgs(5, userID, 1).
+			WillReturnRows(sqlmock.NewRows(checkoutSessionColumns).
+				AddRow(5, userID, 10, "fp", "open", 20000, "USD", time.Now().Add(15*time.Minute)))
+		mock.ExpectQuery(`SELECT .* FROM "checkout_session_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "checkout_session_id", "product_id", "quantity", "price_amount", "price_currency"}).
+				AddRow(50, 5, 1000, 2, 10000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sku"}).AddRow(1000, "Prod 1", "P-1"))
+		mock.ExpectQuery(`SELECT .* FROM "checkout_session_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		resp, err := s.CreateCheckoutSession(userID, &dto.CreateOrderRequest{}, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ID != 5 || resp.Status != "open" || resp.TotalAmount != usd(20000) {
+			t.Errorf("unexpected session %+v", resp)
+		}
+		if len(resp.Items) != 1 || resp.Items[0].SKU != "P-1" || resp.Items[0].Quantity != 2 {
+			t.Errorf("unexpected items %+v", resp.Items)
+		}
+	})
+
+	t.Run("StockReservedByOtherCarts", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 2))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name"}).AddRow(1000, 10000, "USD", 10, "Prod 1"))
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		// 9 of the 10 in stock are held by other carts
+		mock.ExpectQuery(`SELECT product_id, SUM\(quantity\) AS quantity FROM "inventory_reservations" WHERE product_id IN \(\$1\) AND expires_at > \$2 AND cart_id <> \$3`).
+			WithArgs(1000, sqlmock.AnyArg(), 10).
+			WillReturnRows(sqlmock.NewRows([]string{"product_id", "quantity"}).AddRow(1000, 9))
+		mock.ExpectRollback()
+
+		if _, err := s.CreateCheckoutSession(userID, &dto.CreateOrderRequest{}, ""); err == nil || err.Error() != "insufficient stock for product: Prod 1" {
+			t.Errorf("expected insufficient stock, got %v", err)
+		}
+	})
+
+	t.Run("CartNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+		mock.ExpectRollback()
+
+		if _, err := s.CreateCheckoutSession(userID, &dto.CreateOrderRequest{}, ""); !errors.Is(err, services.ErrCartNotFound) {
+			t.Errorf("expected ErrCartNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestCheckoutService_CompleteCheckoutSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mock, err := setupCheckoutServiceTest(ctrl)
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+	cart := models.Cart{ID: 10, CartItems: []models.CartItem{{ProductID: 1000, Quantity: 2}}}
+	fingerprint := cart.Fingerprint()
+
+	expectSession := func(status string, expiresAt time.Time) {
+		mock.ExpectQuery(`SELECT .* FROM "checkout_sessions" WHERE id = \$1 AND user_id = \$2 .* FOR UPDATE`).
+			WithArgs(5, userID, 1).
+			WillReturnRows(sqlmock.NewRows(checkoutSessionColumns).
+				AddRow(5, userID, 10, fingerprint, status, 18000, "USD", expiresAt))
+	}
+
+	expectQuote := func() {
+		mock.ExpectQuery(`SELECT .* FROM "checkout_session_items" WHERE checkout_session_id = \$1 ORDER BY id`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "checkout_session_id", "product_id", "quantity", "price_amount", "price_currency"}).
+				AddRow(50, 5, 1000, 2, 9000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "checkout_session_promotions" WHERE checkout_session_id = \$1 ORDER BY id`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	}
+
+	expectCart := func(quantity int) {
+		mock.ExpectQuery(`SELECT .* FROM "carts" WHERE user_id = \$1`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, quantity))
+		// The product has since been repriced, which the quote ignores
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name", "sku"}).AddRow(1000, 12000, "USD", 10, "Prod 1", "P-1"))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectSession("open", time.Now().Add(10*time.Minute))
+		expectQuote()
+		expectCart(2)
+		expectNoReservedStock(mock)
+
+		expectStockTaken(mock, 1000, 2, 8)
+		mock.ExpectQuery(`INSERT INTO "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(520))
+
+		// The order is placed at the quoted price
+		mock.ExpectQuery(`INSERT INTO "order_items" .*VALUES \(\$1,\$2,\$3,\$4,\$5`).
+			WithArgs(520, 1000, 2, int64(9000), "USD", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "in_stock", 0, nil, "Prod 1", "P-1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(620))
+		mock.ExpectQuery(`INSERT INTO "payments"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(720))
+		mock.ExpectExec(`DELETE FROM "cart_items"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "inventory_reservations" WHERE cart_id = \$1`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectExec(`UPDATE "checkout_sessions" SET "order_id"=\$1,"status"=\$2,"updated_at"=\$3 WHERE id = \$4`).
+			WithArgs(520, models.CheckoutSessionCompleted, sqlmock.AnyArg(), 5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "total_amount", "total_currency"}).AddRow(520, userID, 18000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectCommit()
+
+		resp, err := s.CompleteCheckoutSession(userID, 5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ID != 520 || resp.TotalAmount != usd(18000) {
+			t.Errorf("unexpected order %+v", resp)
+		}
+	})
+
+	t.Run("CartItemsInAnotherOrder", func(t *testing.T) {
+		twoItems := models.Cart{ID: 10, CartItems: []models.CartItem{{ProductID: 1000, Quantity: 2}, {ProductID: 1001, Quantity: 1}}}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "checkout_sessions" WHERE id = \$1 AND user_id = \$2 .* FOR UPDATE`).
+			WithArgs(7, userID, 1).
+			WillReturnRows(sqlmock.NewRows(checkoutSessionColumns).
+				AddRow(7, userID, 10, twoItems.Fingerprint(), "open", 23000, "USD", time.Now().Add(10*time.Minute)))
+		mock.ExpectQuery(`SELECT .* FROM "checkout_session_items" WHERE checkout_session_id = \$1 ORDER BY id`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "checkout_session_id", "product_id", "quantity", "price_amount", "price_currency"}).
+				AddRow(50, 7, 1000, 2, 9000, "USD").
+				AddRow(51, 7, 1001, 1, 5000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "checkout_session_promotions" WHERE checkout_session_id = \$1 ORDER BY id`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		// The cart lists its items the other way round
+		mock.ExpectQuery(`SELECT .* FROM "carts" WHERE user_id = \$1`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).
+				AddRow(101, 10, 1001, 1).
+				AddRow(100, 10, 1000, 2))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name", "inventory_policy", "backorder_limit"}).
+				AddRow(1000, 9000, "USD", 10, "Prod 1", "deny", 0).
+				AddRow(1001, 5000, "USD", 0, "Prod 2", "backorder", 5))
+		expectNoReservedStock(mock)
+
+		// Each order item is marked from the stock of its own product
+		expectStockTaken(mock, 1000, 2, 8)
+		expectStockTaken(mock, 1001, 1, -1)
+		mock.ExpectQuery(`INSERT INTO "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(521))
+		mock.ExpectQuery(`INSERT INTO "order_items"`).
+			WithArgs(
+				521, 1000, 2, int64(9000), "USD", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "in_stock", 0, nil, "Prod 1", "",
+				521, 1001, 1, int64(5000), "USD", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "backordered", 1, nil, "Prod 2", "").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(621).AddRow(622))
+		mock.ExpectQuery(`INSERT INTO "payments"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(721))
+		mock.ExpectExec(`DELETE FROM "cart_items"`).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`DELETE FROM "inventory_reservations" WHERE cart_id = \$1`).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		mock.ExpectExec(`UPDATE "checkout_sessions"`).
+			WithArgs(521, models.CheckoutSessionCompleted, sqlmock.AnyArg(), 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "total_amount", "total_currency"}).AddRow(521, userID, 23000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectCommit()
+
+		if _, err := s.CompleteCheckoutSession(userID, 7); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectSession("open", time.Now().Add(-time.Minute))
+		mock.ExpectRollback()
+
+		if _, err := s.CompleteCheckoutSession(userID, 5); !errors.Is(err, services.ErrCheckoutSessionExpired) {
+			t.Errorf("expected ErrCheckoutSessionExpired, got %v", err)
+		}
+	})
+
+	t.Run("AlreadyCompleted", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectSession("completed", time.Now().Add(10*time.Minute))
+		mock.ExpectRollback()
+
+		if _, err := s.CompleteCheckoutSession(userID, 5); !errors.Is(err, services.ErrCheckoutSessionClosed) {
+			t.Errorf("expected ErrCheckoutSessionClosed, got %v", err)
+		}
+	})
+
+	t.Run("CartChanged", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectSession("open", time.Now().Add(10*time.Minute))
+		expectQuote()
+		expectCart(3)
+		mock.ExpectRollback()
+
+		if _, err := s.CompleteCheckoutSession(userID, 5); !errors.Is(err, services.ErrCheckoutCartChanged) {
+			t.Errorf("expected ErrCheckoutCartChanged, got %v", err)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "checkout_sessions"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+		mock.ExpectRollback()
+
+		if _, err := s.CompleteCheckoutSession(userID, 6); !errors.Is(err, services.ErrCheckoutSessionNotFound) {
+			t.Errorf("expected ErrCheckoutSessionNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupCouponServiceTest() (*services.CouponService, sqlmock.Sqlmock, error) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return services.NewCouponService(gormDB, "USD"), mock, nil
+}
+
+func TestCouponService_CreateCoupon(t *testing.T) {
+	s, mock, err := setupCouponServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "products" WHERE id IN \(\$1\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1000, "Prod 1"))
+		mock.ExpectQuery(`INSERT INTO "coupons"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+
+		// Only the join row is written, the product itself is left alone
+		mock.ExpectExec(`INSERT INTO "coupon_products"`).
+			WithArgs(uint(5), uint(1000)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		resp, err := s.CreateCoupon(&dto.CouponRequest{Code: "summer25", Type: "percentage", Percent: 25, ProductIDs: []uint{1000}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Code != "SUMMER25" || !resp.IsActive {
+			t.Errorf("expected active coupon SUMMER25, got %+v", resp)
+		}
+		if len(resp.ProductIDs) != 1 || resp.ProductIDs[0] != 1000 {
+			t.Errorf("expected the coupon to be restricted to product 1000, got %v", resp.ProductIDs)
+		}
+	})
+
+	t.Run("PercentageOver100", func(t *testing.T) {
+		_, err := s.CreateCoupon(&dto.CouponRequest{Code: "FREE", Type: "percentage", Percent: 150})
+		if !errors.Is(err, services.ErrInvalidCoupon) {
+			t.Errorf("expected ErrInvalidCoupon, got %v", err)
+		}
+	})
+
+	t.Run("FixedAmountInOtherCurrency", func(t *testing.T) {
+		_, err := s.CreateCoupon(&dto.CouponRequest{Code: "TEN", Type: "fixed", FixedAmount: money.New(1000, "EUR")})
+		if !errors.Is(err, services.ErrInvalidCoupon) {
+			t.Errorf("expected ErrInvalidCoupon, got %v", err)
+		}
+	})
+
+	t.Run("UnknownProduct", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1000))
+		mock.ExpectRollback()
+
+		_, err := s.CreateCoupon(&dto.CouponRequest{Code: "TEN", Type: "fixed", FixedAmount: money.Money{Amount: 1000}, ProductIDs: []uint{1000, 1001}})
+		if !errors.Is(err, services.ErrInvalidCoupon) {
+			t.Errorf("expected ErrInvalidCoupon, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestCouponService_UpdateCoupon(t *testing.T) {
+	s, mock, err := setupCouponServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("ReplacesRestrictions", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "coupons"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "code", "type", "percent", "used_count", "is_active"}).
+				AddRow(5, "SUMMER25", "percentage", 25.0, 7, true))
+		mock.ExpectQuery(`SELECT .* FROM "categories" WHERE id IN \(\$1\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(50, "Shoes"))
+		mock.ExpectExec(`UPDATE "coupons"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		// Replacing an association touches the coupon's updated_at
+		mock.ExpectExec(`UPDATE "coupons" SET "updated_at"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "coupon_products" WHERE "coupon_products"."coupon_id" = \$1`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE "coupons" SET "updated_at"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO "coupon_categories"`).
+			WithArgs(uint(5), uint(50)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "coupon_categories" WHERE "coupon_categories"."coupon_id" = \$1 AND "coupon_categories"."category_id" <> \$2`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		resp, err := s.UpdateCoupon(5, &dto.CouponRequest{Code: "SUMMER30", Type: "percentage", Percent: 30, CategoryIDs: []uint{50}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Code != "SUMMER30" || resp.UsedCount != 7 {
+			t.Errorf("expected SUMMER30 keeping its 7 uses, got %+v", resp)
+		}
+		if len(resp.ProductIDs) != 0 || len(resp.CategoryIDs) != 1 {
+			t.Errorf("expected the coupon to be restricted to category 50 only, got %v and %v", resp.ProductIDs, resp.CategoryIDs)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "coupons"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+		mock.ExpectRollback()
+
+		_, err := s.UpdateCoupon(99, &dto.CouponRequest{Code: "SHIPFREE", Type: "free_shipping"})
+		if !errors.Is(err, services.ErrCouponNotFound) {
+			t.Errorf("expected ErrCouponNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestCouponService_DeleteCoupon(t *testing.T) {
+	s, mock, err := setupCouponServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "coupons" SET "deleted_at"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		if err := s.DeleteCoupon(99); !errors.Is(err, services.ErrCouponNotFound) {
+			t.Errorf("expected ErrCouponNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
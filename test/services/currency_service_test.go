@@ -0,0 +1,164 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupCurrencyServiceTest() (*services.CurrencyService, sqlmock.Sqlmock, error) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return services.NewCurrencyService(gormDB, "USD", []money.Currency{"EUR", "JPY"}), mock, nil
+}
+
+func TestCurrencyService_GetCurrencies(t *testing.T) {
+	s, mock, err := setupCurrencyServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	mock.ExpectQuery(`SELECT \* FROM "exchange_rates" WHERE currency IN \(\$1,\$2\) ORDER BY currency`).
+		WithArgs("EUR", "JPY").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "currency", "rate"}).AddRow(1, "EUR", 0.92))
+
+	currencies, err := s.GetCurrencies()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(currencies) != 2 {
+		t.Fatalf("expected the store currency and EUR, got %+v", currencies)
+	}
+	if currencies[0].Code != "USD" || !currencies[0].IsDefault {
+		t.Errorf("expected USD first as the default, got %+v", currencies[0])
+	}
+	if currencies[1].Code != "EUR" || currencies[1].Rate != 0.92 {
+		t.Errorf("expected EUR at 0.92, got %+v", currencies[1])
+	}
+}
+
+func TestCurrencyService_SetExchangeRate(t *testing.T) {
+	s, mock, err := setupCurrencyServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "exchange_rates" WHERE currency = \$1`).
+			WithArgs("EUR", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "exchange_rates"`).
+			WithArgs("EUR", 0.92, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectCommit()
+
+		resp, err := s.SetExchangeRate("EUR", &dto.ExchangeRateRequest{Rate: 0.92})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Currency != "EUR" || resp.Rate != 0.92 {
+			t.Errorf("expected EUR at 0.92, got %+v", resp)
+		}
+	})
+
+	t.Run("StoreCurrency", func(t *testing.T) {
+		_, err := s.SetExchangeRate("USD", &dto.ExchangeRateRequest{Rate: 1})
+		if !errors.Is(err, services.ErrCurrencyNotSupported) {
+			t.Errorf("expected ErrCurrencyNotSupported, got %v", err)
+		}
+	})
+
+	t.Run("UnconfiguredCurrency", func(t *testing.T) {
+		_, err := s.SetExchangeRate("GBP", &dto.ExchangeRateRequest{Rate: 0.79})
+		if !errors.Is(err, services.ErrCurrencyNotSupported) {
+			t.Errorf("expected ErrCurrencyNotSupported, got %v", err)
+		}
+	})
+
+	t.Run("InvalidRate", func(t *testing.T) {
+		_, err := s.SetExchangeRate("EUR", &dto.ExchangeRateRequest{Rate: -1})
+		if !errors.Is(err, services.ErrInvalidExchangeRate) {
+			t.Errorf("expected ErrInvalidExchangeRate, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestCurrencyService_DeleteExchangeRate(t *testing.T) {
+	s, mock, err := setupCurrencyServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "exchange_rates" WHERE currency = \$1`).
+		WithArgs("GBP").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	if err := s.DeleteExchangeRate("GBP"); !errors.Is(err, services.ErrExchangeRateNotFound) {
+		t.Errorf("expected ErrExchangeRateNotFound, got %v", err)
+	}
+}
+
+func TestCurrencyService_SetProductPrice(t *testing.T) {
+	s, mock, err := setupCurrencyServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT "id" FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery(`SELECT \* FROM "product_prices" WHERE product_id = \$1 AND currency = \$2`).
+			WithArgs(1, "JPY", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "product_prices"`).
+			WithArgs(1, "JPY", int64(1500), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+		mock.ExpectCommit()
+
+		resp, err := s.SetProductPrice(1, "JPY", &dto.ProductPriceRequest{Amount: 1500})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Price != money.New(1500, "JPY") {
+			t.Errorf("expected 1500 JPY, got %v", resp.Price)
+		}
+	})
+
+	t.Run("ProductNotFound", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT "id" FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := s.SetProductPrice(99, "EUR", &dto.ProductPriceRequest{Amount: 1500})
+		if !errors.Is(err, services.ErrProductNotFound) {
+			t.Errorf("expected ErrProductNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
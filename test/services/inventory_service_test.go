@@ -0,0 +1,121 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupInventoryServiceTest() (*services.InventoryService, sqlmock.Sqlmock, error) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return services.NewInventoryService(gormDB), mock, nil
+}
+
+func TestInventoryService_ReleaseExpiredReservations(t *testing.T) {
+	s, mock, err := setupInventoryServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	now := time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "inventory_reservations" WHERE expires_at <= \$1`).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	released, err := s.ReleaseExpiredReservations(now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if released != 3 {
+		t.Errorf("expected 3 released reservations, got %d", released)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestInventoryService_ListBackorders(t *testing.T) {
+	s, mock, err := setupInventoryServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	productID := uint(1000)
+	launch := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// Only the items still waiting for stock of orders yet to ship
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "order_items" JOIN orders ON orders.id = order_items.order_id WHERE \(order_items.stock_status <> \$1 AND orders.status IN \(\$2,\$3,\$4\)\) AND order_items.product_id = \$5`).
+		WithArgs(models.StockStatusInStock, models.OrderStatusPending, models.OrderStatusConfirmed, models.OrderStatusPartiallyShipped, productID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(`SELECT "order_items"\."id".* FROM "order_items" JOIN orders .* ORDER BY order_items.created_at, order_items.id LIMIT \$6`).
+		WithArgs(models.StockStatusInStock, models.OrderStatusPending, models.OrderStatusConfirmed, models.OrderStatusPartiallyShipped, productID, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id", "quantity", "stock_status", "backordered_quantity", "available_at"}).
+			AddRow(600, 500, productID, 3, "backordered", 2, nil).
+			AddRow(601, 501, productID, 1, "preordered", 1, launch))
+	mock.ExpectQuery(`SELECT .* FROM "orders"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "number", "status"}).
+			AddRow(500, "GC-AAAA1111", "confirmed").
+			AddRow(501, "GC-BBBB2222", "pending"))
+	mock.ExpectQuery(`SELECT .* FROM "products"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sku", "stock"}).AddRow(productID, "Prod 1", "SKU-1", -3))
+
+	backorders, meta, err := s.ListBackorders(&dto.ListBackordersRequest{ProductID: &productID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.Total != 2 || len(backorders) != 2 {
+		t.Fatalf("expected 2 backorders, got %d of %d", len(backorders), meta.Total)
+	}
+
+	first := backorders[0]
+	if first.OrderNumber != "GC-AAAA1111" || first.StockStatus != "backordered" || first.BackorderedQuantity != 2 || first.Stock != -3 || first.SKU != "SKU-1" {
+		t.Errorf("unexpected first backorder: %+v", first)
+	}
+	if second := backorders[1]; second.StockStatus != "preordered" || second.AvailableAt == nil || !second.AvailableAt.Equal(launch) {
+		t.Errorf("unexpected second backorder: %+v", second)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestInventoryService_ListBackorders_CountError(t *testing.T) {
+	s, mock, err := setupInventoryServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "order_items"`).
+		WillReturnError(errors.New("connection reset"))
+
+	if _, _, err := s.ListBackorders(&dto.ListBackordersRequest{}); err == nil {
+		t.Fatal("expected the count error, got nil")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package services_test
+
+import (
+	"errors"
+	"mime/multipart"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kuldeepstechwork/gocart-api/internal/invoices"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/test/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupInvoiceServiceTest(t *testing.T) (*services.InvoiceService, sqlmock.Sqlmock, *mocks.MockUploadProvider, error) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	provider := mocks.NewMockUploadProvider(gomock.NewController(t))
+
+	return services.NewInvoiceService(gormDB, provider, invoices.NewRenderer()), mock, provider, nil
+}
+
+var invoiceColumns = []string{"id", "order_id", "number", "issued_at", "document_path", "content_type"}
+
+func TestInvoiceService_GetInvoice(t *testing.T) {
+	s, mock, _, err := setupInvoiceServiceTest(t)
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+	orderID := uint(500)
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "orders" WHERE \(id = \$1 AND user_id = \$2\)`).
+			WithArgs(orderID, userID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(orderID, userID))
+		mock.ExpectQuery(`SELECT .* FROM "invoices" WHERE order_id = \$1`).
+			WithArgs(orderID, 1).
+			WillReturnRows(sqlmock.NewRows(invoiceColumns).
+				AddRow(700, orderID, "INV-000042", nil, "/uploads/invoices/500/INV-000042-a.pdf", "application/pdf"))
+
+		resp, err := s.GetInvoice(userID, orderID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Number != "INV-000042" || resp.DocumentPath != "/uploads/invoices/500/INV-000042-a.pdf" {
+			t.Errorf("unexpected invoice %+v", resp)
+		}
+	})
+
+	t.Run("OrderOfAnotherUser", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "orders" WHERE \(id = \$1 AND user_id = \$2\)`).
+			WithArgs(orderID, uint(2), 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		_, err := s.GetInvoice(2, orderID)
+		if !errors.Is(err, services.ErrOrderNotFound) {
+			t.Errorf("expected ErrOrderNotFound, got %v", err)
+		}
+	})
+
+	t.Run("NotInvoiced", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "orders" WHERE \(id = \$1 AND user_id = \$2\)`).
+			WithArgs(orderID, userID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(orderID, userID))
+		mock.ExpectQuery(`SELECT .* FROM "invoices" WHERE order_id = \$1`).
+			WithArgs(orderID, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		_, err := s.GetInvoice(userID, orderID)
+		if !errors.Is(err, services.ErrInvoiceNotFound) {
+			t.Errorf("expected ErrInvoiceNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestInvoiceService_GenerateInvoice(t *testing.T) {
+	s, mock, provider, err := setupInvoiceServiceTest(t)
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	orderID := uint(500)
+	previous := "/uploads/invoices/500/INV-000042-a.pdf"
+
+	expectOrder := func() {
+		mock.ExpectQuery(`SELECT .* FROM "orders" WHERE "orders"."id" = \$1`).
+			WithArgs(orderID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "total_amount", "total_currency", "shipping_first_name", "shipping_line1"}).
+				AddRow(orderID, 1, 5000, "USD", "Ada", "1 Main St"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id", "quantity", "price_amount", "price_currency"}).
+				AddRow(600, orderID, 1000, 2, 2500, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sku"}).AddRow(1000, "Shirt", "SHIRT-1"))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "invoices" WHERE order_id = \$1`).
+			WithArgs(orderID, 1).
+			WillReturnRows(sqlmock.NewRows(invoiceColumns).AddRow(700, orderID, "INV-000042", nil, previous, "application/pdf"))
+		expectOrder()
+
+		var uploaded *multipart.FileHeader
+		var stored string
+		provider.EXPECT().UploadFile(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(file *multipart.FileHeader, path string) (string, error) {
+				uploaded = file
+				stored = "/uploads/" + path
+				return stored, nil
+			})
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "invoices" SET "content_type"=\$1,"document_path"=\$2,"rendered_at"=\$3`).
+			WithArgs("application/pdf", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 700).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		provider.EXPECT().DeleteFile(previous).Return(nil)
+
+		resp, err := s.GenerateInvoice(orderID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !regexp.MustCompile(`^/uploads/invoices/500/INV-000042-[0-9a-f-]{36}\.pdf$`).MatchString(resp.DocumentPath) {
+			t.Errorf("unexpected document path %s", resp.DocumentPath)
+		}
+		if resp.DocumentPath != stored || resp.RenderedAt == nil {
+			t.Errorf("expected the stored document on the invoice, got %+v", resp)
+		}
+		if uploaded.Filename != "INV-000042.pdf" || uploaded.Header.Get("Content-Type") != "application/pdf" || uploaded.Size == 0 {
+			t.Errorf("unexpected uploaded file %s (%s, %d bytes)", uploaded.Filename, uploaded.Header.Get("Content-Type"), uploaded.Size)
+		}
+	})
+
+	t.Run("UploadFails", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "invoices" WHERE order_id = \$1`).
+			WithArgs(orderID, 1).
+			WillReturnRows(sqlmock.NewRows(invoiceColumns).AddRow(700, orderID, "INV-000042", nil, previous, "application/pdf"))
+		expectOrder()
+
+		provider.EXPECT().UploadFile(gomock.Any(), gomock.Any()).Return("", errors.New("disk full"))
+
+		if _, err := s.GenerateInvoice(orderID); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("NotInvoiced", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "invoices" WHERE order_id = \$1`).
+			WithArgs(orderID, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		_, err := s.GenerateInvoice(orderID)
+		if !errors.Is(err, services.ErrInvoiceNotFound) {
+			t.Errorf("expected ErrInvoiceNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,170 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// loyaltyProgram earns a point per dollar and takes a cent off per point,
+// and its points last a year.
+var loyaltyProgram = services.LoyaltyProgram{PointsPerUnit: 1, PointValue: 1, Expiry: 365 * 24 * time.Hour}
+
+var loyaltyOrderColumns = []string{"id", "user_id", "status", "total_amount", "total_currency", "loyalty_points", "loyalty_discount_amount", "loyalty_discount_currency", "exchange_rate", "loyalty_awarded_at"}
+
+func setupLoyaltyServiceTest() (*services.LoyaltyService, sqlmock.Sqlmock, error) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return services.NewLoyaltyService(gormDB, "USD", loyaltyProgram), mock, nil
+}
+
+func TestLoyaltyService_GetLoyalty(t *testing.T) {
+	s, mock, err := setupLoyaltyServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	// Only what remains of the credits not yet expired can be spent
+	mock.ExpectQuery(`SELECT COALESCE\(SUM\(remaining\), 0\) FROM "loyalty_entries" WHERE user_id = \$1 AND remaining > 0 AND \(expires_at IS NULL OR expires_at > \$2\)`).
+		WithArgs(1, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(1500))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "loyalty_entries" WHERE user_id = \$1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(`SELECT \* FROM "loyalty_entries" WHERE user_id = \$1 ORDER BY id DESC LIMIT \$2`).
+		WithArgs(1, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "order_id", "type", "points", "remaining"}).
+			AddRow(61, 1, 501, "redeemed", -500, 0).
+			AddRow(60, 1, 500, "earned", 2000, 1500))
+
+	loyalty, meta, err := s.GetLoyalty(1, 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loyalty.Balance != 1500 || loyalty.BalanceValue != usd(1500) {
+		t.Errorf("expected 1500 points worth 15.00, got %d worth %v", loyalty.Balance, loyalty.BalanceValue)
+	}
+	if len(loyalty.Entries) != 2 || loyalty.Entries[0].Points != -500 {
+		t.Errorf("expected the two entries newest first, got %+v", loyalty.Entries)
+	}
+	if meta.Total != 2 || meta.Page != 1 || meta.Limit != 10 {
+		t.Errorf("unexpected pagination: %+v", meta)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestLoyaltyService_AwardPoints(t *testing.T) {
+	s, mock, err := setupLoyaltyServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	now := time.Now()
+
+	mock.ExpectQuery(`SELECT "id" FROM "orders" WHERE \(status = \$1 AND user_id IS NOT NULL AND loyalty_awarded_at IS NULL AND id > \$2\)`).
+		WithArgs(models.OrderStatusDelivered, 0, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(500).AddRow(501))
+
+	// 123.45 less 20.00 paid with points and 10.00 refunded earns 93 points
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT .* FROM "orders" WHERE \(id = \$1 AND status = \$2 AND loyalty_awarded_at IS NULL\) .* FOR UPDATE`).
+		WithArgs(500, models.OrderStatusDelivered, 1).
+		WillReturnRows(sqlmock.NewRows(loyaltyOrderColumns).AddRow(500, 1, "delivered", 12345, "USD", 2000, 2000, "USD", 1, nil))
+	mock.ExpectQuery(`SELECT COALESCE\(SUM\(refund_amount\), 0\) FROM "returns"`).
+		WithArgs(500, models.ReturnStatusRefunded).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(1000))
+	mock.ExpectQuery(`INSERT INTO "loyalty_entries"`).
+		WithArgs(uint(1), uint(500), models.LoyaltyEntryEarned, 93, 93, now.Add(loyaltyProgram.Expiry), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(60))
+	mock.ExpectExec(`UPDATE "orders" SET "loyalty_awarded_at"=\$1`).
+		WithArgs(now, sqlmock.AnyArg(), 500).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Less than a dollar earns nothing, but the order is still marked
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+		WithArgs(501, models.OrderStatusDelivered, 1).
+		WillReturnRows(sqlmock.NewRows(loyaltyOrderColumns).AddRow(501, 1, "delivered", 50, "USD", 0, 0, "USD", 1, nil))
+	mock.ExpectQuery(`SELECT COALESCE\(SUM\(refund_amount\), 0\) FROM "returns"`).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(0))
+	mock.ExpectExec(`UPDATE "orders" SET "loyalty_awarded_at"=\$1`).
+		WithArgs(now, sqlmock.AnyArg(), 501).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	awarded, err := s.AwardPoints(now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if awarded != 1 {
+		t.Errorf("expected 1 order awarded points, got %d", awarded)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestLoyaltyService_ExpirePoints(t *testing.T) {
+	s, mock, err := setupLoyaltyServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	now := time.Now()
+
+	mock.ExpectQuery(`SELECT "id" FROM "loyalty_entries" WHERE remaining > 0 AND expires_at <= \$1 AND id > \$2 ORDER BY id LIMIT \$3`).
+		WithArgs(now, 0, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(70).AddRow(71))
+
+	// What remains of the lapsed credit is debited
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT .* FROM "loyalty_entries" WHERE id = \$1 AND remaining > 0 AND expires_at <= \$2 .* FOR UPDATE`).
+		WithArgs(70, now, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "order_id", "type", "points", "remaining", "expires_at"}).AddRow(70, 1, 500, "earned", 100, 30, now.Add(-time.Hour)))
+	mock.ExpectExec(`UPDATE "loyalty_entries" SET "remaining"=\$1 WHERE id = \$2`).
+		WithArgs(0, 70).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO "loyalty_entries"`).
+		WithArgs(uint(1), uint(500), models.LoyaltyEntryExpired, -30, 0, nil, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(72))
+	mock.ExpectCommit()
+
+	// Spent in the meantime
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT .* FROM "loyalty_entries" .* FOR UPDATE`).
+		WithArgs(71, now, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	expired, err := s.ExpirePoints(now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expired != 30 {
+		t.Errorf("expected 30 points expired, got %d", expired)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,166 @@
+package services_test
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/kuldeepstechwork/gocart-api/internal/database"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/payments"
+	"github.com/kuldeepstechwork/gocart-api/internal/repositories"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/tax"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDatabase connects to the Postgres database of TEST_DATABASE_DSN,
+// skipping the test when it is not set. The tables are migrated into a
+// schema of their own that is dropped once the test is done, so the
+// database is left as it was.
+func openTestDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to connect to the test database: %v", err)
+	}
+
+	schema := fmt.Sprintf("gocart_test_%d", time.Now().UnixNano())
+	if err := admin.Exec(`CREATE SCHEMA ` + schema).Error; err != nil {
+		t.Fatalf("failed to create the test schema: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Exec(`DROP SCHEMA ` + schema + ` CASCADE`)
+		if sqlDB, err := admin.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	config, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		t.Fatalf("failed to parse TEST_DATABASE_DSN: %v", err)
+	}
+	config.RuntimeParams["search_path"] = schema
+
+	sqlDB := stdlib.OpenDB(*config)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to connect to the test schema: %v", err)
+	}
+
+	if err := database.AutoMigrate(db); err != nil {
+		t.Fatalf("failed to migrate the test schema: %v", err)
+	}
+
+	return db
+}
+
+func TestOrderService_CreateOrder_Concurrent(t *testing.T) {
+	db := openTestDatabase(t)
+
+	const buyers = 10
+
+	category := models.Category{Name: "Limited"}
+	if err := db.Create(&category).Error; err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+
+	product := models.Product{
+		CategoryID: category.ID,
+		Name:       "Last One",
+		SKU:        "LAST-ONE",
+		Price:      money.New(10000, "USD"),
+		Stock:      1,
+	}
+	if err := db.Create(&product).Error; err != nil {
+		t.Fatalf("failed to create product: %v", err)
+	}
+
+	userIDs := make([]uint, buyers)
+	for i := range userIDs {
+		user := models.User{
+			Email:     fmt.Sprintf("buyer%d@example.com", i),
+			Password:  "secret",
+			FirstName: "Buyer",
+			LastName:  fmt.Sprint(i),
+		}
+		if err := db.Create(&user).Error; err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+
+		cart := models.Cart{
+			UserID:    &user.ID,
+			CartItems: []models.CartItem{{ProductID: product.ID, Quantity: 1}},
+		}
+		if err := db.Create(&cart).Error; err != nil {
+			t.Fatalf("failed to create cart: %v", err)
+		}
+
+		userIDs[i] = user.ID
+	}
+
+	taxCalculator := tax.NewTableCalculator(repositories.NewTaxRateRepository(db), tax.PricingExclusive)
+	s := services.NewOrderService(db, "USD", nil, payments.NewFakeProvider(payments.FakeOutcomeSucceed), taxCalculator, nil, services.NewLoyaltyService(db, "USD", loyaltyProgram))
+
+	// Every buyer has read the single unit as in stock by the time any of
+	// them takes it
+	start := make(chan struct{})
+	errs := make([]error, buyers)
+
+	var wg sync.WaitGroup
+	for i, userID := range userIDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, errs[i] = s.CreateOrder(userID, &dto.CreateOrderRequest{}, "")
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	placed := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			placed++
+		case err.Error() != "insufficient stock for product: Last One":
+			t.Errorf("expected insufficient stock error, got %v", err)
+		}
+	}
+	if placed != 1 {
+		t.Errorf("expected exactly one order to be placed, got %d", placed)
+	}
+
+	var stock int
+	if err := db.Model(&models.Product{}).Where("id = ?", product.ID).Pluck("stock", &stock).Error; err != nil {
+		t.Fatalf("failed to read stock: %v", err)
+	}
+	if stock != 0 {
+		t.Errorf("expected stock 0, got %d", stock)
+	}
+
+	var orders int64
+	if err := db.Model(&models.Order{}).Count(&orders).Error; err != nil {
+		t.Fatalf("failed to count orders: %v", err)
+	}
+	if orders != int64(placed) {
+		t.Errorf("expected %d orders, got %d", placed, orders)
+	}
+}
@@ -1,37 +1,85 @@
 package services_test
 
 import (
+	"database/sql/driver"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/exports"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/notifications"
+	"github.com/kuldeepstechwork/gocart-api/internal/payments"
+	"github.com/kuldeepstechwork/gocart-api/internal/repositories"
 	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/tax"
+	"github.com/kuldeepstechwork/gocart-api/test/mocks"
+	"go.uber.org/mock/gomock"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func setupOrderServiceTest() (*services.OrderService, sqlmock.Sqlmock, error) {
+func setupOrderServiceTest(ctrl *gomock.Controller) (*services.OrderService, sqlmock.Sqlmock, *mocks.MockPublisher, *payments.FakeProvider, *mocks.MockInvoiceServiceInterface, error) {
 	sqlDB, mock, err := sqlmock.New()
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, nil, nil, err
 	}
 
 	gormDB, err := gorm.Open(postgres.New(postgres.Config{
 		Conn: sqlDB,
 	}), &gorm.Config{})
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, nil, nil, err
 	}
 
-	return services.NewOrderService(gormDB), mock, nil
+	publisher := mocks.NewMockPublisher(ctrl)
+	provider := payments.NewFakeProvider(payments.FakeOutcomeSucceed)
+
+	taxCalculator := tax.NewTableCalculator(repositories.NewTaxRateRepository(gormDB), tax.PricingExclusive)
+
+	invoices := mocks.NewMockInvoiceServiceInterface(ctrl)
+
+	return services.NewOrderService(gormDB, "USD", publisher, provider, taxCalculator, invoices, services.NewLoyaltyService(gormDB, "USD", loyaltyProgram)), mock, publisher, provider, invoices, nil
+}
+
+// expectNoReservedStock expects the lookup of the stock held by the
+// inventory reservations of other carts, finding none.
+func expectNoReservedStock(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(`SELECT product_id, SUM\(quantity\) AS quantity FROM "inventory_reservations"`).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "quantity"}))
+}
+
+// expectStockTaken expects the quantity to be taken out of the stock of the
+// product, less what other carts hold, leaving stockLeft.
+func expectStockTaken(mock sqlmock.Sqlmock, productID uint, quantity, stockLeft int) {
+	mock.ExpectQuery(`UPDATE "products" SET "stock"=stock - \$1 WHERE \(id = \$2 AND stock \+ backorder_limit - \(SELECT COALESCE\(SUM\(quantity\), 0\) FROM "inventory_reservations" WHERE product_id = \$3 AND expires_at > \$4 AND cart_id <> \$5\) >= \$6\) .*RETURNING "stock"`).
+		WithArgs(quantity, productID, productID, sqlmock.AnyArg(), sqlmock.AnyArg(), quantity).
+		WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(stockLeft))
+}
+
+// expectNoCouponRedeemed expects a cancelled order to have no coupon use to
+// give back.
+func expectNoCouponRedeemed(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(`DELETE FROM "coupon_redemptions" WHERE order_id = \$1 RETURNING \*`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
 }
 
+var paymentColumns = []string{"id", "order_id", "provider", "reference", "status", "amount", "currency", "captured_amount", "captured_currency", "refunded_amount", "refunded_currency"}
+
 func TestOrderService_CreateOrder(t *testing.T) {
-	s, mock, err := setupOrderServiceTest()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mock, _, provider, _, err := setupOrderServiceTest(ctrl)
 	if err != nil {
 		t.Fatalf("failed to setup test: %v", err)
 	}
 
 	userID := uint(1)
+	launch := time.Now().Add(30 * 24 * time.Hour)
 
 	t.Run("Success", func(t *testing.T) {
 		mock.ExpectBegin()
@@ -44,13 +92,15 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 1))
 		mock.ExpectQuery(`SELECT .* FROM "products"`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "price", "stock", "name"}).AddRow(1000, 100.0, 10, "Prod 1"))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name"}).AddRow(1000, 10000, "USD", 10, "Prod 1"))
 
-		// 3. Update Product Stock (tx.Save)
-		mock.ExpectExec(`UPDATE "products" SET`).
-			WillReturnResult(sqlmock.NewResult(0, 1))
+		// 3. Promotions and the stock held by checkout sessions
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectNoReservedStock(mock)
 
-		// 4. Create Order
+		// 4. Take the stock, only while enough is left, and Create Order
+		expectStockTaken(mock, 1000, 1, 9)
 		mock.ExpectQuery(`INSERT INTO "orders"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(500))
 
@@ -58,11 +108,17 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		mock.ExpectQuery(`INSERT INTO "order_items"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(600))
 
-		// 6. Clear Cart (Unscoped)
+		// 6. Record the payment authorization
+		mock.ExpectQuery(`INSERT INTO "payments"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(700))
+
+		// 7. Clear Cart (Unscoped)
 		mock.ExpectExec(`DELETE FROM "cart_items"`).
 			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "inventory_reservations" WHERE cart_id = \$1`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
 
-		// 7. getOrderResponse (Preload Category)
+		// 8. getOrderResponse (Preload Category and Payments)
 		mock.ExpectQuery(`SELECT .* FROM "orders"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(500, userID))
 		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
@@ -71,84 +127,1628 @@ func TestOrderService_CreateOrder(t *testing.T) {
 			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id"}).AddRow(1000, 50))
 		mock.ExpectQuery(`SELECT .* FROM "categories"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(50))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns).AddRow(700, 500, "fake", "fake_1", "authorized", 10000, "USD", 0, "USD", 0, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
 		mock.ExpectCommit()
 
-		resp, err := s.CreateOrder(userID)
+		resp, err := s.CreateOrder(userID, &dto.CreateOrderRequest{}, "")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 		if resp.ID != 500 {
 			t.Errorf("expected order ID 500, got %d", resp.ID)
 		}
+		if len(resp.Payments) != 1 || resp.Payments[0].Status != string(models.PaymentStatusAuthorized) {
+			t.Errorf("expected one authorized payment, got %+v", resp.Payments)
+		}
 	})
-}
 
-func TestOrderService_GetOrders(t *testing.T) {
-	s, mock, err := setupOrderServiceTest()
-	if err != nil {
-		t.Fatalf("failed to setup test: %v", err)
-	}
+	t.Run("PaidByWallet", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			balance       int64
+			walletAmount  int64
+			paymentAmount int64
+		}{
+			{name: "Partly", balance: 4000, walletAmount: 4000, paymentAmount: 6000},
+			{name: "InFull", balance: 15000, walletAmount: 10000},
+		}
 
-	userID := uint(1)
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				mock.ExpectBegin()
 
-	t.Run("Success", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT count\(\*\) FROM "orders"`).
-			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery(`SELECT .* FROM "carts"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+				mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 1))
+				mock.ExpectQuery(`SELECT .* FROM "products"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name"}).AddRow(1000, 10000, "USD", 10, "Prod 1"))
+				mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				expectNoReservedStock(mock)
+
+				expectStockTaken(mock, 1000, 1, 9)
+
+				// The wallet is locked for the order to be paid from it
+				mock.ExpectQuery(`INSERT INTO "wallets" .* ON CONFLICT \("user_id"\) DO NOTHING`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery(`SELECT .* FROM "wallets" WHERE user_id = \$1 .* FOR UPDATE`).
+					WithArgs(userID, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance_amount", "balance_currency"}).AddRow(40, userID, tc.balance, "USD"))
+
+				args := make([]driver.Value, 43)
+				for i := range args {
+					args[i] = sqlmock.AnyArg()
+				}
+				args[37], args[38] = tc.walletAmount, "USD"
+				mock.ExpectQuery(`INSERT INTO "orders"`).
+					WithArgs(args...).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(507))
+				mock.ExpectQuery(`INSERT INTO "order_items"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(607))
+
+				// The wallet is debited for the order
+				mock.ExpectQuery(`INSERT INTO "wallet_entries"`).
+					WithArgs(uint(40), models.WalletEntryDebit, models.WalletEntryOrder, tc.walletAmount, "USD", tc.balance-tc.walletAmount, "USD", nil, uint(507), nil, "", nil, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(41))
+				mock.ExpectExec(`UPDATE "wallets" SET "balance_amount"=\$1,"balance_currency"=\$2`).
+					WithArgs(tc.balance-tc.walletAmount, "USD", sqlmock.AnyArg(), 40).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				// Only the rest is authorized with the payment provider
+				if tc.paymentAmount > 0 {
+					mock.ExpectQuery(`INSERT INTO "payments"`).
+						WithArgs(uint(507), "fake", sqlmock.AnyArg(), models.PaymentStatusAuthorized, tc.paymentAmount, "USD", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(707))
+				}
+
+				mock.ExpectExec(`DELETE FROM "cart_items"`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM "inventory_reservations" WHERE cart_id = \$1`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectQuery(`SELECT .* FROM "orders"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "total_amount", "total_currency", "wallet_amount", "wallet_currency"}).AddRow(507, userID, 10000, "USD", tc.walletAmount, "USD"))
+				mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}))
+				mock.ExpectQuery(`SELECT .* FROM "payments"`).
+					WillReturnRows(sqlmock.NewRows(paymentColumns))
+				mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				mock.ExpectCommit()
+
+				resp, err := s.CreateOrder(userID, &dto.CreateOrderRequest{UseWallet: true}, "")
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if resp.WalletAmount != usd(tc.walletAmount) {
+					t.Errorf("expected %d paid by wallet, got %v", tc.walletAmount, resp.WalletAmount)
+				}
+			})
+		}
+	})
+
+	t.Run("RedeemsPoints", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 1))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name"}).AddRow(1000, 10000, "USD", 10, "Prod 1"))
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectNoReservedStock(mock)
+
+		expectStockTaken(mock, 1000, 1, 9)
+
+		// 2500 points take 25.00 off the order
+		args := make([]driver.Value, 43)
+		for i := range args {
+			args[i] = sqlmock.AnyArg()
+		}
+		args[39], args[40], args[41] = 2500, int64(2500), "USD"
+		mock.ExpectQuery(`INSERT INTO "orders"`).
+			WithArgs(args...).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(508))
+		mock.ExpectQuery(`INSERT INTO "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(608))
+
+		// The credits expiring first are spent first
+		mock.ExpectQuery(`SELECT .* FROM "loyalty_entries" WHERE user_id = \$1 AND remaining > 0 AND \(expires_at IS NULL OR expires_at > \$2\) ORDER BY expires_at, id FOR UPDATE`).
+			WithArgs(userID, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "points", "remaining"}).
+				AddRow(60, userID, "earned", 1000, 1000).
+				AddRow(61, userID, "earned", 5000, 5000))
+		mock.ExpectExec(`UPDATE "loyalty_entries" SET "remaining"=\$1 WHERE id = \$2`).
+			WithArgs(0, 60).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE "loyalty_entries" SET "remaining"=\$1 WHERE id = \$2`).
+			WithArgs(3500, 61).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO "loyalty_entries"`).
+			WithArgs(userID, uint(508), models.LoyaltyEntryRedeemed, -2500, 0, nil, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(62))
+
+		// Only the rest is authorized with the payment provider
+		mock.ExpectQuery(`INSERT INTO "payments"`).
+			WithArgs(uint(508), "fake", sqlmock.AnyArg(), models.PaymentStatusAuthorized, 7500, "USD", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(708))
+
+		mock.ExpectExec(`DELETE FROM "cart_items"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "inventory_reservations" WHERE cart_id = \$1`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		mock.ExpectQuery(`SELECT .* FROM "orders"`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(500, userID))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "total_amount", "total_currency", "loyalty_points", "loyalty_discount_amount", "loyalty_discount_currency"}).AddRow(508, userID, 10000, "USD", 2500, 2500, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		mock.ExpectCommit()
+
+		resp, err := s.CreateOrder(userID, &dto.CreateOrderRequest{RedeemPoints: 2500}, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.LoyaltyPoints != 2500 || resp.LoyaltyDiscount != usd(2500) {
+			t.Errorf("expected 2500 points taking 25.00 off, got %d taking %v", resp.LoyaltyPoints, resp.LoyaltyDiscount)
+		}
+	})
+
+	t.Run("InsufficientPoints", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 1))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name"}).AddRow(1000, 10000, "USD", 10, "Prod 1"))
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectNoReservedStock(mock)
+
+		expectStockTaken(mock, 1000, 1, 9)
+
+		mock.ExpectQuery(`INSERT INTO "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(509))
+		mock.ExpectQuery(`INSERT INTO "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(609))
+
+		// Only 1000 points can be spent
+		mock.ExpectQuery(`SELECT .* FROM "loyalty_entries" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "points", "remaining"}).AddRow(60, userID, "earned", 1000, 1000))
+
+		mock.ExpectRollback()
+
+		_, err := s.CreateOrder(userID, &dto.CreateOrderRequest{RedeemPoints: 2500}, "")
+		if !errors.Is(err, services.ErrInsufficientLoyaltyPoints) {
+			t.Errorf("expected ErrInsufficientLoyaltyPoints, got %v", err)
+		}
+	})
+
+	t.Run("SnapshotsAddresses", func(t *testing.T) {
+		shippingID := uint(30)
+
+		mock.ExpectBegin()
+
+		// The shipping address is copied onto the order and reused for billing
+		mock.ExpectQuery(`SELECT .* FROM "addresses" WHERE \(id = \$1 AND user_id = \$2\)`).
+			WithArgs(shippingID, userID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "first_name", "line1", "city", "postal_code", "country"}).
+				AddRow(shippingID, userID, "Ada", "1 Main St", "Springfield", "12345", "US"))
+
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 1))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name"}).AddRow(1000, 10000, "USD", 10, "Prod 1"))
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectNoReservedStock(mock)
+		mock.ExpectQuery(`SELECT .* FROM "tax_rates"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectStockTaken(mock, 1000, 1, 9)
+		mock.ExpectQuery(`INSERT INTO "orders" .*"shipping_line1".*"billing_line1"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(502))
+		mock.ExpectQuery(`INSERT INTO "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(602))
+		mock.ExpectQuery(`INSERT INTO "payments"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(702))
+		mock.ExpectExec(`DELETE FROM "cart_items"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "inventory_reservations" WHERE cart_id = \$1`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
 
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "shipping_line1", "shipping_city", "billing_line1", "billing_city"}).
+				AddRow(502, userID, "1 Main St", "Springfield", "1 Main St", "Springfield"))
 		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}).AddRow(600, 500, 1000))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		mock.ExpectCommit()
+
+		resp, err := s.CreateOrder(userID, &dto.CreateOrderRequest{ShippingAddressID: &shippingID}, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ShippingAddress == nil || resp.ShippingAddress.Line1 != "1 Main St" {
+			t.Errorf("expected shipping address snapshot, got %+v", resp.ShippingAddress)
+		}
+		if resp.BillingAddress == nil || resp.BillingAddress.City != "Springfield" {
+			t.Errorf("expected billing address snapshot, got %+v", resp.BillingAddress)
+		}
+	})
+
+	t.Run("ChargesSelectedShipping", func(t *testing.T) {
+		shippingID := uint(30)
+
+		mock.ExpectBegin()
 
+		mock.ExpectQuery(`SELECT .* FROM "addresses"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "line1", "postal_code", "country"}).
+				AddRow(shippingID, userID, "1 Main St", "94105", "US"))
+
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "shipping_rate_id"}).AddRow(10, userID, 41))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 2))
 		mock.ExpectQuery(`SELECT .* FROM "products"`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id"}).AddRow(1000, 50))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name", "weight"}).AddRow(1000, 10000, "USD", 10, "Prod 1", 1.5))
+
+		// The selected rate is quoted again for the shipping address
+		mock.ExpectQuery(`SELECT .* FROM "shipping_zones"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "is_active"}).AddRow(4, "Domestic", true))
+		mock.ExpectQuery(`SELECT .* FROM "shipping_rates"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "shipping_zone_id", "name", "type", "price_amount", "price_currency", "min_weight", "max_weight", "is_active"}).
+				AddRow(40, 4, "Standard", "weight", 500, "USD", 0, 1, true).
+				AddRow(41, 4, "Standard", "weight", 950, "USD", 1, 5, true))
+		mock.ExpectQuery(`SELECT .* FROM "shipping_zone_regions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "shipping_zone_id", "country"}).AddRow(1, 4, "US"))
+
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectNoReservedStock(mock)
+		mock.ExpectQuery(`SELECT .* FROM "tax_rates"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectStockTaken(mock, 1000, 2, 8)
+		mock.ExpectQuery(`INSERT INTO "orders" .*"shipping_method".*"shipping_cost_amount"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(503))
+		mock.ExpectQuery(`INSERT INTO "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(603))
+
+		// The payment covers the items and the shipping
+		mock.ExpectQuery(`INSERT INTO "payments"`).
+			WithArgs(uint(503), "fake", sqlmock.AnyArg(), models.PaymentStatusAuthorized, 20950, "USD", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(703))
+		mock.ExpectExec(`DELETE FROM "cart_items"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "inventory_reservations" WHERE cart_id = \$1`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE "carts" SET "shipping_rate_id"=\$1`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "total_amount", "total_currency", "shipping_method", "shipping_cost_amount", "shipping_cost_currency"}).
+				AddRow(503, userID, 20950, "USD", "Standard", 950, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		mock.ExpectCommit()
+
+		resp, err := s.CreateOrder(userID, &dto.CreateOrderRequest{ShippingAddressID: &shippingID}, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ShippingMethod != "Standard" || resp.ShippingCost != usd(950) {
+			t.Errorf("expected Standard shipping for 9.5, got %s for %v", resp.ShippingMethod, resp.ShippingCost)
+		}
+	})
+
+	t.Run("TaxesLines", func(t *testing.T) {
+		shippingID := uint(30)
+
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "addresses"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "line1", "state", "postal_code", "country"}).
+				AddRow(shippingID, userID, "1 Main St", "CA", "94105", "US"))
+
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 1))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name", "tax_class"}).AddRow(1000, 10000, "USD", 10, "Prod 1", "standard"))
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectNoReservedStock(mock)
+
+		// The rates for the destination are looked up once for all lines
+		mock.ExpectQuery(`SELECT .* FROM "tax_rates"`).
+			WithArgs("US", "CA", true).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "country", "state", "tax_class", "rate", "is_active"}).
+				AddRow(1, "US", "US", "", "standard", 20.0, true))
+		expectStockTaken(mock, 1000, 1, 9)
+		mock.ExpectQuery(`INSERT INTO "orders" .*"tax_amount"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(504))
+		mock.ExpectQuery(`INSERT INTO "order_items" .*"tax_rate".*"tax_amount"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(604))
+
+		// Prices exclude tax, so the payment covers the price plus the tax
+		mock.ExpectQuery(`INSERT INTO "payments"`).
+			WithArgs(uint(504), "fake", sqlmock.AnyArg(), models.PaymentStatusAuthorized, 12000, "USD", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(704))
+		mock.ExpectExec(`DELETE FROM "cart_items"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "inventory_reservations" WHERE cart_id = \$1`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
 
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "total_amount", "total_currency", "tax_amount", "tax_currency"}).
+				AddRow(504, userID, 12000, "USD", 2000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id", "tax_class", "tax_rate", "tax_amount", "tax_currency"}).
+				AddRow(604, 504, 1000, "standard", 20.0, 2000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id"}).AddRow(1000, 50))
 		mock.ExpectQuery(`SELECT .* FROM "categories"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(50))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
-		resp, meta, err := s.GetOrders(userID, 1, 10)
+		mock.ExpectCommit()
+
+		resp, err := s.CreateOrder(userID, &dto.CreateOrderRequest{ShippingAddressID: &shippingID}, "")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if len(resp) != 1 {
-			t.Errorf("expected 1 order, got %d", len(resp))
+		if resp.TaxAmount != usd(2000) || resp.TotalAmount != usd(12000) {
+			t.Errorf("expected tax 20 on a total of 120, got %v on %v", resp.TaxAmount, resp.TotalAmount)
 		}
-		if meta.Total != 1 {
-			t.Errorf("expected total 1, got %d", meta.Total)
+		if len(resp.OrderItems) != 1 || resp.OrderItems[0].TaxRate != 20 {
+			t.Errorf("expected the line to carry its tax rate, got %+v", resp.OrderItems)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
 		}
 	})
-}
 
-func TestOrderService_GetOrder(t *testing.T) {
-	s, mock, err := setupOrderServiceTest()
-	if err != nil {
-		t.Fatalf("failed to setup test: %v", err)
-	}
+	t.Run("AppliesCoupon", func(t *testing.T) {
+		shippingID := uint(30)
 
-	userID := uint(1)
-	orderID := uint(500)
+		mock.ExpectBegin()
 
-	t.Run("Success", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT .* FROM "orders"`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(orderID, userID))
+		mock.ExpectQuery(`SELECT .* FROM "addresses"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "line1", "postal_code", "country"}).
+				AddRow(shippingID, userID, "1 Main St", "94105", "US"))
+
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "coupon_id"}).AddRow(10, userID, 5))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 1))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name", "tax_class"}).AddRow(1000, 10000, "USD", 10, "Prod 1", "standard"))
+
+		// The coupon is checked again
+		mock.ExpectQuery(`SELECT .* FROM "coupons"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "code", "type", "percent", "usage_limit", "used_count", "is_active"}).
+				AddRow(5, "SAVE10", "percentage", 10.0, 100, 4, true))
+		mock.ExpectQuery(`SELECT .* FROM "coupon_categories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"coupon_id", "category_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "coupon_products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"coupon_id", "product_id"}))
+
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectNoReservedStock(mock)
+
+		// The line is taxed after its discount
+		mock.ExpectQuery(`SELECT .* FROM "tax_rates"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "country", "state", "tax_class", "rate", "is_active"}).
+				AddRow(1, "US", "", "standard", 20.0, true))
+		// The coupon is redeemed once the order is priced, locked while its
+		// uses are claimed
+		mock.ExpectQuery(`SELECT \* FROM "coupons" WHERE "coupons"."id" = \$1 .* FOR UPDATE`).
+			WithArgs(5, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "code", "usage_limit", "used_count", "is_active"}).AddRow(5, "SAVE10", 100, 4, true))
+		mock.ExpectExec(`UPDATE "coupons" SET "used_count"=used_count \+ 1 WHERE \(id = \$1 AND \(usage_limit = 0 OR used_count < usage_limit\)\)`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectStockTaken(mock, 1000, 1, 9)
+		mock.ExpectQuery(`INSERT INTO "orders" .*"coupon_code".*"discount_amount"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(505))
+		mock.ExpectQuery(`INSERT INTO "order_items" .*"discount_amount"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(605))
+		mock.ExpectQuery(`INSERT INTO "coupon_redemptions"`).
+			WithArgs(uint(5), userID, uint(505), 1000, "USD", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery(`INSERT INTO "payments"`).
+			WithArgs(uint(505), "fake", sqlmock.AnyArg(), models.PaymentStatusAuthorized, 10800, "USD", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(705))
+		mock.ExpectExec(`DELETE FROM "cart_items"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "inventory_reservations" WHERE cart_id = \$1`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE "carts" SET "coupon_id"=\$1`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
 
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "total_amount", "total_currency", "tax_amount", "tax_currency", "coupon_code", "discount_amount", "discount_currency"}).
+				AddRow(505, userID, 10800, "USD", 1800, "USD", "SAVE10", 1000, "USD"))
 		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}).AddRow(600, orderID, 1000))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		mock.ExpectCommit()
+
+		resp, err := s.CreateOrder(userID, &dto.CreateOrderRequest{ShippingAddressID: &shippingID}, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.CouponCode != "SAVE10" || resp.DiscountAmount != usd(1000) {
+			t.Errorf("expected SAVE10 taking 10 off, got %q taking %v off", resp.CouponCode, resp.DiscountAmount)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("AppliesPromotions", func(t *testing.T) {
+		mock.ExpectBegin()
 
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 2))
 		mock.ExpectQuery(`SELECT .* FROM "products"`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id"}).AddRow(1000, 50))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name"}).AddRow(1000, 10000, "USD", 10, "Prod 1"))
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "type", "discount_percent", "stackable", "is_active"}).
+				AddRow(8, "Flash sale", "flash_sale", 10.0, true, true))
+		mock.ExpectQuery(`SELECT .* FROM "promotion_tiers"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "promotion_id"}))
+		expectNoReservedStock(mock)
+		expectStockTaken(mock, 1000, 2, 8)
+		mock.ExpectQuery(`INSERT INTO "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(506))
+		mock.ExpectQuery(`INSERT INTO "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(606))
 
-		mock.ExpectQuery(`SELECT .* FROM "categories"`).
-			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(50))
+		// The promotion is recorded with what it took off the order
+		mock.ExpectQuery(`INSERT INTO "order_promotions"`).
+			WithArgs(uint(506), uint(8), "Flash sale", 2000, "USD", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery(`INSERT INTO "payments"`).
+			WithArgs(uint(506), "fake", sqlmock.AnyArg(), models.PaymentStatusAuthorized, 18000, "USD", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(706))
+		mock.ExpectExec(`DELETE FROM "cart_items"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "inventory_reservations" WHERE cart_id = \$1`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
 
-		resp, err := s.GetOrder(userID, orderID)
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "total_amount", "total_currency", "discount_amount", "discount_currency"}).
+				AddRow(506, userID, 18000, "USD", 2000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "promotion_id", "name", "discount_amount", "discount_currency"}).
+				AddRow(1, 506, 8, "Flash sale", 2000, "USD"))
+
+		mock.ExpectCommit()
+
+		resp, err := s.CreateOrder(userID, &dto.CreateOrderRequest{}, "")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if resp.ID != orderID {
-			t.Errorf("expected order ID %d, got %d", orderID, resp.ID)
+		if resp.DiscountAmount != usd(2000) || len(resp.Promotions) != 1 || resp.Promotions[0].Name != "Flash sale" {
+			t.Errorf("expected the flash sale taking 20 off, got %+v with discount %v", resp.Promotions, resp.DiscountAmount)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("CouponUsedUp", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "coupon_id"}).AddRow(10, userID, 5))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 1))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name"}).AddRow(1000, 10000, "USD", 10, "Prod 1"))
+		mock.ExpectQuery(`SELECT .* FROM "coupons"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "code", "type", "percent", "usage_limit", "used_count", "is_active"}).
+				AddRow(5, "SAVE10", "percentage", 10.0, 100, 99, true))
+		mock.ExpectQuery(`SELECT .* FROM "coupon_categories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"coupon_id", "category_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "coupon_products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"coupon_id", "product_id"}))
+
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectNoReservedStock(mock)
+
+		// Another order claimed the last use in the meantime
+		mock.ExpectQuery(`SELECT \* FROM "coupons" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "code", "usage_limit", "used_count", "is_active"}).AddRow(5, "SAVE10", 100, 100, true))
+		mock.ExpectExec(`UPDATE "coupons" SET "used_count"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		mock.ExpectRollback()
+
+		_, err := s.CreateOrder(userID, &dto.CreateOrderRequest{}, "")
+		if !errors.Is(err, services.ErrCouponNotApplicable) {
+			t.Errorf("expected ErrCouponNotApplicable, got %v", err)
+		}
+	})
+
+	t.Run("CouponUsedUpByCustomer", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "coupon_id"}).AddRow(10, userID, 5))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 1))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name"}).AddRow(1000, 10000, "USD", 10, "Prod 1"))
+		mock.ExpectQuery(`SELECT .* FROM "coupons"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "code", "type", "percent", "per_customer_limit", "is_active"}).
+				AddRow(5, "ONCE", "percentage", 10.0, 1, true))
+		mock.ExpectQuery(`SELECT .* FROM "coupon_categories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"coupon_id", "category_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "coupon_products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"coupon_id", "product_id"}))
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "coupon_redemptions" WHERE coupon_id = \$1 AND user_id = \$2`).
+			WithArgs(5, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectNoReservedStock(mock)
+
+		// Another order of the customer redeemed the coupon in the meantime,
+		// which shows once the coupon is locked
+		mock.ExpectQuery(`SELECT \* FROM "coupons" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "code", "per_customer_limit", "is_active"}).AddRow(5, "ONCE", 1, true))
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "coupon_redemptions" WHERE coupon_id = \$1 AND user_id = \$2`).
+			WithArgs(5, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		mock.ExpectRollback()
+
+		_, err := s.CreateOrder(userID, &dto.CreateOrderRequest{}, "")
+		if !errors.Is(err, services.ErrCouponNotApplicable) {
+			t.Errorf("expected ErrCouponNotApplicable, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
 		}
 	})
+
+	t.Run("StockSoldConcurrently", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 1))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name"}).AddRow(1000, 10000, "USD", 1, "Prod 1"))
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectNoReservedStock(mock)
+
+		// Another order took the last unit, or another cart's session came to
+		// hold it, after the stock was read
+		mock.ExpectQuery(`UPDATE "products" SET "stock"=stock - \$1 WHERE \(id = \$2 AND stock \+ backorder_limit - \(SELECT COALESCE\(SUM\(quantity\), 0\) FROM "inventory_reservations" WHERE product_id = \$3 AND expires_at > \$4 AND cart_id <> \$5\) >= \$6\)`).
+			WithArgs(1, 1000, 1000, sqlmock.AnyArg(), 10, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"stock"}))
+
+		mock.ExpectRollback()
+
+		_, err := s.CreateOrder(userID, &dto.CreateOrderRequest{}, "")
+		if err == nil || err.Error() != "insufficient stock for product: Prod 1" {
+			t.Errorf("expected insufficient stock error, got %v", err)
+		}
+	})
+
+	for _, tc := range []struct {
+		name            string
+		policy          string
+		availableAt     *time.Time
+		stockLeft       int
+		wantStatus      models.StockStatus
+		wantBackordered int
+	}{
+		// 1 of the 3 ordered was in stock
+		{name: "Backorders", policy: "backorder", stockLeft: -2, wantStatus: models.StockStatusBackordered, wantBackordered: 2},
+		{name: "BackorderInStock", policy: "backorder", stockLeft: 0, wantStatus: models.StockStatusInStock},
+		// Every unit ordered ahead of the availability date is preordered
+		{name: "Preorders", policy: "preorder", availableAt: &launch, stockLeft: 7, wantStatus: models.StockStatusPreordered, wantBackordered: 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var availableAt driver.Value
+			if tc.availableAt != nil {
+				availableAt = *tc.availableAt
+			}
+
+			mock.ExpectBegin()
+
+			mock.ExpectQuery(`SELECT .* FROM "carts"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+			mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 3))
+			mock.ExpectQuery(`SELECT .* FROM "products"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name", "inventory_policy", "backorder_limit", "available_at"}).
+					AddRow(1000, 10000, "USD", tc.stockLeft+3, "Prod 1", tc.policy, 5, availableAt))
+			mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			expectNoReservedStock(mock)
+			expectStockTaken(mock, 1000, 3, tc.stockLeft)
+			mock.ExpectQuery(`INSERT INTO "orders"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(502))
+
+			mock.ExpectQuery(`INSERT INTO "order_items"`).
+				WithArgs(502, 1000, 3, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+					tc.wantStatus, tc.wantBackordered, availableAt, "Prod 1", "").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(602))
+			mock.ExpectQuery(`INSERT INTO "payments"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(702))
+			mock.ExpectExec(`DELETE FROM "cart_items"`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(`DELETE FROM "inventory_reservations" WHERE cart_id = \$1`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			mock.ExpectQuery(`SELECT .* FROM "orders"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(502, userID))
+			mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id", "stock_status", "backordered_quantity"}).
+					AddRow(602, 502, 1000, tc.wantStatus, tc.wantBackordered))
+			mock.ExpectQuery(`SELECT .* FROM "products"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1000))
+			mock.ExpectQuery(`SELECT .* FROM "payments"`).
+				WillReturnRows(sqlmock.NewRows(paymentColumns))
+			mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			mock.ExpectCommit()
+
+			resp, err := s.CreateOrder(userID, &dto.CreateOrderRequest{}, "")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if item := resp.OrderItems[0]; item.StockStatus != string(tc.wantStatus) || item.BackorderedQuantity != tc.wantBackordered {
+				t.Errorf("expected %s with %d backordered, got %s with %d", tc.wantStatus, tc.wantBackordered, item.StockStatus, item.BackorderedQuantity)
+			}
+		})
+	}
+
+	t.Run("ShippingAddressRequired", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "shipping_rate_id"}).AddRow(10, userID, 41))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 1))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name"}).AddRow(1000, 10000, "USD", 10, "Prod 1"))
+
+		mock.ExpectRollback()
+
+		_, err := s.CreateOrder(userID, &dto.CreateOrderRequest{}, "")
+		if !errors.Is(err, services.ErrShippingAddressRequired) {
+			t.Errorf("expected ErrShippingAddressRequired, got %v", err)
+		}
+	})
+
+	t.Run("AddressNotFound", func(t *testing.T) {
+		otherUsersAddress := uint(31)
+
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "addresses"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		mock.ExpectRollback()
+
+		_, err := s.CreateOrder(userID, &dto.CreateOrderRequest{ShippingAddressID: &otherUsersAddress}, "")
+		if !errors.Is(err, services.ErrAddressNotFound) {
+			t.Errorf("expected ErrAddressNotFound, got %v", err)
+		}
+	})
+
+	for _, tc := range []struct {
+		name    string
+		outcome payments.FakeOutcome
+		wantErr error
+	}{
+		{name: "PaymentDeclined", outcome: payments.FakeOutcomeDecline, wantErr: payments.ErrPaymentDeclined},
+		{name: "PaymentTimeout", outcome: payments.FakeOutcomeTimeout, wantErr: payments.ErrPaymentTimeout},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			provider.SetOutcome(tc.outcome)
+			defer provider.SetOutcome(payments.FakeOutcomeSucceed)
+
+			mock.ExpectBegin()
+
+			mock.ExpectQuery(`SELECT .* FROM "carts"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+			mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 1))
+			mock.ExpectQuery(`SELECT .* FROM "products"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name"}).AddRow(1000, 10000, "USD", 10, "Prod 1"))
+			mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			expectNoReservedStock(mock)
+			expectStockTaken(mock, 1000, 1, 9)
+			mock.ExpectQuery(`INSERT INTO "orders"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(501))
+			mock.ExpectQuery(`INSERT INTO "order_items"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(601))
+
+			// The failed authorization rolls back the stock update and the order
+			mock.ExpectRollback()
+
+			_, err := s.CreateOrder(userID, &dto.CreateOrderRequest{}, "")
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestOrderService_CreateOrder_VoidsAuthorizationOnRollback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mock, _, provider, _, err := setupOrderServiceTest(ctrl)
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+
+	mock.ExpectBegin()
+
+	mock.ExpectQuery(`SELECT .* FROM "carts"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, userID))
+	mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(100, 10, 1000, 1))
+	mock.ExpectQuery(`SELECT .* FROM "products"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name"}).AddRow(1000, 10000, "USD", 10, "Prod 1"))
+	mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	expectNoReservedStock(mock)
+
+	expectStockTaken(mock, 1000, 1, 9)
+	mock.ExpectQuery(`INSERT INTO "orders"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(500))
+	mock.ExpectQuery(`INSERT INTO "order_items"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(600))
+	mock.ExpectQuery(`INSERT INTO "payments"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(700))
+
+	// The order fails after its payment was authorized
+	mock.ExpectExec(`DELETE FROM "cart_items"`).
+		WillReturnError(errors.New("connection reset"))
+
+	mock.ExpectRollback()
+
+	if _, err := s.CreateOrder(userID, &dto.CreateOrderRequest{}, ""); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	// The authorization was voided, leaving no hold on the card
+	if _, err := provider.Capture("fake_1", usd(10000)); !errors.Is(err, payments.ErrInvalidPaymentOperation) {
+		t.Errorf("expected the authorization to be voided, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestOrderService_CreateGuestOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mock, publisher, _, _, err := setupOrderServiceTest(ctrl)
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	token := "3f0c8a4e-5d1b-4f6a-9c2e-7b8d9e0f1a2b"
+	req := &dto.GuestOrderRequest{
+		Email: " Ada@Example.com ",
+		ShippingAddress: dto.AddressRequest{
+			FirstName: "Ada", LastName: "Lovelace", Line1: "1 Main St",
+			City: "Springfield", PostalCode: "12345", Country: "us",
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "carts" WHERE token = \$1`).
+			WithArgs(token, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "token"}).AddRow(20, token))
+		mock.ExpectQuery(`SELECT .* FROM "cart_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cart_id", "product_id", "quantity"}).AddRow(200, 20, 1000, 1))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name"}).AddRow(1000, 10000, "USD", 10, "Prod 1"))
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectNoReservedStock(mock)
+		mock.ExpectQuery(`SELECT .* FROM "tax_rates"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		expectStockTaken(mock, 1000, 1, 9)
+
+		// The order has a number and the guest's email but no user, and
+		// ships to the address snapshot
+		args := make([]driver.Value, 43)
+		for i := range args {
+			args[i] = sqlmock.AnyArg()
+		}
+		args[1], args[2], args[18], args[23] = nil, "ada@example.com", "1 Main St", "US"
+		mock.ExpectQuery(`INSERT INTO "orders" \("number","user_id","guest_email",`).
+			WithArgs(args...).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(510))
+		mock.ExpectQuery(`INSERT INTO "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(610))
+		mock.ExpectQuery(`INSERT INTO "payments"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(710))
+		mock.ExpectExec(`DELETE FROM "cart_items"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "inventory_reservations" WHERE cart_id = \$1`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "number", "guest_email"}).AddRow(510, "GC-7KQ2M9XW4P", "ada@example.com"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		mock.ExpectCommit()
+
+		publisher.EXPECT().Publish(notifications.GuestOrderPlaced, gomock.Any(), map[string]string{
+			"order_id":     "510",
+			"order_number": "GC-7KQ2M9XW4P",
+			"email":        "ada@example.com",
+		}).Return(nil)
+
+		resp, err := s.CreateGuestOrder(token, req, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ID != 510 || resp.Number != "GC-7KQ2M9XW4P" || resp.UserID != 0 {
+			t.Errorf("unexpected order %+v", resp)
+		}
+	})
+
+	t.Run("CartNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "carts"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+		mock.ExpectRollback()
+
+		if _, err := s.CreateGuestOrder("unknown", req, ""); !errors.Is(err, services.ErrCartNotFound) {
+			t.Errorf("expected ErrCartNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestOrderService_LookupGuestOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mock, _, _, _, err := setupOrderServiceTest(ctrl)
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("Found", func(t *testing.T) {
+		// The email matches in any case
+		mock.ExpectQuery(`SELECT "id" FROM "orders" WHERE \(number = \$1 AND LOWER\(guest_email\) = \$2\)`).
+			WithArgs("GC-7KQ2M9XW4P", "ada@example.com", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(510))
+
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "number", "guest_email"}).AddRow(510, "GC-7KQ2M9XW4P", "ada@example.com"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		resp, err := s.LookupGuestOrder(&dto.GuestOrderLookupRequest{Number: "GC-7KQ2M9XW4P", Email: "Ada@Example.com"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ID != 510 || resp.GuestEmail != "ada@example.com" {
+			t.Errorf("unexpected order %+v", resp)
+		}
+	})
+
+	t.Run("WrongEmail", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT "id" FROM "orders"`).
+			WithArgs("GC-7KQ2M9XW4P", "eve@example.com", 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		_, err := s.LookupGuestOrder(&dto.GuestOrderLookupRequest{Number: "GC-7KQ2M9XW4P", Email: "eve@example.com"})
+		if !errors.Is(err, services.ErrOrderNotFound) {
+			t.Errorf("expected ErrOrderNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestOrderService_GetOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mock, _, _, _, err := setupOrderServiceTest(ctrl)
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(500, userID))
+
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}).AddRow(600, 500, 1000))
+
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id"}).AddRow(1000, 50))
+
+		mock.ExpectQuery(`SELECT .* FROM "categories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(50))
+
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		resp, meta, err := s.GetOrders(userID, 1, 10)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp) != 1 {
+			t.Errorf("expected 1 order, got %d", len(resp))
+		}
+		if meta.Total != 1 {
+			t.Errorf("expected total 1, got %d", meta.Total)
+		}
+	})
+}
+
+func TestOrderService_ListOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mock, _, _, _, err := setupOrderServiceTest(ctrl)
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("Filters", func(t *testing.T) {
+		from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		// The email matches customers' orders and guest orders alike
+		filters := `WHERE orders.status = \$1 AND orders.created_at >= \$2 AND \(` +
+			`orders.user_id IN \(SELECT id FROM users WHERE LOWER\(email\) = LOWER\(\$3\)\) OR LOWER\(orders.guest_email\) = LOWER\(\$4\)\) AND ` +
+			`orders.total_currency = \$5 AND orders.total_amount >= \$6 AND orders.total_amount <= \$7 AND \(` +
+			`EXISTS \(SELECT 1 FROM order_items JOIN products ON products.id = order_items.product_id\s+` +
+			`WHERE order_items.order_id = orders.id AND order_items.deleted_at IS NULL AND products.sku = \$8\)\)`
+		args := []driver.Value{"confirmed", from, "Jane@Example.com", "Jane@Example.com", "USD", int64(1000), int64(5000), "SKU-1"}
+
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "orders" ` + filters).
+			WithArgs(args...).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+
+		mock.ExpectQuery(`SELECT \* FROM "orders" ` + filters + `.* ORDER BY orders.total_amount ASC, orders.id ASC LIMIT \$9 OFFSET \$10`).
+			WithArgs(append(args, 10, 10)...).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "total_amount", "total_currency"}).
+				AddRow(500, 2, "confirmed", 2500, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		resp, meta, err := s.ListOrders(&dto.ListOrdersRequest{
+			Status:      "confirmed",
+			CreatedFrom: &from,
+			Email:       " Jane@Example.com ",
+			MinTotal:    &money.Money{Amount: 1000, Currency: "USD"},
+			MaxTotal:    &money.Money{Amount: 5000},
+			SKU:         "SKU-1",
+			Sort:        "total",
+			Page:        2,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp) != 1 || resp[0].TotalAmount != usd(2500) {
+			t.Errorf("expected the 25.00 order, got %+v", resp)
+		}
+		if meta.Total != 11 || meta.TotalPages != 2 {
+			t.Errorf("expected 11 orders on 2 pages, got %+v", meta)
+		}
+	})
+
+	t.Run("NewestFirstByDefault", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "orders" WHERE "orders"."deleted_at" IS NULL`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(`SELECT \* FROM "orders" .* ORDER BY orders.created_at DESC, orders.id DESC`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		if _, _, err := s.ListOrders(&dto.ListOrdersRequest{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("InvalidFilters", func(t *testing.T) {
+		from := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+		to := from.AddDate(0, 0, -1)
+
+		tests := map[string]struct {
+			req  dto.ListOrdersRequest
+			want error
+		}{
+			"UnknownStatus":  {dto.ListOrdersRequest{Status: "lost"}, services.ErrInvalidOrderStatus},
+			"UnknownSort":    {dto.ListOrdersRequest{Sort: "-email"}, services.ErrInvalidOrderFilter},
+			"InvertedDates":  {dto.ListOrdersRequest{CreatedFrom: &from, CreatedTo: &to}, services.ErrInvalidOrderFilter},
+			"InvertedTotals": {dto.ListOrdersRequest{MinTotal: &money.Money{Amount: 500}, MaxTotal: &money.Money{Amount: 100}}, services.ErrInvalidOrderFilter},
+		}
+		for name, tt := range tests {
+			if _, _, err := s.ListOrders(&tt.req); !errors.Is(err, tt.want) {
+				t.Errorf("%s: expected %v, got %v", name, tt.want, err)
+			}
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestOrderService_GetOrderDetail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mock, _, _, _, err := setupOrderServiceTest(ctrl)
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	orderID := uint(500)
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, 2, "confirmed"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(`SELECT \* FROM "order_status_histories" WHERE "order_status_histories"."order_id" = \$1 ORDER BY created_at, id`).
+			WithArgs(orderID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "from_status", "to_status", "changed_by", "reason"}).
+				AddRow(1, orderID, "pending", "confirmed", 1, "paid"))
+		mock.ExpectQuery(`SELECT .* FROM "users"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email", "first_name", "last_name"}).AddRow(2, "jane@example.com", "Jane", "Doe"))
+
+		resp, err := s.GetOrderDetail(orderID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ID != orderID || resp.Customer.Email != "jane@example.com" {
+			t.Errorf("expected order %d of jane@example.com, got %+v", orderID, resp)
+		}
+		if len(resp.StatusHistory) != 1 || resp.StatusHistory[0].ToStatus != "confirmed" || resp.StatusHistory[0].Reason != "paid" {
+			t.Errorf("expected the confirmation in the history, got %+v", resp.StatusHistory)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		if _, err := s.GetOrderDetail(99); !errors.Is(err, services.ErrOrderNotFound) {
+			t.Errorf("expected ErrOrderNotFound, got %v", err)
+		}
+	})
+}
+
+// recordingExportWriter keeps the exported orders in memory.
+type recordingExportWriter struct {
+	orders  []exports.Order
+	flushed bool
+}
+
+func (w *recordingExportWriter) Write(order *exports.Order) error {
+	w.orders = append(w.orders, *order)
+	return nil
+}
+
+func (w *recordingExportWriter) Flush() error {
+	w.flushed = true
+	return nil
+}
+
+func TestOrderService_ExportOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mock, _, _, _, err := setupOrderServiceTest(ctrl)
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("GroupsItemsByOrder", func(t *testing.T) {
+		columns := []string{
+			"id", "status", "user_id", "email", "total_amount", "total_currency", "exchange_rate",
+			"item_id", "product_id", "sku", "product_name", "quantity", "item_price_amount", "item_price_currency",
+		}
+
+		// Guest orders are exported with the guest's email
+		mock.ExpectQuery(`SELECT orders.id, orders.status, orders.user_id, COALESCE\(users.email, orders.guest_email\) AS email, .* FROM "orders" ` +
+			`LEFT JOIN users ON users.id = orders.user_id ` +
+			`LEFT JOIN order_items ON order_items.order_id = orders.id AND order_items.deleted_at IS NULL ` +
+			`LEFT JOIN products ON products.id = order_items.product_id ` +
+			`WHERE orders.status = \$1 AND "orders"."deleted_at" IS NULL ` +
+			`ORDER BY orders.total_amount DESC, orders.id DESC, order_items.id`).
+			WithArgs("confirmed").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(501, "confirmed", 2, "ada@example.com", 4500, "USD", 1, 10, 1, "MUG-1", "Mug", 1, 999, "USD").
+				AddRow(501, "confirmed", 2, "ada@example.com", 4500, "USD", 1, 11, 2, "CUP-1", "Cup", 3, 1167, "USD").
+				AddRow(500, "confirmed", nil, "grace@example.com", 2500, "EUR", 0.92, 0, 0, "", "", 0, 0, ""))
+
+		w := &recordingExportWriter{}
+		if err := s.ExportOrders(&dto.ListOrdersRequest{Status: "confirmed", Sort: "-total", Page: 3, Limit: 1}, w); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(w.orders) != 2 || !w.flushed {
+			t.Fatalf("expected 2 orders and a flush, got %+v", w)
+		}
+		if first := w.orders[0]; first.ID != 501 || first.CustomerEmail != "ada@example.com" || first.TotalAmount != usd(4500) || len(first.Items) != 2 {
+			t.Errorf("expected order 501 with 2 items, got %+v", first)
+		}
+		if item := w.orders[0].Items[1]; item.SKU != "CUP-1" || item.Quantity != 3 || item.UnitPrice != usd(1167) {
+			t.Errorf("unexpected second item %+v", item)
+		}
+		if second := w.orders[1]; second.ID != 500 || second.CustomerEmail != "grace@example.com" || second.ExchangeRate != 0.92 || len(second.Items) != 0 {
+			t.Errorf("expected guest order 500 without items, got %+v", second)
+		}
+	})
+
+	t.Run("InvalidFilter", func(t *testing.T) {
+		w := &recordingExportWriter{}
+		if err := s.ExportOrders(&dto.ListOrdersRequest{Sort: "email"}, w); !errors.Is(err, services.ErrInvalidOrderFilter) {
+			t.Errorf("expected ErrInvalidOrderFilter, got %v", err)
+		}
+		if len(w.orders) != 0 || w.flushed {
+			t.Errorf("expected nothing to be written, got %+v", w)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestOrderService_GetOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mock, _, _, _, err := setupOrderServiceTest(ctrl)
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+	orderID := uint(500)
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(orderID, userID))
+
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}).AddRow(600, orderID, 1000))
+
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id"}).AddRow(1000, 50))
+
+		mock.ExpectQuery(`SELECT .* FROM "categories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(50))
+
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		resp, err := s.GetOrder(userID, orderID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ID != orderID {
+			t.Errorf("expected order ID %d, got %d", orderID, resp.ID)
+		}
+	})
+}
+
+func TestOrderService_UpdateOrderStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mock, _, provider, invoices, err := setupOrderServiceTest(ctrl)
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	orderID := uint(500)
+	adminID := uint(2)
+
+	t.Run("Success", func(t *testing.T) {
+		authorization, err := provider.Authorize(&payments.AuthorizeRequest{OrderID: orderID, UserID: 1, Amount: usd(10000)})
+		if err != nil {
+			t.Fatalf("failed to authorize payment: %v", err)
+		}
+
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, 1, "pending"))
+
+		mock.ExpectExec(`UPDATE "orders" SET "status"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectQuery(`INSERT INTO "order_status_histories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		// Confirming the order captures its authorized payment
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns).AddRow(700, orderID, "fake", authorization.Reference, "authorized", 10000, "USD", 0, "USD", 0, "USD"))
+		mock.ExpectExec(`UPDATE "payments" SET`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// The invoice takes the next number, with the sequence locked until commit
+		mock.ExpectExec(`INSERT INTO "invoice_sequences" .* ON CONFLICT DO NOTHING`).
+			WithArgs(models.InvoiceSequenceName, int64(0)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT .* FROM "invoice_sequences" WHERE name = \$1 .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "last_number"}).AddRow(models.InvoiceSequenceName, 41))
+		mock.ExpectExec(`UPDATE "invoice_sequences" SET "last_number"=\$1 WHERE "name" = \$2`).
+			WithArgs(int64(42), models.InvoiceSequenceName).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO "invoices"`).
+			WithArgs(orderID, "INV-000042", sqlmock.AnyArg(), "", "", nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, 1, "confirmed"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns).AddRow(700, orderID, "fake", authorization.Reference, "captured", 10000, "USD", 10000, "USD", 0, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		mock.ExpectCommit()
+
+		// The document is rendered once the confirmation is committed
+		invoices.EXPECT().GenerateInvoice(orderID).
+			Return(&dto.InvoiceResponse{OrderID: orderID, Number: "INV-000042"}, nil)
+
+		resp, err := s.UpdateOrderStatus(orderID, adminID, &dto.UpdateOrderStatusRequest{Status: "confirmed", Reason: "payment received"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Status != "confirmed" {
+			t.Errorf("expected status confirmed, got %s", resp.Status)
+		}
+		if len(resp.Payments) != 1 || resp.Payments[0].Status != string(models.PaymentStatusCaptured) {
+			t.Errorf("expected one captured payment, got %+v", resp.Payments)
+		}
+	})
+
+	t.Run("IllegalTransition", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, 1, "delivered"))
+
+		mock.ExpectRollback()
+
+		_, err := s.UpdateOrderStatus(orderID, adminID, &dto.UpdateOrderStatusRequest{Status: "pending"})
+
+		var transitionErr *services.InvalidStatusTransitionError
+		if !errors.As(err, &transitionErr) {
+			t.Fatalf("expected InvalidStatusTransitionError, got %v", err)
+		}
+		if transitionErr.From != models.OrderStatusDelivered || transitionErr.To != models.OrderStatusPending {
+			t.Errorf("unexpected transition error: %+v", transitionErr)
+		}
+	})
+
+	t.Run("UnknownStatus", func(t *testing.T) {
+		_, err := s.UpdateOrderStatus(orderID, adminID, &dto.UpdateOrderStatusRequest{Status: "lost"})
+		if !errors.Is(err, services.ErrInvalidOrderStatus) {
+			t.Errorf("expected ErrInvalidOrderStatus, got %v", err)
+		}
+	})
+
+	t.Run("ShipmentStatus", func(t *testing.T) {
+		// Only shipments move an order to these, so the order is not even loaded
+		for _, status := range []models.OrderStatus{models.OrderStatusPartiallyShipped, models.OrderStatusShipped, models.OrderStatusDelivered} {
+			_, err := s.UpdateOrderStatus(orderID, adminID, &dto.UpdateOrderStatusRequest{Status: string(status)})
+			if !errors.Is(err, services.ErrInvalidOrderStatus) {
+				t.Errorf("%s: expected ErrInvalidOrderStatus, got %v", status, err)
+			}
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestOrderService_CancelOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mock, publisher, provider, _, err := setupOrderServiceTest(ctrl)
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+	orderID := uint(500)
+
+	t.Run("Success", func(t *testing.T) {
+		authorization, err := provider.Authorize(&payments.AuthorizeRequest{OrderID: orderID, UserID: userID, Amount: usd(10000)})
+		if err != nil {
+			t.Fatalf("failed to authorize payment: %v", err)
+		}
+		if _, err := provider.Capture(authorization.Reference, usd(10000)); err != nil {
+			t.Fatalf("failed to capture payment: %v", err)
+		}
+
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, userID, "confirmed"))
+
+		mock.ExpectExec(`UPDATE "orders" SET "status"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO "order_status_histories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		// The captured payment is refunded in full
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns).AddRow(700, orderID, "fake", authorization.Reference, "captured", 10000, "USD", 10000, "USD", 0, "USD"))
+		mock.ExpectExec(`UPDATE "payments" SET`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// Restock every order line
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id", "quantity"}).
+				AddRow(600, orderID, 1000, 2).
+				AddRow(601, orderID, 1001, 1))
+		mock.ExpectExec(`UPDATE "products" SET "stock"=stock \+ \$1 WHERE id = \$2`).
+			WithArgs(2, 1000).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE "products" SET "stock"=stock \+ \$1 WHERE id = \$2`).
+			WithArgs(1, 1001).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// The coupon use the order claimed is given back
+		mock.ExpectQuery(`DELETE FROM "coupon_redemptions" WHERE order_id = \$1 RETURNING \*`).
+			WithArgs(orderID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "coupon_id", "user_id", "order_id"}).AddRow(1, 5, userID, orderID))
+		mock.ExpectExec(`UPDATE "coupons" SET "used_count"=used_count - 1 WHERE \(id = \$1 AND used_count > 0\)`).
+			WithArgs(5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, userID, "cancelled"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns).AddRow(700, orderID, "fake", authorization.Reference, "refunded", 10000, "USD", 10000, "USD", 10000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		mock.ExpectCommit()
+
+		publisher.EXPECT().Publish(notifications.OrderCancelled, gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := s.CancelOrder(userID, orderID, &dto.CancelOrderRequest{Reason: "changed my mind"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Status != string(models.OrderStatusCancelled) {
+			t.Errorf("expected status cancelled, got %s", resp.Status)
+		}
+	})
+
+	t.Run("PaidByWallet", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "wallet_amount", "wallet_currency"}).AddRow(orderID, userID, "pending", 10000, "USD"))
+
+		mock.ExpectExec(`UPDATE "orders" SET "status"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO "order_status_histories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+
+		// What the order took from the wallet is credited back
+		mock.ExpectQuery(`SELECT .* FROM "wallet_entries" WHERE order_id = \$1 AND type = \$2`).
+			WithArgs(orderID, models.WalletEntryDebit).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "wallet_id", "type", "amount", "currency"}).AddRow(41, 40, "debit", 10000, "USD"))
+		mock.ExpectQuery(`INSERT INTO "wallets" .* ON CONFLICT \("user_id"\) DO NOTHING`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(`SELECT .* FROM "wallets" WHERE user_id = \$1 .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance_amount", "balance_currency"}).AddRow(40, userID, 500, "USD"))
+		mock.ExpectQuery(`INSERT INTO "wallet_entries"`).
+			WithArgs(uint(40), models.WalletEntryCredit, models.WalletEntryOrderCancelled, 10000, "USD", 10500, "USD", nil, orderID, nil, "", nil, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+		mock.ExpectExec(`UPDATE "wallets" SET "balance_amount"=\$1,"balance_currency"=\$2`).
+			WithArgs(10500, "USD", sqlmock.AnyArg(), 40).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id", "quantity"}))
+		expectNoCouponRedeemed(mock)
+
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, userID, "cancelled"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		mock.ExpectCommit()
+
+		publisher.EXPECT().Publish(notifications.OrderCancelled, gomock.Any(), gomock.Any()).Return(nil)
+
+		if _, err := s.CancelOrder(userID, orderID, &dto.CancelOrderRequest{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("PaidWithPoints", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "loyalty_points", "loyalty_discount_amount", "loyalty_discount_currency"}).AddRow(orderID, userID, "pending", 2500, 2500, "USD"))
+
+		mock.ExpectExec(`UPDATE "orders" SET "status"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO "order_status_histories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+
+		// The points spent on the order are restored, spendable afresh
+		mock.ExpectQuery(`INSERT INTO "loyalty_entries"`).
+			WithArgs(userID, orderID, models.LoyaltyEntryRestored, 2500, 2500, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(63))
+
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id", "quantity"}))
+		expectNoCouponRedeemed(mock)
+
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, userID, "cancelled"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id"}))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT .* FROM "order_promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		mock.ExpectCommit()
+
+		publisher.EXPECT().Publish(notifications.OrderCancelled, gomock.Any(), gomock.Any()).Return(nil)
+
+		if _, err := s.CancelOrder(userID, orderID, &dto.CancelOrderRequest{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("AlreadyShipped", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, userID, "shipped"))
+
+		mock.ExpectRollback()
+
+		_, err := s.CancelOrder(userID, orderID, &dto.CancelOrderRequest{})
+
+		var transitionErr *services.InvalidStatusTransitionError
+		if !errors.As(err, &transitionErr) {
+			t.Fatalf("expected InvalidStatusTransitionError, got %v", err)
+		}
+	})
+
+	t.Run("NotOwner", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		mock.ExpectRollback()
+
+		_, err := s.CancelOrder(userID, orderID, &dto.CancelOrderRequest{})
+		if !errors.Is(err, services.ErrOrderNotFound) {
+			t.Errorf("expected ErrOrderNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
 }
@@ -1,10 +1,13 @@
 package services_test
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
 	"github.com/kuldeepstechwork/gocart-api/internal/services"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -23,7 +26,7 @@ func setupProductServiceTest() (*services.ProductService, sqlmock.Sqlmock, error
 		return nil, nil, err
 	}
 
-	return services.NewProductService(gormDB), mock, nil
+	return services.NewProductService(gormDB, "USD"), mock, nil
 }
 
 func TestProductService_CreateCategory(t *testing.T) {
@@ -133,15 +136,16 @@ func TestProductService_GetProducts(t *testing.T) {
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
 
 		mock.ExpectQuery(`SELECT .* FROM "products"`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Prod 1"))
-
-		mock.ExpectQuery(`SELECT .* FROM "product_images"`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id"}).AddRow(100, 1))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "name"}).AddRow(1, 1, "Prod 1"))
 
 		mock.ExpectQuery(`SELECT .* FROM "categories"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Cat 1"))
 
-		resp, meta, err := s.GetProducts(1, 10)
+		mock.ExpectQuery(`SELECT .* FROM "product_images"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id"}).AddRow(100, 1))
+		expectNoReservedStock(mock)
+
+		resp, meta, err := s.GetProducts(1, 10, "")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -164,20 +168,93 @@ func TestProductService_GetProduct(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mock.ExpectQuery(`SELECT .* FROM "products"`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(id, "Prod 1"))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "name"}).AddRow(id, 1, "Prod 1"))
+
+		mock.ExpectQuery(`SELECT .* FROM "categories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Cat 1"))
 
 		mock.ExpectQuery(`SELECT .* FROM "product_images"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id"}).AddRow(100, id))
+		expectNoReservedStock(mock)
+
+		resp, err := s.GetProduct(id, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ID != id {
+			t.Errorf("expected product ID %d, got %d", id, resp.ID)
+		}
+	})
+
+	t.Run("ConvertedToCurrency", func(t *testing.T) {
+		s, mock, err := setupProductServiceTest()
+		if err != nil {
+			t.Fatalf("failed to setup test: %v", err)
+		}
 
+		mock.ExpectQuery(`SELECT \* FROM "exchange_rates" WHERE currency = \$1`).
+			WithArgs("EUR", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "currency", "rate"}).AddRow(1, "EUR", 0.92))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "name", "price_amount", "price_currency"}).AddRow(id, 1, "Prod 1", 1999, "USD"))
 		mock.ExpectQuery(`SELECT .* FROM "categories"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Cat 1"))
+		mock.ExpectQuery(`SELECT .* FROM "product_images"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id"}))
+		mock.ExpectQuery(`SELECT \* FROM "product_prices" WHERE product_id IN \(\$1\) AND currency = \$2`).
+			WithArgs(id, "EUR").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "currency", "amount"}))
+		expectNoReservedStock(mock)
 
-		resp, err := s.GetProduct(id)
+		resp, err := s.GetProduct(id, "EUR")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if resp.ID != id {
-			t.Errorf("expected product ID %d, got %d", id, resp.ID)
+		if resp.Price != money.New(1839, "EUR") {
+			t.Errorf("expected 18.39 EUR, got %v", resp.Price)
+		}
+	})
+
+	t.Run("PriceOverride", func(t *testing.T) {
+		s, mock, err := setupProductServiceTest()
+		if err != nil {
+			t.Fatalf("failed to setup test: %v", err)
+		}
+
+		mock.ExpectQuery(`SELECT \* FROM "exchange_rates" WHERE currency = \$1`).
+			WithArgs("EUR", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "currency", "rate"}).AddRow(1, "EUR", 0.92))
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "name", "price_amount", "price_currency"}).AddRow(id, 1, "Prod 1", 1999, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "categories"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Cat 1"))
+		mock.ExpectQuery(`SELECT .* FROM "product_images"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id"}))
+		mock.ExpectQuery(`SELECT \* FROM "product_prices"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "currency", "amount"}).AddRow(1, id, "EUR", 1900))
+		expectNoReservedStock(mock)
+
+		resp, err := s.GetProduct(id, "EUR")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Price != money.New(1900, "EUR") {
+			t.Errorf("expected the 19.00 EUR override, got %v", resp.Price)
+		}
+	})
+
+	t.Run("CurrencyWithoutRate", func(t *testing.T) {
+		s, mock, err := setupProductServiceTest()
+		if err != nil {
+			t.Fatalf("failed to setup test: %v", err)
+		}
+
+		mock.ExpectQuery(`SELECT \* FROM "exchange_rates"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err = s.GetProduct(id, "GBP")
+		if !errors.Is(err, services.ErrCurrencyNotAvailable) {
+			t.Errorf("expected ErrCurrencyNotAvailable, got %v", err)
 		}
 	})
 }
@@ -191,7 +268,7 @@ func TestProductService_CreateProduct(t *testing.T) {
 	req := &dto.CreateProductRequest{
 		CategoryID: 1,
 		Name:       "New Prod",
-		Price:      10.0,
+		Price:      money.Money{Amount: 1000},
 	}
 
 	t.Run("Success", func(t *testing.T) {
@@ -201,10 +278,11 @@ func TestProductService_CreateProduct(t *testing.T) {
 		mock.ExpectCommit()
 
 		mock.ExpectQuery(`SELECT .* FROM "products"`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "New Prod"))
-		mock.ExpectQuery(`SELECT .* FROM "product_images"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "name"}).AddRow(1, 1, "New Prod"))
 		mock.ExpectQuery(`SELECT .* FROM "categories"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Cat 1"))
+		mock.ExpectQuery(`SELECT .* FROM "product_images"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectNoReservedStock(mock)
 
 		resp, err := s.CreateProduct(req)
 		if err != nil {
@@ -214,6 +292,42 @@ func TestProductService_CreateProduct(t *testing.T) {
 			t.Errorf("expected name New Prod, got %s", resp.Name)
 		}
 	})
+
+	t.Run("OtherCurrency", func(t *testing.T) {
+		_, err := s.CreateProduct(&dto.CreateProductRequest{CategoryID: 1, Name: "New Prod", Price: money.New(1000, "EUR")})
+		if !errors.Is(err, services.ErrInvalidProduct) {
+			t.Errorf("expected ErrInvalidProduct, got %v", err)
+		}
+	})
+
+	t.Run("NotPositive", func(t *testing.T) {
+		_, err := s.CreateProduct(&dto.CreateProductRequest{CategoryID: 1, Name: "New Prod"})
+		if !errors.Is(err, services.ErrInvalidProduct) {
+			t.Errorf("expected ErrInvalidProduct, got %v", err)
+		}
+	})
+
+	launch := time.Now().Add(30 * 24 * time.Hour)
+	for _, tc := range []struct {
+		name string
+		req  dto.CreateProductRequest
+	}{
+		{name: "UnknownInventoryPolicy", req: dto.CreateProductRequest{InventoryPolicy: "oversell", BackorderLimit: 5}},
+		{name: "BackorderWithoutLimit", req: dto.CreateProductRequest{InventoryPolicy: "backorder"}},
+		{name: "PreorderWithoutLimit", req: dto.CreateProductRequest{InventoryPolicy: "preorder", AvailableAt: &launch}},
+		{name: "PreorderWithoutDate", req: dto.CreateProductRequest{InventoryPolicy: "preorder", BackorderLimit: 5}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.req.CategoryID = 1
+			tc.req.Name = "New Prod"
+			tc.req.Price = usd(1000)
+
+			_, err := s.CreateProduct(&tc.req)
+			if !errors.Is(err, services.ErrInvalidProduct) {
+				t.Errorf("expected ErrInvalidProduct, got %v", err)
+			}
+		})
+	}
 }
 
 func TestProductService_UpdateProduct(t *testing.T) {
@@ -223,7 +337,7 @@ func TestProductService_UpdateProduct(t *testing.T) {
 	}
 
 	id := uint(1)
-	req := &dto.UpdateProductRequest{Name: "Updated"}
+	req := &dto.UpdateProductRequest{Name: "Updated", Price: usd(1500)}
 
 	t.Run("Success", func(t *testing.T) {
 		mock.ExpectQuery(`SELECT .* FROM "products"`).
@@ -235,10 +349,11 @@ func TestProductService_UpdateProduct(t *testing.T) {
 		mock.ExpectCommit()
 
 		mock.ExpectQuery(`SELECT .* FROM "products"`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(id, "Updated"))
-		mock.ExpectQuery(`SELECT .* FROM "product_images"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "name"}).AddRow(id, 1, "Updated"))
 		mock.ExpectQuery(`SELECT .* FROM "categories"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Cat 1"))
+		mock.ExpectQuery(`SELECT .* FROM "product_images"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectNoReservedStock(mock)
 
 		resp, err := s.UpdateProduct(id, req)
 		if err != nil {
@@ -311,15 +426,16 @@ func TestProductService_SearchProducts(t *testing.T) {
 
 		mock.ExpectQuery(`SELECT products\.\*, ts_rank\(search_vector, plainto_tsquery\('english', \$1\)\) as rank FROM "products"`).
 			WithArgs("test", "test", true, 10).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "rank"}).AddRow(1, "Test Prod", 0.5))
-
-		mock.ExpectQuery(`SELECT .* FROM "product_images"`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id"}).AddRow(100, 1))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "name", "rank"}).AddRow(1, 1, "Test Prod", 0.5))
 
 		mock.ExpectQuery(`SELECT .* FROM "categories"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Cat 1"))
 
-		resp, _, err := s.SearchProducts(req)
+		mock.ExpectQuery(`SELECT .* FROM "product_images"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id"}).AddRow(100, 1))
+		expectNoReservedStock(mock)
+
+		resp, _, err := s.SearchProducts(req, "")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -327,4 +443,29 @@ func TestProductService_SearchProducts(t *testing.T) {
 			t.Errorf("expected 1 search result, got %d", len(resp))
 		}
 	})
+
+	t.Run("PriceRange", func(t *testing.T) {
+		s, mock, err := setupProductServiceTest()
+		if err != nil {
+			t.Fatalf("failed to setup test: %v", err)
+		}
+
+		req := &dto.SearchProductsRequest{Query: "test", MinPrice: &money.Money{Amount: 1000}, MaxPrice: &money.Money{Amount: 5000}}
+
+		// Prices are compared in minor units of the store currency
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "products" .* AND price_currency = \$3 AND price_amount >= \$4 AND price_amount <= \$5`).
+			WithArgs("test", true, "USD", int64(1000), int64(5000)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(`SELECT products\.\*, ts_rank`).
+			WithArgs("test", "test", true, "USD", int64(1000), int64(5000), 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "rank"}))
+
+		resp, _, err := s.SearchProducts(req, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp) != 0 {
+			t.Errorf("expected no search results, got %d", len(resp))
+		}
+	})
 }
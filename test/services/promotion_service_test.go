@@ -0,0 +1,166 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupPromotionServiceTest() (*services.PromotionService, sqlmock.Sqlmock, error) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return services.NewPromotionService(gormDB, "USD"), mock, nil
+}
+
+func TestPromotionService_CreatePromotion(t *testing.T) {
+	s, mock, err := setupPromotionServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("SpendThresholdWithTiers", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "stackable", "is_active"}).AddRow(7, true, true))
+		mock.ExpectQuery(`INSERT INTO "promotion_tiers"`).
+			WithArgs(uint(7), 5000, "USD", 500, "USD", uint(7), 10000, "USD", 1500, "USD").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+		mock.ExpectCommit()
+
+		resp, err := s.CreatePromotion(&dto.PromotionRequest{
+			Name: "Spend more, save more",
+			Type: "spend_threshold",
+			Tiers: []dto.PromotionTierRequest{
+				{Threshold: money.Money{Amount: 5000}, Amount: money.Money{Amount: 500}},
+				{Threshold: money.Money{Amount: 10000}, Amount: money.Money{Amount: 1500}},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ID != 7 || !resp.IsActive || !resp.Stackable {
+			t.Errorf("expected active stackable promotion 7, got %+v", resp)
+		}
+		if len(resp.Tiers) != 2 || resp.Tiers[1].Amount != usd(1500) {
+			t.Errorf("expected two tiers, got %+v", resp.Tiers)
+		}
+	})
+
+	t.Run("SpendThresholdWithoutTiers", func(t *testing.T) {
+		_, err := s.CreatePromotion(&dto.PromotionRequest{Name: "Empty", Type: "spend_threshold"})
+		if !errors.Is(err, services.ErrInvalidPromotion) {
+			t.Errorf("expected ErrInvalidPromotion, got %v", err)
+		}
+	})
+
+	t.Run("TierWithoutAmount", func(t *testing.T) {
+		_, err := s.CreatePromotion(&dto.PromotionRequest{
+			Name:  "Nothing off",
+			Type:  "spend_threshold",
+			Tiers: []dto.PromotionTierRequest{{Threshold: money.Money{Amount: 5000}}},
+		})
+		if !errors.Is(err, services.ErrInvalidPromotion) {
+			t.Errorf("expected ErrInvalidPromotion, got %v", err)
+		}
+	})
+
+	t.Run("BuyXGetYWithoutQuantities", func(t *testing.T) {
+		_, err := s.CreatePromotion(&dto.PromotionRequest{Name: "BOGO", Type: "buy_x_get_y", DiscountPercent: 100})
+		if !errors.Is(err, services.ErrInvalidPromotion) {
+			t.Errorf("expected ErrInvalidPromotion, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPromotionService_UpdatePromotion(t *testing.T) {
+	s, mock, err := setupPromotionServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("ReplacesTiers", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "type", "stackable", "is_active"}).
+				AddRow(7, "Spend more, save more", "spend_threshold", true, true))
+		mock.ExpectExec(`UPDATE "promotions"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "promotion_tiers" WHERE promotion_id = \$1`).
+			WithArgs(uint(7)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectQuery(`INSERT INTO "promotion_tiers"`).
+			WithArgs(uint(7), 20000, "USD", 4000, "USD").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+		mock.ExpectCommit()
+
+		resp, err := s.UpdatePromotion(7, &dto.PromotionRequest{
+			Name:  "Big spender",
+			Type:  "spend_threshold",
+			Tiers: []dto.PromotionTierRequest{{Threshold: money.Money{Amount: 20000}, Amount: money.Money{Amount: 4000}}},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Name != "Big spender" || len(resp.Tiers) != 1 || resp.Tiers[0].Threshold != usd(20000) {
+			t.Errorf("expected Big spender with a single tier, got %+v", resp)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+		mock.ExpectRollback()
+
+		_, err := s.UpdatePromotion(99, &dto.PromotionRequest{Name: "Sale", Type: "flash_sale", DiscountPercent: 20})
+		if !errors.Is(err, services.ErrPromotionNotFound) {
+			t.Errorf("expected ErrPromotionNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPromotionService_DeletePromotion(t *testing.T) {
+	s, mock, err := setupPromotionServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "promotions" SET "deleted_at"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		if err := s.DeletePromotion(99); !errors.Is(err, services.ErrPromotionNotFound) {
+			t.Errorf("expected ErrPromotionNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,697 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/payments"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupReturnServiceTest() (*services.ReturnService, sqlmock.Sqlmock, *payments.FakeProvider, error) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	provider := payments.NewFakeProvider(payments.FakeOutcomeSucceed)
+
+	return services.NewReturnService(gormDB, "USD", provider, services.NewLoyaltyService(gormDB, "USD", loyaltyProgram)), mock, provider, nil
+}
+
+func TestReturnService_RequestReturn(t *testing.T) {
+	s, mock, _, err := setupReturnServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+	orderID := uint(500)
+	orderItemColumns := []string{"id", "order_id", "product_id", "quantity", "price_amount", "price_currency"}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, userID, "delivered"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows(orderItemColumns).AddRow(600, orderID, 1000, 3, 2500, "USD"))
+
+		// Nothing has been returned from this order yet, rejected and cancelled returns aside
+		mock.ExpectQuery(`SELECT return_lines.order_item_id, SUM\(return_lines.quantity\) .* WHERE returns.order_id = \$1 AND returns.status NOT IN \(\$2,\$3\)`).
+			WithArgs(orderID, models.ReturnStatusRejected, models.ReturnStatusCancelled).
+			WillReturnRows(sqlmock.NewRows([]string{"order_item_id", "quantity"}))
+
+		mock.ExpectQuery(`INSERT INTO "returns"`).
+			WithArgs(orderID, userID, "requested", "wrong size", 5000, "USD", false, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 0, "USD", 0).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(900))
+		mock.ExpectQuery(`INSERT INTO "return_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(901))
+
+		mock.ExpectQuery(`SELECT .* FROM "returns"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "user_id", "status", "refund_amount", "refund_currency"}).
+				AddRow(900, orderID, userID, "requested", 5000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "return_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "return_id", "order_item_id", "quantity", "refund_amount", "refund_currency"}).
+				AddRow(901, 900, 600, 2, 5000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows(orderItemColumns).AddRow(600, orderID, 1000, 3, 2500, "USD"))
+
+		mock.ExpectCommit()
+
+		resp, err := s.RequestReturn(userID, orderID, &dto.CreateReturnRequest{
+			Reason: "wrong size",
+			Items:  []dto.ReturnItemRequest{{OrderItemID: 600, Quantity: 2}},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.RefundAmount != usd(5000) {
+			t.Errorf("expected refund amount 50.00, got %v", resp.RefundAmount)
+		}
+		if len(resp.Lines) != 1 || resp.Lines[0].Price != usd(2500) {
+			t.Errorf("expected one line priced at 25.00, got %+v", resp.Lines)
+		}
+	})
+
+	t.Run("ProratesDiscountAndTax", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, userID, "delivered"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id", "quantity", "price_amount", "price_currency", "discount_amount", "discount_currency", "tax_amount", "tax_currency"}).
+				AddRow(600, orderID, 1000, 3, 1000, "USD", 100, "USD", 200, "USD"))
+		mock.ExpectQuery(`SELECT return_lines.order_item_id, SUM\(return_lines.quantity\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"order_item_id", "quantity"}))
+
+		// Two of three units refund 20.00 less 2/3 of the 1.00 discount plus
+		// 2/3 of the 2.00 tax, each share rounded to the cent
+		mock.ExpectQuery(`INSERT INTO "returns"`).
+			WithArgs(orderID, userID, "requested", "", 2066, "USD", false, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 0, "USD", 0).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(902))
+		mock.ExpectQuery(`INSERT INTO "return_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(903))
+
+		mock.ExpectQuery(`SELECT .* FROM "returns"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "user_id", "status"}).AddRow(902, orderID, userID, "requested"))
+		mock.ExpectQuery(`SELECT .* FROM "return_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "return_id", "order_item_id"}))
+
+		mock.ExpectCommit()
+
+		if _, err := s.RequestReturn(userID, orderID, &dto.CreateReturnRequest{
+			Items: []dto.ReturnItemRequest{{OrderItemID: 600, Quantity: 2}},
+		}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("CapsAtWhatWasPaid", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, userID, "delivered"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "product_id", "quantity", "price_amount", "price_currency", "discount_amount", "discount_currency", "tax_amount", "tax_currency"}).
+				AddRow(600, orderID, 1000, 3, 1000, "USD", 100, "USD", 200, "USD"))
+
+		// Two earlier returns of one unit each refunded 10.34 apiece
+		mock.ExpectQuery(`SELECT return_lines.order_item_id, SUM\(return_lines.quantity\) AS quantity, SUM\(return_lines.refund_amount\) AS refund_amount`).
+			WillReturnRows(sqlmock.NewRows([]string{"order_item_id", "quantity", "refund_amount"}).AddRow(600, 2, 2068))
+
+		// The last unit would refund 10.34 as well, but only 10.32 of the
+		// 31.00 paid for the item is left
+		mock.ExpectQuery(`INSERT INTO "returns"`).
+			WithArgs(orderID, userID, "requested", "", 1032, "USD", false, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 0, "USD", 0).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(904))
+		mock.ExpectQuery(`INSERT INTO "return_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(905))
+
+		mock.ExpectQuery(`SELECT .* FROM "returns"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "user_id", "status"}).AddRow(904, orderID, userID, "requested"))
+		mock.ExpectQuery(`SELECT .* FROM "return_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "return_id", "order_item_id"}))
+
+		mock.ExpectCommit()
+
+		if _, err := s.RequestReturn(userID, orderID, &dto.CreateReturnRequest{
+			Items: []dto.ReturnItemRequest{{OrderItemID: 600, Quantity: 1}},
+		}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("OrderNotDelivered", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, userID, "shipped"))
+
+		mock.ExpectRollback()
+
+		_, err := s.RequestReturn(userID, orderID, &dto.CreateReturnRequest{
+			Items: []dto.ReturnItemRequest{{OrderItemID: 600, Quantity: 1}},
+		})
+		if !errors.Is(err, services.ErrOrderNotReturnable) {
+			t.Errorf("expected ErrOrderNotReturnable, got %v", err)
+		}
+	})
+
+	t.Run("QuantityAlreadyReturned", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, userID, "delivered"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows(orderItemColumns).AddRow(600, orderID, 1000, 3, 2500, "USD"))
+		mock.ExpectQuery(`SELECT return_lines.order_item_id, SUM\(return_lines.quantity\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"order_item_id", "quantity"}).AddRow(600, 2))
+
+		mock.ExpectRollback()
+
+		_, err := s.RequestReturn(userID, orderID, &dto.CreateReturnRequest{
+			Items: []dto.ReturnItemRequest{{OrderItemID: 600, Quantity: 2}},
+		})
+		if !errors.Is(err, services.ErrInvalidReturnItem) {
+			t.Errorf("expected ErrInvalidReturnItem, got %v", err)
+		}
+	})
+
+	t.Run("ItemFromAnotherOrder", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, userID, "delivered"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows(orderItemColumns).AddRow(600, orderID, 1000, 3, 2500, "USD"))
+		mock.ExpectQuery(`SELECT return_lines.order_item_id, SUM\(return_lines.quantity\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"order_item_id", "quantity"}))
+
+		mock.ExpectRollback()
+
+		_, err := s.RequestReturn(userID, orderID, &dto.CreateReturnRequest{
+			Items: []dto.ReturnItemRequest{{OrderItemID: 777, Quantity: 1}},
+		})
+		if !errors.Is(err, services.ErrInvalidReturnItem) {
+			t.Errorf("expected ErrInvalidReturnItem, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestReturnService_RejectReturn(t *testing.T) {
+	s, mock, _, err := setupReturnServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	returnID := uint(900)
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "returns" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "status"}).AddRow(returnID, 500, "requested"))
+		mock.ExpectExec(`UPDATE "returns" SET "status"`).
+			WithArgs(models.ReturnStatusRejected, sqlmock.AnyArg(), returnID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectQuery(`SELECT .* FROM "returns"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "status"}).AddRow(returnID, 500, "rejected"))
+		mock.ExpectQuery(`SELECT .* FROM "return_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "return_id", "order_item_id"}))
+
+		mock.ExpectCommit()
+
+		resp, err := s.RejectReturn(returnID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Status != string(models.ReturnStatusRejected) {
+			t.Errorf("expected a rejected return, got %+v", resp)
+		}
+	})
+
+	t.Run("AlreadyApproved", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "returns" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "status"}).AddRow(returnID, 500, "approved"))
+
+		mock.ExpectRollback()
+
+		_, err := s.RejectReturn(returnID)
+
+		var transitionErr *services.InvalidReturnTransitionError
+		if !errors.As(err, &transitionErr) {
+			t.Errorf("expected InvalidReturnTransitionError, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestReturnService_CancelReturn(t *testing.T) {
+	s, mock, _, err := setupReturnServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+	returnID := uint(900)
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "returns" WHERE "returns"."id" = \$1 AND user_id = \$2 .* FOR UPDATE`).
+			WithArgs(returnID, userID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "user_id", "status"}).AddRow(returnID, 500, userID, "approved"))
+		mock.ExpectExec(`UPDATE "returns" SET "status"`).
+			WithArgs(models.ReturnStatusCancelled, sqlmock.AnyArg(), returnID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectQuery(`SELECT .* FROM "returns"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "user_id", "status"}).AddRow(returnID, 500, userID, "cancelled"))
+		mock.ExpectQuery(`SELECT .* FROM "return_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "return_id", "order_item_id"}))
+
+		mock.ExpectCommit()
+
+		resp, err := s.CancelReturn(userID, returnID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Status != string(models.ReturnStatusCancelled) {
+			t.Errorf("expected a cancelled return, got %+v", resp)
+		}
+	})
+
+	t.Run("AlreadyReceived", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "returns" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "user_id", "status"}).AddRow(returnID, 500, userID, "received"))
+
+		mock.ExpectRollback()
+
+		_, err := s.CancelReturn(userID, returnID)
+
+		var transitionErr *services.InvalidReturnTransitionError
+		if !errors.As(err, &transitionErr) {
+			t.Errorf("expected InvalidReturnTransitionError, got %v", err)
+		}
+	})
+
+	t.Run("AnotherUsersReturn", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "returns" WHERE "returns"."id" = \$1 AND user_id = \$2 .* FOR UPDATE`).
+			WithArgs(returnID, uint(2), 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		mock.ExpectRollback()
+
+		_, err := s.CancelReturn(2, returnID)
+		if !errors.Is(err, services.ErrReturnNotFound) {
+			t.Errorf("expected ErrReturnNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestReturnService_ListReturns_CountError(t *testing.T) {
+	s, mock, _, err := setupReturnServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "returns"`).
+		WillReturnError(errors.New("connection reset"))
+
+	if _, _, err := s.ListReturns("", 1, 10); err == nil {
+		t.Fatal("expected the count error, got nil")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestReturnService_ReceiveReturn(t *testing.T) {
+	s, mock, _, err := setupReturnServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	returnID := uint(900)
+
+	t.Run("Restock", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "returns" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "status"}).AddRow(returnID, 500, "approved"))
+		mock.ExpectExec(`UPDATE "returns" SET "status"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectQuery(`SELECT .* FROM "return_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "return_id", "order_item_id", "quantity"}).AddRow(901, returnID, 600, 2))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id"}).AddRow(600, 1000))
+		mock.ExpectExec(`UPDATE "products" SET "stock"=stock \+ \$1 WHERE id = \$2`).
+			WithArgs(2, 1000).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE "returns" SET "restocked"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectQuery(`SELECT .* FROM "returns"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "status", "restocked"}).AddRow(returnID, 500, "received", true))
+		mock.ExpectQuery(`SELECT .* FROM "return_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "return_id", "order_item_id"}))
+
+		mock.ExpectCommit()
+
+		resp, err := s.ReceiveReturn(returnID, &dto.ReceiveReturnRequest{Restock: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Status != string(models.ReturnStatusReceived) || !resp.Restocked {
+			t.Errorf("expected a restocked received return, got %+v", resp)
+		}
+	})
+
+	t.Run("NotApproved", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "returns" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "status"}).AddRow(returnID, 500, "requested"))
+
+		mock.ExpectRollback()
+
+		_, err := s.ReceiveReturn(returnID, &dto.ReceiveReturnRequest{})
+
+		var transitionErr *services.InvalidReturnTransitionError
+		if !errors.As(err, &transitionErr) {
+			t.Errorf("expected InvalidReturnTransitionError, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestReturnService_RefundReturn(t *testing.T) {
+	s, mock, provider, err := setupReturnServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	returnID := uint(900)
+	orderID := uint(500)
+
+	t.Run("Success", func(t *testing.T) {
+		authorization, _ := provider.Authorize(&payments.AuthorizeRequest{OrderID: orderID, UserID: 1, Amount: usd(10000)})
+		if _, err := provider.Capture(authorization.Reference, usd(10000)); err != nil {
+			t.Fatalf("failed to capture payment: %v", err)
+		}
+
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "returns" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "status", "refund_amount", "refund_currency"}).AddRow(returnID, orderID, "received", 4000, "USD"))
+		mock.ExpectExec(`UPDATE "returns" SET "status"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows(walletOrderColumns).AddRow(orderID, 1, 0, "USD", 1))
+
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns).AddRow(700, orderID, "fake", authorization.Reference, "captured", 10000, "USD", 10000, "USD", 0, "USD"))
+		mock.ExpectExec(`UPDATE "payments" SET`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectQuery(`SELECT .* FROM "returns"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "status", "refund_amount", "refund_currency"}).AddRow(returnID, orderID, "refunded", 4000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "return_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "return_id", "order_item_id"}))
+
+		mock.ExpectCommit()
+
+		resp, err := s.RefundReturn(returnID, &dto.RefundReturnRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Status != string(models.ReturnStatusRefunded) {
+			t.Errorf("expected status refunded, got %s", resp.Status)
+		}
+
+		// Only the remaining 60.00 can still be refunded at the provider
+		if _, err := provider.Refund(authorization.Reference, usd(6001)); !errors.Is(err, payments.ErrInvalidPaymentOperation) {
+			t.Errorf("expected the provider to have refunded 40.00, got %v", err)
+		}
+	})
+
+	t.Run("NothingCaptured", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "returns" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "status", "refund_amount", "refund_currency"}).AddRow(returnID, orderID, "received", 4000, "USD"))
+		mock.ExpectExec(`UPDATE "returns" SET "status"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows(walletOrderColumns).AddRow(orderID, 1, 0, "USD", 1))
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT COALESCE\(SUM\(wallet_refund_amount\), 0\) FROM "returns"`).
+			WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(0))
+
+		mock.ExpectRollback()
+
+		_, err := s.RefundReturn(returnID, &dto.RefundReturnRequest{})
+		if !errors.Is(err, services.ErrRefundExceedsPayments) {
+			t.Errorf("expected ErrRefundExceedsPayments, got %v", err)
+		}
+	})
+
+	t.Run("ShortfallRefundsNothing", func(t *testing.T) {
+		authorization, _ := provider.Authorize(&payments.AuthorizeRequest{OrderID: orderID, UserID: 1, Amount: usd(1000)})
+		if _, err := provider.Capture(authorization.Reference, usd(1000)); err != nil {
+			t.Fatalf("failed to capture payment: %v", err)
+		}
+
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "returns" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "status", "refund_amount", "refund_currency"}).AddRow(returnID, orderID, "received", 4000, "USD"))
+		mock.ExpectExec(`UPDATE "returns" SET "status"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows(walletOrderColumns).AddRow(orderID, 1, 0, "USD", 1))
+
+		// The payment covers 10.00 of the 40.00, and neither the wallet nor
+		// points paid for the rest
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns).AddRow(701, orderID, "fake", authorization.Reference, "captured", 1000, "USD", 1000, "USD", 0, "USD"))
+		mock.ExpectQuery(`SELECT COALESCE\(SUM\(wallet_refund_amount\), 0\) FROM "returns"`).
+			WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(0))
+
+		mock.ExpectRollback()
+
+		_, err := s.RefundReturn(returnID, &dto.RefundReturnRequest{})
+		if !errors.Is(err, services.ErrRefundExceedsPayments) {
+			t.Errorf("expected ErrRefundExceedsPayments, got %v", err)
+		}
+
+		// Nothing was refunded at the provider
+		if _, err := provider.Refund(authorization.Reference, usd(1000)); err != nil {
+			t.Errorf("expected the whole payment to be refundable still, got %v", err)
+		}
+	})
+
+	t.Run("PaidByWallet", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "returns" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "user_id", "status", "refund_amount", "refund_currency"}).AddRow(returnID, orderID, 1, "received", 4000, "USD"))
+		mock.ExpectExec(`UPDATE "returns" SET "status"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows(walletOrderColumns).AddRow(orderID, 1, 10000, "USD", 1))
+
+		// The order was paid in full by wallet, so no payment takes anything back
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT COALESCE\(SUM\(wallet_refund_amount\), 0\) FROM "returns"`).
+			WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(0))
+
+		expectWalletEntry(mock, 2500, 6500)
+		mock.ExpectExec(`UPDATE "returns" SET "wallet_refund_amount"=\$1,"wallet_refund_currency"=\$2`).
+			WithArgs(4000, "USD", sqlmock.AnyArg(), returnID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectQuery(`SELECT .* FROM "returns"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "status", "refund_amount", "refund_currency", "wallet_refund_amount", "wallet_refund_currency"}).AddRow(returnID, orderID, "refunded", 4000, "USD", 4000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "return_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "return_id", "order_item_id"}))
+
+		mock.ExpectCommit()
+
+		resp, err := s.RefundReturn(returnID, &dto.RefundReturnRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.WalletRefundAmount != usd(4000) {
+			t.Errorf("expected 40.00 refunded to the wallet, got %v", resp.WalletRefundAmount)
+		}
+	})
+
+	t.Run("ToWallet", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "returns" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "user_id", "status", "refund_amount", "refund_currency"}).AddRow(returnID, orderID, 1, "received", 4000, "USD"))
+		mock.ExpectExec(`UPDATE "returns" SET "status"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows(walletOrderColumns).AddRow(orderID, 1, 0, "USD", 1))
+
+		// The captured payments are left alone
+		expectWalletEntry(mock, 0, 4000)
+		mock.ExpectExec(`UPDATE "returns" SET "wallet_refund_amount"=\$1,"wallet_refund_currency"=\$2`).
+			WithArgs(4000, "USD", sqlmock.AnyArg(), returnID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectQuery(`SELECT .* FROM "returns"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "status", "refund_amount", "refund_currency", "wallet_refund_amount", "wallet_refund_currency"}).AddRow(returnID, orderID, "refunded", 4000, "USD", 4000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "return_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "return_id", "order_item_id"}))
+
+		mock.ExpectCommit()
+
+		if _, err := s.RefundReturn(returnID, &dto.RefundReturnRequest{ToWallet: true}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("PaidWithPoints", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "returns" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "user_id", "status", "refund_amount", "refund_currency"}).AddRow(returnID, orderID, 1, "received", 4000, "USD"))
+		mock.ExpectExec(`UPDATE "returns" SET "status"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows(loyaltyOrderColumns).AddRow(orderID, 1, "delivered", 5000, "USD", 5000, 5000, "USD", 1, nil))
+
+		// The order was paid in full with points, so neither a payment nor
+		// the wallet takes anything back
+		mock.ExpectQuery(`SELECT .* FROM "payments"`).
+			WillReturnRows(sqlmock.NewRows(paymentColumns))
+		mock.ExpectQuery(`SELECT COALESCE\(SUM\(wallet_refund_amount\), 0\) FROM "returns"`).
+			WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(0))
+
+		mock.ExpectQuery(`SELECT COALESCE\(SUM\(points\), 0\) FROM "loyalty_entries" WHERE order_id = \$1 AND type = \$2`).
+			WithArgs(orderID, models.LoyaltyEntryRestored).
+			WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(1000))
+		mock.ExpectQuery(`INSERT INTO "loyalty_entries"`).
+			WithArgs(uint(1), orderID, models.LoyaltyEntryRestored, 4000, 4000, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(64))
+		mock.ExpectExec(`UPDATE "returns" SET "loyalty_points_restored"=\$1`).
+			WithArgs(4000, sqlmock.AnyArg(), returnID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectQuery(`SELECT .* FROM "returns"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "status", "refund_amount", "refund_currency", "loyalty_points_restored"}).AddRow(returnID, orderID, "refunded", 4000, "USD", 4000))
+		mock.ExpectQuery(`SELECT .* FROM "return_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "return_id", "order_item_id"}))
+
+		mock.ExpectCommit()
+
+		resp, err := s.RefundReturn(returnID, &dto.RefundReturnRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.LoyaltyPointsRestored != 4000 {
+			t.Errorf("expected 4000 points restored, got %d", resp.LoyaltyPointsRestored)
+		}
+	})
+
+	t.Run("ReversesEarnedPoints", func(t *testing.T) {
+		awardedAt := time.Now().Add(-time.Hour)
+
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "returns" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "user_id", "status", "refund_amount", "refund_currency"}).AddRow(returnID, orderID, 1, "received", 4000, "USD"))
+		mock.ExpectExec(`UPDATE "returns" SET "status"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT .* FROM "orders"`).
+			WillReturnRows(sqlmock.NewRows(loyaltyOrderColumns).AddRow(orderID, 1, "delivered", 10000, "USD", 0, 0, "USD", 1, awardedAt))
+
+		expectWalletEntry(mock, 0, 4000)
+		mock.ExpectExec(`UPDATE "returns" SET "wallet_refund_amount"=\$1,"wallet_refund_currency"=\$2`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// 40.00 of the 100.00 paid is refunded, taking back 40 of the 100
+		// points the order earned
+		mock.ExpectQuery(`SELECT .* FROM "loyalty_entries" WHERE order_id = \$1 AND type = \$2 .* FOR UPDATE`).
+			WithArgs(orderID, models.LoyaltyEntryEarned, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "order_id", "type", "points", "remaining"}).AddRow(65, 1, orderID, "earned", 100, 100))
+		mock.ExpectExec(`UPDATE "loyalty_entries" SET "remaining"=\$1 WHERE id = \$2`).
+			WithArgs(60, 65).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO "loyalty_entries"`).
+			WithArgs(uint(1), orderID, models.LoyaltyEntryReversed, -40, 0, nil, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(66))
+
+		mock.ExpectQuery(`SELECT .* FROM "returns"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "status", "refund_amount", "refund_currency"}).AddRow(returnID, orderID, "refunded", 4000, "USD"))
+		mock.ExpectQuery(`SELECT .* FROM "return_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "return_id", "order_item_id"}))
+
+		mock.ExpectCommit()
+
+		if _, err := s.RefundReturn(returnID, &dto.RefundReturnRequest{ToWallet: true}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "returns" .* FOR UPDATE`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		mock.ExpectRollback()
+
+		_, err := s.RefundReturn(returnID, &dto.RefundReturnRequest{})
+		if !errors.Is(err, services.ErrReturnNotFound) {
+			t.Errorf("expected ErrReturnNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
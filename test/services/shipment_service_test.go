@@ -0,0 +1,284 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupShipmentServiceTest() (*services.ShipmentService, sqlmock.Sqlmock, error) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return services.NewShipmentService(gormDB), mock, nil
+}
+
+func TestShipmentService_CreateShipment(t *testing.T) {
+	s, mock, err := setupShipmentServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	adminID := uint(1)
+	orderID := uint(500)
+	orderItemColumns := []string{"id", "order_id", "product_id", "quantity"}
+	shipmentColumns := []string{"id", "order_id", "carrier", "tracking_number", "shipped_at", "delivered_at"}
+	lineColumns := []string{"id", "shipment_id", "order_item_id", "quantity"}
+
+	t.Run("PartiallyShipped", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, 2, "confirmed"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows(orderItemColumns).AddRow(600, orderID, 1000, 3).AddRow(601, orderID, 1001, 1))
+
+		// Nothing has been shipped from this order yet
+		mock.ExpectQuery(`SELECT shipment_lines.order_item_id, SUM\(shipment_lines.quantity\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"order_item_id", "quantity"}))
+
+		mock.ExpectQuery(`INSERT INTO "shipments"`).
+			WithArgs(orderID, "UPS", "1Z999", sqlmock.AnyArg(), nil, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(700))
+		mock.ExpectQuery(`INSERT INTO "shipment_lines"`).
+			WithArgs(700, 600, 2, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(701))
+
+		// Two of three units of the first item leave the order partially shipped
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows(orderItemColumns).AddRow(600, orderID, 1000, 3).AddRow(601, orderID, 1001, 1))
+		mock.ExpectQuery(`SELECT .* FROM "shipments"`).
+			WillReturnRows(sqlmock.NewRows(shipmentColumns).AddRow(700, orderID, "UPS", "1Z999", time.Now(), nil))
+		mock.ExpectQuery(`SELECT .* FROM "shipment_lines"`).
+			WillReturnRows(sqlmock.NewRows(lineColumns).AddRow(701, 700, 600, 2))
+		mock.ExpectExec(`UPDATE "orders" SET "status"=\$1`).
+			WithArgs("partially_shipped", sqlmock.AnyArg(), orderID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO "order_status_histories"`).
+			WithArgs(orderID, "confirmed", "partially_shipped", adminID, "shipment 700 sent with UPS", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		mock.ExpectQuery(`SELECT .* FROM "shipments"`).
+			WillReturnRows(sqlmock.NewRows(shipmentColumns).AddRow(700, orderID, "UPS", "1Z999", time.Now(), nil))
+		mock.ExpectQuery(`SELECT .* FROM "shipment_lines"`).
+			WillReturnRows(sqlmock.NewRows(lineColumns).AddRow(701, 700, 600, 2))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows(orderItemColumns).AddRow(600, orderID, 1000, 3))
+
+		mock.ExpectCommit()
+
+		resp, err := s.CreateShipment(orderID, adminID, &dto.CreateShipmentRequest{
+			Carrier:        "UPS",
+			TrackingNumber: "1Z999",
+			Items:          []dto.ShipmentItemRequest{{OrderItemID: 600, Quantity: 2}},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Status != "shipped" || len(resp.Lines) != 1 || resp.Lines[0].ProductID != 1000 {
+			t.Errorf("expected a shipped shipment of product 1000, got %+v", resp)
+		}
+	})
+
+	t.Run("RemainingItemsShipOrder", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, 2, "partially_shipped"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows(orderItemColumns).AddRow(600, orderID, 1000, 3).AddRow(601, orderID, 1001, 1))
+		mock.ExpectQuery(`SELECT shipment_lines.order_item_id, SUM\(shipment_lines.quantity\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"order_item_id", "quantity"}).AddRow(600, 2))
+
+		// Without items, whatever is left of the order is shipped
+		mock.ExpectQuery(`INSERT INTO "shipments"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(702))
+		mock.ExpectQuery(`INSERT INTO "shipment_lines"`).
+			WithArgs(702, 600, 1, sqlmock.AnyArg(), 702, 601, 1, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(703).AddRow(704))
+
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows(orderItemColumns).AddRow(600, orderID, 1000, 3).AddRow(601, orderID, 1001, 1))
+		mock.ExpectQuery(`SELECT .* FROM "shipments"`).
+			WillReturnRows(sqlmock.NewRows(shipmentColumns).
+				AddRow(700, orderID, "UPS", "1Z999", time.Now(), time.Now()).
+				AddRow(702, orderID, "UPS", "1Z998", time.Now(), nil))
+		mock.ExpectQuery(`SELECT .* FROM "shipment_lines"`).
+			WillReturnRows(sqlmock.NewRows(lineColumns).
+				AddRow(701, 700, 600, 2).
+				AddRow(703, 702, 600, 1).
+				AddRow(704, 702, 601, 1))
+		mock.ExpectExec(`UPDATE "orders" SET "status"=\$1`).
+			WithArgs("shipped", sqlmock.AnyArg(), orderID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO "order_status_histories"`).
+			WithArgs(orderID, "partially_shipped", "shipped", adminID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+
+		mock.ExpectQuery(`SELECT .* FROM "shipments"`).
+			WillReturnRows(sqlmock.NewRows(shipmentColumns).AddRow(702, orderID, "UPS", "1Z998", time.Now(), nil))
+		mock.ExpectQuery(`SELECT .* FROM "shipment_lines"`).
+			WillReturnRows(sqlmock.NewRows(lineColumns))
+
+		mock.ExpectCommit()
+
+		if _, err := s.CreateShipment(orderID, adminID, &dto.CreateShipmentRequest{Carrier: "UPS", TrackingNumber: "1Z998"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("MoreThanOrdered", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, 2, "partially_shipped"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows(orderItemColumns).AddRow(600, orderID, 1000, 3))
+		mock.ExpectQuery(`SELECT shipment_lines.order_item_id, SUM\(shipment_lines.quantity\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"order_item_id", "quantity"}).AddRow(600, 2))
+
+		mock.ExpectRollback()
+
+		_, err := s.CreateShipment(orderID, adminID, &dto.CreateShipmentRequest{
+			Carrier: "UPS",
+			Items:   []dto.ShipmentItemRequest{{OrderItemID: 600, Quantity: 2}},
+		})
+		if !errors.Is(err, services.ErrInvalidShipmentItem) {
+			t.Errorf("expected ErrInvalidShipmentItem, got %v", err)
+		}
+	})
+
+	t.Run("OrderNotConfirmed", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, 2, "pending"))
+
+		mock.ExpectRollback()
+
+		_, err := s.CreateShipment(orderID, adminID, &dto.CreateShipmentRequest{Carrier: "UPS"})
+		if !errors.Is(err, services.ErrOrderNotShippable) {
+			t.Errorf("expected ErrOrderNotShippable, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestShipmentService_UpdateShipment(t *testing.T) {
+	s, mock, err := setupShipmentServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	adminID := uint(1)
+	orderID := uint(500)
+	deliveredAt := time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC)
+	shipmentColumns := []string{"id", "order_id", "carrier", "tracking_number", "shipped_at", "delivered_at"}
+
+	t.Run("LastDeliveryDeliversOrder", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "shipments" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows(shipmentColumns).AddRow(702, orderID, "UPS", "1Z998", time.Now(), nil))
+		mock.ExpectExec(`UPDATE "shipments"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectQuery(`SELECT .* FROM "orders" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(orderID, 2, "shipped"))
+		mock.ExpectQuery(`SELECT .* FROM "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "quantity"}).AddRow(600, orderID, 3))
+		mock.ExpectQuery(`SELECT .* FROM "shipments"`).
+			WillReturnRows(sqlmock.NewRows(shipmentColumns).
+				AddRow(700, orderID, "UPS", "1Z999", time.Now(), deliveredAt).
+				AddRow(702, orderID, "UPS", "1Z998", time.Now(), deliveredAt))
+		mock.ExpectQuery(`SELECT .* FROM "shipment_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "shipment_id", "order_item_id", "quantity"}).
+				AddRow(701, 700, 600, 2).
+				AddRow(703, 702, 600, 1))
+		mock.ExpectExec(`UPDATE "orders" SET "status"=\$1`).
+			WithArgs("delivered", sqlmock.AnyArg(), orderID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO "order_status_histories"`).
+			WithArgs(orderID, "shipped", "delivered", adminID, "shipment 702 delivered", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+		mock.ExpectQuery(`SELECT .* FROM "shipments"`).
+			WillReturnRows(sqlmock.NewRows(shipmentColumns).AddRow(702, orderID, "UPS", "1Z998", time.Now(), deliveredAt))
+		mock.ExpectQuery(`SELECT .* FROM "shipment_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "shipment_id"}))
+
+		mock.ExpectCommit()
+
+		resp, err := s.UpdateShipment(702, adminID, &dto.UpdateShipmentRequest{DeliveredAt: &deliveredAt})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Status != "delivered" {
+			t.Errorf("expected a delivered shipment, got %s", resp.Status)
+		}
+	})
+
+	t.Run("TrackingOnly", func(t *testing.T) {
+		tracking := "1Z997"
+
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "shipments" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows(shipmentColumns).AddRow(702, orderID, "UPS", "1Z998", time.Now(), nil))
+		mock.ExpectExec(`UPDATE "shipments"`).
+			WithArgs(orderID, "UPS", tracking, sqlmock.AnyArg(), nil, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 702).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// The order is left alone
+		mock.ExpectQuery(`SELECT .* FROM "shipments"`).
+			WillReturnRows(sqlmock.NewRows(shipmentColumns).AddRow(702, orderID, "UPS", tracking, time.Now(), nil))
+		mock.ExpectQuery(`SELECT .* FROM "shipment_lines"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "shipment_id"}))
+
+		mock.ExpectCommit()
+
+		resp, err := s.UpdateShipment(702, adminID, &dto.UpdateShipmentRequest{TrackingNumber: &tracking})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.TrackingNumber != tracking {
+			t.Errorf("expected tracking number %s, got %s", tracking, resp.TrackingNumber)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`SELECT .* FROM "shipments" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows(shipmentColumns))
+
+		mock.ExpectRollback()
+
+		_, err := s.UpdateShipment(99, adminID, &dto.UpdateShipmentRequest{DeliveredAt: &deliveredAt})
+		if !errors.Is(err, services.ErrShipmentNotFound) {
+			t.Errorf("expected ErrShipmentNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupShippingServiceTest() (*services.ShippingService, sqlmock.Sqlmock, error) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return services.NewShippingService(gormDB, "USD"), mock, nil
+}
+
+func TestShippingService_CreateZone(t *testing.T) {
+	s, mock, err := setupShippingServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "shipping_zones"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery(`INSERT INTO "shipping_zone_regions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+		mock.ExpectCommit()
+
+		resp, err := s.CreateZone(&dto.ShippingZoneRequest{
+			Name: "North America",
+			Regions: []dto.ShippingZoneRegionRequest{
+				{Country: "us"},
+				{Country: "CA", State: "ON", PostcodePrefix: "M5"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ID != 1 || !resp.IsActive {
+			t.Errorf("expected active zone 1, got %+v", resp)
+		}
+		if len(resp.Regions) != 2 || resp.Regions[0].Country != "US" {
+			t.Errorf("expected two regions with upper cased countries, got %+v", resp.Regions)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestShippingService_UpdateZone(t *testing.T) {
+	s, mock, err := setupShippingServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	req := &dto.ShippingZoneRequest{
+		Name:    "Domestic",
+		Regions: []dto.ShippingZoneRegionRequest{{Country: "US"}},
+	}
+
+	t.Run("ReplacesRegions", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "shipping_zones"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "is_active"}).AddRow(1, "Old", true))
+		mock.ExpectExec(`UPDATE "shipping_zones" SET`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "shipping_zone_regions" WHERE shipping_zone_id = \$1`).
+			WithArgs(uint(1)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectQuery(`INSERT INTO "shipping_zone_regions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+		mock.ExpectQuery(`SELECT .* FROM "shipping_zones"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "is_active"}).AddRow(1, "Domestic", true))
+		mock.ExpectQuery(`SELECT .* FROM "shipping_rates"`).
+			WillReturnRows(sqlmock.NewRows(shippingRateColumns))
+		mock.ExpectQuery(`SELECT .* FROM "shipping_zone_regions"`).
+			WillReturnRows(sqlmock.NewRows(shippingRegionColumns).AddRow(3, 1, "US", "", ""))
+		mock.ExpectCommit()
+
+		resp, err := s.UpdateZone(1, req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Name != "Domestic" || len(resp.Regions) != 1 || resp.Regions[0].ID != 3 {
+			t.Errorf("expected renamed zone with the new region, got %+v", resp)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "shipping_zones"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		_, err := s.UpdateZone(99, req)
+		if !errors.Is(err, services.ErrShippingZoneNotFound) {
+			t.Errorf("expected ErrShippingZoneNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestShippingService_DeleteZone(t *testing.T) {
+	s, mock, err := setupShippingServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "shipping_zones" SET "deleted_at"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		if err := s.DeleteZone(99); !errors.Is(err, services.ErrShippingZoneNotFound) {
+			t.Errorf("expected ErrShippingZoneNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestShippingService_CreateRate(t *testing.T) {
+	s, mock, err := setupShippingServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "shipping_zones"`).
+			WithArgs(uint(1), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "is_active"}).AddRow(1, "Domestic", true))
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "shipping_rates"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+		mock.ExpectCommit()
+
+		resp, err := s.CreateRate(1, &dto.ShippingRateRequest{Name: "Standard", Type: "weight", Price: money.Money{Amount: 600}, MinWeight: 2, MaxWeight: 10})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ID != 10 || resp.ZoneID != 1 || !resp.IsActive {
+			t.Errorf("expected active rate 10 in zone 1, got %+v", resp)
+		}
+		if resp.Price != money.New(600, "USD") {
+			t.Errorf("expected the price in the store currency, got %v", resp.Price)
+		}
+	})
+
+	t.Run("NegativePrice", func(t *testing.T) {
+		_, err := s.CreateRate(1, &dto.ShippingRateRequest{Name: "Standard", Type: "flat", Price: money.Money{Amount: -600}})
+		if !errors.Is(err, services.ErrInvalidShippingRate) {
+			t.Errorf("expected ErrInvalidShippingRate, got %v", err)
+		}
+	})
+
+	t.Run("InvalidWeightTier", func(t *testing.T) {
+		_, err := s.CreateRate(1, &dto.ShippingRateRequest{Name: "Standard", Type: "weight", Price: money.Money{Amount: 600}, MinWeight: 10, MaxWeight: 2})
+		if !errors.Is(err, services.ErrInvalidShippingRate) {
+			t.Errorf("expected ErrInvalidShippingRate, got %v", err)
+		}
+	})
+
+	t.Run("ZoneNotFound", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "shipping_zones"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := s.CreateRate(99, &dto.ShippingRateRequest{Name: "Standard", Type: "flat", Price: money.Money{Amount: 600}})
+		if !errors.Is(err, services.ErrShippingZoneNotFound) {
+			t.Errorf("expected ErrShippingZoneNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
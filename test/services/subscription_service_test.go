@@ -0,0 +1,368 @@
+package services_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/payments"
+	"github.com/kuldeepstechwork/gocart-api/internal/repositories"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"github.com/kuldeepstechwork/gocart-api/internal/tax"
+	"github.com/kuldeepstechwork/gocart-api/test/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupSubscriptionServiceTest(ctrl *gomock.Controller) (*services.SubscriptionService, sqlmock.Sqlmock, *payments.FakeProvider, error) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	provider := payments.NewFakeProvider(payments.FakeOutcomeSucceed)
+	taxCalculator := tax.NewTableCalculator(repositories.NewTaxRateRepository(gormDB), tax.PricingExclusive)
+	orders := services.NewOrderService(gormDB, "USD", mocks.NewMockPublisher(ctrl),
+		provider, taxCalculator, mocks.NewMockInvoiceServiceInterface(ctrl),
+		services.NewLoyaltyService(gormDB, "USD", loyaltyProgram))
+
+	return services.NewSubscriptionService(gormDB, orders), mock, provider, nil
+}
+
+var subscriptionColumns = []string{"id", "user_id", "product_id", "quantity", "interval_weeks", "shipping_address_id", "status", "next_run_at"}
+
+// expectSubscriptionLoaded expects GetSubscription to load the subscription
+// with its product and runs.
+func expectSubscriptionLoaded(mock sqlmock.Sqlmock, subscriptionID, userID uint, status models.SubscriptionStatus, nextRunAt time.Time) {
+	mock.ExpectQuery(`SELECT .* FROM "subscriptions" WHERE id = \$1 AND user_id = \$2`).
+		WithArgs(subscriptionID, userID, 1).
+		WillReturnRows(sqlmock.NewRows(subscriptionColumns).AddRow(subscriptionID, userID, 1000, 2, 4, 30, status, nextRunAt))
+	mock.ExpectQuery(`SELECT .* FROM "products"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1000, "Coffee Beans"))
+	mock.ExpectQuery(`SELECT .* FROM "subscription_runs" WHERE "subscription_runs"."subscription_id" = \$1 ORDER BY run_at DESC LIMIT \$2`).
+		WithArgs(subscriptionID, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "subscription_id", "run_at", "order_id"}))
+}
+
+func TestSubscriptionService_CreateSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mock, _, err := setupSubscriptionServiceTest(ctrl)
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+	startsAt := time.Date(2026, 11, 2, 9, 0, 0, 0, time.UTC)
+
+	req := &dto.CreateSubscriptionRequest{
+		ProductID:         1000,
+		Quantity:          2,
+		IntervalWeeks:     4,
+		ShippingAddressID: 30,
+		StartsAt:          &startsAt,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "products" WHERE \(id = \$1 AND is_active = \$2\)`).
+			WithArgs(1000, true, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "is_active"}).AddRow(1000, "Coffee Beans", true))
+		mock.ExpectQuery(`SELECT .* FROM "addresses" WHERE \(id = \$1 AND user_id = \$2\)`).
+			WithArgs(30, userID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(30, userID))
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "subscriptions"`).
+			WithArgs(userID, 1000, 2, 4, 30, nil, nil, models.SubscriptionStatusActive, startsAt, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+		mock.ExpectCommit()
+
+		resp, err := s.CreateSubscription(userID, req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.ID != 7 || resp.ProductName != "Coffee Beans" {
+			t.Errorf("expected subscription 7 to Coffee Beans, got %+v", resp)
+		}
+		if !resp.NextRunAt.Equal(startsAt) {
+			t.Errorf("expected the first order at %v, got %v", startsAt, resp.NextRunAt)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("InactiveProduct", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		_, err := s.CreateSubscription(userID, req)
+		if !errors.Is(err, services.ErrProductNotFound) {
+			t.Errorf("expected ErrProductNotFound, got %v", err)
+		}
+	})
+
+	t.Run("ForeignAddress", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "is_active"}).AddRow(1000, "Coffee Beans", true))
+		mock.ExpectQuery(`SELECT .* FROM "addresses"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		_, err := s.CreateSubscription(userID, req)
+		if !errors.Is(err, services.ErrAddressNotFound) {
+			t.Errorf("expected ErrAddressNotFound, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
+
+func TestSubscriptionService_ManageSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mock, _, err := setupSubscriptionServiceTest(ctrl)
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+	subscriptionID := uint(7)
+
+	t.Run("Pause", func(t *testing.T) {
+		next := time.Now().Add(24 * time.Hour)
+
+		mock.ExpectQuery(`SELECT .* FROM "subscriptions"`).
+			WillReturnRows(sqlmock.NewRows(subscriptionColumns).AddRow(subscriptionID, userID, 1000, 2, 4, 30, models.SubscriptionStatusActive, next))
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "subscriptions" SET "status"=\$1,"updated_at"=\$2 WHERE "id" = \$3`).
+			WithArgs(models.SubscriptionStatusPaused, sqlmock.AnyArg(), subscriptionID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		expectSubscriptionLoaded(mock, subscriptionID, userID, models.SubscriptionStatusPaused, next)
+
+		resp, err := s.PauseSubscription(userID, subscriptionID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Status != string(models.SubscriptionStatusPaused) {
+			t.Errorf("expected the subscription to be paused, got %s", resp.Status)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("ResumeSkipsMissedRuns", func(t *testing.T) {
+		// Paused five weeks ago on a four weekly schedule, the missed run is
+		// skipped and the next one falls three weeks from now
+		missed := time.Now().Add(-5 * 7 * 24 * time.Hour)
+		next := missed.AddDate(0, 0, 56)
+
+		mock.ExpectQuery(`SELECT .* FROM "subscriptions"`).
+			WillReturnRows(sqlmock.NewRows(subscriptionColumns).AddRow(subscriptionID, userID, 1000, 2, 4, 30, models.SubscriptionStatusPaused, missed))
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "subscriptions" SET "next_run_at"=\$1,"status"=\$2,"updated_at"=\$3 WHERE "id" = \$4`).
+			WithArgs(next, models.SubscriptionStatusActive, sqlmock.AnyArg(), subscriptionID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		expectSubscriptionLoaded(mock, subscriptionID, userID, models.SubscriptionStatusActive, next)
+
+		if _, err := s.ResumeSubscription(userID, subscriptionID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		next := time.Now().Add(24 * time.Hour)
+
+		mock.ExpectQuery(`SELECT .* FROM "subscriptions"`).
+			WillReturnRows(sqlmock.NewRows(subscriptionColumns).AddRow(subscriptionID, userID, 1000, 2, 4, 30, models.SubscriptionStatusActive, next))
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "subscriptions" SET "cancelled_at"=\$1,"status"=\$2,"updated_at"=\$3 WHERE "id" = \$4`).
+			WithArgs(sqlmock.AnyArg(), models.SubscriptionStatusCancelled, sqlmock.AnyArg(), subscriptionID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		expectSubscriptionLoaded(mock, subscriptionID, userID, models.SubscriptionStatusCancelled, next)
+
+		resp, err := s.CancelSubscription(userID, subscriptionID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Status != string(models.SubscriptionStatusCancelled) {
+			t.Errorf("expected the subscription to be cancelled, got %s", resp.Status)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "subscriptions"`).
+			WillReturnRows(sqlmock.NewRows(subscriptionColumns).AddRow(subscriptionID, userID, 1000, 2, 4, 30, models.SubscriptionStatusCancelled, time.Now()))
+
+		_, err := s.ResumeSubscription(userID, subscriptionID)
+		if !errors.Is(err, services.ErrSubscriptionCancelled) {
+			t.Errorf("expected ErrSubscriptionCancelled, got %v", err)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "subscriptions"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		_, err := s.PauseSubscription(userID, subscriptionID)
+		if !errors.Is(err, services.ErrSubscriptionNotFound) {
+			t.Errorf("expected ErrSubscriptionNotFound, got %v", err)
+		}
+	})
+}
+
+func TestSubscriptionService_RunDueSubscriptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, mock, provider, err := setupSubscriptionServiceTest(ctrl)
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	userID := uint(1)
+	subscriptionID := uint(7)
+	now := time.Date(2026, 11, 2, 9, 30, 0, 0, time.UTC)
+	due := time.Date(2026, 11, 2, 9, 0, 0, 0, time.UTC)
+	next := due.AddDate(0, 0, 28)
+
+	// expectDue expects the due subscriptions to be listed and the one to be
+	// locked, still due.
+	expectDue := func() {
+		mock.ExpectQuery(`SELECT "id" FROM "subscriptions" WHERE status = \$1 AND next_run_at <= \$2 AND id > \$3 ORDER BY id LIMIT \$4`).
+			WithArgs(models.SubscriptionStatusActive, now, 0, 100).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(subscriptionID))
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "subscriptions" WHERE id = \$1 AND status = \$2 AND next_run_at <= \$3 .* FOR UPDATE`).
+			WithArgs(subscriptionID, models.SubscriptionStatusActive, now, 1).
+			WillReturnRows(sqlmock.NewRows(subscriptionColumns).AddRow(subscriptionID, userID, 1000, 2, 4, nil, models.SubscriptionStatusActive, due))
+		mock.ExpectExec(`SAVEPOINT sp`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+
+	// expectRunRecorded expects the run to be recorded and the subscription
+	// moved on to its next date.
+	expectRunRecorded := func(orderID interface{}, runError interface{}) {
+		mock.ExpectQuery(`INSERT INTO "subscription_runs"`).
+			WithArgs(subscriptionID, due, orderID, runError, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(70))
+		mock.ExpectExec(`UPDATE "subscriptions" SET "next_run_at"=\$1,"updated_at"=\$2 WHERE id = \$3`).
+			WithArgs(next, sqlmock.AnyArg(), subscriptionID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+
+	t.Run("PlacesOrder", func(t *testing.T) {
+		expectDue()
+
+		// The order is priced and placed like one from a cart, which is not
+		// stored and so not cleared
+		mock.ExpectQuery(`SELECT .* FROM "products" WHERE \(id = \$1 AND is_active = \$2\)`).
+			WithArgs(1000, true, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "stock", "name", "is_active"}).AddRow(1000, 1500, "USD", 10, "Coffee Beans", true))
+		mock.ExpectQuery(`SELECT .* FROM "promotions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		expectNoReservedStock(mock)
+		expectStockTaken(mock, 1000, 2, 8)
+		mock.ExpectQuery(`INSERT INTO "orders"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(500))
+		mock.ExpectQuery(`INSERT INTO "order_items"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(600))
+		mock.ExpectQuery(`INSERT INTO "payments"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(700))
+
+		expectRunRecorded(500, "")
+
+		ordered, failed, err := s.RunDueSubscriptions(now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ordered != 1 || failed != 0 {
+			t.Errorf("expected one order and no failures, got %d and %d", ordered, failed)
+		}
+
+		// The payment was authorized under a key for the run, so a retry of
+		// the run gets the same authorization
+		retried, err := provider.Authorize(&payments.AuthorizeRequest{
+			Amount:         usd(3000),
+			IdempotencyKey: fmt.Sprintf("subscription-%d-%d", subscriptionID, due.Unix()),
+		})
+		if err != nil || retried.Reference != "fake_1" {
+			t.Errorf("expected the run's authorization fake_1 back, got %+v (%v)", retried, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("RecordsFailure", func(t *testing.T) {
+		expectDue()
+
+		// The product is no longer sold, so the order is rolled back and the
+		// run recorded with the error; the next date is still taken
+		mock.ExpectQuery(`SELECT .* FROM "products"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+		mock.ExpectExec(`ROLLBACK TO SAVEPOINT sp`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		expectRunRecorded(nil, "product is no longer sold")
+
+		ordered, failed, err := s.RunDueSubscriptions(now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ordered != 0 || failed != 1 {
+			t.Errorf("expected no orders and one failure, got %d and %d", ordered, failed)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("AlreadyRun", func(t *testing.T) {
+		// Another runner placed the order after the subscription was listed,
+		// so it is no longer due once locked
+		mock.ExpectQuery(`SELECT "id" FROM "subscriptions"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(subscriptionID))
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "subscriptions" .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows(subscriptionColumns))
+		mock.ExpectCommit()
+
+		ordered, failed, err := s.RunDueSubscriptions(now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ordered != 0 || failed != 0 {
+			t.Errorf("expected nothing to run, got %d orders and %d failures", ordered, failed)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
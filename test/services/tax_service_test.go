@@ -0,0 +1,117 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupTaxServiceTest() (*services.TaxService, sqlmock.Sqlmock, error) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return services.NewTaxService(gormDB), mock, nil
+}
+
+func TestTaxService_CreateTaxRate(t *testing.T) {
+	s, mock, err := setupTaxServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "tax_rates"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectCommit()
+
+		resp, err := s.CreateTaxRate(&dto.TaxRateRequest{Name: "California", Country: "us", State: "CA", Rate: 7.25})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Country != "US" || resp.TaxClass != models.DefaultTaxClass || !resp.IsActive {
+			t.Errorf("expected an active standard rate for US, got %+v", resp)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestTaxService_UpdateTaxRate(t *testing.T) {
+	s, mock, err := setupTaxServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "tax_rates"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "country", "tax_class", "rate", "is_active"}).
+				AddRow(1, "UK", "GB", "standard", 20.0, true))
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tax_rates"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		inactive := false
+		resp, err := s.UpdateTaxRate(1, &dto.TaxRateRequest{Name: "UK reduced", Country: "GB", TaxClass: " Reduced ", Rate: 5, IsActive: &inactive})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.TaxClass != "reduced" || resp.Rate != 5 || resp.IsActive {
+			t.Errorf("expected an inactive reduced rate of 5, got %+v", resp)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "tax_rates"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		_, err := s.UpdateTaxRate(99, &dto.TaxRateRequest{Name: "UK", Country: "GB", Rate: 20})
+		if !errors.Is(err, services.ErrTaxRateNotFound) {
+			t.Errorf("expected ErrTaxRateNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestTaxService_DeleteTaxRate(t *testing.T) {
+	s, mock, err := setupTaxServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tax_rates" SET "deleted_at"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		if err := s.DeleteTaxRate(99); !errors.Is(err, services.ErrTaxRateNotFound) {
+			t.Errorf("expected ErrTaxRateNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kuldeepstechwork/gocart-api/internal/dto"
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/services"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupWalletServiceTest() (*services.WalletService, sqlmock.Sqlmock, error) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return services.NewWalletService(gormDB, "USD"), mock, nil
+}
+
+var walletOrderColumns = []string{"id", "user_id", "wallet_amount", "wallet_currency", "exchange_rate"}
+
+// expectWalletEntry expects user 1's wallet, holding balance, to be locked
+// and an entry posted that leaves it with newBalance.
+func expectWalletEntry(mock sqlmock.Sqlmock, balance, newBalance int64) {
+	mock.ExpectQuery(`INSERT INTO "wallets" .* ON CONFLICT \("user_id"\) DO NOTHING`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(`SELECT .* FROM "wallets" WHERE user_id = \$1 .* FOR UPDATE`).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance_amount", "balance_currency"}).AddRow(40, 1, balance, "USD"))
+	mock.ExpectQuery(`INSERT INTO "wallet_entries"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(41))
+	mock.ExpectExec(`UPDATE "wallets" SET "balance_amount"=\$1,"balance_currency"=\$2`).
+		WithArgs(newBalance, "USD", sqlmock.AnyArg(), 40).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestWalletService_RedeemGiftCard(t *testing.T) {
+	s, mock, err := setupWalletServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	code := "7KQ2-M9XW-4PHT-C3RD"
+	giftCardColumns := []string{"id", "code_hash", "initial_value_amount", "initial_value_currency", "expires_at", "redeemed_by", "redeemed_at"}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+
+		// The code is matched however it was typed in
+		mock.ExpectQuery(`SELECT .* FROM "gift_cards" WHERE code_hash = \$1 .* FOR UPDATE`).
+			WithArgs(models.HashGiftCardCode(code), 1).
+			WillReturnRows(sqlmock.NewRows(giftCardColumns).AddRow(30, models.HashGiftCardCode(code), 5000, "USD", nil, nil, nil))
+		mock.ExpectExec(`UPDATE "gift_cards" SET "redeemed_at"=\$1,"redeemed_by"=\$2`).
+			WithArgs(sqlmock.AnyArg(), 1, sqlmock.AnyArg(), 30).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectWalletEntry(mock, 1000, 6000)
+
+		mock.ExpectCommit()
+
+		wallet, err := s.RedeemGiftCard(1, &dto.RedeemGiftCardRequest{Code: "7kq2 m9xw 4pht c3rd"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if wallet.Balance != usd(6000) {
+			t.Errorf("expected a balance of 60.00, got %v", wallet.Balance)
+		}
+	})
+
+	t.Run("AlreadyRedeemed", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "gift_cards" WHERE code_hash = \$1 .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows(giftCardColumns).AddRow(30, models.HashGiftCardCode(code), 5000, "USD", nil, 2, time.Now()))
+		mock.ExpectRollback()
+
+		_, err := s.RedeemGiftCard(1, &dto.RedeemGiftCardRequest{Code: code})
+		if !errors.Is(err, services.ErrGiftCardNotRedeemable) {
+			t.Errorf("expected ErrGiftCardNotRedeemable, got %v", err)
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "gift_cards" WHERE code_hash = \$1 .* FOR UPDATE`).
+			WillReturnRows(sqlmock.NewRows(giftCardColumns).AddRow(30, models.HashGiftCardCode(code), 5000, "USD", time.Now().Add(-time.Hour), nil, nil))
+		mock.ExpectRollback()
+
+		_, err := s.RedeemGiftCard(1, &dto.RedeemGiftCardRequest{Code: code})
+		if !errors.Is(err, services.ErrGiftCardNotRedeemable) {
+			t.Errorf("expected ErrGiftCardNotRedeemable, got %v", err)
+		}
+	})
+
+	t.Run("UnknownCode", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "gift_cards" WHERE code_hash = \$1 .* FOR UPDATE`).
+			WillReturnError(gorm.ErrRecordNotFound)
+		mock.ExpectRollback()
+
+		_, err := s.RedeemGiftCard(1, &dto.RedeemGiftCardRequest{Code: "AAAA-BBBB-CCCC-DDDD"})
+		if !errors.Is(err, services.ErrGiftCardNotFound) {
+			t.Errorf("expected ErrGiftCardNotFound, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestWalletService_GrantCredit(t *testing.T) {
+	s, mock, err := setupWalletServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	adminID := uint(9)
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT "id" FROM "users" WHERE "users"."id" = \$1`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		expectWalletEntry(mock, 0, 2500)
+		mock.ExpectCommit()
+
+		entry, err := s.GrantCredit(adminID, 1, &dto.GrantCreditRequest{Amount: usd(2500), Note: "late delivery"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if entry.Type != string(models.WalletEntryCredit) || entry.Source != string(models.WalletEntryGrant) {
+			t.Errorf("expected a granted credit, got a %s from %s", entry.Type, entry.Source)
+		}
+		if entry.Balance != usd(2500) {
+			t.Errorf("expected a balance of 25.00, got %v", entry.Balance)
+		}
+	})
+
+	t.Run("UserNotFound", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT "id" FROM "users"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+		mock.ExpectRollback()
+
+		_, err := s.GrantCredit(adminID, 2, &dto.GrantCreditRequest{Amount: usd(2500)})
+		if !errors.Is(err, services.ErrUserNotFound) {
+			t.Errorf("expected ErrUserNotFound, got %v", err)
+		}
+	})
+
+	t.Run("NotPositive", func(t *testing.T) {
+		_, err := s.GrantCredit(adminID, 1, &dto.GrantCreditRequest{Amount: usd(0)})
+		if !errors.Is(err, services.ErrInvalidWalletCredit) {
+			t.Errorf("expected ErrInvalidWalletCredit, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestWalletService_IssueGiftCard(t *testing.T) {
+	s, mock, err := setupWalletServiceTest()
+	if err != nil {
+		t.Fatalf("failed to setup test: %v", err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "gift_cards"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(30))
+		mock.ExpectCommit()
+
+		card, err := s.IssueGiftCard(9, &dto.GiftCardRequest{Value: usd(5000)})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if card.Code == "" {
+			t.Fatal("expected the code to be returned")
+		}
+		if card.CodeHint != models.GiftCardCodeHint(card.Code) {
+			t.Errorf("expected the hint of %s, got %s", card.Code, card.CodeHint)
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Hour)
+
+		_, err := s.IssueGiftCard(9, &dto.GiftCardRequest{Value: usd(5000), ExpiresAt: &expiresAt})
+		if !errors.Is(err, services.ErrInvalidGiftCard) {
+			t.Errorf("expected ErrInvalidGiftCard, got %v", err)
+		}
+	})
+
+	t.Run("WrongCurrency", func(t *testing.T) {
+		_, err := s.IssueGiftCard(9, &dto.GiftCardRequest{Value: money.New(5000, "EUR")})
+		if !errors.Is(err, services.ErrInvalidGiftCard) {
+			t.Errorf("expected ErrInvalidGiftCard, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package tax_test
+
+import (
+	"testing"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/models"
+	"github.com/kuldeepstechwork/gocart-api/internal/money"
+	"github.com/kuldeepstechwork/gocart-api/internal/tax"
+	mocks "github.com/kuldeepstechwork/gocart-api/test/mocks/repositories"
+	"go.uber.org/mock/gomock"
+)
+
+func usd(amount int64) money.Money {
+	return money.New(amount, "USD")
+}
+
+var californiaRates = []models.TaxRate{
+	{ID: 1, Country: "US", TaxClass: "standard", Rate: 5},
+	{ID: 2, Country: "US", State: "CA", TaxClass: "standard", Rate: 7.25},
+	{ID: 3, Country: "US", TaxClass: "reduced", Rate: 2},
+}
+
+func TestTableCalculator_Exclusive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rates := mocks.NewMockTaxRateRepositoryInterface(ctrl)
+	rates.EXPECT().FindTaxRates("US", "CA").Return(californiaRates, nil)
+
+	calculator := tax.NewTableCalculator(rates, tax.PricingExclusive)
+
+	result, err := calculator.Calculate(&tax.Request{
+		Address: tax.Address{Country: "us", State: "CA"},
+		Lines: []tax.Line{
+			{ID: 1, TaxClass: "standard", Amount: usd(10000)},
+			{ID: 2, TaxClass: "reduced", Amount: usd(5000)},
+			{ID: 3, TaxClass: "zero", Amount: usd(1000)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []tax.LineResult{
+		// The state rate overrides the country wide standard rate
+		{ID: 1, TaxClass: "standard", Rate: 7.25, NetAmount: usd(10000), TaxAmount: usd(725), GrossAmount: usd(10725)},
+		{ID: 2, TaxClass: "reduced", Rate: 2, NetAmount: usd(5000), TaxAmount: usd(100), GrossAmount: usd(5100)},
+		// Classes without a rate are not taxed
+		{ID: 3, TaxClass: "zero", Rate: 0, NetAmount: usd(1000), TaxAmount: usd(0), GrossAmount: usd(1000)},
+	}
+	for i := range expected {
+		if result.Lines[i] != expected[i] {
+			t.Errorf("line %d: expected %+v, got %+v", i, expected[i], result.Lines[i])
+		}
+	}
+
+	if result.NetTotal != usd(16000) || result.TaxTotal != usd(825) || result.GrossTotal != usd(16825) {
+		t.Errorf("unexpected totals: net %v, tax %v, gross %v", result.NetTotal, result.TaxTotal, result.GrossTotal)
+	}
+}
+
+func TestTableCalculator_Inclusive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rates := mocks.NewMockTaxRateRepositoryInterface(ctrl)
+	rates.EXPECT().FindTaxRates("GB", "").Return([]models.TaxRate{{Country: "GB", TaxClass: "standard", Rate: 20}}, nil)
+
+	calculator := tax.NewTableCalculator(rates, tax.PricingInclusive)
+
+	result, err := calculator.Calculate(&tax.Request{
+		Address: tax.Address{Country: "GB"},
+		Lines:   []tax.Line{{ID: 1, TaxClass: "standard", Amount: usd(12000)}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	line := result.Lines[0]
+	if line.NetAmount != usd(10000) || line.TaxAmount != usd(2000) || line.GrossAmount != usd(12000) {
+		t.Errorf("expected the tax to be extracted from the price, got %+v", line)
+	}
+	if result.GrossTotal != usd(12000) {
+		t.Errorf("expected the gross total to stay at the price, got %v", result.GrossTotal)
+	}
+}
+
+func TestTableCalculator_NoDestination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Without a country no rates are looked up and nothing is taxed
+	calculator := tax.NewTableCalculator(mocks.NewMockTaxRateRepositoryInterface(ctrl), tax.PricingExclusive)
+
+	result, err := calculator.Calculate(&tax.Request{
+		Lines: []tax.Line{{ID: 1, TaxClass: "standard", Amount: usd(10000)}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.TaxTotal.IsZero() || result.GrossTotal != usd(10000) {
+		t.Errorf("expected no tax, got %+v", result)
+	}
+}
+
+func TestApply_Rounding(t *testing.T) {
+	net, taxAmount, gross := tax.Apply(usd(1999), 7.25, tax.PricingExclusive)
+	if net != usd(1999) || taxAmount != usd(145) || gross != usd(2144) {
+		t.Errorf("unexpected exclusive breakdown: %v %v %v", net, taxAmount, gross)
+	}
+
+	net, taxAmount, gross = tax.Apply(usd(1999), 20, tax.PricingInclusive)
+	if taxAmount != usd(333) || gross != usd(1999) || net != usd(1666) {
+		t.Errorf("unexpected inclusive breakdown: %v %v %v", net, taxAmount, gross)
+	}
+}
@@ -104,6 +104,15 @@ func TestResponseUtils(t *testing.T) {
 		}
 	})
 
+	t.Run("ConflictResponse", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		utils.ConflictResponse(c, "conflict", errors.New("already exists"))
+		if w.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %d", w.Code)
+		}
+	})
+
 	t.Run("InternalServerErrorResponse", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
@@ -0,0 +1,35 @@
+package workers_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/workers"
+	"github.com/kuldeepstechwork/gocart-api/test/mocks"
+	"github.com/rs/zerolog"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLoyaltyRunner_RunOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	loyalty := mocks.NewMockLoyaltyServiceInterface(ctrl)
+	log := zerolog.Nop()
+	runner := workers.NewLoyaltyRunner(loyalty, time.Minute, &log)
+	now := time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+
+	gomock.InOrder(
+		loyalty.EXPECT().AwardPoints(now).Return(2, nil),
+		loyalty.EXPECT().ExpirePoints(now).Return(150, nil),
+	)
+	runner.RunOnce(now)
+
+	// Points still expire when awarding them fails
+	gomock.InOrder(
+		loyalty.EXPECT().AwardPoints(now).Return(0, errors.New("connection reset")),
+		loyalty.EXPECT().ExpirePoints(now).Return(0, nil),
+	)
+	runner.RunOnce(now)
+}
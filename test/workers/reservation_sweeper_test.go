@@ -0,0 +1,59 @@
+package workers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/workers"
+	"github.com/kuldeepstechwork/gocart-api/test/mocks"
+	"github.com/rs/zerolog"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReservationSweeper_Sweep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inventory := mocks.NewMockInventoryServiceInterface(ctrl)
+	log := zerolog.Nop()
+	sweeper := workers.NewReservationSweeper(inventory, time.Minute, &log)
+	now := time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+
+	inventory.EXPECT().ReleaseExpiredReservations(now).Return(int64(2), nil)
+	sweeper.Sweep(now)
+
+	// A failed sweep is left to the next one
+	inventory.EXPECT().ReleaseExpiredReservations(now).Return(int64(0), errors.New("connection reset"))
+	sweeper.Sweep(now)
+}
+
+func TestReservationSweeper_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inventory := mocks.NewMockInventoryServiceInterface(ctrl)
+	log := zerolog.Nop()
+	sweeper := workers.NewReservationSweeper(inventory, 5*time.Millisecond, &log)
+
+	// The first sweep stops the sweeper; a tick racing the cancellation may
+	// still sweep once more
+	ctx, cancel := context.WithCancel(context.Background())
+	inventory.EXPECT().ReleaseExpiredReservations(gomock.Any()).DoAndReturn(func(time.Time) (int64, error) {
+		cancel()
+		return 0, nil
+	}).MinTimes(1)
+
+	done := make(chan struct{})
+	go func() {
+		sweeper.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the sweeper to stop once its context is done")
+	}
+}
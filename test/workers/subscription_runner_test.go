@@ -0,0 +1,63 @@
+package workers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kuldeepstechwork/gocart-api/internal/workers"
+	"github.com/kuldeepstechwork/gocart-api/test/mocks"
+	"github.com/rs/zerolog"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSubscriptionRunner_RunDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subscriptions := mocks.NewMockSubscriptionServiceInterface(ctrl)
+	log := zerolog.Nop()
+	runner := workers.NewSubscriptionRunner(subscriptions, time.Minute, &log)
+	now := time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+
+	subscriptions.EXPECT().RunDueSubscriptions(now).Return(3, 1, nil)
+	runner.RunDue(now)
+
+	// A failed run is left to the next one
+	subscriptions.EXPECT().RunDueSubscriptions(now).Return(0, 0, errors.New("connection reset"))
+	runner.RunDue(now)
+}
+
+func TestSubscriptionRunner_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subscriptions := mocks.NewMockSubscriptionServiceInterface(ctrl)
+	log := zerolog.Nop()
+	runner := workers.NewSubscriptionRunner(subscriptions, 5*time.Millisecond, &log)
+
+	// The subscriptions that fell due while nothing ran them are run on
+	// start, before the first tick; the second run stops the runner
+	ctx, cancel := context.WithCancel(context.Background())
+	runs := 0
+	subscriptions.EXPECT().RunDueSubscriptions(gomock.Any()).DoAndReturn(func(time.Time) (int, int, error) {
+		runs++
+		if runs == 2 {
+			cancel()
+		}
+		return 0, 0, nil
+	}).MinTimes(2)
+
+	done := make(chan struct{})
+	go func() {
+		runner.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the runner to stop once its context is done")
+	}
+}